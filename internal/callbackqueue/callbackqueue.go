@@ -1,6 +1,7 @@
 package callbackqueue
 
 import (
+	"context"
 	"sync"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp"
@@ -8,24 +9,35 @@ import (
 
 type CallbackQueue struct {
 	callbacksMutex sync.RWMutex
-	callbacks      map[string][]func(confirmation ocpp.Response, err error)
+	callbacks      map[string][]queuedCallback
+}
+
+// queuedCallback associates a callback to the ID of the request it was queued for.
+type queuedCallback struct {
+	requestID string
+	callback  func(confirmation ocpp.Response, err error)
 }
 
 func New() CallbackQueue {
 	return CallbackQueue{
-		callbacks: make(map[string][]func(confirmation ocpp.Response, err error)),
+		callbacks: make(map[string][]queuedCallback),
 	}
 }
 
-func (cq *CallbackQueue) TryQueue(id string, try func() error, callback func(confirmation ocpp.Response, err error)) error {
+// TryQueue queues a callback for the given id and invokes try, which is expected to send a request and return its request ID.
+// The callback is dropped if try returns an error.
+//
+// The queue is locked while try runs, hence a result can't be dequeued before the request ID of its callback is known.
+func (cq *CallbackQueue) TryQueue(id string, try func() (string, error), callback func(confirmation ocpp.Response, err error)) error {
 	cq.callbacksMutex.Lock()
 	defer cq.callbacksMutex.Unlock()
 
-	cq.callbacks[id] = append(cq.callbacks[id], callback)
+	cq.callbacks[id] = append(cq.callbacks[id], queuedCallback{callback: callback})
 
-	if err := try(); err != nil {
+	requestID, err := try()
+	callbacks := cq.callbacks[id]
+	if err != nil {
 		// pop off last element
-		callbacks := cq.callbacks[id]
 		cq.callbacks[id] = callbacks[:len(callbacks)-1]
 		if len(cq.callbacks[id]) == 0 {
 			delete(cq.callbacks, id)
@@ -33,10 +45,12 @@ func (cq *CallbackQueue) TryQueue(id string, try func() error, callback func(con
 
 		return err
 	}
+	callbacks[len(callbacks)-1].requestID = requestID
 
 	return nil
 }
 
+// Dequeue removes the oldest callback for the given id.
 func (cq *CallbackQueue) Dequeue(id string) (func(confirmation ocpp.Response, err error), bool) {
 	cq.callbacksMutex.Lock()
 	defer cq.callbacksMutex.Unlock()
@@ -50,13 +64,64 @@ func (cq *CallbackQueue) Dequeue(id string) (func(confirmation ocpp.Response, er
 		panic("Internal CallbackQueue inconsistency")
 	}
 
-	callback := callbacks[0]
+	return cq.remove(id, 0), true
+}
+
+// DequeueRequest removes the callback that was queued for a specific request of the given id.
+// Unlike Dequeue, the result of any queued request may be matched to its callback, e.g. after a request was canceled.
+func (cq *CallbackQueue) DequeueRequest(id string, requestID string) (func(confirmation ocpp.Response, err error), bool) {
+	cq.callbacksMutex.Lock()
+	defer cq.callbacksMutex.Unlock()
+
+	for i, c := range cq.callbacks[id] {
+		if c.requestID == requestID {
+			return cq.remove(id, i), true
+		}
+	}
+	return nil, false
+}
 
+func (cq *CallbackQueue) remove(id string, i int) func(confirmation ocpp.Response, err error) {
+	callbacks := cq.callbacks[id]
+	callback := callbacks[i].callback
 	if len(callbacks) == 1 {
 		delete(cq.callbacks, id)
 	} else {
-		cq.callbacks[id] = callbacks[1:]
+		cq.callbacks[id] = append(callbacks[:i:i], callbacks[i+1:]...)
 	}
+	return callback
+}
 
-	return callback, ok
+// WithContext wraps a callback, so that it is invoked exactly once: either with the result of a request,
+// or with ctx.Err() as soon as the context is done, whichever happens first.
+// Once the context is done, the callback is always invoked with ctx.Err().
+//
+// The returned release function must be invoked if the request couldn't be sent, so that the callback is never invoked.
+func WithContext(ctx context.Context, callback func(confirmation ocpp.Response, err error)) (func(confirmation ocpp.Response, err error), func()) {
+	var once sync.Once
+	done := make(chan struct{})
+	wrapped := func(confirmation ocpp.Response, err error) {
+		once.Do(func() {
+			close(done)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				// Results received after the context is done, e.g. due to the request being canceled, are discarded
+				callback(nil, ctxErr)
+			} else {
+				callback(confirmation, err)
+			}
+		})
+	}
+	release := func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			wrapped(nil, ctx.Err())
+		case <-done:
+		}
+	}()
+	return wrapped, release
 }
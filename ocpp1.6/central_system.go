@@ -1,7 +1,11 @@
 package ocpp16
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/lorenzodonini/ocpp-go/internal/callbackqueue"
 	"github.com/lorenzodonini/ocpp-go/ocpp"
@@ -10,22 +14,40 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ocpps"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
 
 type centralSystem struct {
-	server               *ocppj.Server
-	coreHandler          core.CentralSystemHandler
-	localAuthListHandler localauth.CentralSystemHandler
-	firmwareHandler      firmware.CentralSystemHandler
-	reservationHandler   reservation.CentralSystemHandler
-	remoteTriggerHandler remotetrigger.CentralSystemHandler
-	smartChargingHandler smartcharging.CentralSystemHandler
-	callbackQueue        callbackqueue.CallbackQueue
-	errC                 chan error
+	server                         *ocppj.Server
+	soapServer                     *ocpps.Server
+	soapClient                     *ocpps.Client
+	soapCheckClientHandler         ocpps.CheckClientHandler
+	newChargePointHandler          ChargePointConnectionHandler
+	chargePointDisconnectedHandler ChargePointConnectionHandler
+	connectedChargePoints          map[string]bool
+	soapQueues                     map[string][]soapRequest
+	connectionMutex                sync.Mutex
+	coreHandler                    core.CentralSystemHandler
+	localAuthListHandler           localauth.CentralSystemHandler
+	firmwareHandler                firmware.CentralSystemHandler
+	reservationHandler             reservation.CentralSystemHandler
+	remoteTriggerHandler           remotetrigger.CentralSystemHandler
+	smartChargingHandler           smartcharging.CentralSystemHandler
+	securityHandler                security.CentralSystemHandler
+	callbackQueue                  callbackqueue.CallbackQueue
+	errC                           chan error
+}
+
+// A request waiting to be sent to a charge point via OCPP-S.
+type soapRequest struct {
+	ctx      context.Context
+	request  ocpp.Request
+	callback func(confirmation ocpp.Response, err error)
 }
 
 func newCentralSystem(server *ocppj.Server) centralSystem {
@@ -33,8 +55,12 @@ func newCentralSystem(server *ocppj.Server) centralSystem {
 		panic("server must not be nil")
 	}
 	return centralSystem{
-		server:        server,
-		callbackQueue: callbackqueue.New(),
+		server:                server,
+		soapServer:            ocpps.NewServer(ocpps.CentralSystemNamespace, server.Profiles...),
+		soapClient:            ocpps.NewClient(ocpps.ChargePointNamespace, nil, server.Profiles...),
+		callbackQueue:         callbackqueue.New(),
+		connectedChargePoints: map[string]bool{},
+		soapQueues:            map[string][]soapRequest{},
 	}
 }
 
@@ -246,6 +272,36 @@ func (cs *centralSystem) UpdateFirmware(clientId string, callback func(*firmware
 	return cs.SendRequestAsync(clientId, request, genericCallback)
 }
 
+func (cs *centralSystem) GetLog(clientId string, callback func(*firmware.GetLogConfirmation, error), logType firmware.LogType, requestID int, logParameters firmware.LogParameters, props ...func(request *firmware.GetLogRequest)) error {
+	request := firmware.NewGetLogRequest(logType, requestID, logParameters)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*firmware.GetLogConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *centralSystem) SignedUpdateFirmware(clientId string, callback func(*firmware.SignedUpdateFirmwareConfirmation, error), requestID int, fw firmware.Firmware, props ...func(request *firmware.SignedUpdateFirmwareRequest)) error {
+	request := firmware.NewSignedUpdateFirmwareRequest(requestID, fw)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*firmware.SignedUpdateFirmwareConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
 func (cs *centralSystem) ReserveNow(clientId string, callback func(*reservation.ReserveNowConfirmation, error), connectorId int, expiryDate *types.DateTime, idTag string, reservationId int, props ...func(request *reservation.ReserveNowRequest)) error {
 	request := reservation.NewReserveNowRequest(connectorId, expiryDate, idTag, reservationId)
 	for _, fn := range props {
@@ -291,6 +347,21 @@ func (cs *centralSystem) TriggerMessage(clientId string, callback func(*remotetr
 	return cs.SendRequestAsync(clientId, request, genericCallback)
 }
 
+func (cs *centralSystem) ExtendedTriggerMessage(clientId string, callback func(*remotetrigger.ExtendedTriggerMessageConfirmation, error), requestedMessage remotetrigger.ExtendedMessageTrigger, props ...func(request *remotetrigger.ExtendedTriggerMessageRequest)) error {
+	request := remotetrigger.NewExtendedTriggerMessageRequest(requestedMessage)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*remotetrigger.ExtendedTriggerMessageConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
 func (cs *centralSystem) SetChargingProfile(clientId string, callback func(*smartcharging.SetChargingProfileConfirmation, error), connectorId int, chargingProfile *types.ChargingProfile, props ...func(request *smartcharging.SetChargingProfileRequest)) error {
 	request := smartcharging.NewSetChargingProfileRequest(connectorId, chargingProfile)
 	for _, fn := range props {
@@ -336,6 +407,66 @@ func (cs *centralSystem) GetCompositeSchedule(clientId string, callback func(*sm
 	return cs.SendRequestAsync(clientId, request, genericCallback)
 }
 
+func (cs *centralSystem) CertificateSigned(clientId string, callback func(*security.CertificateSignedConfirmation, error), certificateChain string, props ...func(request *security.CertificateSignedRequest)) error {
+	request := security.NewCertificateSignedRequest(certificateChain)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*security.CertificateSignedConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *centralSystem) InstallCertificate(clientId string, callback func(*security.InstallCertificateConfirmation, error), certificateType types.CertificateUse, certificate string, props ...func(request *security.InstallCertificateRequest)) error {
+	request := security.NewInstallCertificateRequest(certificateType, certificate)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*security.InstallCertificateConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *centralSystem) DeleteCertificate(clientId string, callback func(*security.DeleteCertificateConfirmation, error), certificateHashData types.CertificateHashData, props ...func(request *security.DeleteCertificateRequest)) error {
+	request := security.NewDeleteCertificateRequest(certificateHashData)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*security.DeleteCertificateConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *centralSystem) GetInstalledCertificateIds(clientId string, callback func(*security.GetInstalledCertificateIdsConfirmation, error), certificateType types.CertificateUse, props ...func(request *security.GetInstalledCertificateIdsRequest)) error {
+	request := security.NewGetInstalledCertificateIdsRequest(certificateType)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(confirmation ocpp.Response, protoError error) {
+		if confirmation != nil {
+			callback(confirmation.(*security.GetInstalledCertificateIdsConfirmation), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
 func (cs *centralSystem) SetCoreHandler(handler core.CentralSystemHandler) {
 	cs.coreHandler = handler
 }
@@ -360,23 +491,125 @@ func (cs *centralSystem) SetSmartChargingHandler(handler smartcharging.CentralSy
 	cs.smartChargingHandler = handler
 }
 
+func (cs *centralSystem) SetSecurityHandler(handler security.CentralSystemHandler) {
+	cs.securityHandler = handler
+}
+
 func (cs *centralSystem) SetNewChargePointHandler(handler ChargePointConnectionHandler) {
-	cs.server.SetNewClientHandler(func(chargePoint ws.Channel) {
-		handler(chargePoint)
-	})
+	cs.newChargePointHandler = handler
 }
 
 func (cs *centralSystem) SetChargePointDisconnectedHandler(handler ChargePointConnectionHandler) {
-	cs.server.SetDisconnectedClientHandler(func(chargePoint ws.Channel) {
-		for cb, ok := cs.callbackQueue.Dequeue(chargePoint.ID()); ok; cb, ok = cs.callbackQueue.Dequeue(chargePoint.ID()) {
-			err := ocpp.NewError(ocppj.GenericError, "client disconnected, no response received from client", "")
-			cb(nil, err)
+	cs.chargePointDisconnectedHandler = handler
+}
+
+func (cs *centralSystem) SetRequestTimeout(timeout time.Duration) error {
+	return cs.server.SetRequestTimeout(timeout)
+}
+
+func (cs *centralSystem) SetFeatureRequestTimeout(featureName string, timeout time.Duration) error {
+	return cs.server.SetFeatureRequestTimeout(featureName, timeout)
+}
+
+func (cs *centralSystem) SetSOAPCheckClientHandler(handler ocpps.CheckClientHandler) {
+	cs.soapCheckClientHandler = handler
+}
+
+func (cs *centralSystem) handleNewChargePoint(chargePoint ws.Channel) {
+	cs.connectionMutex.Lock()
+	cs.connectedChargePoints[chargePoint.ID()] = true
+	cs.connectionMutex.Unlock()
+	// A live websocket connection always takes precedence over a previously advertised SOAP address
+	cs.soapServer.RemoveClientAddress(chargePoint.ID())
+	if cs.newChargePointHandler != nil {
+		cs.newChargePointHandler(chargePoint)
+	}
+}
+
+func (cs *centralSystem) handleChargePointDisconnected(chargePoint ws.Channel) {
+	cs.connectionMutex.Lock()
+	delete(cs.connectedChargePoints, chargePoint.ID())
+	cs.connectionMutex.Unlock()
+	for cb, ok := cs.callbackQueue.Dequeue(chargePoint.ID()); ok; cb, ok = cs.callbackQueue.Dequeue(chargePoint.ID()) {
+		err := ocpp.NewError(ocppj.GenericError, "client disconnected, no response received from client", "")
+		cb(nil, err)
+	}
+	if cs.chargePointDisconnectedHandler != nil {
+		cs.chargePointDisconnectedHandler(chargePoint)
+	}
+}
+
+// Authenticates a charge point sending requests via OCPP-S.
+// Charge points currently connected via websocket are always rejected, so that a SOAP request may never take over a live connection.
+func (cs *centralSystem) checkSOAPClient(chargeBoxIdentity string, r *http.Request) bool {
+	cs.connectionMutex.Lock()
+	connected := cs.connectedChargePoints[chargeBoxIdentity]
+	cs.connectionMutex.Unlock()
+	if connected || cs.soapCheckClientHandler == nil {
+		return false
+	}
+	return cs.soapCheckClientHandler(chargeBoxIdentity, r)
+}
+
+// Returns true if requests to the charge point should be sent via OCPP-S,
+// i.e. the charge point advertised a SOAP address and isn't connected via websocket.
+func (cs *centralSystem) isSOAPChargePoint(clientId string) bool {
+	cs.connectionMutex.Lock()
+	connected := cs.connectedChargePoints[clientId]
+	cs.connectionMutex.Unlock()
+	if connected {
+		return false
+	}
+	_, ok := cs.soapServer.GetClientAddress(clientId)
+	return ok
+}
+
+// Queues a request to a charge point connected via OCPP-S.
+// Requests to the same charge point are sent sequentially, in the order they were queued.
+func (cs *centralSystem) queueSOAPRequest(ctx context.Context, clientId string, request ocpp.Request, callback func(confirmation ocpp.Response, err error)) {
+	cs.connectionMutex.Lock()
+	defer cs.connectionMutex.Unlock()
+	cs.soapQueues[clientId] = append(cs.soapQueues[clientId], soapRequest{ctx: ctx, request: request, callback: callback})
+	if len(cs.soapQueues[clientId]) == 1 {
+		go cs.processSOAPQueue(clientId)
+	}
+}
+
+// Sends all queued requests to a charge point connected via OCPP-S, one at a time.
+// Only one goroutine per charge point runs this function.
+func (cs *centralSystem) processSOAPQueue(clientId string) {
+	for {
+		cs.connectionMutex.Lock()
+		next := cs.soapQueues[clientId][0]
+		cs.connectionMutex.Unlock()
+		if next.ctx.Err() == nil {
+			if address, ok := cs.soapServer.GetClientAddress(clientId); ok {
+				confirmation, err := cs.soapClient.SendRequestWithContext(next.ctx, address, clientId, next.request)
+				next.callback(confirmation, err)
+			} else {
+				next.callback(nil, ocpp.NewError(ocppj.GenericError, "client disconnected, no SOAP address available for client", ""))
+			}
 		}
-		handler(chargePoint)
-	})
+		cs.connectionMutex.Lock()
+		queue := cs.soapQueues[clientId][1:]
+		if len(queue) == 0 {
+			delete(cs.soapQueues, clientId)
+			cs.connectionMutex.Unlock()
+			return
+		}
+		cs.soapQueues[clientId] = queue
+		cs.connectionMutex.Unlock()
+	}
 }
 
 func (cs *centralSystem) SendRequestAsync(clientId string, request ocpp.Request, callback func(confirmation ocpp.Response, err error)) error {
+	return cs.SendRequestAsyncWithContext(context.Background(), clientId, request, callback)
+}
+
+func (cs *centralSystem) SendRequestAsyncWithContext(ctx context.Context, clientId string, request ocpp.Request, callback func(confirmation ocpp.Response, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	featureName := request.GetFeatureName()
 	if _, found := cs.server.GetProfileForFeature(featureName); !found {
 		return fmt.Errorf("feature %v is unsupported on central system (missing profile), cannot send request", featureName)
@@ -384,18 +617,36 @@ func (cs *centralSystem) SendRequestAsync(clientId string, request ocpp.Request,
 	switch featureName {
 	case core.ChangeAvailabilityFeatureName, core.ChangeConfigurationFeatureName, core.ClearCacheFeatureName, core.DataTransferFeatureName, core.GetConfigurationFeatureName, core.RemoteStartTransactionFeatureName, core.RemoteStopTransactionFeatureName, core.ResetFeatureName, core.UnlockConnectorFeatureName,
 		localauth.GetLocalListVersionFeatureName, localauth.SendLocalListFeatureName,
-		firmware.GetDiagnosticsFeatureName, firmware.UpdateFirmwareFeatureName,
+		firmware.GetDiagnosticsFeatureName, firmware.UpdateFirmwareFeatureName, firmware.GetLogFeatureName, firmware.SignedUpdateFirmwareFeatureName,
 		reservation.ReserveNowFeatureName, reservation.CancelReservationFeatureName,
-		remotetrigger.TriggerMessageFeatureName,
-		smartcharging.SetChargingProfileFeatureName, smartcharging.ClearChargingProfileFeatureName, smartcharging.GetCompositeScheduleFeatureName:
+		remotetrigger.TriggerMessageFeatureName, remotetrigger.ExtendedTriggerMessageFeatureName,
+		smartcharging.SetChargingProfileFeatureName, smartcharging.ClearChargingProfileFeatureName, smartcharging.GetCompositeScheduleFeatureName,
+		security.CertificateSignedFeatureName, security.InstallCertificateFeatureName, security.DeleteCertificateFeatureName, security.GetInstalledCertificateIdsFeatureName:
 	default:
 		return fmt.Errorf("unsupported action %v on central system, cannot send request", featureName)
 	}
 
-	send := func() error {
-		return cs.server.SendRequest(clientId, request)
+	var release func()
+	if ctx.Done() != nil {
+		callback, release = callbackqueue.WithContext(ctx, callback)
+	}
+	// Charge points connected via OCPP-S are reached at the address they advertised
+	if cs.isSOAPChargePoint(clientId) {
+		cs.queueSOAPRequest(ctx, clientId, request, callback)
+		return nil
+	}
+	send := func() (string, error) {
+		return cs.server.SendRequestWithContext(ctx, clientId, request)
 	}
-	return cs.callbackQueue.TryQueue(clientId, send, callback)
+	err := cs.callbackQueue.TryQueue(clientId, send, callback)
+	if err != nil && release != nil {
+		release()
+	}
+	return err
+}
+
+func (cs *centralSystem) SOAPHandler() http.Handler {
+	return cs.soapServer
 }
 
 func (cs *centralSystem) Start(listenPort int, listenPath string) {
@@ -444,72 +695,87 @@ func (cs *centralSystem) notSupportedError(chargePointId string, requestId strin
 	}
 }
 
+// Returns true if a handler for the given profile was registered.
+func (cs *centralSystem) hasHandler(profileName string) bool {
+	switch profileName {
+	case core.ProfileName:
+		return cs.coreHandler != nil
+	case localauth.ProfileName:
+		return cs.localAuthListHandler != nil
+	case firmware.ProfileName:
+		return cs.firmwareHandler != nil
+	case reservation.ProfileName:
+		return cs.reservationHandler != nil
+	case remotetrigger.ProfileName:
+		return cs.remoteTriggerHandler != nil
+	case smartcharging.ProfileName:
+		return cs.smartChargingHandler != nil
+	case security.ProfileName:
+		return cs.securityHandler != nil
+	}
+	return false
+}
+
+// Passes an incoming request to the matching handler.
+// Returns a false flag if no handler function exists for the given action.
+func (cs *centralSystem) dispatchRequest(chargePointId string, request ocpp.Request, action string) (confirmation ocpp.Response, supported bool, err error) {
+	switch action {
+	case core.BootNotificationFeatureName:
+		confirmation, err = cs.coreHandler.OnBootNotification(chargePointId, request.(*core.BootNotificationRequest))
+	case core.AuthorizeFeatureName:
+		confirmation, err = cs.coreHandler.OnAuthorize(chargePointId, request.(*core.AuthorizeRequest))
+	case core.DataTransferFeatureName:
+		confirmation, err = cs.coreHandler.OnDataTransfer(chargePointId, request.(*core.DataTransferRequest))
+	case core.HeartbeatFeatureName:
+		confirmation, err = cs.coreHandler.OnHeartbeat(chargePointId, request.(*core.HeartbeatRequest))
+	case core.MeterValuesFeatureName:
+		confirmation, err = cs.coreHandler.OnMeterValues(chargePointId, request.(*core.MeterValuesRequest))
+	case core.StartTransactionFeatureName:
+		confirmation, err = cs.coreHandler.OnStartTransaction(chargePointId, request.(*core.StartTransactionRequest))
+	case core.StopTransactionFeatureName:
+		confirmation, err = cs.coreHandler.OnStopTransaction(chargePointId, request.(*core.StopTransactionRequest))
+	case core.StatusNotificationFeatureName:
+		confirmation, err = cs.coreHandler.OnStatusNotification(chargePointId, request.(*core.StatusNotificationRequest))
+	case firmware.DiagnosticsStatusNotificationFeatureName:
+		confirmation, err = cs.firmwareHandler.OnDiagnosticsStatusNotification(chargePointId, request.(*firmware.DiagnosticsStatusNotificationRequest))
+	case firmware.FirmwareStatusNotificationFeatureName:
+		confirmation, err = cs.firmwareHandler.OnFirmwareStatusNotification(chargePointId, request.(*firmware.FirmwareStatusNotificationRequest))
+	case firmware.LogStatusNotificationFeatureName:
+		handler, ok := cs.firmwareHandler.(firmware.CentralSystemSecurityExtensionHandler)
+		if !ok {
+			return nil, false, nil
+		}
+		confirmation, err = handler.OnLogStatusNotification(chargePointId, request.(*firmware.LogStatusNotificationRequest))
+	case firmware.SignedFirmwareStatusNotificationFeatureName:
+		handler, ok := cs.firmwareHandler.(firmware.CentralSystemSecurityExtensionHandler)
+		if !ok {
+			return nil, false, nil
+		}
+		confirmation, err = handler.OnSignedFirmwareStatusNotification(chargePointId, request.(*firmware.SignedFirmwareStatusNotificationRequest))
+	case security.SignCertificateFeatureName:
+		confirmation, err = cs.securityHandler.OnSignCertificate(chargePointId, request.(*security.SignCertificateRequest))
+	case security.SecurityEventNotificationFeatureName:
+		confirmation, err = cs.securityHandler.OnSecurityEventNotification(chargePointId, request.(*security.SecurityEventNotificationRequest))
+	default:
+		return nil, false, nil
+	}
+	return confirmation, true, err
+}
+
 func (cs *centralSystem) handleIncomingRequest(chargePoint ChargePointConnection, request ocpp.Request, requestId string, action string) {
 	profile, found := cs.server.GetProfileForFeature(action)
 	// Check whether action is supported and a handler for it exists
 	if !found {
 		cs.notImplementedError(chargePoint.ID(), requestId, action)
 		return
-	} else {
-		switch profile.Name {
-		case core.ProfileName:
-			if cs.coreHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		case localauth.ProfileName:
-			if cs.localAuthListHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		case firmware.ProfileName:
-			if cs.firmwareHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		case reservation.ProfileName:
-			if cs.reservationHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		case remotetrigger.ProfileName:
-			if cs.remoteTriggerHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		case smartcharging.ProfileName:
-			if cs.smartChargingHandler == nil {
-				cs.notSupportedError(chargePoint.ID(), requestId, action)
-				return
-			}
-		}
+	} else if !cs.hasHandler(profile.Name) {
+		cs.notSupportedError(chargePoint.ID(), requestId, action)
+		return
 	}
-	var confirmation ocpp.Response = nil
-	var err error = nil
 	// Execute in separate goroutine, so the caller goroutine is available
 	go func() {
-		switch action {
-		case core.BootNotificationFeatureName:
-			confirmation, err = cs.coreHandler.OnBootNotification(chargePoint.ID(), request.(*core.BootNotificationRequest))
-		case core.AuthorizeFeatureName:
-			confirmation, err = cs.coreHandler.OnAuthorize(chargePoint.ID(), request.(*core.AuthorizeRequest))
-		case core.DataTransferFeatureName:
-			confirmation, err = cs.coreHandler.OnDataTransfer(chargePoint.ID(), request.(*core.DataTransferRequest))
-		case core.HeartbeatFeatureName:
-			confirmation, err = cs.coreHandler.OnHeartbeat(chargePoint.ID(), request.(*core.HeartbeatRequest))
-		case core.MeterValuesFeatureName:
-			confirmation, err = cs.coreHandler.OnMeterValues(chargePoint.ID(), request.(*core.MeterValuesRequest))
-		case core.StartTransactionFeatureName:
-			confirmation, err = cs.coreHandler.OnStartTransaction(chargePoint.ID(), request.(*core.StartTransactionRequest))
-		case core.StopTransactionFeatureName:
-			confirmation, err = cs.coreHandler.OnStopTransaction(chargePoint.ID(), request.(*core.StopTransactionRequest))
-		case core.StatusNotificationFeatureName:
-			confirmation, err = cs.coreHandler.OnStatusNotification(chargePoint.ID(), request.(*core.StatusNotificationRequest))
-		case firmware.DiagnosticsStatusNotificationFeatureName:
-			confirmation, err = cs.firmwareHandler.OnDiagnosticsStatusNotification(chargePoint.ID(), request.(*firmware.DiagnosticsStatusNotificationRequest))
-		case firmware.FirmwareStatusNotificationFeatureName:
-			confirmation, err = cs.firmwareHandler.OnFirmwareStatusNotification(chargePoint.ID(), request.(*firmware.FirmwareStatusNotificationRequest))
-		default:
+		confirmation, supported, err := cs.dispatchRequest(chargePoint.ID(), request, action)
+		if !supported {
 			cs.notSupportedError(chargePoint.ID(), requestId, action)
 			return
 		}
@@ -517,8 +783,34 @@ func (cs *centralSystem) handleIncomingRequest(chargePoint ChargePointConnection
 	}()
 }
 
+// Handles a request received from a charge point via OCPP-S.
+// Unlike OCPP-J requests, the confirmation is returned synchronously to the SOAP server.
+func (cs *centralSystem) handleIncomingSOAPRequest(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+	chargePointId := header.ChargeBoxIdentity
+	profile, found := cs.soapServer.GetProfileForFeature(action)
+	if !found {
+		return nil, ocpp.NewError(ocppj.NotImplemented, fmt.Sprintf("no handler for action %v implemented", action), header.MessageID)
+	} else if !cs.hasHandler(profile.Name) {
+		return nil, ocpp.NewError(ocppj.NotSupported, fmt.Sprintf("unsupported action %v on central system", action), header.MessageID)
+	}
+	confirmation, supported, err := cs.dispatchRequest(chargePointId, request, action)
+	if !supported {
+		return nil, ocpp.NewError(ocppj.NotSupported, fmt.Sprintf("unsupported action %v on central system", action), header.MessageID)
+	}
+	if err != nil {
+		cs.error(fmt.Errorf("error handling request: %w", err))
+		return nil, ocpp.NewError(ocppj.InternalError, "Error handling request", header.MessageID)
+	}
+	if confirmation == nil {
+		err = fmt.Errorf("empty confirmation to %s for request %s", chargePointId, header.MessageID)
+		cs.error(err)
+		return nil, ocpp.NewError(ocppj.InternalError, "Error handling request", header.MessageID)
+	}
+	return confirmation, nil
+}
+
 func (cs *centralSystem) handleIncomingConfirmation(chargePoint ChargePointConnection, confirmation ocpp.Response, requestId string) {
-	if callback, ok := cs.callbackQueue.Dequeue(chargePoint.ID()); ok {
+	if callback, ok := cs.callbackQueue.DequeueRequest(chargePoint.ID(), requestId); ok {
 		callback(confirmation, nil)
 	} else {
 		err := fmt.Errorf("no handler available for call of type %v from client %s for request %s", confirmation.GetFeatureName(), chargePoint.ID(), requestId)
@@ -527,7 +819,7 @@ func (cs *centralSystem) handleIncomingConfirmation(chargePoint ChargePointConne
 }
 
 func (cs *centralSystem) handleIncomingError(chargePoint ChargePointConnection, err *ocpp.Error, details interface{}) {
-	if callback, ok := cs.callbackQueue.Dequeue(chargePoint.ID()); ok {
+	if callback, ok := cs.callbackQueue.DequeueRequest(chargePoint.ID(), err.MessageId); ok {
 		callback(nil, err)
 	} else {
 		err := fmt.Errorf("no handler available for call error %w from client %s", err, chargePoint.ID())
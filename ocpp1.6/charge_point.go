@@ -1,6 +1,7 @@
 package ocpp16
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
@@ -24,14 +26,21 @@ type chargePoint struct {
 	reservationHandler   reservation.ChargePointHandler
 	remoteTriggerHandler remotetrigger.ChargePointHandler
 	smartChargingHandler smartcharging.ChargePointHandler
-	confirmationHandler  chan ocpp.Response
-	errorHandler         chan error
+	securityHandler      security.ChargePointHandler
+	confirmationHandler  chan receivedResponse
+	errorHandler         chan *ocpp.Error
 	callbacks            callbackqueue.CallbackQueue
 	stopC                chan struct{}
 	errC                 chan error // external error channel
 	incomingWaitGroup    sync.WaitGroup
 }
 
+// A response received from the server, together with the ID of the request it refers to.
+type receivedResponse struct {
+	requestId string
+	response  ocpp.Response
+}
+
 func (cp *chargePoint) error(err error) {
 	if cp.errC != nil {
 		cp.errC <- err
@@ -183,6 +192,58 @@ func (cp *chargePoint) FirmwareStatusNotification(status firmware.FirmwareStatus
 	}
 }
 
+func (cp *chargePoint) LogStatusNotification(status firmware.UploadLogStatus, props ...func(request *firmware.LogStatusNotificationRequest)) (*firmware.LogStatusNotificationConfirmation, error) {
+	request := firmware.NewLogStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	confirmation, err := cp.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return confirmation.(*firmware.LogStatusNotificationConfirmation), err
+	}
+}
+
+func (cp *chargePoint) SignedFirmwareStatusNotification(status firmware.SignedFirmwareStatus, props ...func(request *firmware.SignedFirmwareStatusNotificationRequest)) (*firmware.SignedFirmwareStatusNotificationConfirmation, error) {
+	request := firmware.NewSignedFirmwareStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	confirmation, err := cp.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return confirmation.(*firmware.SignedFirmwareStatusNotificationConfirmation), err
+	}
+}
+
+func (cp *chargePoint) SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateConfirmation, error) {
+	request := security.NewSignCertificateRequest(csr)
+	for _, fn := range props {
+		fn(request)
+	}
+	confirmation, err := cp.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return confirmation.(*security.SignCertificateConfirmation), err
+	}
+}
+
+func (cp *chargePoint) SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationConfirmation, error) {
+	request := security.NewSecurityEventNotificationRequest(typ, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	confirmation, err := cp.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return confirmation.(*security.SecurityEventNotificationConfirmation), err
+	}
+}
+
 func (cp *chargePoint) SetCoreHandler(handler core.ChargePointHandler) {
 	cp.coreHandler = handler
 }
@@ -207,7 +268,18 @@ func (cp *chargePoint) SetSmartChargingHandler(handler smartcharging.ChargePoint
 	cp.smartChargingHandler = handler
 }
 
+func (cp *chargePoint) SetSecurityHandler(handler security.ChargePointHandler) {
+	cp.securityHandler = handler
+}
+
 func (cp *chargePoint) SendRequest(request ocpp.Request) (ocpp.Response, error) {
+	return cp.SendRequestWithContext(context.Background(), request)
+}
+
+func (cp *chargePoint) SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	featureName := request.GetFeatureName()
 	if _, found := cp.client.GetProfileForFeature(featureName); !found {
 		return nil, fmt.Errorf("feature %v is unsupported on charge point (missing profile), cannot send request", featureName)
@@ -220,8 +292,8 @@ func (cp *chargePoint) SendRequest(request ocpp.Request) (ocpp.Response, error)
 	}
 	// Create channel and pass it to a callback function, for retrieving asynchronous response
 	asyncResponseC := make(chan asyncResponse, 1)
-	send := func() error {
-		return cp.client.SendRequest(request)
+	send := func() (string, error) {
+		return cp.client.SendRequestWithContext(ctx, request)
 	}
 	err := cp.callbacks.TryQueue("main", send, func(confirmation ocpp.Response, err error) {
 		asyncResponseC <- asyncResponse{r: confirmation, e: err}
@@ -235,6 +307,8 @@ func (cp *chargePoint) SendRequest(request ocpp.Request) (ocpp.Response, error)
 			return nil, fmt.Errorf("internal error while receiving result for %v request", request.GetFeatureName())
 		}
 		return asyncResult.r, asyncResult.e
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	case <-cp.stopC:
 		return nil, fmt.Errorf("client stopped while waiting for response to %v", request.GetFeatureName())
 	}
@@ -247,14 +321,15 @@ func (cp *chargePoint) SendRequestAsync(request ocpp.Request, callback func(conf
 	}
 	switch featureName {
 	case core.AuthorizeFeatureName, core.BootNotificationFeatureName, core.DataTransferFeatureName, core.HeartbeatFeatureName, core.MeterValuesFeatureName, core.StartTransactionFeatureName, core.StopTransactionFeatureName, core.StatusNotificationFeatureName,
-		firmware.DiagnosticsStatusNotificationFeatureName, firmware.FirmwareStatusNotificationFeatureName:
+		firmware.DiagnosticsStatusNotificationFeatureName, firmware.FirmwareStatusNotificationFeatureName, firmware.LogStatusNotificationFeatureName, firmware.SignedFirmwareStatusNotificationFeatureName,
+		security.SignCertificateFeatureName, security.SecurityEventNotificationFeatureName:
 		break
 	default:
 		return fmt.Errorf("unsupported action %v on charge point, cannot send request", featureName)
 	}
 	// Response will be retrieved asynchronously via asyncHandler
-	send := func() error {
-		return cp.client.SendRequest(request)
+	send := func() (string, error) {
+		return cp.client.SendRequestWithContext(context.Background(), request)
 	}
 	err := cp.callbacks.TryQueue("main", send, callback)
 	return err
@@ -263,17 +338,17 @@ func (cp *chargePoint) SendRequestAsync(request ocpp.Request, callback func(conf
 func (cp *chargePoint) asyncCallbackHandler() {
 	for {
 		select {
-		case confirmation := <-cp.confirmationHandler:
+		case received := <-cp.confirmationHandler:
 			// Get and invoke callback
-			if callback, ok := cp.callbacks.Dequeue("main"); ok {
-				callback(confirmation, nil)
+			if callback, ok := cp.callbacks.DequeueRequest("main", received.requestId); ok {
+				callback(received.response, nil)
 			} else {
-				err := fmt.Errorf("no handler available for incoming response %v", confirmation.GetFeatureName())
+				err := fmt.Errorf("no handler available for incoming response %v", received.response.GetFeatureName())
 				cp.error(err)
 			}
 		case protoError := <-cp.errorHandler:
 			// Get and invoke callback
-			if callback, ok := cp.callbacks.Dequeue("main"); ok {
+			if callback, ok := cp.callbacks.DequeueRequest("main", protoError.MessageId); ok {
 				callback(nil, protoError)
 			} else {
 				err := fmt.Errorf("no handler available for error %v", protoError.Error())
@@ -405,6 +480,11 @@ func (cp *chargePoint) handleIncomingRequest(request ocpp.Request, requestId str
 				cp.notSupportedError(requestId, action)
 				return
 			}
+		case security.ProfileName:
+			if cp.securityHandler == nil {
+				cp.notSupportedError(requestId, action)
+				return
+			}
 		}
 	}
 	// Process request
@@ -438,18 +518,47 @@ func (cp *chargePoint) handleIncomingRequest(request ocpp.Request, requestId str
 		confirmation, err = cp.firmwareHandler.OnGetDiagnostics(request.(*firmware.GetDiagnosticsRequest))
 	case firmware.UpdateFirmwareFeatureName:
 		confirmation, err = cp.firmwareHandler.OnUpdateFirmware(request.(*firmware.UpdateFirmwareRequest))
+	case firmware.GetLogFeatureName:
+		handler, ok := cp.firmwareHandler.(firmware.ChargePointSecurityExtensionHandler)
+		if !ok {
+			cp.notSupportedError(requestId, action)
+			return
+		}
+		confirmation, err = handler.OnGetLog(request.(*firmware.GetLogRequest))
+	case firmware.SignedUpdateFirmwareFeatureName:
+		handler, ok := cp.firmwareHandler.(firmware.ChargePointSecurityExtensionHandler)
+		if !ok {
+			cp.notSupportedError(requestId, action)
+			return
+		}
+		confirmation, err = handler.OnSignedUpdateFirmware(request.(*firmware.SignedUpdateFirmwareRequest))
 	case reservation.ReserveNowFeatureName:
 		confirmation, err = cp.reservationHandler.OnReserveNow(request.(*reservation.ReserveNowRequest))
 	case reservation.CancelReservationFeatureName:
 		confirmation, err = cp.reservationHandler.OnCancelReservation(request.(*reservation.CancelReservationRequest))
 	case remotetrigger.TriggerMessageFeatureName:
 		confirmation, err = cp.remoteTriggerHandler.OnTriggerMessage(request.(*remotetrigger.TriggerMessageRequest))
+	case remotetrigger.ExtendedTriggerMessageFeatureName:
+		handler, ok := cp.remoteTriggerHandler.(remotetrigger.ChargePointExtendedTriggerHandler)
+		if !ok {
+			cp.notSupportedError(requestId, action)
+			return
+		}
+		confirmation, err = handler.OnExtendedTriggerMessage(request.(*remotetrigger.ExtendedTriggerMessageRequest))
 	case smartcharging.SetChargingProfileFeatureName:
 		confirmation, err = cp.smartChargingHandler.OnSetChargingProfile(request.(*smartcharging.SetChargingProfileRequest))
 	case smartcharging.ClearChargingProfileFeatureName:
 		confirmation, err = cp.smartChargingHandler.OnClearChargingProfile(request.(*smartcharging.ClearChargingProfileRequest))
 	case smartcharging.GetCompositeScheduleFeatureName:
 		confirmation, err = cp.smartChargingHandler.OnGetCompositeSchedule(request.(*smartcharging.GetCompositeScheduleRequest))
+	case security.CertificateSignedFeatureName:
+		confirmation, err = cp.securityHandler.OnCertificateSigned(request.(*security.CertificateSignedRequest))
+	case security.InstallCertificateFeatureName:
+		confirmation, err = cp.securityHandler.OnInstallCertificate(request.(*security.InstallCertificateRequest))
+	case security.DeleteCertificateFeatureName:
+		confirmation, err = cp.securityHandler.OnDeleteCertificate(request.(*security.DeleteCertificateRequest))
+	case security.GetInstalledCertificateIdsFeatureName:
+		confirmation, err = cp.securityHandler.OnGetInstalledCertificateIds(request.(*security.GetInstalledCertificateIdsRequest))
 	default:
 		cp.notSupportedError(requestId, action)
 		return
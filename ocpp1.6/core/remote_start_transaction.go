@@ -19,7 +19,7 @@ type RemoteStartTransactionRequest struct {
 // This field definition of the RemoteStartTransaction confirmation payload, sent by the Charge Point to the Central System in response to a RemoteStartTransactionRequest.
 // In case the request was invalid, or couldn't be processed, an error will be sent instead.
 type RemoteStartTransactionConfirmation struct {
-	Status types.RemoteStartStopStatus `json:"status" validate:"required,remoteStartStopStatus"`
+	Status types.RemoteStartStopStatus `json:"status" validate:"required,remoteStartStopStatus16"`
 }
 
 // Central System can request a Charge Point to start a transaction by sending a RemoteStartTransactionRequest.
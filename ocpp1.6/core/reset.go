@@ -45,13 +45,13 @@ func isValidResetStatus(fl validator.FieldLevel) bool {
 
 // The field definition of the Reset request payload sent by the Central System to the Charge Point.
 type ResetRequest struct {
-	Type ResetType `json:"type" validate:"required,resetType"`
+	Type ResetType `json:"type" validate:"required,resetType16"`
 }
 
 // This field definition of the Reset confirmation payload, sent by the Charge Point to the Central System in response to a ResetRequest.
 // In case the request was invalid, or couldn't be processed, an error will be sent instead.
 type ResetConfirmation struct {
-	Status ResetStatus `json:"status" validate:"required,resetStatus"`
+	Status ResetStatus `json:"status" validate:"required,resetStatus16"`
 }
 
 // The Central System SHALL send a ResetRequest for requesting a Charge Point to reset itself.
@@ -97,6 +97,6 @@ func NewResetConfirmation(status ResetStatus) *ResetConfirmation {
 }
 
 func init() {
-	_ = types.Validate.RegisterValidation("resetType", isValidResetType)
-	_ = types.Validate.RegisterValidation("resetStatus", isValidResetStatus)
+	_ = types.Validate.RegisterValidation("resetType16", isValidResetType)
+	_ = types.Validate.RegisterValidation("resetStatus16", isValidResetStatus)
 }
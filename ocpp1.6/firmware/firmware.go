@@ -1,4 +1,4 @@
-// Contains support for firmware update management and diagnostic log file download.
+// Contains support for firmware update management and diagnostic log file download, including the signed firmware and log retrieval messages of the security extension.
 package firmware
 
 import (
@@ -11,12 +11,28 @@ type CentralSystemHandler interface {
 	OnFirmwareStatusNotification(chargePointId string, request *FirmwareStatusNotificationRequest) (confirmation *FirmwareStatusNotificationConfirmation, err error)
 }
 
+// May optionally be implemented by a CentralSystemHandler, for handling the log retrieval and signed firmware update
+// messages of the OCPP 1.6 security extension.
+// If the registered handler doesn't implement this interface, such requests are rejected with a NotSupported error.
+type CentralSystemSecurityExtensionHandler interface {
+	OnLogStatusNotification(chargePointId string, request *LogStatusNotificationRequest) (confirmation *LogStatusNotificationConfirmation, err error)
+	OnSignedFirmwareStatusNotification(chargePointId string, request *SignedFirmwareStatusNotificationRequest) (confirmation *SignedFirmwareStatusNotificationConfirmation, err error)
+}
+
 // Needs to be implemented by Charge points for handling messages part of the OCPP 1.6 FirmwareManagement profile.
 type ChargePointHandler interface {
 	OnGetDiagnostics(request *GetDiagnosticsRequest) (confirmation *GetDiagnosticsConfirmation, err error)
 	OnUpdateFirmware(request *UpdateFirmwareRequest) (confirmation *UpdateFirmwareConfirmation, err error)
 }
 
+// May optionally be implemented by a ChargePointHandler, for handling the log retrieval and signed firmware update
+// messages of the OCPP 1.6 security extension.
+// If the registered handler doesn't implement this interface, such requests are rejected with a NotSupported error.
+type ChargePointSecurityExtensionHandler interface {
+	OnGetLog(request *GetLogRequest) (confirmation *GetLogConfirmation, err error)
+	OnSignedUpdateFirmware(request *SignedUpdateFirmwareRequest) (confirmation *SignedUpdateFirmwareConfirmation, err error)
+}
+
 // The profile name
 const ProfileName = "firmwareManagement"
 
@@ -26,4 +42,8 @@ var Profile = ocpp.NewProfile(
 	GetDiagnosticsFeature{},
 	DiagnosticsStatusNotificationFeature{},
 	FirmwareStatusNotificationFeature{},
-	UpdateFirmwareFeature{})
+	UpdateFirmwareFeature{},
+	GetLogFeature{},
+	LogStatusNotificationFeature{},
+	SignedFirmwareStatusNotificationFeature{},
+	SignedUpdateFirmwareFeature{})
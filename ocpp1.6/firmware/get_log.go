@@ -0,0 +1,109 @@
+package firmware
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Get Log (CS -> CP) --------------------
+
+const GetLogFeatureName = "GetLog"
+
+// LogType represents the type of log file that the Charge Point should send. It is used in GetLogRequest.
+type LogType string
+
+// LogStatus represents the status returned by a Charge Point in a GetLogConfirmation.
+type LogStatus string
+
+const (
+	LogTypeDiagnostics        LogType   = "DiagnosticsLog"   // This contains the field definition of a diagnostics log file
+	LogTypeSecurity           LogType   = "SecurityLog"      // Sent by the Central System to the Charge Point to request that the Charge Point uploads the security log
+	LogStatusAccepted         LogStatus = "Accepted"         // Accepted this log upload. This does not mean the log file is uploaded is successfully, the Charge Point will now start the log file upload.
+	LogStatusRejected         LogStatus = "Rejected"         // Log update request rejected.
+	LogStatusAcceptedCanceled LogStatus = "AcceptedCanceled" // Accepted this log upload, but in doing this has canceled an ongoing log file upload.
+)
+
+func isValidLogType(fl validator.FieldLevel) bool {
+	status := LogType(fl.Field().String())
+	switch status {
+	case LogTypeDiagnostics, LogTypeSecurity:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidLogStatus(fl validator.FieldLevel) bool {
+	status := LogStatus(fl.Field().String())
+	switch status {
+	case LogStatusAccepted, LogStatusRejected, LogStatusAcceptedCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// LogParameters specifies the requested log and the location to which the log should be sent. It is used in GetLogRequest.
+type LogParameters struct {
+	RemoteLocation  string          `json:"remoteLocation" validate:"required,max=512,uri"`
+	OldestTimestamp *types.DateTime `json:"oldestTimestamp,omitempty" validate:"omitempty"`
+	LatestTimestamp *types.DateTime `json:"latestTimestamp,omitempty" validate:"omitempty"`
+}
+
+// The field definition of the GetLog request payload sent by the Central System to the Charge Point.
+type GetLogRequest struct {
+	LogType       LogType       `json:"logType" validate:"required,logType"`
+	RequestID     int           `json:"requestId" validate:"gte=0"`
+	Retries       *int          `json:"retries,omitempty" validate:"omitempty,gte=0"`
+	RetryInterval *int          `json:"retryInterval,omitempty" validate:"omitempty,gte=0"`
+	Log           LogParameters `json:"log" validate:"required"`
+}
+
+// This field definition of the GetLog confirmation payload, sent by the Charge Point to the Central System in response to a GetLogRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetLogConfirmation struct {
+	Status   LogStatus `json:"status" validate:"required,logStatus"`            // This field indicates whether the Charge Point was able to accept the request.
+	Filename string    `json:"filename,omitempty" validate:"omitempty,max=255"` // This contains the name of the log file that will be uploaded. This field is not present when no logging information is available.
+}
+
+// The Central System can request a Charge Point to upload a diagnostics or security log file, by sending a GetLogRequest.
+// The Charge Point responds with a GetLogConfirmation, stating the name of the file that will be uploaded.
+// During the upload, the Charge Point MUST send LogStatusNotificationRequests to keep the Central System updated with the status of the upload process.
+// This message supersedes the GetDiagnostics message for Charge Points implementing the security extension.
+type GetLogFeature struct{}
+
+func (f GetLogFeature) GetFeatureName() string {
+	return GetLogFeatureName
+}
+
+func (f GetLogFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetLogRequest{})
+}
+
+func (f GetLogFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetLogConfirmation{})
+}
+
+func (r GetLogRequest) GetFeatureName() string {
+	return GetLogFeatureName
+}
+
+func (c GetLogConfirmation) GetFeatureName() string {
+	return GetLogFeatureName
+}
+
+// Creates a new GetLogRequest, containing all required fields. Optional fields may be set afterwards.
+func NewGetLogRequest(logType LogType, requestID int, logParameters LogParameters) *GetLogRequest {
+	return &GetLogRequest{LogType: logType, RequestID: requestID, Log: logParameters}
+}
+
+// Creates a new GetLogConfirmation, containing all required fields. Optional fields may be set afterwards.
+func NewGetLogConfirmation(status LogStatus) *GetLogConfirmation {
+	return &GetLogConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("logType", isValidLogType)
+	_ = types.Validate.RegisterValidation("logStatus", isValidLogStatus)
+}
@@ -0,0 +1,85 @@
+package firmware
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Log Status Notification (CP -> CS) --------------------
+
+const LogStatusNotificationFeatureName = "LogStatusNotification"
+
+// UploadLogStatus represents the current status of the log-upload procedure, reported by a Charge Point in a LogStatusNotificationRequest.
+type UploadLogStatus string
+
+const (
+	UploadLogStatusBadMessage       UploadLogStatus = "BadMessage"            // A badly formatted packet or other protocol incompatibility was detected.
+	UploadLogStatusIdle             UploadLogStatus = "Idle"                  // The Charge Point is not uploading a log file. Idle SHALL only be used when the message was triggered by an ExtendedTriggerMessageRequest.
+	UploadLogStatusNotSupportedOp   UploadLogStatus = "NotSupportedOperation" // The server does not support the operation.
+	UploadLogStatusPermissionDenied UploadLogStatus = "PermissionDenied"      // Insufficient permissions to perform the operation.
+	UploadLogStatusUploaded         UploadLogStatus = "Uploaded"              // File has been uploaded successfully.
+	UploadLogStatusUploadFailure    UploadLogStatus = "UploadFailure"         // Failed to upload the requested file.
+	UploadLogStatusUploading        UploadLogStatus = "Uploading"             // File is being uploaded.
+)
+
+func isValidUploadLogStatus(fl validator.FieldLevel) bool {
+	status := UploadLogStatus(fl.Field().String())
+	switch status {
+	case UploadLogStatusBadMessage, UploadLogStatusIdle, UploadLogStatusNotSupportedOp, UploadLogStatusPermissionDenied, UploadLogStatusUploaded, UploadLogStatusUploadFailure, UploadLogStatusUploading:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the LogStatusNotification request payload sent by a Charge Point to the Central System.
+type LogStatusNotificationRequest struct {
+	Status    UploadLogStatus `json:"status" validate:"required,uploadLogStatus"`     // This contains the status of the log upload.
+	RequestID *int            `json:"requestId,omitempty" validate:"omitempty,gte=0"` // The request id that was provided in the GetLogRequest that started this log upload.
+}
+
+// This field definition of the LogStatusNotification confirmation payload, sent by the Central System to the Charge Point in response to a LogStatusNotificationRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type LogStatusNotificationConfirmation struct {
+}
+
+// A Charge Point shall send LogStatusNotification requests to update the Central System with the current status of a log-upload procedure.
+// The Central System shall respond with a LogStatusNotificationConfirmation acknowledging the status update request.
+//
+// After a successful log upload, the Charge Point returns to Idle status.
+type LogStatusNotificationFeature struct{}
+
+func (f LogStatusNotificationFeature) GetFeatureName() string {
+	return LogStatusNotificationFeatureName
+}
+
+func (f LogStatusNotificationFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(LogStatusNotificationRequest{})
+}
+
+func (f LogStatusNotificationFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(LogStatusNotificationConfirmation{})
+}
+
+func (r LogStatusNotificationRequest) GetFeatureName() string {
+	return LogStatusNotificationFeatureName
+}
+
+func (c LogStatusNotificationConfirmation) GetFeatureName() string {
+	return LogStatusNotificationFeatureName
+}
+
+// Creates a new LogStatusNotificationRequest, containing all required fields. Optional fields may be set afterwards.
+func NewLogStatusNotificationRequest(status UploadLogStatus) *LogStatusNotificationRequest {
+	return &LogStatusNotificationRequest{Status: status}
+}
+
+// Creates a new LogStatusNotificationConfirmation, which doesn't contain any required or optional fields.
+func NewLogStatusNotificationConfirmation() *LogStatusNotificationConfirmation {
+	return &LogStatusNotificationConfirmation{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("uploadLogStatus", isValidUploadLogStatus)
+}
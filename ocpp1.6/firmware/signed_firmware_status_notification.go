@@ -0,0 +1,94 @@
+package firmware
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Signed Firmware Status Notification (CP -> CS) --------------------
+
+const SignedFirmwareStatusNotificationFeatureName = "SignedFirmwareStatusNotification"
+
+// Status reported in SignedFirmwareStatusNotificationRequest.
+type SignedFirmwareStatus string
+
+const (
+	SignedFirmwareStatusDownloaded                SignedFirmwareStatus = "Downloaded"
+	SignedFirmwareStatusDownloadFailed            SignedFirmwareStatus = "DownloadFailed"
+	SignedFirmwareStatusDownloading               SignedFirmwareStatus = "Downloading"
+	SignedFirmwareStatusDownloadScheduled         SignedFirmwareStatus = "DownloadScheduled"
+	SignedFirmwareStatusDownloadPaused            SignedFirmwareStatus = "DownloadPaused"
+	SignedFirmwareStatusIdle                      SignedFirmwareStatus = "Idle"
+	SignedFirmwareStatusInstallationFailed        SignedFirmwareStatus = "InstallationFailed"
+	SignedFirmwareStatusInstalling                SignedFirmwareStatus = "Installing"
+	SignedFirmwareStatusInstalled                 SignedFirmwareStatus = "Installed"
+	SignedFirmwareStatusInstallRebooting          SignedFirmwareStatus = "InstallRebooting"
+	SignedFirmwareStatusInstallScheduled          SignedFirmwareStatus = "InstallScheduled"
+	SignedFirmwareStatusInstallVerificationFailed SignedFirmwareStatus = "InstallVerificationFailed"
+	SignedFirmwareStatusInvalidSignature          SignedFirmwareStatus = "InvalidSignature"
+	SignedFirmwareStatusSignatureVerified         SignedFirmwareStatus = "SignatureVerified"
+)
+
+func isValidSignedFirmwareStatus(fl validator.FieldLevel) bool {
+	status := SignedFirmwareStatus(fl.Field().String())
+	switch status {
+	case SignedFirmwareStatusDownloaded, SignedFirmwareStatusDownloadFailed, SignedFirmwareStatusDownloading, SignedFirmwareStatusDownloadScheduled, SignedFirmwareStatusDownloadPaused,
+		SignedFirmwareStatusIdle, SignedFirmwareStatusInstallationFailed, SignedFirmwareStatusInstalling, SignedFirmwareStatusInstalled, SignedFirmwareStatusInstallRebooting,
+		SignedFirmwareStatusInstallScheduled, SignedFirmwareStatusInstallVerificationFailed, SignedFirmwareStatusInvalidSignature, SignedFirmwareStatusSignatureVerified:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the SignedFirmwareStatusNotification request payload sent by the Charge Point to the Central System.
+type SignedFirmwareStatusNotificationRequest struct {
+	Status    SignedFirmwareStatus `json:"status" validate:"required,signedFirmwareStatus"`
+	RequestID *int                 `json:"requestId,omitempty" validate:"omitempty,gte=0"` // The request id that was provided in the SignedUpdateFirmwareRequest which triggered this action.
+}
+
+// This field definition of the SignedFirmwareStatusNotification confirmation payload, sent by the Central System to the Charge Point in response to a SignedFirmwareStatusNotificationRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SignedFirmwareStatusNotificationConfirmation struct {
+}
+
+// The Charge Point sends a notification to inform the Central System about the progress of a signed firmware update.
+// Compared to the FirmwareStatusNotification, this message reports additional steps of the secure update process,
+// such as the verification of the firmware signature.
+// The Charge Point SHALL only send the status Idle after receipt of an ExtendedTriggerMessage for a Firmware Status Notification, when it is not busy downloading/installing firmware.
+type SignedFirmwareStatusNotificationFeature struct{}
+
+func (f SignedFirmwareStatusNotificationFeature) GetFeatureName() string {
+	return SignedFirmwareStatusNotificationFeatureName
+}
+
+func (f SignedFirmwareStatusNotificationFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SignedFirmwareStatusNotificationRequest{})
+}
+
+func (f SignedFirmwareStatusNotificationFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SignedFirmwareStatusNotificationConfirmation{})
+}
+
+func (r SignedFirmwareStatusNotificationRequest) GetFeatureName() string {
+	return SignedFirmwareStatusNotificationFeatureName
+}
+
+func (c SignedFirmwareStatusNotificationConfirmation) GetFeatureName() string {
+	return SignedFirmwareStatusNotificationFeatureName
+}
+
+// Creates a new SignedFirmwareStatusNotificationRequest, containing all required fields. Optional fields may be set afterwards.
+func NewSignedFirmwareStatusNotificationRequest(status SignedFirmwareStatus) *SignedFirmwareStatusNotificationRequest {
+	return &SignedFirmwareStatusNotificationRequest{Status: status}
+}
+
+// Creates a new SignedFirmwareStatusNotificationConfirmation, which doesn't contain any required or optional fields.
+func NewSignedFirmwareStatusNotificationConfirmation() *SignedFirmwareStatusNotificationConfirmation {
+	return &SignedFirmwareStatusNotificationConfirmation{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("signedFirmwareStatus", isValidSignedFirmwareStatus)
+}
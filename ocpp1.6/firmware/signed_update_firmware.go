@@ -0,0 +1,96 @@
+package firmware
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Signed Update Firmware (CS -> CP) --------------------
+
+const SignedUpdateFirmwareFeatureName = "SignedUpdateFirmware"
+
+// Status returned in response to a SignedUpdateFirmwareRequest.
+type UpdateFirmwareStatus string
+
+const (
+	UpdateFirmwareStatusAccepted           UpdateFirmwareStatus = "Accepted"
+	UpdateFirmwareStatusRejected           UpdateFirmwareStatus = "Rejected"
+	UpdateFirmwareStatusAcceptedCanceled   UpdateFirmwareStatus = "AcceptedCanceled"
+	UpdateFirmwareStatusInvalidCertificate UpdateFirmwareStatus = "InvalidCertificate"
+	UpdateFirmwareStatusRevokedCertificate UpdateFirmwareStatus = "RevokedCertificate"
+)
+
+func isValidUpdateFirmwareStatus(fl validator.FieldLevel) bool {
+	status := UpdateFirmwareStatus(fl.Field().String())
+	switch status {
+	case UpdateFirmwareStatusAccepted, UpdateFirmwareStatusRejected, UpdateFirmwareStatusAcceptedCanceled, UpdateFirmwareStatusInvalidCertificate, UpdateFirmwareStatusRevokedCertificate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Represents a copy of the firmware that can be loaded/updated on the Charge Point.
+type Firmware struct {
+	Location           string          `json:"location" validate:"required,max=512,uri"`        // URI defining the origin of the firmware.
+	RetrieveDateTime   *types.DateTime `json:"retrieveDateTime" validate:"required"`            // Date and time at which the firmware shall be retrieved.
+	InstallDateTime    *types.DateTime `json:"installDateTime,omitempty" validate:"omitempty"`  // Date and time at which the firmware shall be installed.
+	SigningCertificate string          `json:"signingCertificate" validate:"required,max=5500"` // Certificate with which the firmware was signed. PEM encoded X.509 certificate.
+	Signature          string          `json:"signature" validate:"required,max=800"`           // Base64 encoded firmware signature.
+}
+
+// The field definition of the SignedUpdateFirmware request payload sent by the Central System to the Charge Point.
+type SignedUpdateFirmwareRequest struct {
+	Retries       *int     `json:"retries,omitempty" validate:"omitempty,gte=0"`       // This specifies how many times Charge Point must try to download the firmware before giving up. If this field is not present, it is left to Charge Point to decide how many times it wants to retry.
+	RetryInterval *int     `json:"retryInterval,omitempty" validate:"omitempty,gte=0"` // The interval in seconds after which a retry may be attempted. If this field is not present, it is left to Charge Point to decide how long to wait between attempts.
+	RequestID     int      `json:"requestId" validate:"gte=0"`                         // The Id of this request
+	Firmware      Firmware `json:"firmware" validate:"required"`                       // Specifies the firmware to be updated on the Charge Point.
+}
+
+// This field definition of the SignedUpdateFirmware confirmation payload, sent by the Charge Point to the Central System in response to a SignedUpdateFirmwareRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SignedUpdateFirmwareConfirmation struct {
+	Status UpdateFirmwareStatus `json:"status" validate:"required,updateFirmwareStatus"`
+}
+
+// A Central System may instruct a Charge Point to install a new, signed firmware image by sending a SignedUpdateFirmwareRequest.
+// The request contains the location of the firmware, the certificate that was used to sign it and the signature itself.
+// The Charge Point SHALL validate the signing certificate before accepting the request and responds with a SignedUpdateFirmwareConfirmation.
+// After downloading the firmware, the Charge Point SHALL verify the signature before installing it.
+// During the whole process, the Charge Point MUST send SignedFirmwareStatusNotificationRequest payloads to keep the Central System updated with the status of the update process.
+type SignedUpdateFirmwareFeature struct{}
+
+func (f SignedUpdateFirmwareFeature) GetFeatureName() string {
+	return SignedUpdateFirmwareFeatureName
+}
+
+func (f SignedUpdateFirmwareFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SignedUpdateFirmwareRequest{})
+}
+
+func (f SignedUpdateFirmwareFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SignedUpdateFirmwareConfirmation{})
+}
+
+func (r SignedUpdateFirmwareRequest) GetFeatureName() string {
+	return SignedUpdateFirmwareFeatureName
+}
+
+func (c SignedUpdateFirmwareConfirmation) GetFeatureName() string {
+	return SignedUpdateFirmwareFeatureName
+}
+
+// Creates a new SignedUpdateFirmwareRequest, containing all required fields. Optional fields may be set afterwards.
+func NewSignedUpdateFirmwareRequest(requestID int, firmware Firmware) *SignedUpdateFirmwareRequest {
+	return &SignedUpdateFirmwareRequest{RequestID: requestID, Firmware: firmware}
+}
+
+// Creates a new SignedUpdateFirmwareConfirmation, containing all required fields. There are no optional fields for this message.
+func NewSignedUpdateFirmwareConfirmation(status UpdateFirmwareStatus) *SignedUpdateFirmwareConfirmation {
+	return &SignedUpdateFirmwareConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("updateFirmwareStatus", isValidUpdateFirmwareStatus)
+}
@@ -0,0 +1,88 @@
+package remotetrigger
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Extended Trigger Message (CS -> CP) --------------------
+
+const ExtendedTriggerMessageFeatureName = "ExtendedTriggerMessage"
+
+// Type of request to be triggered in an ExtendedTriggerMessageRequest.
+type ExtendedMessageTrigger string
+
+const (
+	ExtendedMessageTriggerBootNotification           ExtendedMessageTrigger = "BootNotification"
+	ExtendedMessageTriggerLogStatusNotification      ExtendedMessageTrigger = "LogStatusNotification"
+	ExtendedMessageTriggerFirmwareStatusNotification ExtendedMessageTrigger = "FirmwareStatusNotification"
+	ExtendedMessageTriggerHeartbeat                  ExtendedMessageTrigger = "Heartbeat"
+	ExtendedMessageTriggerMeterValues                ExtendedMessageTrigger = "MeterValues"
+	ExtendedMessageTriggerSignChargePointCertificate ExtendedMessageTrigger = "SignChargePointCertificate"
+	ExtendedMessageTriggerStatusNotification         ExtendedMessageTrigger = "StatusNotification"
+)
+
+func isValidExtendedMessageTrigger(fl validator.FieldLevel) bool {
+	trigger := ExtendedMessageTrigger(fl.Field().String())
+	switch trigger {
+	case ExtendedMessageTriggerBootNotification, ExtendedMessageTriggerLogStatusNotification, ExtendedMessageTriggerFirmwareStatusNotification, ExtendedMessageTriggerHeartbeat,
+		ExtendedMessageTriggerMeterValues, ExtendedMessageTriggerSignChargePointCertificate, ExtendedMessageTriggerStatusNotification:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the ExtendedTriggerMessage request payload sent by the Central System to the Charge Point.
+type ExtendedTriggerMessageRequest struct {
+	RequestedMessage ExtendedMessageTrigger `json:"requestedMessage" validate:"required,extendedMessageTrigger"`
+	ConnectorId      *int                   `json:"connectorId,omitempty" validate:"omitempty,gt=0"` // Only filled in when request applies to a specific connector.
+}
+
+// This field definition of the ExtendedTriggerMessage confirmation payload, sent by the Charge Point to the Central System in response to an ExtendedTriggerMessageRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ExtendedTriggerMessageConfirmation struct {
+	Status TriggerMessageStatus `json:"status" validate:"required,triggerMessageStatus16"`
+}
+
+// The ExtendedTriggerMessage works like the TriggerMessage, but additionally allows the Central System to trigger
+// messages introduced by the security extension, such as LogStatusNotification, SignedFirmwareStatusNotification
+// (requested via FirmwareStatusNotification) and a SignCertificate request for the Charge Point certificate.
+// The Charge Point SHALL first send the ExtendedTriggerMessage response, before sending the requested message.
+// If the requested message is unknown or not implemented the Charge Point SHALL return NotImplemented.
+type ExtendedTriggerMessageFeature struct{}
+
+func (f ExtendedTriggerMessageFeature) GetFeatureName() string {
+	return ExtendedTriggerMessageFeatureName
+}
+
+func (f ExtendedTriggerMessageFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ExtendedTriggerMessageRequest{})
+}
+
+func (f ExtendedTriggerMessageFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ExtendedTriggerMessageConfirmation{})
+}
+
+func (r ExtendedTriggerMessageRequest) GetFeatureName() string {
+	return ExtendedTriggerMessageFeatureName
+}
+
+func (c ExtendedTriggerMessageConfirmation) GetFeatureName() string {
+	return ExtendedTriggerMessageFeatureName
+}
+
+// Creates a new ExtendedTriggerMessageRequest, containing all required fields. Optional fields may be set afterwards.
+func NewExtendedTriggerMessageRequest(requestedMessage ExtendedMessageTrigger) *ExtendedTriggerMessageRequest {
+	return &ExtendedTriggerMessageRequest{RequestedMessage: requestedMessage}
+}
+
+// Creates a new ExtendedTriggerMessageConfirmation, containing all required fields. There are no optional fields for this message.
+func NewExtendedTriggerMessageConfirmation(status TriggerMessageStatus) *ExtendedTriggerMessageConfirmation {
+	return &ExtendedTriggerMessageConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("extendedMessageTrigger", isValidExtendedMessageTrigger)
+}
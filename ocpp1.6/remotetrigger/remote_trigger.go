@@ -12,10 +12,17 @@ type ChargePointHandler interface {
 	OnTriggerMessage(request *TriggerMessageRequest) (confirmation *TriggerMessageConfirmation, err error)
 }
 
+// May optionally be implemented by a ChargePointHandler, for handling the ExtendedTriggerMessage of the OCPP 1.6 security extension.
+// If the registered handler doesn't implement this interface, such requests are rejected with a NotSupported error.
+type ChargePointExtendedTriggerHandler interface {
+	OnExtendedTriggerMessage(request *ExtendedTriggerMessageRequest) (confirmation *ExtendedTriggerMessageConfirmation, err error)
+}
+
 // The profile name
 const ProfileName = "RemoteTrigger"
 
 // Provides support for remote triggering of Charge Point initiated messages.
 var Profile = ocpp.NewProfile(
 	ProfileName,
-	TriggerMessageFeature{})
+	TriggerMessageFeature{},
+	ExtendedTriggerMessageFeature{})
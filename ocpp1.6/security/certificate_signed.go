@@ -0,0 +1,80 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Certificate Signed (CS -> CP) --------------------
+
+const CertificateSignedFeatureName = "CertificateSigned"
+
+// Status returned in response to CertificateSignedRequest, that indicates whether certificate signing has been accepted or rejected.
+type CertificateSignedStatus string
+
+const (
+	CertificateSignedStatusAccepted CertificateSignedStatus = "Accepted"
+	CertificateSignedStatusRejected CertificateSignedStatus = "Rejected"
+)
+
+func isValidCertificateSignedStatus(fl validator.FieldLevel) bool {
+	status := CertificateSignedStatus(fl.Field().String())
+	switch status {
+	case CertificateSignedStatusAccepted, CertificateSignedStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the CertificateSigned request payload sent by the Central System to the Charge Point.
+type CertificateSignedRequest struct {
+	CertificateChain string `json:"certificateChain" validate:"required,max=10000"` // The signed PEM encoded X.509 certificates. This can also contain the necessary sub CA certificates.
+}
+
+// This field definition of the CertificateSigned confirmation payload, sent by the Charge Point to the Central System in response to a CertificateSignedRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type CertificateSignedConfirmation struct {
+	Status CertificateSignedStatus `json:"status" validate:"required,certificateSignedStatus"` // Returns whether certificate signing has been accepted, otherwise rejected.
+}
+
+// During the a certificate update procedure, the Central System sends a new certificate, signed by a CA,
+// to the Charge Point with a CertificateSignedRequest.
+// The Charge Point verifies the signed certificate, installs it locally and responds with
+// a CertificateSignedConfirmation to the Central System with the status Accepted or Rejected.
+type CertificateSignedFeature struct{}
+
+func (f CertificateSignedFeature) GetFeatureName() string {
+	return CertificateSignedFeatureName
+}
+
+func (f CertificateSignedFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(CertificateSignedRequest{})
+}
+
+func (f CertificateSignedFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(CertificateSignedConfirmation{})
+}
+
+func (r CertificateSignedRequest) GetFeatureName() string {
+	return CertificateSignedFeatureName
+}
+
+func (c CertificateSignedConfirmation) GetFeatureName() string {
+	return CertificateSignedFeatureName
+}
+
+// Creates a new CertificateSignedRequest, containing all required fields. There are no optional fields for this message.
+func NewCertificateSignedRequest(certificateChain string) *CertificateSignedRequest {
+	return &CertificateSignedRequest{CertificateChain: certificateChain}
+}
+
+// Creates a new CertificateSignedConfirmation, containing all required fields. There are no optional fields for this message.
+func NewCertificateSignedConfirmation(status CertificateSignedStatus) *CertificateSignedConfirmation {
+	return &CertificateSignedConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("certificateSignedStatus", isValidCertificateSignedStatus)
+}
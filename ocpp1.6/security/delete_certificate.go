@@ -0,0 +1,79 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Delete Certificate (CS -> CP) --------------------
+
+const DeleteCertificateFeatureName = "DeleteCertificate"
+
+// Status returned in response to DeleteCertificateRequest.
+type DeleteCertificateStatus string
+
+const (
+	DeleteCertificateStatusAccepted DeleteCertificateStatus = "Accepted"
+	DeleteCertificateStatusFailed   DeleteCertificateStatus = "Failed"
+	DeleteCertificateStatusNotFound DeleteCertificateStatus = "NotFound"
+)
+
+func isValidDeleteCertificateStatus(fl validator.FieldLevel) bool {
+	status := DeleteCertificateStatus(fl.Field().String())
+	switch status {
+	case DeleteCertificateStatusAccepted, DeleteCertificateStatusFailed, DeleteCertificateStatusNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the DeleteCertificate request payload sent by the Central System to the Charge Point.
+type DeleteCertificateRequest struct {
+	CertificateHashData types.CertificateHashData `json:"certificateHashData" validate:"required"` // Indicates the certificate of which deletion is requested.
+}
+
+// This field definition of the DeleteCertificate confirmation payload, sent by the Charge Point to the Central System in response to a DeleteCertificateRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type DeleteCertificateConfirmation struct {
+	Status DeleteCertificateStatus `json:"status" validate:"required,deleteCertificateStatus"` // Charge Point indicates if it can process the request.
+}
+
+// The Central System can request the Charge Point to delete an installed root certificate by sending a DeleteCertificateRequest.
+// The Charge Point attempts to delete the certificate and responds with a DeleteCertificateConfirmation.
+type DeleteCertificateFeature struct{}
+
+func (f DeleteCertificateFeature) GetFeatureName() string {
+	return DeleteCertificateFeatureName
+}
+
+func (f DeleteCertificateFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(DeleteCertificateRequest{})
+}
+
+func (f DeleteCertificateFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(DeleteCertificateConfirmation{})
+}
+
+func (r DeleteCertificateRequest) GetFeatureName() string {
+	return DeleteCertificateFeatureName
+}
+
+func (c DeleteCertificateConfirmation) GetFeatureName() string {
+	return DeleteCertificateFeatureName
+}
+
+// Creates a new DeleteCertificateRequest, containing all required fields. There are no optional fields for this message.
+func NewDeleteCertificateRequest(certificateHashData types.CertificateHashData) *DeleteCertificateRequest {
+	return &DeleteCertificateRequest{CertificateHashData: certificateHashData}
+}
+
+// Creates a new DeleteCertificateConfirmation, containing all required fields. There are no optional fields for this message.
+func NewDeleteCertificateConfirmation(status DeleteCertificateStatus) *DeleteCertificateConfirmation {
+	return &DeleteCertificateConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("deleteCertificateStatus", isValidDeleteCertificateStatus)
+}
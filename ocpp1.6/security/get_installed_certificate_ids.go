@@ -0,0 +1,80 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Get Installed Certificate IDs (CS -> CP) --------------------
+
+const GetInstalledCertificateIdsFeatureName = "GetInstalledCertificateIds"
+
+// Status returned in response to a GetInstalledCertificateIdsRequest.
+type GetInstalledCertificateStatus string
+
+const (
+	GetInstalledCertificateStatusAccepted GetInstalledCertificateStatus = "Accepted" // Normal successful completion (no errors).
+	GetInstalledCertificateStatusNotFound GetInstalledCertificateStatus = "NotFound" // Requested resource not found
+)
+
+func isValidGetInstalledCertificateStatus(fl validator.FieldLevel) bool {
+	status := GetInstalledCertificateStatus(fl.Field().String())
+	switch status {
+	case GetInstalledCertificateStatusAccepted, GetInstalledCertificateStatusNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the GetInstalledCertificateIds request payload sent by the Central System to the Charge Point.
+type GetInstalledCertificateIdsRequest struct {
+	CertificateType types.CertificateUse `json:"certificateType" validate:"required,certificateUse16"` // Indicates the type of certificates requested.
+}
+
+// This field definition of the GetInstalledCertificateIds confirmation payload, sent by the Charge Point to the Central System in response to a GetInstalledCertificateIdsRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetInstalledCertificateIdsConfirmation struct {
+	Status              GetInstalledCertificateStatus `json:"status" validate:"required,getInstalledCertificateStatus"` // Charge Point indicates if it can process the request.
+	CertificateHashData []types.CertificateHashData   `json:"certificateHashData,omitempty" validate:"omitempty,dive"`  // The Charge Point includes the Certificate information for each available certificate.
+}
+
+// To facilitate the management of the Charge Point's installed certificates, a method of retrieving the installed certificates is provided.
+// The Central System requests the Charge Point to send a list of installed certificates by sending a GetInstalledCertificateIdsRequest.
+// The Charge Point responds with a GetInstalledCertificateIdsConfirmation.
+type GetInstalledCertificateIdsFeature struct{}
+
+func (f GetInstalledCertificateIdsFeature) GetFeatureName() string {
+	return GetInstalledCertificateIdsFeatureName
+}
+
+func (f GetInstalledCertificateIdsFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetInstalledCertificateIdsRequest{})
+}
+
+func (f GetInstalledCertificateIdsFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetInstalledCertificateIdsConfirmation{})
+}
+
+func (r GetInstalledCertificateIdsRequest) GetFeatureName() string {
+	return GetInstalledCertificateIdsFeatureName
+}
+
+func (c GetInstalledCertificateIdsConfirmation) GetFeatureName() string {
+	return GetInstalledCertificateIdsFeatureName
+}
+
+// Creates a new GetInstalledCertificateIdsRequest, containing all required fields. There are no optional fields for this message.
+func NewGetInstalledCertificateIdsRequest(certificateType types.CertificateUse) *GetInstalledCertificateIdsRequest {
+	return &GetInstalledCertificateIdsRequest{CertificateType: certificateType}
+}
+
+// Creates a new GetInstalledCertificateIdsConfirmation, containing all required fields. Optional fields may be set afterwards.
+func NewGetInstalledCertificateIdsConfirmation(status GetInstalledCertificateStatus) *GetInstalledCertificateIdsConfirmation {
+	return &GetInstalledCertificateIdsConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("getInstalledCertificateStatus", isValidGetInstalledCertificateStatus)
+}
@@ -0,0 +1,80 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Install Certificate (CS -> CP) --------------------
+
+const InstallCertificateFeatureName = "InstallCertificate"
+
+// Charge Point indicates if installation was successful.
+type InstallCertificateStatus string
+
+const (
+	InstallCertificateStatusAccepted InstallCertificateStatus = "Accepted"
+	InstallCertificateStatusRejected InstallCertificateStatus = "Rejected"
+	InstallCertificateStatusFailed   InstallCertificateStatus = "Failed"
+)
+
+func isValidInstallCertificateStatus(fl validator.FieldLevel) bool {
+	status := InstallCertificateStatus(fl.Field().String())
+	switch status {
+	case InstallCertificateStatusAccepted, InstallCertificateStatusRejected, InstallCertificateStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the InstallCertificate request payload sent by the Central System to the Charge Point.
+type InstallCertificateRequest struct {
+	CertificateType types.CertificateUse `json:"certificateType" validate:"required,certificateUse16"` // Indicates the certificate type that is sent.
+	Certificate     string               `json:"certificate" validate:"required,max=5500"`             // A PEM encoded X.509 certificate.
+}
+
+// This field definition of the InstallCertificate confirmation payload, sent by the Charge Point to the Central System in response to an InstallCertificateRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type InstallCertificateConfirmation struct {
+	Status InstallCertificateStatus `json:"status" validate:"required,installCertificateStatus"`
+}
+
+// The Central System requests the Charge Point to install a new root certificate by sending an InstallCertificateRequest.
+// The Charge Point attempts to install the certificate and responds with an InstallCertificateConfirmation.
+type InstallCertificateFeature struct{}
+
+func (f InstallCertificateFeature) GetFeatureName() string {
+	return InstallCertificateFeatureName
+}
+
+func (f InstallCertificateFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(InstallCertificateRequest{})
+}
+
+func (f InstallCertificateFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(InstallCertificateConfirmation{})
+}
+
+func (r InstallCertificateRequest) GetFeatureName() string {
+	return InstallCertificateFeatureName
+}
+
+func (c InstallCertificateConfirmation) GetFeatureName() string {
+	return InstallCertificateFeatureName
+}
+
+// Creates a new InstallCertificateRequest, containing all required fields. There are no optional fields for this message.
+func NewInstallCertificateRequest(certificateType types.CertificateUse, certificate string) *InstallCertificateRequest {
+	return &InstallCertificateRequest{CertificateType: certificateType, Certificate: certificate}
+}
+
+// Creates a new InstallCertificateConfirmation, containing all required fields. There are no optional fields for this message.
+func NewInstallCertificateConfirmation(status InstallCertificateStatus) *InstallCertificateConfirmation {
+	return &InstallCertificateConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("installCertificateStatus", isValidInstallCertificateStatus)
+}
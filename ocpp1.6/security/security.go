@@ -0,0 +1,33 @@
+// Contains support for the messages introduced by the OCPP 1.6 Security Whitepaper: certificate management and security event notifications.
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+)
+
+// Needs to be implemented by Central systems for handling messages part of the OCPP 1.6 Security extension.
+type CentralSystemHandler interface {
+	OnSecurityEventNotification(chargePointId string, request *SecurityEventNotificationRequest) (confirmation *SecurityEventNotificationConfirmation, err error)
+	OnSignCertificate(chargePointId string, request *SignCertificateRequest) (confirmation *SignCertificateConfirmation, err error)
+}
+
+// Needs to be implemented by Charge points for handling messages part of the OCPP 1.6 Security extension.
+type ChargePointHandler interface {
+	OnCertificateSigned(request *CertificateSignedRequest) (confirmation *CertificateSignedConfirmation, err error)
+	OnDeleteCertificate(request *DeleteCertificateRequest) (confirmation *DeleteCertificateConfirmation, err error)
+	OnGetInstalledCertificateIds(request *GetInstalledCertificateIdsRequest) (confirmation *GetInstalledCertificateIdsConfirmation, err error)
+	OnInstallCertificate(request *InstallCertificateRequest) (confirmation *InstallCertificateConfirmation, err error)
+}
+
+// The profile name
+const ProfileName = "security"
+
+// Provides support for certificate management and security event notifications, as defined by the OCPP 1.6 Security Whitepaper.
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	CertificateSignedFeature{},
+	DeleteCertificateFeature{},
+	GetInstalledCertificateIdsFeature{},
+	InstallCertificateFeature{},
+	SecurityEventNotificationFeature{},
+	SignCertificateFeature{})
@@ -0,0 +1,57 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"reflect"
+)
+
+// -------------------- Security Event Notification (CP -> CS) --------------------
+
+const SecurityEventNotificationFeatureName = "SecurityEventNotification"
+
+// The field definition of the SecurityEventNotification request payload sent by the Charge Point to the Central System.
+type SecurityEventNotificationRequest struct {
+	Type      string          `json:"type" validate:"required,max=50"`                 // Type of the security event. This value should be taken from the Security events list.
+	Timestamp *types.DateTime `json:"timestamp" validate:"required"`                   // Date and time at which the event occurred.
+	TechInfo  string          `json:"techInfo,omitempty" validate:"omitempty,max=255"` // Additional information about the occurred security event.
+}
+
+// This field definition of the SecurityEventNotification confirmation payload, sent by the Central System to the Charge Point in response to a SecurityEventNotificationRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SecurityEventNotificationConfirmation struct {
+}
+
+// In case of critical security events, a Charge Point may immediately inform the Central System of such events,
+// via a SecurityEventNotificationRequest.
+// The Central System responds with a SecurityEventNotificationConfirmation to the Charge Point.
+type SecurityEventNotificationFeature struct{}
+
+func (f SecurityEventNotificationFeature) GetFeatureName() string {
+	return SecurityEventNotificationFeatureName
+}
+
+func (f SecurityEventNotificationFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SecurityEventNotificationRequest{})
+}
+
+func (f SecurityEventNotificationFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SecurityEventNotificationConfirmation{})
+}
+
+func (r SecurityEventNotificationRequest) GetFeatureName() string {
+	return SecurityEventNotificationFeatureName
+}
+
+func (c SecurityEventNotificationConfirmation) GetFeatureName() string {
+	return SecurityEventNotificationFeatureName
+}
+
+// Creates a new SecurityEventNotificationRequest, containing all required fields. Optional fields may be set afterwards.
+func NewSecurityEventNotificationRequest(typ string, timestamp *types.DateTime) *SecurityEventNotificationRequest {
+	return &SecurityEventNotificationRequest{Type: typ, Timestamp: timestamp}
+}
+
+// Creates a new SecurityEventNotificationConfirmation, which doesn't contain any required or optional fields.
+func NewSecurityEventNotificationConfirmation() *SecurityEventNotificationConfirmation {
+	return &SecurityEventNotificationConfirmation{}
+}
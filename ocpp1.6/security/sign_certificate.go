@@ -0,0 +1,82 @@
+package security
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Sign Certificate (CP -> CS) --------------------
+
+const SignCertificateFeatureName = "SignCertificate"
+
+// Generic status returned by the Central System in a SignCertificateConfirmation.
+type GenericStatus string
+
+const (
+	GenericStatusAccepted GenericStatus = "Accepted"
+	GenericStatusRejected GenericStatus = "Rejected"
+)
+
+func isValidGenericStatus(fl validator.FieldLevel) bool {
+	status := GenericStatus(fl.Field().String())
+	switch status {
+	case GenericStatusAccepted, GenericStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the SignCertificate request payload sent by the Charge Point to the Central System.
+type SignCertificateRequest struct {
+	CSR string `json:"csr" validate:"required,max=5500"` // The Charge Point SHALL send the public key in form of a Certificate Signing Request (CSR) as described in RFC 2986 and then PEM encoded.
+}
+
+// This field definition of the SignCertificate confirmation payload, sent by the Central System to the Charge Point in response to a SignCertificateRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SignCertificateConfirmation struct {
+	Status GenericStatus `json:"status" validate:"required,genericStatus"` // Specifies whether the Central System can process the request.
+}
+
+// If a Charge Point detects that its certificate is due to expire, it will generate a new public/private key pair,
+// then send a SignCertificateRequest to the Central System containing a valid Certificate Signing Request.
+//
+// The Central System responds with a SignCertificateConfirmation and will then forward the CSR to a CA server.
+// Once the CA has issued a valid certificate, the Central System will send a CertificateSignedRequest to the
+// Charge Point (asynchronously).
+type SignCertificateFeature struct{}
+
+func (f SignCertificateFeature) GetFeatureName() string {
+	return SignCertificateFeatureName
+}
+
+func (f SignCertificateFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SignCertificateRequest{})
+}
+
+func (f SignCertificateFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SignCertificateConfirmation{})
+}
+
+func (r SignCertificateRequest) GetFeatureName() string {
+	return SignCertificateFeatureName
+}
+
+func (c SignCertificateConfirmation) GetFeatureName() string {
+	return SignCertificateFeatureName
+}
+
+// Creates a new SignCertificateRequest, containing all required fields. There are no optional fields for this message.
+func NewSignCertificateRequest(csr string) *SignCertificateRequest {
+	return &SignCertificateRequest{CSR: csr}
+}
+
+// Creates a new SignCertificateConfirmation, containing all required fields. There are no optional fields for this message.
+func NewSignCertificateConfirmation(status GenericStatus) *SignCertificateConfirmation {
+	return &SignCertificateConfirmation{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("genericStatus", isValidGenericStatus)
+}
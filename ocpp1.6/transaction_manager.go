@@ -0,0 +1,511 @@
+package ocpp16
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+// A request queued by the TransactionMessageManager, together with its transmission state.
+type queuedMessage struct {
+	request            ocpp.Request
+	callback           func(confirmation ocpp.Response, err error)
+	attempts           int
+	localTransactionID int
+	transactionMessage bool
+}
+
+// TransactionMessageManager delivers transaction-related messages of a charge point reliably,
+// as defined by the OCPP 1.6 specification.
+//
+// StartTransaction, StopTransaction and MeterValues requests containing a transaction ID are transaction-related.
+// If the central system responds to such a message with a CallError or doesn't respond at all,
+// the message is sent again as defined by the retry policy of the manager.
+// By default, the message is sent again after waiting TransactionMessageRetryInterval, multiplied by the number
+// of preceding attempts. Once TransactionMessageAttempts attempts failed, the message is discarded and
+// the error is passed to its callback.
+//
+// Messages sent via the manager are transmitted one at a time.
+// Transaction-related messages are always sent in chronological order, before any other message queued in the manager.
+// While a transaction-related message is waiting to be retransmitted, no other message is sent.
+// This way, messages queued while the charge point is offline are delivered in the correct order after reconnecting.
+// All requests should therefore be sent via the manager, rather than directly via the charge point.
+//
+// StartTransaction returns a locally generated transaction ID, which may be used in subsequent
+// StopTransaction and MeterValues requests, before the transaction was confirmed by the central system.
+// Local IDs are negative numbers, and are replaced by the ID assigned by the central system before sending the requests.
+// If the StartTransaction request fails, subsequent requests for the same local ID are discarded.
+//
+// A manager created via NewTransactionMessageManager keeps its messages in memory only.
+// A manager created via NewPersistentTransactionMessageManager stores all queued transaction-related messages in a file,
+// so that they are delivered after restarting the charge point as well. Other messages are never persisted.
+//
+// The manager is opt-in and needs to be created explicitly for a charge point:
+//	manager := ocpp16.NewTransactionMessageManager(chargePoint, 3, 60*time.Second)
+type TransactionMessageManager struct {
+	chargePoint          ChargePoint
+	retryPolicy          *ocppj.RetryPolicy
+	path                 string
+	transactionMessages  []*queuedMessage
+	messages             []*queuedMessage
+	inFlight             bool
+	retryPending         bool
+	lastLocalID          int
+	transactionIDs       map[int]int
+	failedTransactionIDs map[int]bool
+	mutex                sync.Mutex
+}
+
+// NewTransactionMessageManager creates a TransactionMessageManager, which sends requests via the given charge point.
+// The attempts and retryInterval values correspond to the TransactionMessageAttempts and TransactionMessageRetryInterval
+// configuration keys, see NewTransactionMessageRetryPolicy.
+func NewTransactionMessageManager(chargePoint ChargePoint, attempts int, retryInterval time.Duration) *TransactionMessageManager {
+	return &TransactionMessageManager{
+		chargePoint:          chargePoint,
+		retryPolicy:          NewTransactionMessageRetryPolicy(attempts, retryInterval),
+		transactionIDs:       map[int]int{},
+		failedTransactionIDs: map[int]bool{},
+	}
+}
+
+// NewPersistentTransactionMessageManager creates a TransactionMessageManager like NewTransactionMessageManager,
+// which persists its transaction-related messages in the file at path.
+// If the file exists already, the messages queued by a previous run are restored from it. They are sent ahead of
+// all new messages once Start is invoked. Their callbacks are lost, hence their results aren't reported.
+//
+// Transaction-related messages are only passed to the charge point once they are sent, so the charge point
+// doesn't need a persistent request queue for them. If it uses one anyway (see ocppj.FileClientQueue),
+// a message which was awaiting a response while stopping may be sent twice after restarting.
+//
+// An error is returned, if the file couldn't be read or written.
+func NewPersistentTransactionMessageManager(chargePoint ChargePoint, path string, attempts int, retryInterval time.Duration) (*TransactionMessageManager, error) {
+	m := NewTransactionMessageManager(chargePoint, attempts, retryInterval)
+	m.path = path
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	if err := m.persist(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewTransactionMessageRetryPolicy creates the retry policy for transaction-related messages defined by OCPP 1.6.
+// A message is sent up to attempts times, waiting retryInterval multiplied by the number of failed attempts
+// before sending it again. The values correspond to the TransactionMessageAttempts and TransactionMessageRetryInterval
+// configuration keys.
+func NewTransactionMessageRetryPolicy(attempts int, retryInterval time.Duration) *ocppj.RetryPolicy {
+	policy := ocppj.NewRetryPolicy(attempts, core.StartTransactionFeatureName, core.StopTransactionFeatureName, core.MeterValuesFeatureName)
+	policy.InitialInterval = retryInterval
+	policy.MaxInterval = 0
+	policy.Jitter = 0
+	policy.Linear = true
+	return policy
+}
+
+// SetRetryPolicy sets the policy, which defines whether and when a failed transaction-related message is sent again.
+// The new policy applies to messages, which are currently queued as well. Passing nil disables retransmissions.
+// The policy must not be modified after being set.
+//
+// When the TransactionMessageAttempts or TransactionMessageRetryInterval configuration keys change, pass a new policy:
+//	manager.SetRetryPolicy(ocpp16.NewTransactionMessageRetryPolicy(attempts, retryInterval))
+func (m *TransactionMessageManager) SetRetryPolicy(policy *ocppj.RetryPolicy) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.retryPolicy = policy
+}
+
+// Start sends the messages restored from the queue file of a persistent manager.
+// It should be invoked once the charge point was started. Until then, restored messages are only sent
+// together with the next message queued via the manager.
+func (m *TransactionMessageManager) Start() {
+	m.sendNext()
+}
+
+// TransactionID returns the transaction ID assigned by the central system to the transaction with the given local ID.
+// The second return value is false, if the transaction wasn't confirmed yet.
+func (m *TransactionMessageManager) TransactionID(localID int) (int, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	transactionID, ok := m.transactionIDs[localID]
+	return transactionID, ok
+}
+
+// StartTransaction queues a StartTransaction request and returns the local ID of the new transaction.
+// The callback is invoked once the request was confirmed, or after all attempts failed.
+func (m *TransactionMessageManager) StartTransaction(connectorId int, idTag string, meterStart int, timestamp *types.DateTime, callback func(confirmation *core.StartTransactionConfirmation, err error), props ...func(request *core.StartTransactionRequest)) (int, error) {
+	request := core.NewStartTransactionRequest(connectorId, idTag, meterStart, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	m.mutex.Lock()
+	m.lastLocalID--
+	localID := m.lastLocalID
+	m.mutex.Unlock()
+	err := m.enqueue(request, localID, func(confirmation ocpp.Response, err error) {
+		if callback == nil {
+			return
+		}
+		if err != nil {
+			callback(nil, err)
+		} else {
+			callback(confirmation.(*core.StartTransactionConfirmation), nil)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return localID, nil
+}
+
+// StopTransaction queues a StopTransaction request. The transactionId may be a local transaction ID.
+// The callback is invoked once the request was confirmed, or after all attempts failed.
+func (m *TransactionMessageManager) StopTransaction(meterStop int, timestamp *types.DateTime, transactionId int, callback func(confirmation *core.StopTransactionConfirmation, err error), props ...func(request *core.StopTransactionRequest)) error {
+	request := core.NewStopTransactionRequest(meterStop, timestamp, transactionId)
+	for _, fn := range props {
+		fn(request)
+	}
+	return m.enqueue(request, 0, func(confirmation ocpp.Response, err error) {
+		if callback == nil {
+			return
+		}
+		if err != nil {
+			callback(nil, err)
+		} else {
+			callback(confirmation.(*core.StopTransactionConfirmation), nil)
+		}
+	})
+}
+
+// MeterValues queues a MeterValues request. The transaction ID of the request may be a local transaction ID.
+// The callback is invoked once the request was confirmed, or after all attempts failed.
+func (m *TransactionMessageManager) MeterValues(connectorId int, meterValues []types.MeterValue, callback func(confirmation *core.MeterValuesConfirmation, err error), props ...func(request *core.MeterValuesRequest)) error {
+	request := core.NewMeterValuesRequest(connectorId, meterValues)
+	for _, fn := range props {
+		fn(request)
+	}
+	return m.enqueue(request, 0, func(confirmation ocpp.Response, err error) {
+		if callback == nil {
+			return
+		}
+		if err != nil {
+			callback(nil, err)
+		} else {
+			callback(confirmation.(*core.MeterValuesConfirmation), nil)
+		}
+	})
+}
+
+// SendRequestAsync queues any request supported by the charge point.
+// Transaction-related requests are retransmitted on failure, all other requests are sent once.
+func (m *TransactionMessageManager) SendRequestAsync(request ocpp.Request, callback func(confirmation ocpp.Response, err error)) error {
+	return m.enqueue(request, 0, callback)
+}
+
+func isTransactionMessage(request ocpp.Request) bool {
+	switch req := request.(type) {
+	case *core.StartTransactionRequest, *core.StopTransactionRequest:
+		return true
+	case *core.MeterValuesRequest:
+		return req.TransactionId != nil
+	default:
+		return false
+	}
+}
+
+func (m *TransactionMessageManager) enqueue(request ocpp.Request, localTransactionID int, callback func(confirmation ocpp.Response, err error)) error {
+	if err := ocppj.Validate.Struct(request); err != nil {
+		return err
+	}
+	message := &queuedMessage{
+		request:            request,
+		callback:           callback,
+		localTransactionID: localTransactionID,
+		transactionMessage: isTransactionMessage(request),
+	}
+	m.mutex.Lock()
+	if message.transactionMessage {
+		m.transactionMessages = append(m.transactionMessages, message)
+		if err := m.persist(); err != nil {
+			m.transactionMessages = m.transactionMessages[:len(m.transactionMessages)-1]
+			m.mutex.Unlock()
+			return err
+		}
+	} else {
+		m.messages = append(m.messages, message)
+	}
+	m.mutex.Unlock()
+	m.sendNext()
+	return nil
+}
+
+// Replaces a local transaction ID contained in a request with the ID assigned by the central system.
+// Returns an error, if the transaction with the local ID couldn't be started.
+func (m *TransactionMessageManager) remapTransactionID(request ocpp.Request) error {
+	switch req := request.(type) {
+	case *core.StopTransactionRequest:
+		transactionID, err := m.confirmedTransactionID(req.TransactionId, request)
+		if err != nil {
+			return err
+		}
+		req.TransactionId = transactionID
+	case *core.MeterValuesRequest:
+		if req.TransactionId == nil {
+			return nil
+		}
+		transactionID, err := m.confirmedTransactionID(*req.TransactionId, request)
+		if err != nil {
+			return err
+		}
+		req.TransactionId = &transactionID
+	}
+	return nil
+}
+
+// Returns the transaction ID assigned by the central system for a local transaction ID.
+// IDs which aren't local, or belong to a transaction that wasn't confirmed yet, are returned unchanged.
+func (m *TransactionMessageManager) confirmedTransactionID(transactionID int, request ocpp.Request) (int, error) {
+	if transactionID >= 0 {
+		return transactionID, nil
+	}
+	if m.failedTransactionIDs[transactionID] {
+		return 0, fmt.Errorf("transaction %v was never started, discarding %v request", transactionID, request.GetFeatureName())
+	}
+	if confirmedID, ok := m.transactionIDs[transactionID]; ok {
+		return confirmedID, nil
+	}
+	return transactionID, nil
+}
+
+// Sends queued messages until one is awaiting a response.
+// Transaction-related messages are sent first. No message is sent while a transaction-related message is waiting to be retransmitted.
+func (m *TransactionMessageManager) sendNext() {
+	for {
+		m.mutex.Lock()
+		if m.inFlight || m.retryPending {
+			m.mutex.Unlock()
+			return
+		}
+		var message *queuedMessage
+		if len(m.transactionMessages) > 0 {
+			message = m.transactionMessages[0]
+			if err := m.remapTransactionID(message.request); err != nil {
+				m.transactionMessages = m.transactionMessages[1:]
+				_ = m.persist()
+				m.mutex.Unlock()
+				if message.callback != nil {
+					message.callback(nil, err)
+				}
+				continue
+			}
+		} else if len(m.messages) > 0 {
+			message = m.messages[0]
+			m.messages = m.messages[1:]
+		} else {
+			m.mutex.Unlock()
+			return
+		}
+		m.inFlight = true
+		message.attempts++
+		if message.transactionMessage {
+			_ = m.persist()
+		}
+		m.mutex.Unlock()
+		err := m.chargePoint.SendRequestAsync(message.request, func(confirmation ocpp.Response, err error) {
+			m.onResult(message, confirmation, err)
+		})
+		if err == nil {
+			return
+		}
+		// The request couldn't be sent at all. Its result is processed here, before moving on to the next message
+		m.processResult(message, nil, err)
+	}
+}
+
+// Processes the result of a transmission attempt, then sends the next message.
+func (m *TransactionMessageManager) onResult(message *queuedMessage, confirmation ocpp.Response, err error) {
+	m.processResult(message, confirmation, err)
+	m.sendNext()
+}
+
+// Processes the result of a transmission attempt, invoking the callback of the message unless it will be retransmitted.
+func (m *TransactionMessageManager) processResult(message *queuedMessage, confirmation ocpp.Response, err error) {
+	m.mutex.Lock()
+	m.inFlight = false
+	if message.transactionMessage {
+		if err != nil && m.retryPolicy.ShouldRetry(message.request.GetFeatureName(), message.attempts) {
+			// Keep message in the queue, all messages are blocked until it was retransmitted
+			m.scheduleRetry(m.retryPolicy.Delay(message.attempts))
+			m.mutex.Unlock()
+			return
+		}
+		m.removeTransactionMessage(message)
+		if message.localTransactionID != 0 {
+			if err != nil {
+				m.failedTransactionIDs[message.localTransactionID] = true
+			} else {
+				m.transactionIDs[message.localTransactionID] = confirmation.(*core.StartTransactionConfirmation).TransactionId
+			}
+		}
+		_ = m.persist()
+	}
+	m.mutex.Unlock()
+	if message.callback != nil {
+		message.callback(confirmation, err)
+	}
+}
+
+// Blocks sending messages, until the delay elapsed.
+// If messages are blocked already, the pending delay applies. Must be called while holding the mutex.
+func (m *TransactionMessageManager) scheduleRetry(delay time.Duration) {
+	if m.retryPending {
+		return
+	}
+	m.retryPending = true
+	time.AfterFunc(delay, func() {
+		m.mutex.Lock()
+		m.retryPending = false
+		m.mutex.Unlock()
+		m.sendNext()
+	})
+}
+
+// Removes a message from the transaction-related messages. Must be called while holding the mutex.
+func (m *TransactionMessageManager) removeTransactionMessage(message *queuedMessage) {
+	for i, queued := range m.transactionMessages {
+		if queued == message {
+			m.transactionMessages = append(m.transactionMessages[:i:i], m.transactionMessages[i+1:]...)
+			return
+		}
+	}
+}
+
+// Contents of the queue file of a persistent TransactionMessageManager.
+// Transaction IDs are only stored for local IDs, which are referenced by queued messages.
+type persistedState struct {
+	LastLocalID          int                `json:"lastLocalId"`
+	TransactionIDs       map[int]int        `json:"transactionIds,omitempty"`
+	FailedTransactionIDs []int              `json:"failedTransactionIds,omitempty"`
+	Messages             []persistedMessage `json:"messages"`
+}
+
+type persistedMessage struct {
+	Action             string          `json:"action"`
+	Request            json.RawMessage `json:"request"`
+	Attempts           int             `json:"attempts"`
+	LocalTransactionID int             `json:"localTransactionId,omitempty"`
+}
+
+// Returns the transaction ID contained in a StopTransaction or MeterValues request.
+func referencedTransactionID(request ocpp.Request) (int, bool) {
+	switch req := request.(type) {
+	case *core.StopTransactionRequest:
+		return req.TransactionId, true
+	case *core.MeterValuesRequest:
+		if req.TransactionId != nil {
+			return *req.TransactionId, true
+		}
+	}
+	return 0, false
+}
+
+// Restores the state of a persistent manager from its queue file, if the file exists.
+func (m *TransactionMessageManager) load() error {
+	data, err := ioutil.ReadFile(m.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("couldn't read transaction message queue file %v: %w", m.path, err)
+	}
+	var state persistedState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("couldn't parse transaction message queue file %v: %w", m.path, err)
+	}
+	m.lastLocalID = state.LastLocalID
+	for localID, transactionID := range state.TransactionIDs {
+		m.transactionIDs[localID] = transactionID
+	}
+	for _, localID := range state.FailedTransactionIDs {
+		m.failedTransactionIDs[localID] = true
+	}
+	for _, persisted := range state.Messages {
+		feature := core.Profile.GetFeature(persisted.Action)
+		if feature == nil {
+			return fmt.Errorf("invalid action %v in transaction message queue file %v", persisted.Action, m.path)
+		}
+		request := reflect.New(feature.GetRequestType()).Interface()
+		if err = json.Unmarshal(persisted.Request, request); err != nil {
+			return fmt.Errorf("couldn't parse %v request in transaction message queue file %v: %w", persisted.Action, m.path, err)
+		}
+		m.transactionMessages = append(m.transactionMessages, &queuedMessage{
+			request:            request.(ocpp.Request),
+			attempts:           persisted.Attempts,
+			localTransactionID: persisted.LocalTransactionID,
+			transactionMessage: true,
+		})
+	}
+	return nil
+}
+
+// Atomically replaces the queue file of a persistent manager with the current transaction-related messages.
+// Since the whole state is written every time, a failed write is repaired by the next one.
+// Must be called while holding the mutex.
+func (m *TransactionMessageManager) persist() error {
+	if m.path == "" {
+		return nil
+	}
+	state := persistedState{LastLocalID: m.lastLocalID, TransactionIDs: map[int]int{}, Messages: []persistedMessage{}}
+	referenced := map[int]bool{}
+	for _, message := range m.transactionMessages {
+		request, err := json.Marshal(message.request)
+		if err != nil {
+			return err
+		}
+		state.Messages = append(state.Messages, persistedMessage{
+			Action:             message.request.GetFeatureName(),
+			Request:            request,
+			Attempts:           message.attempts,
+			LocalTransactionID: message.localTransactionID,
+		})
+		if localID, ok := referencedTransactionID(message.request); ok && localID < 0 {
+			referenced[localID] = true
+		}
+	}
+	for localID := range referenced {
+		if transactionID, ok := m.transactionIDs[localID]; ok {
+			state.TransactionIDs[localID] = transactionID
+		} else if m.failedTransactionIDs[localID] {
+			state.FailedTransactionIDs = append(state.FailedTransactionIDs, localID)
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmpPath := m.path + ".tmp"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't create transaction message queue file %v: %w", tmpPath, err)
+	}
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	_ = tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't write transaction message queue file %v: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, m.path); err != nil {
+		return fmt.Errorf("couldn't replace transaction message queue file %v: %w", m.path, err)
+	}
+	return nil
+}
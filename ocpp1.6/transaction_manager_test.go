@@ -0,0 +1,273 @@
+package ocpp16
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+type sentRequest struct {
+	request  ocpp.Request
+	callback func(confirmation ocpp.Response, err error)
+	sentAt   time.Time
+}
+
+// Charge point stub, which exposes all asynchronously sent requests on a channel.
+// If sendErr is set, requests aren't sent but rejected right away.
+type mockTransactionChargePoint struct {
+	ChargePoint
+	requestC chan sentRequest
+	sendErr  error
+}
+
+func (cp *mockTransactionChargePoint) SendRequestAsync(request ocpp.Request, callback func(confirmation ocpp.Response, err error)) error {
+	if cp.sendErr != nil {
+		return cp.sendErr
+	}
+	cp.requestC <- sentRequest{request: request, callback: callback, sentAt: time.Now()}
+	return nil
+}
+
+func newMockTransactionChargePoint() *mockTransactionChargePoint {
+	return &mockTransactionChargePoint{requestC: make(chan sentRequest, 10)}
+}
+
+func expectSentRequest(t *testing.T, cp *mockTransactionChargePoint) sentRequest {
+	select {
+	case sent := <-cp.requestC:
+		return sent
+	case <-time.After(time.Second):
+		require.FailNow(t, "no request was sent")
+		return sentRequest{}
+	}
+}
+
+func expectNoSentRequest(t *testing.T, cp *mockTransactionChargePoint, timeout time.Duration) {
+	select {
+	case sent := <-cp.requestC:
+		assert.Fail(t, "unexpected request sent", "%v", sent.request.GetFeatureName())
+	case <-time.After(timeout):
+	}
+}
+
+func TestTransactionMessageRetry(t *testing.T) {
+	retryInterval := 20 * time.Millisecond
+	cp := newMockTransactionChargePoint()
+	manager := NewTransactionMessageManager(cp, 3, retryInterval)
+	resultC := make(chan error, 1)
+	localID, err := manager.StartTransaction(1, "tag1", 100, types.NewDateTime(time.Now()), func(confirmation *core.StartTransactionConfirmation, err error) {
+		assert.Nil(t, confirmation)
+		resultC <- err
+	})
+	require.NoError(t, err)
+	assert.True(t, localID < 0)
+	// Every attempt fails, the delay grows with the number of attempts
+	sent := expectSentRequest(t, cp)
+	for attempt := 1; attempt < 3; attempt++ {
+		sent.callback(nil, ocpp.NewError(ocppj.InternalError, "mock error", "1234"))
+		retry := expectSentRequest(t, cp)
+		assert.Equal(t, sent.request, retry.request)
+		assert.True(t, retry.sentAt.Sub(sent.sentAt) >= retryInterval*time.Duration(attempt))
+		sent = retry
+	}
+	sent.callback(nil, ocpp.NewError(ocppj.GenericError, "request timed out, no response received from server", "1234"))
+	err = <-resultC
+	require.Error(t, err)
+	assert.Equal(t, ocppj.GenericError, err.(*ocpp.Error).Code)
+	// Messages of the failed transaction are discarded
+	err = manager.StopTransaction(200, types.NewDateTime(time.Now()), localID, func(confirmation *core.StopTransactionConfirmation, err error) {
+		resultC <- err
+	})
+	require.NoError(t, err)
+	assert.Error(t, <-resultC)
+	expectNoSentRequest(t, cp, 4*retryInterval)
+}
+
+func TestTransactionMessageRetryPolicy(t *testing.T) {
+	cp := newMockTransactionChargePoint()
+	manager := NewTransactionMessageManager(cp, 3, time.Second)
+	// Only StopTransaction requests are retransmitted, without waiting
+	policy := ocppj.NewRetryPolicy(2, core.StopTransactionFeatureName)
+	policy.InitialInterval = 0
+	manager.SetRetryPolicy(policy)
+	resultC := make(chan error, 1)
+	_, err := manager.StartTransaction(1, "tag1", 100, types.NewDateTime(time.Now()), func(confirmation *core.StartTransactionConfirmation, err error) {
+		resultC <- err
+	})
+	require.NoError(t, err)
+	sent := expectSentRequest(t, cp)
+	sent.callback(nil, ocpp.NewError(ocppj.InternalError, "mock error", "1234"))
+	assert.Error(t, <-resultC)
+	err = manager.StopTransaction(200, types.NewDateTime(time.Now()), 42, func(confirmation *core.StopTransactionConfirmation, err error) {
+		resultC <- err
+	})
+	require.NoError(t, err)
+	sent = expectSentRequest(t, cp)
+	sent.callback(nil, ocpp.NewError(ocppj.InternalError, "mock error", "1234"))
+	sent = expectSentRequest(t, cp)
+	require.IsType(t, &core.StopTransactionRequest{}, sent.request)
+	sent.callback(core.NewStopTransactionConfirmation(), nil)
+	assert.NoError(t, <-resultC)
+	// Without a policy, failed messages are never retransmitted
+	manager.SetRetryPolicy(nil)
+	err = manager.StopTransaction(200, types.NewDateTime(time.Now()), 42, func(confirmation *core.StopTransactionConfirmation, err error) {
+		resultC <- err
+	})
+	require.NoError(t, err)
+	sent = expectSentRequest(t, cp)
+	sent.callback(nil, ocpp.NewError(ocppj.InternalError, "mock error", "1234"))
+	assert.Error(t, <-resultC)
+	expectNoSentRequest(t, cp, 50*time.Millisecond)
+}
+
+func TestTransactionMessageOrder(t *testing.T) {
+	transactionID := 42
+	retryInterval := 20 * time.Millisecond
+	cp := newMockTransactionChargePoint()
+	manager := NewTransactionMessageManager(cp, 3, retryInterval)
+	localID, err := manager.StartTransaction(1, "tag1", 100, types.NewDateTime(time.Now()), nil)
+	require.NoError(t, err)
+	// Other messages are queued behind transaction-related messages
+	err = manager.SendRequestAsync(core.NewHeartbeatRequest(), nil)
+	require.NoError(t, err)
+	err = manager.MeterValues(1, []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: "value"}}}}, nil, func(request *core.MeterValuesRequest) {
+		request.TransactionId = &localID
+	})
+	require.NoError(t, err)
+	err = manager.StopTransaction(200, types.NewDateTime(time.Now()), localID, nil)
+	require.NoError(t, err)
+	// StartTransaction fails once, no other message is sent until it was retransmitted
+	sent := expectSentRequest(t, cp)
+	require.IsType(t, &core.StartTransactionRequest{}, sent.request)
+	sent.callback(nil, ocpp.NewError(ocppj.InternalError, "mock error", "1234"))
+	sent = expectSentRequest(t, cp)
+	require.IsType(t, &core.StartTransactionRequest{}, sent.request)
+	_, ok := manager.TransactionID(localID)
+	assert.False(t, ok)
+	sent.callback(core.NewStartTransactionConfirmation(types.NewIdTagInfo(types.AuthorizationStatusAccepted), transactionID), nil)
+	confirmedID, ok := manager.TransactionID(localID)
+	assert.True(t, ok)
+	assert.Equal(t, transactionID, confirmedID)
+	// Subsequent messages use the transaction ID assigned by the central system
+	sent = expectSentRequest(t, cp)
+	meterValues, ok := sent.request.(*core.MeterValuesRequest)
+	require.True(t, ok)
+	assert.Equal(t, transactionID, *meterValues.TransactionId)
+	sent.callback(core.NewMeterValuesConfirmation(), nil)
+	sent = expectSentRequest(t, cp)
+	stopTransaction, ok := sent.request.(*core.StopTransactionRequest)
+	require.True(t, ok)
+	assert.Equal(t, transactionID, stopTransaction.TransactionId)
+	sent.callback(core.NewStopTransactionConfirmation(), nil)
+	// The heartbeat is sent last
+	sent = expectSentRequest(t, cp)
+	require.IsType(t, &core.HeartbeatRequest{}, sent.request)
+	sent.callback(core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil)
+}
+
+func TestTransactionMessageSendError(t *testing.T) {
+	retryInterval := 10 * time.Millisecond
+	cp := newMockTransactionChargePoint()
+	cp.sendErr = fmt.Errorf("mock send error")
+	manager := NewTransactionMessageManager(cp, 3, retryInterval)
+	resultC := make(chan string, 10)
+	_, err := manager.StartTransaction(1, "tag1", 100, types.NewDateTime(time.Now()), func(confirmation *core.StartTransactionConfirmation, err error) {
+		assert.Equal(t, cp.sendErr, err)
+		resultC <- core.StartTransactionFeatureName
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = manager.SendRequestAsync(core.NewHeartbeatRequest(), func(confirmation ocpp.Response, err error) {
+			assert.Equal(t, cp.sendErr, err)
+			resultC <- core.HeartbeatFeatureName
+		})
+		require.NoError(t, err)
+	}
+	// The transaction-related message is attempted three times, before all other messages fail right away
+	expected := []string{core.StartTransactionFeatureName, core.HeartbeatFeatureName, core.HeartbeatFeatureName, core.HeartbeatFeatureName}
+	for _, feature := range expected {
+		select {
+		case result := <-resultC:
+			assert.Equal(t, feature, result)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no result received")
+		}
+	}
+}
+
+func TestTransactionMessagePersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ocpp16-transactions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "transactions")
+	cp := newMockTransactionChargePoint()
+	manager, err := NewPersistentTransactionMessageManager(cp, path, 3, 10*time.Millisecond)
+	require.NoError(t, err)
+	// Two transactions are run, while the central system doesn't respond to MeterValues
+	localID1, err := manager.StartTransaction(1, "tag1", 100, types.NewDateTime(time.Now()), nil)
+	require.NoError(t, err)
+	meterValues := []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: "value"}}}}
+	err = manager.MeterValues(1, meterValues, nil, func(request *core.MeterValuesRequest) {
+		request.TransactionId = &localID1
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.StopTransaction(200, types.NewDateTime(time.Now()), localID1, nil))
+	localID2, err := manager.StartTransaction(2, "tag2", 100, types.NewDateTime(time.Now()), nil)
+	require.NoError(t, err)
+	require.NoError(t, manager.StopTransaction(300, types.NewDateTime(time.Now()), localID2, nil))
+	require.NoError(t, manager.SendRequestAsync(core.NewHeartbeatRequest(), nil))
+	sent := expectSentRequest(t, cp)
+	require.IsType(t, &core.StartTransactionRequest{}, sent.request)
+	sent.callback(core.NewStartTransactionConfirmation(types.NewIdTagInfo(types.AuthorizationStatusAccepted), 42), nil)
+	sent = expectSentRequest(t, cp)
+	require.IsType(t, &core.MeterValuesRequest{}, sent.request)
+	// After restarting, all transaction-related messages are delivered in order, using the confirmed transaction IDs
+	cp = newMockTransactionChargePoint()
+	manager, err = NewPersistentTransactionMessageManager(cp, path, 3, 10*time.Millisecond)
+	require.NoError(t, err)
+	expectNoSentRequest(t, cp, 20*time.Millisecond)
+	manager.Start()
+	sent = expectSentRequest(t, cp)
+	restoredMeterValues, ok := sent.request.(*core.MeterValuesRequest)
+	require.True(t, ok)
+	assert.Equal(t, 42, *restoredMeterValues.TransactionId)
+	assert.Equal(t, meterValues[0].SampledValue, restoredMeterValues.MeterValue[0].SampledValue)
+	sent.callback(core.NewMeterValuesConfirmation(), nil)
+	sent = expectSentRequest(t, cp)
+	stopTransaction, ok := sent.request.(*core.StopTransactionRequest)
+	require.True(t, ok)
+	assert.Equal(t, 42, stopTransaction.TransactionId)
+	sent.callback(core.NewStopTransactionConfirmation(), nil)
+	sent = expectSentRequest(t, cp)
+	startTransaction, ok := sent.request.(*core.StartTransactionRequest)
+	require.True(t, ok)
+	assert.Equal(t, 2, startTransaction.ConnectorId)
+	sent.callback(core.NewStartTransactionConfirmation(types.NewIdTagInfo(types.AuthorizationStatusAccepted), 43), nil)
+	transactionID, ok := manager.TransactionID(localID2)
+	assert.True(t, ok)
+	assert.Equal(t, 43, transactionID)
+	// New local IDs don't collide with restored ones
+	localID3, err := manager.StartTransaction(1, "tag3", 100, types.NewDateTime(time.Now()), nil)
+	require.NoError(t, err)
+	assert.True(t, localID3 < localID2)
+	sent = expectSentRequest(t, cp)
+	stopTransaction, ok = sent.request.(*core.StopTransactionRequest)
+	require.True(t, ok)
+	assert.Equal(t, 43, stopTransaction.TransactionId)
+	sent.callback(core.NewStopTransactionConfirmation(), nil)
+	sent = expectSentRequest(t, cp)
+	require.IsType(t, &core.StartTransactionRequest{}, sent.request)
+	sent.callback(core.NewStartTransactionConfirmation(types.NewIdTagInfo(types.AuthorizationStatusAccepted), 44), nil)
+	expectNoSentRequest(t, cp, 20*time.Millisecond)
+}
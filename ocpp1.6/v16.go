@@ -2,7 +2,10 @@
 package ocpp16
 
 import (
+	"context"
 	"crypto/tls"
+	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -13,9 +16,11 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ocpps"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
 
@@ -34,7 +39,7 @@ type ChargePointConnectionHandler func(chargePoint ChargePointConnection)
 // The logic for incoming messages needs to be implemented, and the message handlers need to be registered with the charge point:
 // 	handler := &ChargePointHandler{}
 //	client.SetCoreHandler(handler)
-// Refer to the ChargePointHandler interfaces in the respective core, firmware, localauth, remotetrigger, reservation and smartcharging profiles for the implementation requirements.
+// Refer to the ChargePointHandler interfaces in the respective core, firmware, localauth, remotetrigger, reservation, smartcharging and security profiles for the implementation requirements.
 //
 // A charge point can be started and stopped using the Start and Stop functions.
 // While running, messages can be sent to the Central system by calling the Charge point's functions, e.g.
@@ -63,25 +68,44 @@ type ChargePoint interface {
 	DiagnosticsStatusNotification(status firmware.DiagnosticsStatus, props ...func(request *firmware.DiagnosticsStatusNotificationRequest)) (*firmware.DiagnosticsStatusNotificationConfirmation, error)
 	// Notifies the central system of a status change during the download of a new firmware version.
 	FirmwareStatusNotification(status firmware.FirmwareStatus, props ...func(request *firmware.FirmwareStatusNotificationRequest)) (*firmware.FirmwareStatusNotificationConfirmation, error)
+	// Notifies the central system of a status change during the upload of a log file.
+	LogStatusNotification(status firmware.UploadLogStatus, props ...func(request *firmware.LogStatusNotificationRequest)) (*firmware.LogStatusNotificationConfirmation, error)
+	// Notifies the central system of a status change during the download, verification and installation of a signed firmware image.
+	SignedFirmwareStatusNotification(status firmware.SignedFirmwareStatus, props ...func(request *firmware.SignedFirmwareStatusNotificationRequest)) (*firmware.SignedFirmwareStatusNotificationConfirmation, error)
+	// Sends a certificate signing request to the central system. The signed certificate will be delivered asynchronously via a CertificateSigned message.
+	SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateConfirmation, error)
+	// Notifies the central system of a security-related event that occurred on the charge point.
+	SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationConfirmation, error)
 
 	// Registers a handler for incoming core profile messages
 	SetCoreHandler(listener core.ChargePointHandler)
 	// Registers a handler for incoming local authorization profile messages
 	SetLocalAuthListHandler(listener localauth.ChargePointHandler)
-	// Registers a handler for incoming firmware management profile messages
+	// Registers a handler for incoming firmware management profile messages.
+	// Log retrieval and signed firmware update requests are only handled, if the handler also implements firmware.ChargePointSecurityExtensionHandler.
 	SetFirmwareManagementHandler(listener firmware.ChargePointHandler)
 	// Registers a handler for incoming reservation profile messages
 	SetReservationHandler(listener reservation.ChargePointHandler)
-	// Registers a handler for incoming remote trigger profile messages
+	// Registers a handler for incoming remote trigger profile messages.
+	// ExtendedTriggerMessage requests are only handled, if the handler also implements remotetrigger.ChargePointExtendedTriggerHandler.
 	SetRemoteTriggerHandler(listener remotetrigger.ChargePointHandler)
 	// Registers a handler for incoming smart charging profile messages
 	SetSmartChargingHandler(listener smartcharging.ChargePointHandler)
+	// Registers a handler for incoming security extension messages
+	SetSecurityHandler(listener security.ChargePointHandler)
 	// Sends a request to the central system.
 	// The central system will respond with a confirmation, or with an error if the request was invalid or could not be processed.
 	// In case of network issues (i.e. the remote host couldn't be reached), the function also returns an error.
 	//
 	// The request is synchronous blocking.
 	SendRequest(request ocpp.Request) (ocpp.Response, error)
+	// Sends a request to the central system, like SendRequest.
+	// If the context is done before a response is received, ctx.Err() is returned right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	//
+	// Typed helpers such as BootNotification may be replaced by passing the respective request to this function, e.g.:
+	//	confirmation, err := chargePoint.SendRequestWithContext(ctx, core.NewBootNotificationRequest("model1", "vendor1"))
+	SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error)
 	// Sends an asynchronous request to the central system.
 	// The central system will respond with a confirmation messages, or with an error if the request was invalid or could not be processed.
 	// This result is propagated via a callback, called asynchronously.
@@ -126,6 +150,9 @@ type ChargePoint interface {
 //
 // For more advanced options, or if a customer networking/occpj layer is required,
 // please refer to ocppj.Client and ws.WsClient.
+//
+// Requests that can't be written to the network are canceled right away. To retransmit them instead,
+// pass an endpoint whose ocppj.DefaultClientDispatcher has a retry policy (see SetRetryPolicy).
 func NewChargePoint(id string, endpoint *ocppj.Client, client ws.WsClient) ChargePoint {
 	if client == nil {
 		client = ws.NewClient()
@@ -143,18 +170,18 @@ func NewChargePoint(id string, endpoint *ocppj.Client, client ws.WsClient) Charg
 			dialer.Subprotocols = append(dialer.Subprotocols, types.V16Subprotocol)
 		}
 	})
-	cp := chargePoint{confirmationHandler: make(chan ocpp.Response, 1), errorHandler: make(chan error, 1), callbacks: callbackqueue.New()}
+	cp := chargePoint{confirmationHandler: make(chan receivedResponse, 1), errorHandler: make(chan *ocpp.Error, 1), callbacks: callbackqueue.New()}
 
 	if endpoint == nil {
 		dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
-		endpoint = ocppj.NewClient(id, client, dispatcher, nil, core.Profile, localauth.Profile, firmware.Profile, reservation.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint = ocppj.NewClient(id, client, dispatcher, nil, core.Profile, localauth.Profile, firmware.Profile, reservation.Profile, remotetrigger.Profile, smartcharging.Profile, security.Profile)
 	}
 	// Callback invoked by dispatcher, whenever a queued request is canceled, due to timeout.
 	endpoint.SetOnRequestCanceled(cp.onRequestTimeout)
 	cp.client = endpoint
 
 	cp.client.SetResponseHandler(func(confirmation ocpp.Response, requestId string) {
-		cp.confirmationHandler <- confirmation
+		cp.confirmationHandler <- receivedResponse{requestId: requestId, response: confirmation}
 	})
 	cp.client.SetErrorHandler(func(err *ocpp.Error, details interface{}) {
 		cp.errorHandler <- err
@@ -171,7 +198,7 @@ func NewChargePoint(id string, endpoint *ocppj.Client, client ws.WsClient) Charg
 // The logic for handling incoming messages needs to be implemented, and the message handlers need to be registered with the central system:
 //	handler := &CentralSystemHandler{}
 //	server.SetCoreHandler(handler)
-// Refer to the CentralSystemHandler interfaces in the respective core, firmware, localauth, remotetrigger, reservation and smartcharging profiles for the implementation requirements.
+// Refer to the CentralSystemHandler interfaces in the respective core, firmware, localauth, remotetrigger, reservation, smartcharging and security profiles for the implementation requirements.
 //
 // A Central system can be started by using the Start function.
 // To be notified of incoming (dis)connections from charge points refer to the SetNewClientHandler and SetChargePointDisconnectedHandler functions.
@@ -211,24 +238,39 @@ type CentralSystem interface {
 	GetDiagnostics(clientId string, callback func(*firmware.GetDiagnosticsConfirmation, error), location string, props ...func(request *firmware.GetDiagnosticsRequest)) error
 	// Instructs the charge point to download and install a new firmware version. The firmware binary will be downloaded out-of-band from the provided URL location.
 	UpdateFirmware(clientId string, callback func(*firmware.UpdateFirmwareConfirmation, error), location string, retrieveDate *types.DateTime, props ...func(request *firmware.UpdateFirmwareRequest)) error
+	// Requests a diagnostics or security log from a charge point. The log will be uploaded out-of-band to the location specified in the log parameters.
+	GetLog(clientId string, callback func(*firmware.GetLogConfirmation, error), logType firmware.LogType, requestID int, logParameters firmware.LogParameters, props ...func(request *firmware.GetLogRequest)) error
+	// Instructs the charge point to download, verify and install a signed firmware image.
+	SignedUpdateFirmware(clientId string, callback func(*firmware.SignedUpdateFirmwareConfirmation, error), requestID int, fw firmware.Firmware, props ...func(request *firmware.SignedUpdateFirmwareRequest)) error
 	// Instructs the charge point to reserve a connector for a specific IdTag (client). The connector, or the entire charge point, will be reserved until the provided expiration time.
 	ReserveNow(clientId string, callback func(*reservation.ReserveNowConfirmation, error), connectorId int, expiryDate *types.DateTime, idTag string, reservationId int, props ...func(request *reservation.ReserveNowRequest)) error
 	// Cancels a previously reserved charge point or connector, given the reservation ID.
 	CancelReservation(clientId string, callback func(*reservation.CancelReservationConfirmation, error), reservationId int, props ...func(request *reservation.CancelReservationRequest)) error
 	// Instructs a charge point to send a specific message to the central system. This is used for forcefully triggering status updates, when the last known state is either too old or not clear to the central system.
 	TriggerMessage(clientId string, callback func(*remotetrigger.TriggerMessageConfirmation, error), requestedMessage remotetrigger.MessageTrigger, props ...func(request *remotetrigger.TriggerMessageRequest)) error
+	// Instructs a charge point to send a specific message to the central system. Unlike TriggerMessage, this also allows triggering messages introduced by the security extension.
+	ExtendedTriggerMessage(clientId string, callback func(*remotetrigger.ExtendedTriggerMessageConfirmation, error), requestedMessage remotetrigger.ExtendedMessageTrigger, props ...func(request *remotetrigger.ExtendedTriggerMessageRequest)) error
 	// Sends a smart charging profile to a charge point. Refer to the smart charging documentation for more information.
 	SetChargingProfile(clientId string, callback func(*smartcharging.SetChargingProfileConfirmation, error), connectorId int, chargingProfile *types.ChargingProfile, props ...func(request *smartcharging.SetChargingProfileRequest)) error
 	// Removes one or more charging profiles from a charge point.
 	ClearChargingProfile(clientId string, callback func(*smartcharging.ClearChargingProfileConfirmation, error), props ...func(request *smartcharging.ClearChargingProfileRequest)) error
 	// Queries a charge point to the composite smart charging schedules and rules for a specified time interval.
 	GetCompositeSchedule(clientId string, callback func(*smartcharging.GetCompositeScheduleConfirmation, error), connectorId int, duration int, props ...func(request *smartcharging.GetCompositeScheduleRequest)) error
+	// Sends a signed certificate chain to a charge point, in response to a previous SignCertificate request.
+	CertificateSigned(clientId string, callback func(*security.CertificateSignedConfirmation, error), certificateChain string, props ...func(request *security.CertificateSignedRequest)) error
+	// Instructs a charge point to install a new root certificate.
+	InstallCertificate(clientId string, callback func(*security.InstallCertificateConfirmation, error), certificateType types.CertificateUse, certificate string, props ...func(request *security.InstallCertificateRequest)) error
+	// Instructs a charge point to delete an installed root certificate, identified by its hash data.
+	DeleteCertificate(clientId string, callback func(*security.DeleteCertificateConfirmation, error), certificateHashData types.CertificateHashData, props ...func(request *security.DeleteCertificateRequest)) error
+	// Retrieves the hash data of all root certificates of a given type, installed on a charge point.
+	GetInstalledCertificateIds(clientId string, callback func(*security.GetInstalledCertificateIdsConfirmation, error), certificateType types.CertificateUse, props ...func(request *security.GetInstalledCertificateIdsRequest)) error
 
 	// Registers a handler for incoming core profile messages.
 	SetCoreHandler(handler core.CentralSystemHandler)
 	// Registers a handler for incoming local authorization profile messages.
 	SetLocalAuthListHandler(handler localauth.CentralSystemHandler)
 	// Registers a handler for incoming firmware management profile messages.
+	// Log and signed firmware status notifications are only handled, if the handler also implements firmware.CentralSystemSecurityExtensionHandler.
 	SetFirmwareManagementHandler(handler firmware.CentralSystemHandler)
 	// Registers a handler for incoming reservation profile messages.
 	SetReservationHandler(handler reservation.CentralSystemHandler)
@@ -236,15 +278,43 @@ type CentralSystem interface {
 	SetRemoteTriggerHandler(handler remotetrigger.CentralSystemHandler)
 	// Registers a handler for incoming smart charging profile messages.
 	SetSmartChargingHandler(handler smartcharging.CentralSystemHandler)
+	// Registers a handler for incoming security extension messages.
+	SetSecurityHandler(handler security.CentralSystemHandler)
 	// Registers a handler for new incoming charge point connections.
 	SetNewChargePointHandler(handler ChargePointConnectionHandler)
 	// Registers a handler for charge point disconnections.
 	SetChargePointDisconnectedHandler(handler ChargePointConnectionHandler)
+	// Sets the default timeout for responses to requests sent to charge points. A timeout of zero disables the timeout, which is the default.
+	// If a charge point doesn't respond in time, the request is discarded and its callback is invoked with a GenericError.
+	//
+	// This function must be called before starting the central system. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetRequestTimeout(timeout time.Duration) error
+	// Sets the timeout for responses to requests of a specific feature (e.g. a slow firmware update), overriding the default request timeout for that feature only.
+	//
+	// This function must be called before starting the central system. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetFeatureRequestTimeout(featureName string, timeout time.Duration) error
 	// Sends an asynchronous request to the charge point.
 	// The charge point will respond with a confirmation message, or with an error if the request was invalid or could not be processed.
 	// This result is propagated via a callback, called asynchronously.
 	// In case of network issues (i.e. the remote host couldn't be reached), the function returns an error directly. In this case, the callback is never called.
 	SendRequestAsync(clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
+	// Sends an asynchronous request to the charge point, like SendRequestAsync.
+	// If the context is done before a response is received, the callback is invoked with ctx.Err() right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	SendRequestAsyncWithContext(ctx context.Context, clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
+	// Returns an HTTP handler, serving charge points which communicate via SOAP (OCPP-S) instead of websockets.
+	// The handler may be mounted on any HTTP server. Incoming SOAP requests are passed to the same handlers as OCPP-J requests.
+	//
+	// Charge points are identified by the chargeBoxIdentity header. Once a charge point advertised its own address via the
+	// WS-Addressing From header, all requests to that charge point are sent via SOAP to the advertised address.
+	// Requests to the same charge point are sent sequentially. A charge point connected via websocket is never reached via SOAP.
+	//
+	// All SOAP requests are rejected, unless a handler was registered via SetSOAPCheckClientHandler.
+	SOAPHandler() http.Handler
+	// Registers a handler for authenticating charge points, which send requests via OCPP-S.
+	// The handler receives the claimed chargeBoxIdentity and the raw HTTP request, e.g. for checking basic auth credentials.
+	// Requests from charge points currently connected via websocket are always rejected.
+	SetSOAPCheckClientHandler(handler ocpps.CheckClientHandler)
 	// Starts running the central system on the specified port and URL.
 	// The central system runs as a daemon and handles incoming charge point connections and messages.
 	//
@@ -270,7 +340,7 @@ func NewCentralSystem(endpoint *ocppj.Server, server ws.WsServer) CentralSystem
 	}
 	server.AddSupportedSubprotocol(types.V16Subprotocol)
 	if endpoint == nil {
-		endpoint = ocppj.NewServer(server, nil, nil, core.Profile, localauth.Profile, firmware.Profile, reservation.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint = ocppj.NewServer(server, nil, nil, core.Profile, localauth.Profile, firmware.Profile, reservation.Profile, remotetrigger.Profile, smartcharging.Profile, security.Profile)
 	}
 	cs := newCentralSystem(endpoint)
 	cs.server.SetRequestHandler(func(client ws.Channel, request ocpp.Request, requestId string, action string) {
@@ -282,5 +352,17 @@ func NewCentralSystem(endpoint *ocppj.Server, server ws.WsServer) CentralSystem
 	cs.server.SetErrorHandler(func(client ws.Channel, err *ocpp.Error, details interface{}) {
 		cs.handleIncomingError(client, err, details)
 	})
+	// Callback invoked by dispatcher, whenever a queued request is canceled, due to timeout.
+	cs.server.SetOnRequestCanceled(ocppj.NewRequestTimeoutHandler(cs.callbackQueue.DequeueRequest, cs.error))
+	cs.server.SetNewClientHandler(func(client ws.Channel) {
+		cs.handleNewChargePoint(client)
+	})
+	cs.server.SetDisconnectedClientHandler(func(client ws.Channel) {
+		cs.handleChargePointDisconnected(client)
+	})
+	cs.soapServer.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		return cs.handleIncomingSOAPRequest(header, request, action)
+	})
+	cs.soapServer.SetCheckClientHandler(cs.checkSOAPClient)
 	return &cs
 }
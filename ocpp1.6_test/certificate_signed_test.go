@@ -0,0 +1,78 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestCertificateSignedRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.CertificateSignedRequest{CertificateChain: "sampleCert"}, true},
+		{security.CertificateSignedRequest{CertificateChain: ""}, false},
+		{security.CertificateSignedRequest{}, false},
+		{security.CertificateSignedRequest{CertificateChain: newLongString(10001)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestCertificateSignedConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.CertificateSignedConfirmation{Status: security.CertificateSignedStatusAccepted}, true},
+		{security.CertificateSignedConfirmation{Status: security.CertificateSignedStatusRejected}, true},
+		{security.CertificateSignedConfirmation{Status: "invalidCertificateSignedStatus"}, false},
+		{security.CertificateSignedConfirmation{}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestCertificateSignedE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	certificateChain := "someX509CertificateChain"
+	status := security.CertificateSignedStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateChain":"%v"}]`, messageId, security.CertificateSignedFeatureName, certificateChain)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	certificateSignedConfirmation := security.NewCertificateSignedConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockChargePointSecurityListener{}
+	securityListener.On("OnCertificateSigned", mock.Anything).Return(certificateSignedConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*security.CertificateSignedRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, certificateChain, request.CertificateChain)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.CertificateSigned(wsId, func(confirmation *security.CertificateSignedConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		resultChannel <- true
+	}, certificateChain)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestCertificateSignedInvalidEndpoint() {
+	messageId := defaultMessageId
+	certificateChain := "someX509CertificateChain"
+	certificateSignedRequest := security.NewCertificateSignedRequest(certificateChain)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateChain":"%v"}]`, messageId, security.CertificateSignedFeatureName, certificateChain)
+	testUnsupportedRequestFromChargePoint(suite, certificateSignedRequest, requestJson, messageId)
+}
@@ -30,7 +30,9 @@ func (suite *OcppV16TestSuite) TestClearChargingProfileConfirmationValidation()
 	t := suite.T()
 	var confirmationTable = []GenericTestEntry{
 		{smartcharging.ClearChargingProfileConfirmation{Status: smartcharging.ClearChargingProfileStatusAccepted}, true},
+		{smartcharging.ClearChargingProfileConfirmation{Status: smartcharging.ClearChargingProfileStatusUnknown}, true},
 		{smartcharging.ClearChargingProfileConfirmation{Status: "invalidClearChargingProfileStatus"}, false},
+		{smartcharging.ClearChargingProfileConfirmation{Status: smartcharging.ClearChargingProfileStatus(smartcharging.ChargingProfileStatusRejected)}, false},
 		{smartcharging.ClearChargingProfileConfirmation{}, false},
 	}
 	ExecuteGenericTestTable(t, confirmationTable)
@@ -2,6 +2,7 @@ package ocpp16_test
 
 import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,10 @@ func newFloat(f float64) *float64 {
 	return &f
 }
 
+func newLongString(length int) string {
+	return strings.Repeat(".", length)
+}
+
 // Test
 func (suite *OcppV16TestSuite) TestIdTagInfoValidation() {
 	var testTable = []GenericTestEntry{
@@ -0,0 +1,84 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestDeleteCertificateRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.DeleteCertificateRequest{CertificateHashData: types.CertificateHashData{HashAlgorithm: types.SHA256, IssuerNameHash: "hash00", IssuerKeyHash: "hash01", SerialNumber: "serial0"}}, true},
+		{security.DeleteCertificateRequest{}, false},
+		{security.DeleteCertificateRequest{CertificateHashData: types.CertificateHashData{HashAlgorithm: "invalidHashAlgorithm", IssuerNameHash: "hash00", IssuerKeyHash: "hash01", SerialNumber: "serial0"}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestDeleteCertificateConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.DeleteCertificateConfirmation{Status: security.DeleteCertificateStatusAccepted}, true},
+		{security.DeleteCertificateConfirmation{Status: security.DeleteCertificateStatusFailed}, true},
+		{security.DeleteCertificateConfirmation{Status: security.DeleteCertificateStatusNotFound}, true},
+		{security.DeleteCertificateConfirmation{Status: "invalidDeleteCertificateStatus"}, false},
+		{security.DeleteCertificateConfirmation{}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestDeleteCertificateE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	certificateHashData := types.CertificateHashData{HashAlgorithm: types.SHA256, IssuerNameHash: "hash00", IssuerKeyHash: "hash01", SerialNumber: "serial0"}
+	status := security.DeleteCertificateStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateHashData":{"hashAlgorithm":"%v","issuerNameHash":"%v","issuerKeyHash":"%v","serialNumber":"%v"}}]`,
+		messageId, security.DeleteCertificateFeatureName, certificateHashData.HashAlgorithm, certificateHashData.IssuerNameHash, certificateHashData.IssuerKeyHash, certificateHashData.SerialNumber)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	deleteCertificateConfirmation := security.NewDeleteCertificateConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockChargePointSecurityListener{}
+	securityListener.On("OnDeleteCertificate", mock.Anything).Return(deleteCertificateConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*security.DeleteCertificateRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, certificateHashData.HashAlgorithm, request.CertificateHashData.HashAlgorithm)
+		assert.Equal(t, certificateHashData.IssuerNameHash, request.CertificateHashData.IssuerNameHash)
+		assert.Equal(t, certificateHashData.IssuerKeyHash, request.CertificateHashData.IssuerKeyHash)
+		assert.Equal(t, certificateHashData.SerialNumber, request.CertificateHashData.SerialNumber)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.DeleteCertificate(wsId, func(confirmation *security.DeleteCertificateConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		resultChannel <- true
+	}, certificateHashData)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestDeleteCertificateInvalidEndpoint() {
+	messageId := defaultMessageId
+	certificateHashData := types.CertificateHashData{HashAlgorithm: types.SHA256, IssuerNameHash: "hash00", IssuerKeyHash: "hash01", SerialNumber: "serial0"}
+	deleteCertificateRequest := security.NewDeleteCertificateRequest(certificateHashData)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateHashData":{"hashAlgorithm":"%v","issuerNameHash":"%v","issuerKeyHash":"%v","serialNumber":"%v"}}]`,
+		messageId, security.DeleteCertificateFeatureName, certificateHashData.HashAlgorithm, certificateHashData.IssuerNameHash, certificateHashData.IssuerKeyHash, certificateHashData.SerialNumber)
+	testUnsupportedRequestFromChargePoint(suite, deleteCertificateRequest, requestJson, messageId)
+}
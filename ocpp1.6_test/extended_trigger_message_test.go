@@ -0,0 +1,130 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestExtendedTriggerMessageRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{remotetrigger.ExtendedTriggerMessageRequest{RequestedMessage: remotetrigger.ExtendedMessageTriggerStatusNotification, ConnectorId: newInt(1)}, true},
+		{remotetrigger.ExtendedTriggerMessageRequest{RequestedMessage: remotetrigger.ExtendedMessageTriggerSignChargePointCertificate}, true},
+		{remotetrigger.ExtendedTriggerMessageRequest{RequestedMessage: remotetrigger.ExtendedMessageTriggerLogStatusNotification}, true},
+		{remotetrigger.ExtendedTriggerMessageRequest{}, false},
+		{remotetrigger.ExtendedTriggerMessageRequest{RequestedMessage: remotetrigger.ExtendedMessageTriggerStatusNotification, ConnectorId: newInt(0)}, false},
+		{remotetrigger.ExtendedTriggerMessageRequest{RequestedMessage: "DiagnosticsStatusNotification"}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestExtendedTriggerMessageConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{remotetrigger.ExtendedTriggerMessageConfirmation{Status: remotetrigger.TriggerMessageStatusAccepted}, true},
+		{remotetrigger.ExtendedTriggerMessageConfirmation{Status: remotetrigger.TriggerMessageStatusNotImplemented}, true},
+		{remotetrigger.ExtendedTriggerMessageConfirmation{Status: "invalidTriggerMessageStatus"}, false},
+		{remotetrigger.ExtendedTriggerMessageConfirmation{}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestExtendedTriggerMessageE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	connectorId := newInt(1)
+	requestedMessage := remotetrigger.ExtendedMessageTriggerStatusNotification
+	status := remotetrigger.TriggerMessageStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestedMessage":"%v","connectorId":%v}]`, messageId, remotetrigger.ExtendedTriggerMessageFeatureName, requestedMessage, *connectorId)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	extendedTriggerMessageConfirmation := remotetrigger.NewExtendedTriggerMessageConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	remoteTriggerListener := MockChargePointRemoteTriggerListener{}
+	remoteTriggerListener.On("OnExtendedTriggerMessage", mock.Anything).Return(extendedTriggerMessageConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*remotetrigger.ExtendedTriggerMessageRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, requestedMessage, request.RequestedMessage)
+		require.NotNil(t, request.ConnectorId)
+		assert.Equal(t, *connectorId, *request.ConnectorId)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetRemoteTriggerHandler(remoteTriggerListener)
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.ExtendedTriggerMessage(wsId, func(confirmation *remotetrigger.ExtendedTriggerMessageConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		resultChannel <- true
+	}, requestedMessage, func(request *remotetrigger.ExtendedTriggerMessageRequest) {
+		request.ConnectorId = connectorId
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestExtendedTriggerMessageInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestedMessage := remotetrigger.ExtendedMessageTriggerStatusNotification
+	extendedTriggerMessageRequest := remotetrigger.NewExtendedTriggerMessageRequest(requestedMessage)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestedMessage":"%v"}]`, messageId, remotetrigger.ExtendedTriggerMessageFeatureName, requestedMessage)
+	testUnsupportedRequestFromChargePoint(suite, extendedTriggerMessageRequest, requestJson, messageId)
+}
+
+// Remote trigger handler, which doesn't implement the optional remotetrigger.ChargePointExtendedTriggerHandler interface.
+type mockChargePointBasicRemoteTriggerListener struct {
+	mock.Mock
+}
+
+func (remoteTriggerListener *mockChargePointBasicRemoteTriggerListener) OnTriggerMessage(request *remotetrigger.TriggerMessageRequest) (confirmation *remotetrigger.TriggerMessageConfirmation, err error) {
+	args := remoteTriggerListener.MethodCalled("OnTriggerMessage", request)
+	conf := args.Get(0).(*remotetrigger.TriggerMessageConfirmation)
+	return conf, args.Error(1)
+}
+
+func (suite *OcppV16TestSuite) TestExtendedTriggerMessageWithoutExtendedHandler() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestedMessage := remotetrigger.ExtendedMessageTriggerStatusNotification
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestedMessage":"%v"}]`, messageId, remotetrigger.ExtendedTriggerMessageFeatureName, requestedMessage)
+	errorDescription := fmt.Sprintf("unsupported action %v on charge point", remotetrigger.ExtendedTriggerMessageFeatureName)
+	errorJson := fmt.Sprintf(`[4,"%v","%v","%v",null]`, messageId, ocppj.NotSupported, errorDescription)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(errorJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetRemoteTriggerHandler(&mockChargePointBasicRemoteTriggerListener{})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.ExtendedTriggerMessage(wsId, func(confirmation *remotetrigger.ExtendedTriggerMessageConfirmation, err error) {
+		assert.Nil(t, confirmation)
+		require.Error(t, err)
+		protoErr, ok := err.(*ocpp.Error)
+		require.True(t, ok)
+		assert.Equal(t, ocppj.NotSupported, protoErr.Code)
+		resultChannel <- true
+	}, requestedMessage)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
@@ -0,0 +1,89 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestGetInstalledCertificateIdsRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.GetInstalledCertificateIdsRequest{CertificateType: types.CentralSystemRootCertificate}, true},
+		{security.GetInstalledCertificateIdsRequest{CertificateType: types.ManufacturerRootCertificate}, true},
+		{security.GetInstalledCertificateIdsRequest{}, false},
+		{security.GetInstalledCertificateIdsRequest{CertificateType: "invalidCertificateUse"}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestGetInstalledCertificateIdsConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.GetInstalledCertificateIdsConfirmation{Status: security.GetInstalledCertificateStatusAccepted, CertificateHashData: []types.CertificateHashData{{HashAlgorithm: types.SHA256, IssuerNameHash: "name0", IssuerKeyHash: "key0", SerialNumber: "serial0"}}}, true},
+		{security.GetInstalledCertificateIdsConfirmation{Status: security.GetInstalledCertificateStatusNotFound, CertificateHashData: []types.CertificateHashData{{HashAlgorithm: types.SHA256, IssuerNameHash: "name0", IssuerKeyHash: "key0", SerialNumber: "serial0"}}}, true},
+		{security.GetInstalledCertificateIdsConfirmation{Status: security.GetInstalledCertificateStatusAccepted, CertificateHashData: []types.CertificateHashData{}}, true},
+		{security.GetInstalledCertificateIdsConfirmation{Status: security.GetInstalledCertificateStatusAccepted}, true},
+		{security.GetInstalledCertificateIdsConfirmation{}, false},
+		{security.GetInstalledCertificateIdsConfirmation{Status: "invalidGetInstalledCertificateStatus"}, false},
+		{security.GetInstalledCertificateIdsConfirmation{Status: security.GetInstalledCertificateStatusAccepted, CertificateHashData: []types.CertificateHashData{{HashAlgorithm: "invalidHashAlgorithm", IssuerNameHash: "name0", IssuerKeyHash: "key0", SerialNumber: "serial0"}}}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestGetInstalledCertificateIdsE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	certificateType := types.CentralSystemRootCertificate
+	status := security.GetInstalledCertificateStatusAccepted
+	certificateHashData := []types.CertificateHashData{
+		{HashAlgorithm: types.SHA256, IssuerNameHash: "name0", IssuerKeyHash: "key0", SerialNumber: "serial0"},
+	}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateType":"%v"}]`, messageId, security.GetInstalledCertificateIdsFeatureName, certificateType)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v","certificateHashData":[{"hashAlgorithm":"%v","issuerNameHash":"%v","issuerKeyHash":"%v","serialNumber":"%v"}]}]`,
+		messageId, status, certificateHashData[0].HashAlgorithm, certificateHashData[0].IssuerNameHash, certificateHashData[0].IssuerKeyHash, certificateHashData[0].SerialNumber)
+	getInstalledCertificateIdsConfirmation := security.NewGetInstalledCertificateIdsConfirmation(status)
+	getInstalledCertificateIdsConfirmation.CertificateHashData = certificateHashData
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockChargePointSecurityListener{}
+	securityListener.On("OnGetInstalledCertificateIds", mock.Anything).Return(getInstalledCertificateIdsConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*security.GetInstalledCertificateIdsRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, certificateType, request.CertificateType)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.GetInstalledCertificateIds(wsId, func(confirmation *security.GetInstalledCertificateIdsConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		require.Len(t, confirmation.CertificateHashData, len(certificateHashData))
+		assert.Equal(t, certificateHashData[0], confirmation.CertificateHashData[0])
+		resultChannel <- true
+	}, certificateType)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestGetInstalledCertificateIdsInvalidEndpoint() {
+	messageId := defaultMessageId
+	certificateType := types.CentralSystemRootCertificate
+	getInstalledCertificateIdsRequest := security.NewGetInstalledCertificateIdsRequest(certificateType)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateType":"%v"}]`, messageId, security.GetInstalledCertificateIdsFeatureName, certificateType)
+	testUnsupportedRequestFromChargePoint(suite, getInstalledCertificateIdsRequest, requestJson, messageId)
+}
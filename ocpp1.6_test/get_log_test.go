@@ -0,0 +1,112 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestGetLogRequestValidation() {
+	t := suite.T()
+	logParameters := firmware.LogParameters{RemoteLocation: "ftp://someurl/diagnostics/1", OldestTimestamp: types.NewDateTime(time.Now().Add(-2 * time.Hour)), LatestTimestamp: types.NewDateTime(time.Now())}
+	var requestTable = []GenericTestEntry{
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: 1, Retries: newInt(5), RetryInterval: newInt(120), Log: logParameters}, true},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeSecurity, RequestID: 1, Log: logParameters}, true},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, Log: firmware.LogParameters{RemoteLocation: "ftp://someurl/diagnostics/1"}}, true},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics}, false},
+		{firmware.GetLogRequest{Log: logParameters}, false},
+		{firmware.GetLogRequest{}, false},
+		{firmware.GetLogRequest{LogType: "invalidLogType", RequestID: 1, Log: logParameters}, false},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: -1, Log: logParameters}, false},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: 1, Retries: newInt(-1), Log: logParameters}, false},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: 1, RetryInterval: newInt(-1), Log: logParameters}, false},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: 1, Log: firmware.LogParameters{RemoteLocation: "invalidUri"}}, false},
+		{firmware.GetLogRequest{LogType: firmware.LogTypeDiagnostics, RequestID: 1, Log: firmware.LogParameters{RemoteLocation: "ftp://" + newLongString(507)}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestGetLogConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{firmware.GetLogConfirmation{Status: firmware.LogStatusAccepted, Filename: "someFileName.log"}, true},
+		{firmware.GetLogConfirmation{Status: firmware.LogStatusAcceptedCanceled}, true},
+		{firmware.GetLogConfirmation{}, false},
+		{firmware.GetLogConfirmation{Status: "invalidLogStatus"}, false},
+		{firmware.GetLogConfirmation{Status: firmware.LogStatusAccepted, Filename: newLongString(256)}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestGetLogE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	logType := firmware.LogTypeSecurity
+	requestID := 42
+	retries := newInt(5)
+	retryInterval := newInt(120)
+	logParameters := firmware.LogParameters{RemoteLocation: "ftp://someurl/security/1", OldestTimestamp: types.NewDateTime(time.Now().Add(-2 * time.Hour)), LatestTimestamp: types.NewDateTime(time.Now())}
+	status := firmware.LogStatusAccepted
+	filename := "someFileName.log"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"logType":"%v","requestId":%v,"retries":%v,"retryInterval":%v,"log":{"remoteLocation":"%v","oldestTimestamp":"%v","latestTimestamp":"%v"}}]`,
+		messageId, firmware.GetLogFeatureName, logType, requestID, *retries, *retryInterval, logParameters.RemoteLocation, logParameters.OldestTimestamp.FormatTimestamp(), logParameters.LatestTimestamp.FormatTimestamp())
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v","filename":"%v"}]`, messageId, status, filename)
+	getLogConfirmation := firmware.NewGetLogConfirmation(status)
+	getLogConfirmation.Filename = filename
+	channel := NewMockWebSocket(wsId)
+
+	firmwareListener := MockChargePointFirmwareManagementListener{}
+	firmwareListener.On("OnGetLog", mock.Anything).Return(getLogConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*firmware.GetLogRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, logType, request.LogType)
+		assert.Equal(t, requestID, request.RequestID)
+		require.NotNil(t, request.Retries)
+		assert.Equal(t, *retries, *request.Retries)
+		require.NotNil(t, request.RetryInterval)
+		assert.Equal(t, *retryInterval, *request.RetryInterval)
+		assert.Equal(t, logParameters.RemoteLocation, request.Log.RemoteLocation)
+		assertDateTimeEquality(t, *logParameters.OldestTimestamp, *request.Log.OldestTimestamp)
+		assertDateTimeEquality(t, *logParameters.LatestTimestamp, *request.Log.LatestTimestamp)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetFirmwareManagementHandler(firmwareListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.GetLog(wsId, func(confirmation *firmware.GetLogConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		assert.Equal(t, filename, confirmation.Filename)
+		resultChannel <- true
+	}, logType, requestID, logParameters, func(request *firmware.GetLogRequest) {
+		request.Retries = retries
+		request.RetryInterval = retryInterval
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestGetLogInvalidEndpoint() {
+	messageId := defaultMessageId
+	logType := firmware.LogTypeSecurity
+	requestID := 42
+	logParameters := firmware.LogParameters{RemoteLocation: "ftp://someurl/security/1"}
+	getLogRequest := firmware.NewGetLogRequest(logType, requestID, logParameters)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"logType":"%v","requestId":%v,"log":{"remoteLocation":"%v"}}]`,
+		messageId, firmware.GetLogFeatureName, logType, requestID, logParameters.RemoteLocation)
+	testUnsupportedRequestFromChargePoint(suite, getLogRequest, requestJson, messageId)
+}
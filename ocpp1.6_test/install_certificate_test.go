@@ -0,0 +1,86 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestInstallCertificateRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.InstallCertificateRequest{CertificateType: types.CentralSystemRootCertificate, Certificate: "0xdeadbeef"}, true},
+		{security.InstallCertificateRequest{CertificateType: types.ManufacturerRootCertificate, Certificate: "0xdeadbeef"}, true},
+		{security.InstallCertificateRequest{CertificateType: types.CentralSystemRootCertificate}, false},
+		{security.InstallCertificateRequest{Certificate: "0xdeadbeef"}, false},
+		{security.InstallCertificateRequest{}, false},
+		{security.InstallCertificateRequest{CertificateType: "V2GRootCertificate", Certificate: "0xdeadbeef"}, false},
+		{security.InstallCertificateRequest{CertificateType: types.CentralSystemRootCertificate, Certificate: newLongString(5501)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestInstallCertificateConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.InstallCertificateConfirmation{Status: security.InstallCertificateStatusAccepted}, true},
+		{security.InstallCertificateConfirmation{Status: security.InstallCertificateStatusRejected}, true},
+		{security.InstallCertificateConfirmation{Status: security.InstallCertificateStatusFailed}, true},
+		{security.InstallCertificateConfirmation{Status: "invalidInstallCertificateStatus"}, false},
+		{security.InstallCertificateConfirmation{}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestInstallCertificateE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	certificateType := types.CentralSystemRootCertificate
+	certificate := "0xdeadbeef"
+	status := security.InstallCertificateStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateType":"%v","certificate":"%v"}]`, messageId, security.InstallCertificateFeatureName, certificateType, certificate)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	installCertificateConfirmation := security.NewInstallCertificateConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockChargePointSecurityListener{}
+	securityListener.On("OnInstallCertificate", mock.Anything).Return(installCertificateConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*security.InstallCertificateRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, certificateType, request.CertificateType)
+		assert.Equal(t, certificate, request.Certificate)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.InstallCertificate(wsId, func(confirmation *security.InstallCertificateConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		resultChannel <- true
+	}, certificateType, certificate)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestInstallCertificateInvalidEndpoint() {
+	messageId := defaultMessageId
+	certificateType := types.CentralSystemRootCertificate
+	certificate := "0xdeadbeef"
+	installCertificateRequest := security.NewInstallCertificateRequest(certificateType, certificate)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"certificateType":"%v","certificate":"%v"}]`, messageId, security.InstallCertificateFeatureName, certificateType, certificate)
+	testUnsupportedRequestFromChargePoint(suite, installCertificateRequest, requestJson, messageId)
+}
@@ -0,0 +1,73 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestLogStatusNotificationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{firmware.LogStatusNotificationRequest{Status: firmware.UploadLogStatusIdle, RequestID: newInt(42)}, true},
+		{firmware.LogStatusNotificationRequest{Status: firmware.UploadLogStatusUploaded}, true},
+		{firmware.LogStatusNotificationRequest{}, false},
+		{firmware.LogStatusNotificationRequest{Status: "invalidUploadLogStatus"}, false},
+		{firmware.LogStatusNotificationRequest{Status: firmware.UploadLogStatusIdle, RequestID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestLogStatusNotificationConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{firmware.LogStatusNotificationConfirmation{}, true},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestLogStatusNotificationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	status := firmware.UploadLogStatusUploading
+	requestID := newInt(42)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v","requestId":%v}]`, messageId, firmware.LogStatusNotificationFeatureName, status, *requestID)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	logStatusNotificationConfirmation := firmware.NewLogStatusNotificationConfirmation()
+	channel := NewMockWebSocket(wsId)
+
+	firmwareListener := MockCentralSystemFirmwareManagementListener{}
+	firmwareListener.On("OnLogStatusNotification", mock.AnythingOfType("string"), mock.Anything).Return(logStatusNotificationConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*firmware.LogStatusNotificationRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, status, request.Status)
+		require.NotNil(t, request.RequestID)
+		assert.Equal(t, *requestID, *request.RequestID)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	suite.centralSystem.SetFirmwareManagementHandler(firmwareListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	confirmation, err := suite.chargePoint.LogStatusNotification(status, func(request *firmware.LogStatusNotificationRequest) {
+		request.RequestID = requestID
+	})
+	require.Nil(t, err)
+	require.NotNil(t, confirmation)
+}
+
+func (suite *OcppV16TestSuite) TestLogStatusNotificationInvalidEndpoint() {
+	messageId := defaultMessageId
+	status := firmware.UploadLogStatusUploading
+	logStatusNotificationRequest := firmware.NewLogStatusNotificationRequest(status)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v"}]`, messageId, firmware.LogStatusNotificationFeatureName, status)
+	testUnsupportedRequestFromCentralSystem(suite, logStatusNotificationRequest, requestJson, messageId)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
@@ -35,6 +36,10 @@ func (websocket MockWebSocket) TLSConnectionState() *tls.ConnectionState {
 	return nil
 }
 
+func (websocket MockWebSocket) Subprotocol() string {
+	return ""
+}
+
 func NewMockWebSocket(id string) MockWebSocket {
 	return MockWebSocket{id: id}
 }
@@ -333,6 +338,18 @@ func (firmwareListener MockCentralSystemFirmwareManagementListener) OnFirmwareSt
 	return conf, args.Error(1)
 }
 
+func (firmwareListener MockCentralSystemFirmwareManagementListener) OnLogStatusNotification(chargePointId string, request *firmware.LogStatusNotificationRequest) (confirmation *firmware.LogStatusNotificationConfirmation, err error) {
+	args := firmwareListener.MethodCalled("OnLogStatusNotification", chargePointId, request)
+	conf := args.Get(0).(*firmware.LogStatusNotificationConfirmation)
+	return conf, args.Error(1)
+}
+
+func (firmwareListener MockCentralSystemFirmwareManagementListener) OnSignedFirmwareStatusNotification(chargePointId string, request *firmware.SignedFirmwareStatusNotificationRequest) (confirmation *firmware.SignedFirmwareStatusNotificationConfirmation, err error) {
+	args := firmwareListener.MethodCalled("OnSignedFirmwareStatusNotification", chargePointId, request)
+	conf := args.Get(0).(*firmware.SignedFirmwareStatusNotificationConfirmation)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CP FIRMWARE MANAGEMENT LISTENER ----------------------
 type MockChargePointFirmwareManagementListener struct {
 	mock.Mock
@@ -350,6 +367,18 @@ func (firmwareListener MockChargePointFirmwareManagementListener) OnUpdateFirmwa
 	return conf, args.Error(1)
 }
 
+func (firmwareListener MockChargePointFirmwareManagementListener) OnGetLog(request *firmware.GetLogRequest) (confirmation *firmware.GetLogConfirmation, err error) {
+	args := firmwareListener.MethodCalled("OnGetLog", request)
+	conf := args.Get(0).(*firmware.GetLogConfirmation)
+	return conf, args.Error(1)
+}
+
+func (firmwareListener MockChargePointFirmwareManagementListener) OnSignedUpdateFirmware(request *firmware.SignedUpdateFirmwareRequest) (confirmation *firmware.SignedUpdateFirmwareConfirmation, err error) {
+	args := firmwareListener.MethodCalled("OnSignedUpdateFirmware", request)
+	conf := args.Get(0).(*firmware.SignedUpdateFirmwareConfirmation)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS RESERVATION LISTENER ----------------------
 type MockCentralSystemReservationListener struct {
 	mock.Mock
@@ -388,6 +417,12 @@ func (remoteTriggerListener MockChargePointRemoteTriggerListener) OnTriggerMessa
 	return conf, args.Error(1)
 }
 
+func (remoteTriggerListener MockChargePointRemoteTriggerListener) OnExtendedTriggerMessage(request *remotetrigger.ExtendedTriggerMessageRequest) (confirmation *remotetrigger.ExtendedTriggerMessageConfirmation, err error) {
+	args := remoteTriggerListener.MethodCalled("OnExtendedTriggerMessage", request)
+	conf := args.Get(0).(*remotetrigger.ExtendedTriggerMessageConfirmation)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS SMART CHARGING LISTENER ----------------------
 type MockCentralSystemSmartChargingListener struct {
 	mock.Mock
@@ -416,6 +451,52 @@ func (smartChargingListener MockChargePointSmartChargingListener) OnGetComposite
 	return conf, args.Error(1)
 }
 
+// ---------------------- MOCK CS SECURITY LISTENER ----------------------
+type MockCentralSystemSecurityListener struct {
+	mock.Mock
+}
+
+func (securityListener MockCentralSystemSecurityListener) OnSecurityEventNotification(chargePointId string, request *security.SecurityEventNotificationRequest) (confirmation *security.SecurityEventNotificationConfirmation, err error) {
+	args := securityListener.MethodCalled("OnSecurityEventNotification", chargePointId, request)
+	conf := args.Get(0).(*security.SecurityEventNotificationConfirmation)
+	return conf, args.Error(1)
+}
+
+func (securityListener MockCentralSystemSecurityListener) OnSignCertificate(chargePointId string, request *security.SignCertificateRequest) (confirmation *security.SignCertificateConfirmation, err error) {
+	args := securityListener.MethodCalled("OnSignCertificate", chargePointId, request)
+	conf := args.Get(0).(*security.SignCertificateConfirmation)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CP SECURITY LISTENER ----------------------
+type MockChargePointSecurityListener struct {
+	mock.Mock
+}
+
+func (securityListener MockChargePointSecurityListener) OnCertificateSigned(request *security.CertificateSignedRequest) (confirmation *security.CertificateSignedConfirmation, err error) {
+	args := securityListener.MethodCalled("OnCertificateSigned", request)
+	conf := args.Get(0).(*security.CertificateSignedConfirmation)
+	return conf, args.Error(1)
+}
+
+func (securityListener MockChargePointSecurityListener) OnDeleteCertificate(request *security.DeleteCertificateRequest) (confirmation *security.DeleteCertificateConfirmation, err error) {
+	args := securityListener.MethodCalled("OnDeleteCertificate", request)
+	conf := args.Get(0).(*security.DeleteCertificateConfirmation)
+	return conf, args.Error(1)
+}
+
+func (securityListener MockChargePointSecurityListener) OnGetInstalledCertificateIds(request *security.GetInstalledCertificateIdsRequest) (confirmation *security.GetInstalledCertificateIdsConfirmation, err error) {
+	args := securityListener.MethodCalled("OnGetInstalledCertificateIds", request)
+	conf := args.Get(0).(*security.GetInstalledCertificateIdsConfirmation)
+	return conf, args.Error(1)
+}
+
+func (securityListener MockChargePointSecurityListener) OnInstallCertificate(request *security.InstallCertificateRequest) (confirmation *security.InstallCertificateConfirmation, err error) {
+	args := securityListener.MethodCalled("OnInstallCertificate", request)
+	conf := args.Get(0).(*security.InstallCertificateConfirmation)
+	return conf, args.Error(1)
+}
+
 // ---------------------- COMMON UTILITY METHODS ----------------------
 func NewWebsocketServer(t *testing.T, onMessage func(data []byte) ([]byte, error)) *ws.Server {
 	wsServer := ws.Server{}
@@ -657,14 +738,15 @@ func (suite *OcppV16TestSuite) SetupTest() {
 	reservationProfile := reservation.Profile
 	remoteTriggerProfile := remotetrigger.Profile
 	smartChargingProfile := smartcharging.Profile
+	securityProfile := security.Profile
 	mockClient := MockWebsocketClient{}
 	mockServer := MockWebsocketServer{}
 	suite.mockWsClient = &mockClient
 	suite.mockWsServer = &mockServer
 	suite.clientDispatcher = ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(queueCapacity))
 	suite.serverDispatcher = ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(queueCapacity))
-	suite.ocppjChargePoint = ocppj.NewClient("test_id", suite.mockWsClient, suite.clientDispatcher, nil, coreProfile, localAuthListProfile, firmwareProfile, reservationProfile, remoteTriggerProfile, smartChargingProfile)
-	suite.ocppjCentralSystem = ocppj.NewServer(suite.mockWsServer, suite.serverDispatcher, nil, coreProfile, localAuthListProfile, firmwareProfile, reservationProfile, remoteTriggerProfile, smartChargingProfile)
+	suite.ocppjChargePoint = ocppj.NewClient("test_id", suite.mockWsClient, suite.clientDispatcher, nil, coreProfile, localAuthListProfile, firmwareProfile, reservationProfile, remoteTriggerProfile, smartChargingProfile, securityProfile)
+	suite.ocppjCentralSystem = ocppj.NewServer(suite.mockWsServer, suite.serverDispatcher, nil, coreProfile, localAuthListProfile, firmwareProfile, reservationProfile, remoteTriggerProfile, smartChargingProfile, securityProfile)
 	suite.chargePoint = ocpp16.NewChargePoint("test_id", suite.ocppjChargePoint, suite.mockWsClient)
 	suite.centralSystem = ocpp16.NewCentralSystem(suite.ocppjCentralSystem, suite.mockWsServer)
 	suite.messageIdGenerator = TestRandomIdGenerator{generator: func() string {
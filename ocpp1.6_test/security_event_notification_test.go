@@ -0,0 +1,79 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestSecurityEventNotificationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.SecurityEventNotificationRequest{Type: "type1", Timestamp: types.NewDateTime(time.Now()), TechInfo: "someTechInfo"}, true},
+		{security.SecurityEventNotificationRequest{Type: "type1", Timestamp: types.NewDateTime(time.Now())}, true},
+		{security.SecurityEventNotificationRequest{Type: "type1"}, false},
+		{security.SecurityEventNotificationRequest{Timestamp: types.NewDateTime(time.Now())}, false},
+		{security.SecurityEventNotificationRequest{}, false},
+		{security.SecurityEventNotificationRequest{Type: newLongString(51), Timestamp: types.NewDateTime(time.Now())}, false},
+		{security.SecurityEventNotificationRequest{Type: "type1", Timestamp: types.NewDateTime(time.Now()), TechInfo: newLongString(256)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestSecurityEventNotificationConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.SecurityEventNotificationConfirmation{}, true},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestSecurityEventNotificationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	typ := "type1"
+	timestamp := types.NewDateTime(time.Now())
+	techInfo := "someTechInfo"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","timestamp":"%v","techInfo":"%v"}]`, messageId, security.SecurityEventNotificationFeatureName, typ, timestamp.FormatTimestamp(), techInfo)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	securityEventNotificationConfirmation := security.NewSecurityEventNotificationConfirmation()
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockCentralSystemSecurityListener{}
+	securityListener.On("OnSecurityEventNotification", mock.AnythingOfType("string"), mock.Anything).Return(securityEventNotificationConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*security.SecurityEventNotificationRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, typ, request.Type)
+		assertDateTimeEquality(t, *timestamp, *request.Timestamp)
+		assert.Equal(t, techInfo, request.TechInfo)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	suite.centralSystem.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	confirmation, err := suite.chargePoint.SecurityEventNotification(typ, timestamp, func(request *security.SecurityEventNotificationRequest) {
+		request.TechInfo = techInfo
+	})
+	require.Nil(t, err)
+	require.NotNil(t, confirmation)
+}
+
+func (suite *OcppV16TestSuite) TestSecurityEventNotificationInvalidEndpoint() {
+	messageId := defaultMessageId
+	typ := "type1"
+	timestamp := types.NewDateTime(time.Now())
+	securityEventNotificationRequest := security.NewSecurityEventNotificationRequest(typ, timestamp)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","timestamp":"%v"}]`, messageId, security.SecurityEventNotificationFeatureName, typ, timestamp.FormatTimestamp())
+	testUnsupportedRequestFromCentralSystem(suite, securityEventNotificationRequest, requestJson, messageId)
+}
@@ -0,0 +1,71 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestSignCertificateRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.SignCertificateRequest{CSR: "deadc0de"}, true},
+		{security.SignCertificateRequest{}, false},
+		{security.SignCertificateRequest{CSR: newLongString(5501)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestSignCertificateConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{security.SignCertificateConfirmation{Status: security.GenericStatusAccepted}, true},
+		{security.SignCertificateConfirmation{Status: security.GenericStatusRejected}, true},
+		{security.SignCertificateConfirmation{}, false},
+		{security.SignCertificateConfirmation{Status: "invalidGenericStatus"}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestSignCertificateE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	csr := "deadc0de"
+	status := security.GenericStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"csr":"%v"}]`, messageId, security.SignCertificateFeatureName, csr)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	signCertificateConfirmation := security.NewSignCertificateConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	securityListener := MockCentralSystemSecurityListener{}
+	securityListener.On("OnSignCertificate", mock.AnythingOfType("string"), mock.Anything).Return(signCertificateConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*security.SignCertificateRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, csr, request.CSR)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	suite.centralSystem.SetSecurityHandler(securityListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	confirmation, err := suite.chargePoint.SignCertificate(csr)
+	require.Nil(t, err)
+	require.NotNil(t, confirmation)
+	assert.Equal(t, status, confirmation.Status)
+}
+
+func (suite *OcppV16TestSuite) TestSignCertificateInvalidEndpoint() {
+	messageId := defaultMessageId
+	csr := "deadc0de"
+	signCertificateRequest := security.NewSignCertificateRequest(csr)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"csr":"%v"}]`, messageId, security.SignCertificateFeatureName, csr)
+	testUnsupportedRequestFromCentralSystem(suite, signCertificateRequest, requestJson, messageId)
+}
@@ -0,0 +1,127 @@
+package ocpp16_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV16TestSuite) TestSignedUpdateFirmwareRequestValidation() {
+	t := suite.T()
+	fw := firmware.Firmware{
+		Location:           "https://someurl/firmware.bin",
+		RetrieveDateTime:   types.NewDateTime(time.Now()),
+		InstallDateTime:    types.NewDateTime(time.Now().Add(time.Hour)),
+		SigningCertificate: "1337c0de",
+		Signature:          "deadc0de",
+	}
+	var requestTable = []GenericTestEntry{
+		{firmware.SignedUpdateFirmwareRequest{Retries: newInt(5), RetryInterval: newInt(300), RequestID: 42, Firmware: fw}, true},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: fw}, true},
+		{firmware.SignedUpdateFirmwareRequest{Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: "1337c0de", Signature: "deadc0de"}}, true},
+		{firmware.SignedUpdateFirmwareRequest{}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42}, false},
+		{firmware.SignedUpdateFirmwareRequest{Retries: newInt(-1), RequestID: 42, Firmware: fw}, false},
+		{firmware.SignedUpdateFirmwareRequest{RetryInterval: newInt(-1), RequestID: 42, Firmware: fw}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: -1, Firmware: fw}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "invalidUri", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: "1337c0de", Signature: "deadc0de"}}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", SigningCertificate: "1337c0de", Signature: "deadc0de"}}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", RetrieveDateTime: types.NewDateTime(time.Now()), Signature: "deadc0de"}}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: "1337c0de"}}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: newLongString(5501), Signature: "deadc0de"}}, false},
+		{firmware.SignedUpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl/firmware.bin", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: "1337c0de", Signature: newLongString(801)}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV16TestSuite) TestSignedUpdateFirmwareConfirmationValidation() {
+	t := suite.T()
+	var confirmationTable = []GenericTestEntry{
+		{firmware.SignedUpdateFirmwareConfirmation{Status: firmware.UpdateFirmwareStatusAccepted}, true},
+		{firmware.SignedUpdateFirmwareConfirmation{Status: firmware.UpdateFirmwareStatusInvalidCertificate}, true},
+		{firmware.SignedUpdateFirmwareConfirmation{Status: firmware.UpdateFirmwareStatusRevokedCertificate}, true},
+		{firmware.SignedUpdateFirmwareConfirmation{}, false},
+		{firmware.SignedUpdateFirmwareConfirmation{Status: "invalidUpdateFirmwareStatus"}, false},
+	}
+	ExecuteGenericTestTable(t, confirmationTable)
+}
+
+func (suite *OcppV16TestSuite) TestSignedUpdateFirmwareE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	retries := newInt(5)
+	retryInterval := newInt(300)
+	requestID := 42
+	fw := firmware.Firmware{
+		Location:           "https://someurl/firmware.bin",
+		RetrieveDateTime:   types.NewDateTime(time.Now()),
+		InstallDateTime:    types.NewDateTime(time.Now().Add(time.Hour)),
+		SigningCertificate: "1337c0de",
+		Signature:          "deadc0de",
+	}
+	status := firmware.UpdateFirmwareStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"retries":%v,"retryInterval":%v,"requestId":%v,"firmware":{"location":"%v","retrieveDateTime":"%v","installDateTime":"%v","signingCertificate":"%v","signature":"%v"}}]`,
+		messageId, firmware.SignedUpdateFirmwareFeatureName, *retries, *retryInterval, requestID, fw.Location, fw.RetrieveDateTime.FormatTimestamp(), fw.InstallDateTime.FormatTimestamp(), fw.SigningCertificate, fw.Signature)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	signedUpdateFirmwareConfirmation := firmware.NewSignedUpdateFirmwareConfirmation(status)
+	channel := NewMockWebSocket(wsId)
+
+	firmwareListener := MockChargePointFirmwareManagementListener{}
+	firmwareListener.On("OnSignedUpdateFirmware", mock.Anything).Return(signedUpdateFirmwareConfirmation, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*firmware.SignedUpdateFirmwareRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.Retries)
+		assert.Equal(t, *retries, *request.Retries)
+		require.NotNil(t, request.RetryInterval)
+		assert.Equal(t, *retryInterval, *request.RetryInterval)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.Equal(t, fw.Location, request.Firmware.Location)
+		assertDateTimeEquality(t, *fw.RetrieveDateTime, *request.Firmware.RetrieveDateTime)
+		assertDateTimeEquality(t, *fw.InstallDateTime, *request.Firmware.InstallDateTime)
+		assert.Equal(t, fw.SigningCertificate, request.Firmware.SigningCertificate)
+		assert.Equal(t, fw.Signature, request.Firmware.Signature)
+	})
+	setupDefaultCentralSystemHandlers(suite, nil, expectedCentralSystemOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	suite.chargePoint.SetFirmwareManagementHandler(firmwareListener)
+	setupDefaultChargePointHandlers(suite, nil, expectedChargePointOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.centralSystem.Start(8887, "somePath")
+	err := suite.chargePoint.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.SignedUpdateFirmware(wsId, func(confirmation *firmware.SignedUpdateFirmwareConfirmation, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, status, confirmation.Status)
+		resultChannel <- true
+	}, requestID, fw, func(request *firmware.SignedUpdateFirmwareRequest) {
+		request.Retries = retries
+		request.RetryInterval = retryInterval
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestSignedUpdateFirmwareInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	fw := firmware.Firmware{
+		Location:           "https://someurl/firmware.bin",
+		RetrieveDateTime:   types.NewDateTime(time.Now()),
+		SigningCertificate: "1337c0de",
+		Signature:          "deadc0de",
+	}
+	signedUpdateFirmwareRequest := firmware.NewSignedUpdateFirmwareRequest(requestID, fw)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"firmware":{"location":"%v","retrieveDateTime":"%v","signingCertificate":"%v","signature":"%v"}}]`,
+		messageId, firmware.SignedUpdateFirmwareFeatureName, requestID, fw.Location, fw.RetrieveDateTime.FormatTimestamp(), fw.SigningCertificate, fw.Signature)
+	testUnsupportedRequestFromChargePoint(suite, signedUpdateFirmwareRequest, requestJson, messageId)
+}
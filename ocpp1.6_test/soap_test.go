@@ -0,0 +1,192 @@
+package ocpp16_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ocpps"
+)
+
+func acceptSOAPClient(chargeBoxIdentity string, r *http.Request) bool {
+	return true
+}
+
+func (suite *OcppV16TestSuite) TestSOAPBootNotification() {
+	t := suite.T()
+	chargePointId := "soap_cp"
+	currentTime := types.NewDateTime(time.Now().UTC().Truncate(time.Second))
+	coreListener := MockCentralSystemCoreListener{}
+	coreListener.On("OnBootNotification", chargePointId, mock.Anything).Return(core.NewBootNotificationConfirmation(currentTime, 60, core.RegistrationStatusAccepted), nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*core.BootNotificationRequest)
+		require.True(t, ok)
+		assert.Equal(t, "model1", request.ChargePointModel)
+		assert.Equal(t, "vendor1", request.ChargePointVendor)
+	})
+	suite.centralSystem.SetCoreHandler(coreListener)
+	suite.centralSystem.SetSOAPCheckClientHandler(acceptSOAPClient)
+	httpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer httpServer.Close()
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	response, err := client.SendRequest(httpServer.URL, chargePointId, core.NewBootNotificationRequest("model1", "vendor1"))
+	require.NoError(t, err)
+	require.IsType(t, &core.BootNotificationConfirmation{}, response)
+	confirmation := response.(*core.BootNotificationConfirmation)
+	assert.Equal(t, core.RegistrationStatusAccepted, confirmation.Status)
+	assert.Equal(t, 60, confirmation.Interval)
+	assert.Equal(t, currentTime.FormatTimestamp(), confirmation.CurrentTime.FormatTimestamp())
+}
+
+func (suite *OcppV16TestSuite) TestSOAPRequestWithoutHandler() {
+	t := suite.T()
+	suite.centralSystem.SetSOAPCheckClientHandler(acceptSOAPClient)
+	httpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer httpServer.Close()
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	response, err := client.SendRequest(httpServer.URL, "soap_cp", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.NotSupported, err.(*ocpp.Error).Code)
+}
+
+func (suite *OcppV16TestSuite) TestSOAPCentralSystemRequest() {
+	t := suite.T()
+	chargePointId := "soap_cp"
+	// Mock charge point SOAP service
+	chargePointServer := ocpps.NewServer(ocpps.ChargePointNamespace, core.Profile)
+	chargePointServer.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		assert.Equal(t, chargePointId, header.ChargeBoxIdentity)
+		require.IsType(t, &core.ResetRequest{}, request)
+		assert.Equal(t, core.ResetTypeSoft, request.(*core.ResetRequest).Type)
+		return core.NewResetConfirmation(core.ResetStatusAccepted), nil
+	})
+	chargePointHttpServer := httptest.NewServer(chargePointServer)
+	defer chargePointHttpServer.Close()
+	// Charge point advertises its own address on first request
+	coreListener := MockCentralSystemCoreListener{}
+	coreListener.On("OnHeartbeat", chargePointId, mock.Anything).Return(core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil)
+	suite.centralSystem.SetCoreHandler(coreListener)
+	suite.centralSystem.SetSOAPCheckClientHandler(acceptSOAPClient)
+	centralSystemHttpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer centralSystemHttpServer.Close()
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	client.SetFromAddress(chargePointHttpServer.URL)
+	_, err := client.SendRequest(centralSystemHttpServer.URL, chargePointId, core.NewHeartbeatRequest())
+	require.NoError(t, err)
+	// Central system request is routed via SOAP
+	resultChannel := make(chan bool, 1)
+	err = suite.centralSystem.Reset(chargePointId, func(confirmation *core.ResetConfirmation, err error) {
+		require.NoError(t, err)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, core.ResetStatusAccepted, confirmation.Status)
+		resultChannel <- true
+	}, core.ResetTypeSoft)
+	require.NoError(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV16TestSuite) TestSOAPUnauthenticatedClient() {
+	t := suite.T()
+	coreListener := MockCentralSystemCoreListener{}
+	suite.centralSystem.SetCoreHandler(coreListener)
+	httpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer httpServer.Close()
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	client.SetFromAddress("http://soap_cp:8080/")
+	// Without a check handler, all SOAP clients are rejected
+	response, err := client.SendRequest(httpServer.URL, "soap_cp", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.SecurityError, err.(*ocpp.Error).Code)
+	// Rejected by check handler
+	suite.centralSystem.SetSOAPCheckClientHandler(func(chargeBoxIdentity string, r *http.Request) bool {
+		username, password, ok := r.BasicAuth()
+		return ok && username == chargeBoxIdentity && password == "secret"
+	})
+	response, err = client.SendRequest(httpServer.URL, "soap_cp", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.SecurityError, err.(*ocpp.Error).Code)
+	coreListener.AssertNotCalled(t, "OnHeartbeat", mock.Anything, mock.Anything)
+}
+
+func (suite *OcppV16TestSuite) TestSOAPWebsocketPrecedence() {
+	t := suite.T()
+	chargePointId := "ws_cp"
+	channel := NewMockWebSocket(chargePointId)
+	writeC := make(chan string, 1)
+	suite.mockWsServer.On("Start", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+	suite.mockWsServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		writeC <- args.String(0)
+	})
+	suite.centralSystem.SetSOAPCheckClientHandler(acceptSOAPClient)
+	suite.centralSystem.Start(8887, "somePath")
+	suite.mockWsServer.NewClientHandler(channel)
+	httpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer httpServer.Close()
+	// A SOAP request claiming the identity of a connected charge point is rejected
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	client.SetFromAddress("http://attacker:8080/")
+	response, err := client.SendRequest(httpServer.URL, chargePointId, core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.SecurityError, err.(*ocpp.Error).Code)
+	// Requests are still sent via websocket
+	err = suite.centralSystem.Reset(chargePointId, func(confirmation *core.ResetConfirmation, err error) {}, core.ResetTypeSoft)
+	require.NoError(t, err)
+	assert.Equal(t, chargePointId, <-writeC)
+}
+
+func (suite *OcppV16TestSuite) TestSOAPSequentialRequests() {
+	t := suite.T()
+	chargePointId := "soap_cp"
+	requestCount := 3
+	// Mock charge point SOAP service, detecting concurrent requests
+	var pending int32
+	chargePointServer := ocpps.NewServer(ocpps.ChargePointNamespace, core.Profile)
+	chargePointServer.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		assert.Equal(t, int32(1), atomic.AddInt32(&pending, 1))
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&pending, -1)
+		return core.NewClearCacheConfirmation(core.ClearCacheStatusAccepted), nil
+	})
+	chargePointHttpServer := httptest.NewServer(chargePointServer)
+	defer chargePointHttpServer.Close()
+	coreListener := MockCentralSystemCoreListener{}
+	coreListener.On("OnHeartbeat", chargePointId, mock.Anything).Return(core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil)
+	suite.centralSystem.SetCoreHandler(coreListener)
+	suite.centralSystem.SetSOAPCheckClientHandler(acceptSOAPClient)
+	centralSystemHttpServer := httptest.NewServer(suite.centralSystem.SOAPHandler())
+	defer centralSystemHttpServer.Close()
+	client := ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	client.SetFromAddress(chargePointHttpServer.URL)
+	_, err := client.SendRequest(centralSystemHttpServer.URL, chargePointId, core.NewHeartbeatRequest())
+	require.NoError(t, err)
+	// Callbacks are invoked in the order the requests were sent
+	resultChannel := make(chan int, requestCount)
+	for i := 0; i < requestCount; i++ {
+		index := i
+		err = suite.centralSystem.ClearCache(chargePointId, func(confirmation *core.ClearCacheConfirmation, err error) {
+			require.NoError(t, err)
+			resultChannel <- index
+		})
+		require.NoError(t, err)
+	}
+	for i := 0; i < requestCount; i++ {
+		assert.Equal(t, i, <-resultChannel)
+	}
+}
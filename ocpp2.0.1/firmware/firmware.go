@@ -0,0 +1,33 @@
+// The firmware functional block contains OCPP 2.0.1 features that enable firmware updates on a charging station.
+package firmware
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0.1 Firmware profile.
+type CSMSHandler interface {
+	// OnFirmwareStatusNotification is called on the CSMS whenever a FirmwareStatusNotificationRequest is received from a charging station.
+	OnFirmwareStatusNotification(chargingStationID string, request *FirmwareStatusNotificationRequest) (confirmation *FirmwareStatusNotificationResponse, err error)
+	// OnPublishFirmwareStatusNotification is called on the CSMS whenever a PublishFirmwareStatusNotificationRequest is received from a local controller.
+	OnPublishFirmwareStatusNotification(chargingStationID string, request *PublishFirmwareStatusNotificationRequest) (confirmation *PublishFirmwareStatusNotificationResponse, err error)
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0.1 Firmware profile.
+type ChargingStationHandler interface {
+	// OnPublishFirmware is called on a charging station whenever a PublishFirmwareRequest is received from the CSMS.
+	OnPublishFirmware(request *PublishFirmwareRequest) (confirmation *PublishFirmwareResponse, err error)
+	// OnUnpublishFirmware is called on a charging station whenever a UnpublishFirmwareRequest is received from the CSMS.
+	OnUnpublishFirmware(request *UnpublishFirmwareRequest) (confirmation *UnpublishFirmwareResponse, err error)
+	// OnUpdateFirmware is called on a charging station whenever a UpdateFirmwareRequest is received from the CSMS.
+	OnUpdateFirmware(request *UpdateFirmwareRequest) (confirmation *UpdateFirmwareResponse, err error)
+}
+
+const ProfileName = "firmware"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	FirmwareStatusNotificationFeature{},
+	PublishFirmwareFeature{},
+	PublishFirmwareStatusNotificationFeature{},
+	UnpublishFirmwareFeature{},
+	UpdateFirmwareFeature{},
+)
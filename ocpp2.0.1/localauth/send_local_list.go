@@ -0,0 +1,116 @@
+package localauth
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Send Local List (CSMS -> CS) --------------------
+
+const SendLocalListFeatureName = "SendLocalList"
+
+// Indicates the type of update (full or differential) for a SendLocalListRequest.
+type UpdateType string
+
+// Indicates whether the Charging Station has successfully received and applied the update of the Local Authorization List.
+type SendLocalListStatus string
+
+const (
+	UpdateTypeDifferential             UpdateType          = "Differential"
+	UpdateTypeFull                     UpdateType          = "Full"
+	SendLocalListStatusAccepted        SendLocalListStatus = "Accepted"
+	SendLocalListStatusFailed          SendLocalListStatus = "Failed"
+	SendLocalListStatusVersionMismatch SendLocalListStatus = "VersionMismatch"
+)
+
+func isValidUpdateType(fl validator.FieldLevel) bool {
+	status := UpdateType(fl.Field().String())
+	switch status {
+	case UpdateTypeDifferential, UpdateTypeFull:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidSendLocalListStatus(fl validator.FieldLevel) bool {
+	status := SendLocalListStatus(fl.Field().String())
+	switch status {
+	case SendLocalListStatusAccepted, SendLocalListStatusFailed, SendLocalListStatusVersionMismatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Contains the identifier to use for authorization.
+type AuthorizationData struct {
+	IdTokenInfo *types.IdTokenInfo `json:"idTokenInfo,omitempty" validate:"omitempty"` // Required when UpdateType is Full. This contains information about authorization status, expiry and group id. For a Differential update the following applies: If this element is present, then this entry SHALL be added or updated in the Local Authorization List. If this element is absent, then the entry for this IdToken in the Local Authorization List SHALL be deleted.
+	IdToken     types.IdToken      `json:"idToken" validate:"required"`                // This contains the identifier which needs to be stored for authorization.
+}
+
+// The field definition of the SendLocalList request payload sent by the CSMS to the Charging Station.
+// If no (empty) localAuthorizationList is given and the updateType is Full, all IdTokens are removed from the list.
+//
+// Requesting a Differential update without (empty) localAuthorizationList will have no effect on the list.
+// All IdTokens in the localAuthorizationList MUST be unique, no duplicate values are allowed.
+type SendLocalListRequest struct {
+	VersionNumber          int                 `json:"versionNumber" validate:"gte=0"`                             // In case of a full update this is the version number of the full list. In case of a differential update it is the version number of the list after the update has been applied.
+	UpdateType             UpdateType          `json:"updateType" validate:"required,updateType201"`               // This contains the type of update (full or differential) of this request.
+	LocalAuthorizationList []AuthorizationData `json:"localAuthorizationList,omitempty" validate:"omitempty,dive"` // This contains the Local Authorization List entries.
+}
+
+// This field definition of the SendLocalList response payload, sent by the Charging Station to the CSMS in response to a SendLocalListRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SendLocalListResponse struct {
+	Status     SendLocalListStatus `json:"status" validate:"required,sendLocalListStatus201"` // This indicates whether the Charging Station has successfully received and applied the update of the Local Authorization List.
+	StatusInfo *types.StatusInfo   `json:"statusInfo,omitempty" validate:"omitempty"`         // Detailed status information.
+}
+
+// Enables the CSMS to send a Local Authorization List which a Charging Station can use for the authorization of idTokens.
+// The list MAY be either a full list to replace the current list in the Charging Station or it MAY be a differential list with updates to be applied to the current list in the Charging Station.
+//
+// To install or update a local authorization list, the CSMS sends a SendLocalListRequest to a Charging Station,
+// which responds with a SendLocalListResponse, containing the status of the operation.
+//
+// Upon receipt of a SendLocalListRequest the Charging Station SHALL respond with:
+//   - Accepted, if the local authorization list was successfully updated
+//   - Failed, if the update could not be applied
+//   - VersionMismatch, if a differential update was requested but the version number of the current list doesn't match
+type SendLocalListFeature struct{}
+
+func (f SendLocalListFeature) GetFeatureName() string {
+	return SendLocalListFeatureName
+}
+
+func (f SendLocalListFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SendLocalListRequest{})
+}
+
+func (f SendLocalListFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SendLocalListResponse{})
+}
+
+func (r SendLocalListRequest) GetFeatureName() string {
+	return SendLocalListFeatureName
+}
+
+func (c SendLocalListResponse) GetFeatureName() string {
+	return SendLocalListFeatureName
+}
+
+// Creates a new SendLocalListRequest, containing all required fields. Optional fields may be set afterwards.
+func NewSendLocalListRequest(versionNumber int, updateType UpdateType) *SendLocalListRequest {
+	return &SendLocalListRequest{VersionNumber: versionNumber, UpdateType: updateType}
+}
+
+// Creates a new SendLocalListResponse, containing all required fields. There are no optional fields for this message.
+func NewSendLocalListResponse(status SendLocalListStatus) *SendLocalListResponse {
+	return &SendLocalListResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("updateType201", isValidUpdateType)
+	_ = types.Validate.RegisterValidation("sendLocalListStatus201", isValidSendLocalListStatus)
+}
@@ -0,0 +1,85 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Get Report (CSMS -> CS) --------------------
+
+const GetReportFeatureName = "GetReport"
+
+// Criteria for components, used for filtering a GetReportRequest.
+type ComponentCriterion string
+
+const (
+	ComponentCriterionActive    ComponentCriterion = "Active"
+	ComponentCriterionAvailable ComponentCriterion = "Available"
+	ComponentCriterionEnabled   ComponentCriterion = "Enabled"
+	ComponentCriterionProblem   ComponentCriterion = "Problem"
+)
+
+func isValidComponentCriterion(fl validator.FieldLevel) bool {
+	criterion := ComponentCriterion(fl.Field().String())
+	switch criterion {
+	case ComponentCriterionActive, ComponentCriterionAvailable, ComponentCriterionEnabled, ComponentCriterionProblem:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the GetReport request payload sent by the CSMS to the Charging Station.
+type GetReportRequest struct {
+	RequestID         *int                      `json:"requestId,omitempty" validate:"omitempty,gte=0"`                                    // The Id of the request.
+	ComponentCriteria []ComponentCriterion      `json:"componentCriteria,omitempty" validate:"omitempty,max=4,dive,componentCriterion201"` // This field contains criteria for components for which a report is requested.
+	ComponentVariable []types.ComponentVariable `json:"componentVariable,omitempty" validate:"omitempty,dive"`                             // This field specifies the components and variables for which a report is requested.
+}
+
+// This field definition of the GetReport response payload, sent by the Charging Station to the CSMS in response to a GetReportRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetReportResponse struct {
+	Status     types.GenericDeviceModelStatus `json:"status" validate:"required,genericDeviceModelStatus201"` // This field indicates whether the Charging Station was able to accept the request.
+	StatusInfo *types.StatusInfo              `json:"statusInfo,omitempty" validate:"omitempty"`              // Detailed status information.
+}
+
+// The CSO may want to request a report of specific components and variables from a Charging Station.
+// The CSMS sends a GetReportRequest to the Charging Station, optionally filtered on componentCriteria and componentVariables.
+// The Charging Station responds with a GetReportResponse.
+// The result will be returned asynchronously in one or more NotifyReportRequest messages (one for each report part).
+type GetReportFeature struct{}
+
+func (f GetReportFeature) GetFeatureName() string {
+	return GetReportFeatureName
+}
+
+func (f GetReportFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetReportRequest{})
+}
+
+func (f GetReportFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetReportResponse{})
+}
+
+func (r GetReportRequest) GetFeatureName() string {
+	return GetReportFeatureName
+}
+
+func (c GetReportResponse) GetFeatureName() string {
+	return GetReportFeatureName
+}
+
+// Creates a new GetReportRequest. All fields are optional and may be set afterwards.
+func NewGetReportRequest() *GetReportRequest {
+	return &GetReportRequest{}
+}
+
+// Creates a new GetReportResponse, containing all required fields. There are no optional fields for this message.
+func NewGetReportResponse(status types.GenericDeviceModelStatus) *GetReportResponse {
+	return &GetReportResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("componentCriterion201", isValidComponentCriterion)
+}
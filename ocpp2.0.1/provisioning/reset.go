@@ -0,0 +1,101 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Reset (CSMS -> CS) --------------------
+
+const ResetFeatureName = "Reset"
+
+// Type of reset requested by the CSMS.
+type ResetType string
+
+// Result of a ResetRequest.
+type ResetStatus string
+
+const (
+	ResetTypeImmediate   ResetType   = "Immediate"
+	ResetTypeOnIdle      ResetType   = "OnIdle"
+	ResetStatusAccepted  ResetStatus = "Accepted"
+	ResetStatusRejected  ResetStatus = "Rejected"
+	ResetStatusScheduled ResetStatus = "Scheduled"
+)
+
+func isValidResetType(fl validator.FieldLevel) bool {
+	status := ResetType(fl.Field().String())
+	switch status {
+	case ResetTypeImmediate, ResetTypeOnIdle:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidResetStatus(fl validator.FieldLevel) bool {
+	status := ResetStatus(fl.Field().String())
+	switch status {
+	case ResetStatusAccepted, ResetStatusRejected, ResetStatusScheduled:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the Reset request payload sent by the CSMS to the Charging Station.
+type ResetRequest struct {
+	Type   ResetType `json:"type" validate:"required,resetType201"`       // This contains the type of reset that the Charging Station or EVSE should perform.
+	EvseID *int      `json:"evseId,omitempty" validate:"omitempty,gte=0"` // This contains the ID of a specific EVSE that needs to be reset, instead of the entire Charging Station.
+}
+
+// This field definition of the Reset response payload, sent by the Charging Station to the CSMS in response to a ResetRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ResetResponse struct {
+	Status     ResetStatus       `json:"status" validate:"required,resetStatus201"` // This indicates whether the Charging Station is able to perform the reset.
+	StatusInfo *types.StatusInfo `json:"statusInfo,omitempty" validate:"omitempty"` // Detailed status information.
+}
+
+// The CSO may trigger the CSMS to request a Charging Station to reset itself or an EVSE.
+// This can be used when a Charging Station is not functioning correctly, or when a configuration change requires a reboot to become active.
+// The CSMS sends a ResetRequest to the Charging Station, which responds with a ResetResponse.
+//
+// An Immediate reset causes ongoing transactions to be stopped, whereas an OnIdle reset is only performed once all transactions have ended.
+// In the latter case, the Charging Station may respond with a Scheduled status.
+type ResetFeature struct{}
+
+func (f ResetFeature) GetFeatureName() string {
+	return ResetFeatureName
+}
+
+func (f ResetFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ResetRequest{})
+}
+
+func (f ResetFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ResetResponse{})
+}
+
+func (r ResetRequest) GetFeatureName() string {
+	return ResetFeatureName
+}
+
+func (c ResetResponse) GetFeatureName() string {
+	return ResetFeatureName
+}
+
+// Creates a new ResetRequest, containing all required fields. Optional fields may be set afterwards.
+func NewResetRequest(t ResetType) *ResetRequest {
+	return &ResetRequest{Type: t}
+}
+
+// Creates a new ResetResponse, containing all required fields. There are no optional fields for this message.
+func NewResetResponse(status ResetStatus) *ResetResponse {
+	return &ResetResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("resetType201", isValidResetType)
+	_ = types.Validate.RegisterValidation("resetStatus201", isValidResetStatus)
+}
@@ -0,0 +1,101 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Set Variables (CSMS -> CS) --------------------
+
+const SetVariablesFeatureName = "SetVariables"
+
+// Result status of a SetVariableData request.
+type SetVariableStatus string
+
+const (
+	SetVariableStatusAccepted                  SetVariableStatus = "Accepted"
+	SetVariableStatusRejected                  SetVariableStatus = "Rejected"
+	SetVariableStatusUnknownComponent          SetVariableStatus = "UnknownComponent"
+	SetVariableStatusUnknownVariable           SetVariableStatus = "UnknownVariable"
+	SetVariableStatusNotSupportedAttributeType SetVariableStatus = "NotSupportedAttributeType"
+	SetVariableStatusRebootRequired            SetVariableStatus = "RebootRequired"
+)
+
+func isValidSetVariableStatus(fl validator.FieldLevel) bool {
+	status := SetVariableStatus(fl.Field().String())
+	switch status {
+	case SetVariableStatusAccepted, SetVariableStatusRejected, SetVariableStatusUnknownComponent, SetVariableStatusUnknownVariable, SetVariableStatusNotSupportedAttributeType, SetVariableStatusRebootRequired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Specifies the component, variable and attribute type for which a new value shall be set.
+type SetVariableData struct {
+	AttributeType  Attribute       `json:"attributeType,omitempty" validate:"omitempty,attribute201"` // Type of attribute: Actual, Target, MinSet, MaxSet. Default is Actual when omitted.
+	AttributeValue string          `json:"attributeValue" validate:"required,max=1000"`               // Value to be assigned to attribute of variable.
+	Component      types.Component `json:"component" validate:"required"`                             // The component for which the variable is to be set.
+	Variable       types.Variable  `json:"variable" validate:"required"`                              // Specifies the variable to be set.
+}
+
+// Contains the result of a single SetVariableData request.
+type SetVariableResult struct {
+	AttributeType       Attribute         `json:"attributeType,omitempty" validate:"omitempty,attribute201"` // Type of attribute: Actual, Target, MinSet, MaxSet. Default is Actual when omitted.
+	AttributeStatus     SetVariableStatus `json:"attributeStatus" validate:"required,setVariableStatus201"`  // Result status of setting the variable.
+	AttributeStatusInfo *types.StatusInfo `json:"attributeStatusInfo,omitempty" validate:"omitempty"`        // Detailed status information.
+	Component           types.Component   `json:"component" validate:"required"`                             // The component for which result is returned.
+	Variable            types.Variable    `json:"variable" validate:"required"`                              // The variable for which the result is returned.
+}
+
+// The field definition of the SetVariables request payload sent by the CSMS to the Charging Station.
+type SetVariablesRequest struct {
+	SetVariableData []SetVariableData `json:"setVariableData" validate:"required,min=1,dive"` // List of variables to be set.
+}
+
+// This field definition of the SetVariables response payload, sent by the Charging Station to the CSMS in response to a SetVariablesRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetVariablesResponse struct {
+	SetVariableResult []SetVariableResult `json:"setVariableResult" validate:"required,min=1,dive"` // List of results, one for each variable that was requested to be set.
+}
+
+// A Charging Station can have a lot of variables that can be configured/changed by the CSMS.
+// The CSMS can set the value of one or more variables on a Charging Station by sending a SetVariablesRequest.
+// The Charging Station responds with a SetVariablesResponse, containing the result for each variable.
+// Variables that require a reboot in order to become active, are reported with the RebootRequired status.
+type SetVariablesFeature struct{}
+
+func (f SetVariablesFeature) GetFeatureName() string {
+	return SetVariablesFeatureName
+}
+
+func (f SetVariablesFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetVariablesRequest{})
+}
+
+func (f SetVariablesFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetVariablesResponse{})
+}
+
+func (r SetVariablesRequest) GetFeatureName() string {
+	return SetVariablesFeatureName
+}
+
+func (c SetVariablesResponse) GetFeatureName() string {
+	return SetVariablesFeatureName
+}
+
+// Creates a new SetVariablesRequest, containing all required fields. There are no optional fields for this message.
+func NewSetVariablesRequest(variableData []SetVariableData) *SetVariablesRequest {
+	return &SetVariablesRequest{SetVariableData: variableData}
+}
+
+// Creates a new SetVariablesResponse, containing all required fields. There are no optional fields for this message.
+func NewSetVariablesResponse(result []SetVariableResult) *SetVariablesResponse {
+	return &SetVariablesResponse{SetVariableResult: result}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("setVariableStatus201", isValidSetVariableStatus)
+}
@@ -0,0 +1,133 @@
+package smartcharging
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Notify EV Charging Needs (CS -> CSMS) --------------------
+
+const NotifyEVChargingNeedsFeatureName = "NotifyEVChargingNeeds"
+
+// EnergyTransferMode contains the different energy transfer modes, requested by an EV over ISO 15118.
+type EnergyTransferMode string
+
+// Status returned in NotifyEVChargingNeedsResponse, indicating whether the CSMS is able to process the charging needs.
+type EVChargingNeedsStatus string
+
+const (
+	EnergyTransferModeDC            EnergyTransferMode = "DC"              // DC charging.
+	EnergyTransferModeACSinglePhase EnergyTransferMode = "AC_single_phase" // AC single phase charging according to IEC 62196.
+	EnergyTransferModeACTwoPhase    EnergyTransferMode = "AC_two_phase"    // AC two phase charging according to IEC 62196.
+	EnergyTransferModeACThreePhase  EnergyTransferMode = "AC_three_phase"  // AC three phase charging according to IEC 62196.
+
+	EVChargingNeedsStatusAccepted   EVChargingNeedsStatus = "Accepted"   // A schedule will be provided momentarily.
+	EVChargingNeedsStatusRejected   EVChargingNeedsStatus = "Rejected"   // Service not available.
+	EVChargingNeedsStatusProcessing EVChargingNeedsStatus = "Processing" // The CSMS is gathering information to provide a schedule.
+)
+
+func isValidEnergyTransferMode(fl validator.FieldLevel) bool {
+	status := EnergyTransferMode(fl.Field().String())
+	switch status {
+	case EnergyTransferModeDC, EnergyTransferModeACSinglePhase, EnergyTransferModeACTwoPhase, EnergyTransferModeACThreePhase:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidEVChargingNeedsStatus(fl validator.FieldLevel) bool {
+	status := EVChargingNeedsStatus(fl.Field().String())
+	switch status {
+	case EVChargingNeedsStatusAccepted, EVChargingNeedsStatusRejected, EVChargingNeedsStatusProcessing:
+		return true
+	default:
+		return false
+	}
+}
+
+// ACChargingParameters contains EV AC charging parameters. Used by ChargingNeeds.
+type ACChargingParameters struct {
+	EnergyAmount int `json:"energyAmount" validate:"gte=0"` // Amount of energy requested (in Wh). This includes energy required for preconditioning.
+	EVMinCurrent int `json:"evMinCurrent" validate:"gte=0"` // Minimum current (amps) supported by the electric vehicle (per phase).
+	EVMaxCurrent int `json:"evMaxCurrent" validate:"gte=0"` // Maximum current (amps) supported by the electric vehicle (per phase). Includes cable capacity.
+	EVMaxVoltage int `json:"evMaxVoltage" validate:"gte=0"` // Maximum voltage supported by the electric vehicle.
+}
+
+// DCChargingParameters contains EV DC charging parameters. Used by ChargingNeeds.
+type DCChargingParameters struct {
+	EVMaxCurrent     int  `json:"evMaxCurrent" validate:"gte=0"`                              // Maximum current (amps) supported by the electric vehicle. Includes cable capacity.
+	EVMaxVoltage     int  `json:"evMaxVoltage" validate:"gte=0"`                              // Maximum voltage supported by the electric vehicle.
+	EnergyAmount     *int `json:"energyAmount,omitempty" validate:"omitempty,gte=0"`          // Amount of energy requested (in Wh). This includes energy required for preconditioning.
+	EVMaxPower       *int `json:"evMaxPower,omitempty" validate:"omitempty,gte=0"`            // Maximum power that the EV supports (in W).
+	StateOfCharge    *int `json:"stateOfCharge,omitempty" validate:"omitempty,gte=0,lte=100"` // Energy available in the battery (in percent of the battery capacity).
+	EVEnergyCapacity *int `json:"evEnergyCapacity,omitempty" validate:"omitempty,gte=0"`      // Capacity of the electric vehicle battery (in Wh).
+	FullSoC          *int `json:"fullSoC,omitempty" validate:"omitempty,gte=0,lte=100"`       // Percentage of SoC at which the EV considers the battery fully charged.
+	BulkSoC          *int `json:"bulkSoC,omitempty" validate:"omitempty,gte=0,lte=100"`       // Percentage of SoC at which the EV considers a fast charging process to end.
+}
+
+// ChargingNeeds contains the characteristics of the energy delivery required, as reported by an EV over ISO 15118.
+type ChargingNeeds struct {
+	RequestedEnergyTransfer EnergyTransferMode    `json:"requestedEnergyTransfer" validate:"required,energyTransferMode201"` // Mode of energy transfer requested by the EV.
+	DepartureTime           *types.DateTime       `json:"departureTime,omitempty" validate:"omitempty"`                      // Estimated departure time of the EV.
+	ACChargingParameters    *ACChargingParameters `json:"acChargingParameters,omitempty" validate:"omitempty"`               // EV AC charging parameters.
+	DCChargingParameters    *DCChargingParameters `json:"dcChargingParameters,omitempty" validate:"omitempty"`               // EV DC charging parameters.
+}
+
+// The field definition of the NotifyEVChargingNeeds request payload sent by the Charging Station to the CSMS.
+type NotifyEVChargingNeedsRequest struct {
+	MaxScheduleTuples *int          `json:"maxScheduleTuples,omitempty" validate:"omitempty,gte=0"` // Contains the maximum schedule tuples the car supports per schedule.
+	EvseID            int           `json:"evseId" validate:"gt=0"`                                 // Defines the EVSE and connector to which the EV is connected. EvseId may not be 0.
+	ChargingNeeds     ChargingNeeds `json:"chargingNeeds" validate:"required"`                      // The characteristics of the energy delivery required.
+}
+
+// This field definition of the NotifyEVChargingNeeds response payload, sent by the CSMS to the Charging Station in response to a NotifyEVChargingNeedsRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyEVChargingNeedsResponse struct {
+	Status     EVChargingNeedsStatus `json:"status" validate:"required,evChargingNeedsStatus201"` // Returns whether the CSMS has been able to process the message successfully. It does not imply that the evChargingNeeds can be met with the current charging profile.
+	StatusInfo *types.StatusInfo     `json:"statusInfo,omitempty" validate:"omitempty"`           // Detailed status information.
+}
+
+// When an EV sends a ChargeParameterDiscoveryReq with charging needs parameters over ISO 15118,
+// the Charging Station sends this information in a NotifyEVChargingNeedsRequest to the CSMS.
+// The CSMS replies to the Charging Station with a NotifyEVChargingNeedsResponse message.
+//
+// The CSMS will then attempt to calculate a new charging schedule, that satisfies the EV's charging needs,
+// and send it to the Charging Station via a SetChargingProfileRequest.
+type NotifyEVChargingNeedsFeature struct{}
+
+func (f NotifyEVChargingNeedsFeature) GetFeatureName() string {
+	return NotifyEVChargingNeedsFeatureName
+}
+
+func (f NotifyEVChargingNeedsFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyEVChargingNeedsRequest{})
+}
+
+func (f NotifyEVChargingNeedsFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyEVChargingNeedsResponse{})
+}
+
+func (r NotifyEVChargingNeedsRequest) GetFeatureName() string {
+	return NotifyEVChargingNeedsFeatureName
+}
+
+func (c NotifyEVChargingNeedsResponse) GetFeatureName() string {
+	return NotifyEVChargingNeedsFeatureName
+}
+
+// Creates a new NotifyEVChargingNeedsRequest, containing all required fields. Optional fields may be set afterwards.
+func NewNotifyEVChargingNeedsRequest(evseID int, chargingNeeds ChargingNeeds) *NotifyEVChargingNeedsRequest {
+	return &NotifyEVChargingNeedsRequest{EvseID: evseID, ChargingNeeds: chargingNeeds}
+}
+
+// Creates a new NotifyEVChargingNeedsResponse, containing all required fields. There are no optional fields for this message.
+func NewNotifyEVChargingNeedsResponse(status EVChargingNeedsStatus) *NotifyEVChargingNeedsResponse {
+	return &NotifyEVChargingNeedsResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("energyTransferMode201", isValidEnergyTransferMode)
+	_ = types.Validate.RegisterValidation("evChargingNeedsStatus201", isValidEVChargingNeedsStatus)
+}
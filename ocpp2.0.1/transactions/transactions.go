@@ -0,0 +1,24 @@
+// The transactions functional block contains OCPP 2.0.1 features related to OCPP transactions.
+package transactions
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0.1 Transactions profile.
+type CSMSHandler interface {
+	// OnTransactionEvent is called on the CSMS whenever a TransactionEventRequest is received from a charging station.
+	OnTransactionEvent(chargingStationID string, request *TransactionEventRequest) (confirmation *TransactionEventResponse, err error)
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0.1 Transactions profile.
+type ChargingStationHandler interface {
+	// OnGetTransactionStatus is called on a charging station whenever a GetTransactionStatusRequest is received from the CSMS.
+	OnGetTransactionStatus(request *GetTransactionStatusRequest) (confirmation *GetTransactionStatusResponse, err error)
+}
+
+const ProfileName = "transactions"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	GetTransactionStatusFeature{},
+	TransactionEventFeature{},
+)
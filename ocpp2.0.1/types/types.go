@@ -0,0 +1,631 @@
+// Contains common and shared data types between OCPP 2.0.1 messages.
+package types
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+const (
+	V2Subprotocol = "ocpp2.0.1"
+)
+
+type PropertyViolation struct {
+	error
+	Property string
+}
+
+func (e *PropertyViolation) Error() string {
+	return ""
+}
+
+type AuthorizationStatus string
+
+const (
+	AuthorizationStatusAccepted           AuthorizationStatus = "Accepted"
+	AuthorizationStatusBlocked            AuthorizationStatus = "Blocked"
+	AuthorizationStatusExpired            AuthorizationStatus = "Expired"
+	AuthorizationStatusInvalid            AuthorizationStatus = "Invalid"
+	AuthorizationStatusConcurrentTx       AuthorizationStatus = "ConcurrentTx"
+	AuthorizationStatusNoCredit           AuthorizationStatus = "NoCredit"
+	AuthorizationStatusNotAllowedTypeEVSE AuthorizationStatus = "NotAllowedTypeEVSE"
+	AuthorizationStatusNotAtThisLocation  AuthorizationStatus = "NotAtThisLocation"
+	AuthorizationStatusNotAtThisTime      AuthorizationStatus = "NotAtThisTime"
+	AuthorizationStatusUnknown            AuthorizationStatus = "Unknown"
+)
+
+func isValidAuthorizationStatus(fl validator.FieldLevel) bool {
+	status := AuthorizationStatus(fl.Field().String())
+	switch status {
+	case AuthorizationStatusAccepted, AuthorizationStatusBlocked, AuthorizationStatusExpired, AuthorizationStatusInvalid, AuthorizationStatusConcurrentTx, AuthorizationStatusNoCredit, AuthorizationStatusNotAllowedTypeEVSE, AuthorizationStatusNotAtThisLocation, AuthorizationStatusNotAtThisTime, AuthorizationStatusUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// ID Token
+type IdTokenType string
+
+const (
+	IdTokenTypeCentral         IdTokenType = "Central"
+	IdTokenTypeEMAID           IdTokenType = "eMAID"
+	IdTokenTypeISO14443        IdTokenType = "ISO14443"
+	IdTokenTypeKeyCode         IdTokenType = "KeyCode"
+	IdTokenTypeLocal           IdTokenType = "Local"
+	IdTokenTypeNoAuthorization IdTokenType = "NoAuthorization"
+	IdTokenTypeISO15693        IdTokenType = "ISO15693"
+	IdTokenTypeMacAddress      IdTokenType = "MacAddress"
+)
+
+func isValidIdTokenType(fl validator.FieldLevel) bool {
+	tokenType := IdTokenType(fl.Field().String())
+	switch tokenType {
+	case IdTokenTypeCentral, IdTokenTypeEMAID, IdTokenTypeISO14443, IdTokenTypeKeyCode, IdTokenTypeLocal, IdTokenTypeNoAuthorization, IdTokenTypeISO15693, IdTokenTypeMacAddress:
+		return true
+	default:
+		return false
+	}
+}
+
+type AdditionalInfo struct {
+	AdditionalIdToken string `json:"additionalIdToken" validate:"required,max=36"`
+	Type              string `json:"type" validate:"required,max=50"`
+}
+
+type IdToken struct {
+	IdToken        string           `json:"idToken" validate:"required,max=36"`
+	Type           IdTokenType      `json:"type" validate:"required,idTokenType201"`
+	AdditionalInfo []AdditionalInfo `json:"additionalInfo,omitempty" validate:"omitempty,dive"`
+}
+
+// Generic Device Model Status
+type GenericDeviceModelStatus string
+
+const (
+	GenericDeviceModelStatusAccepted       GenericDeviceModelStatus = "Accepted"
+	GenericDeviceModelStatusRejected       GenericDeviceModelStatus = "Rejected"
+	GenericDeviceModelStatusNotSupported   GenericDeviceModelStatus = "NotSupported"
+	GenericDeviceModelStatusEmptyResultSet GenericDeviceModelStatus = "EmptyResultSet"
+)
+
+func isValidGenericDeviceModelStatus(fl validator.FieldLevel) bool {
+	status := GenericDeviceModelStatus(fl.Field().String())
+	switch status {
+	case GenericDeviceModelStatusAccepted, GenericDeviceModelStatusRejected, GenericDeviceModelStatusNotSupported, GenericDeviceModelStatusEmptyResultSet:
+		return true
+	default:
+		return false
+	}
+}
+
+// Generic Status
+type GenericStatus string
+
+const (
+	GenericStatusAccepted GenericStatus = "Accepted"
+	GenericStatusRejected GenericStatus = "Rejected"
+)
+
+func isValidGenericStatus(fl validator.FieldLevel) bool {
+	status := GenericStatus(fl.Field().String())
+	switch status {
+	case GenericStatusAccepted, GenericStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Hash Algorithms
+type HashAlgorithmType string
+
+const (
+	SHA256 HashAlgorithmType = "SHA256"
+	SHA384 HashAlgorithmType = "SHA384"
+	SHA512 HashAlgorithmType = "SHA512"
+)
+
+func isValidHashAlgorithmType(fl validator.FieldLevel) bool {
+	algorithm := HashAlgorithmType(fl.Field().String())
+	switch algorithm {
+	case SHA256, SHA384, SHA512:
+		return true
+	default:
+		return false
+	}
+}
+
+// OCSPRequestDataType
+type OCSPRequestDataType struct {
+	HashAlgorithm  HashAlgorithmType `json:"hashAlgorithm" validate:"required,hashAlgorithm201"`
+	IssuerNameHash string            `json:"issuerNameHash" validate:"required,max=128"`
+	IssuerKeyHash  string            `json:"issuerKeyHash" validate:"required,max=128"`
+	SerialNumber   string            `json:"serialNumber" validate:"required,max=20"`
+	ResponderURL   string            `json:"responderURL,omitempty" validate:"max=512"`
+}
+
+// CertificateHashDataType
+type CertificateHashData struct {
+	HashAlgorithm  HashAlgorithmType `json:"hashAlgorithm" validate:"required,hashAlgorithm201"`
+	IssuerNameHash string            `json:"issuerNameHash" validate:"required,max=128"`
+	IssuerKeyHash  string            `json:"issuerKeyHash" validate:"required,max=128"`
+	SerialNumber   string            `json:"serialNumber" validate:"required,max=20"`
+}
+
+// CertificateStatus
+type CertificateStatus string
+
+const (
+	CertificateStatusAccepted               CertificateStatus = "Accepted"
+	CertificateStatusSignatureError         CertificateStatus = "SignatureError"
+	CertificateStatusCertificateExpired     CertificateStatus = "CertificateExpired"
+	CertificateStatusCertificateRevoked     CertificateStatus = "CertificateRevoked"
+	CertificateStatusNoCertificateAvailable CertificateStatus = "NoCertificateAvailable"
+	CertificateStatusCertChainError         CertificateStatus = "CertChainError"
+	CertificateStatusContractCancelled      CertificateStatus = "ContractCancelled"
+)
+
+func isValidCertificateStatus(fl validator.FieldLevel) bool {
+	status := CertificateStatus(fl.Field().String())
+	switch status {
+	case CertificateStatusAccepted, CertificateStatusCertChainError, CertificateStatusCertificateExpired, CertificateStatusSignatureError, CertificateStatusNoCertificateAvailable, CertificateStatusCertificateRevoked, CertificateStatusContractCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Certificate15118EVStatus
+type Certificate15118EVStatus string
+
+const (
+	Certificate15188EVStatusAccepted Certificate15118EVStatus = "Accepted"
+	Certificate15118EVStatusFailed   Certificate15118EVStatus = "Failed"
+)
+
+func isValidCertificate15118EVStatus(fl validator.FieldLevel) bool {
+	status := Certificate15118EVStatus(fl.Field().String())
+	switch status {
+	case Certificate15188EVStatusAccepted, Certificate15118EVStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Indicates the type of the signed certificate that is returned.
+// When omitted the certificate is used for both the 15118 connection (if implemented) and the Charging Station to CSMS connection.
+// This field is required when a typeOfCertificate was included in the SignCertificateRequest that requested this certificate to be signed AND both the 15118 connection and the Charging Station connection are implemented.
+type CertificateSigningUse string
+
+const (
+	ChargingStationCert CertificateSigningUse = "ChargingStationCertificate"
+	V2GCertificate      CertificateSigningUse = "V2GCertificate"
+)
+
+func isValidCertificateSigningUse(fl validator.FieldLevel) bool {
+	status := CertificateSigningUse(fl.Field().String())
+	switch status {
+	case ChargingStationCert, V2GCertificate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Indicates the type of the requested certificate.
+// It is used in GetInstalledCertificateIdsRequest and InstallCertificateRequest messages.
+type CertificateUse string
+
+const (
+	V2GRootCertificate          CertificateUse = "V2GRootCertificate"
+	MORootCertificate           CertificateUse = "MORootCertificate"
+	CSMSRootCertificate         CertificateUse = "CSMSRootCertificate"
+	V2GCertificateChain         CertificateUse = "V2GCertificateChain"
+	ManufacturerRootCertificate CertificateUse = "ManufacturerRootCertificate"
+)
+
+func isValidCertificateUse(fl validator.FieldLevel) bool {
+	use := CertificateUse(fl.Field().String())
+	switch use {
+	case V2GRootCertificate, MORootCertificate, CSMSRootCertificate, V2GCertificateChain, ManufacturerRootCertificate:
+		return true
+	default:
+		return false
+	}
+}
+
+// ID Token Info
+type MessageFormatType string
+
+const (
+	MessageFormatASCII MessageFormatType = "ASCII"
+	MessageFormatHTML  MessageFormatType = "HTML"
+	MessageFormatURI   MessageFormatType = "URI"
+	MessageFormatUTF8  MessageFormatType = "UTF8"
+)
+
+func isValidMessageFormatType(fl validator.FieldLevel) bool {
+	algorithm := MessageFormatType(fl.Field().String())
+	switch algorithm {
+	case MessageFormatASCII, MessageFormatHTML, MessageFormatURI, MessageFormatUTF8:
+		return true
+	default:
+		return false
+	}
+}
+
+type MessageContent struct {
+	Format   MessageFormatType `json:"format" validate:"required,messageFormat201"`
+	Language string            `json:"language,omitempty" validate:"max=8"`
+	Content  string            `json:"content" validate:"required,max=512"`
+}
+
+type GroupIdToken struct {
+	IdToken string      `json:"idToken" validate:"required,max=36"`
+	Type    IdTokenType `json:"type" validate:"required,idTokenType201"`
+}
+
+type IdTokenInfo struct {
+	Status              AuthorizationStatus `json:"status" validate:"required,authorizationStatus201"`
+	CacheExpiryDateTime *DateTime           `json:"cacheExpiryDateTime,omitempty" validate:"omitempty"`
+	ChargingPriority    int                 `json:"chargingPriority,omitempty" validate:"min=-9,max=9"`
+	Language1           string              `json:"language1,omitempty" validate:"max=8"`
+	Language2           string              `json:"language2,omitempty" validate:"max=8"`
+	EvseID              []int               `json:"evseId,omitempty" validate:"omitempty,dive,gte=0"`
+	GroupIdToken        *GroupIdToken       `json:"groupIdToken,omitempty"`
+	PersonalMessage     *MessageContent     `json:"personalMessage,omitempty"`
+}
+
+// NewIdTokenInfo creates an IdTokenInfo. Optional parameters may be set afterwards on the initialized struct.
+func NewIdTokenInfo(status AuthorizationStatus) *IdTokenInfo {
+	return &IdTokenInfo{Status: status}
+}
+
+// StatusInfo contains more information about the status returned in a response.
+type StatusInfo struct {
+	ReasonCode     string `json:"reasonCode" validate:"required,max=20"`                 // A predefined code for the reason why the status is returned in this response. The string is case-insensitive.
+	AdditionalInfo string `json:"additionalInfo,omitempty" validate:"omitempty,max=512"` // Additional text to provide detailed information.
+}
+
+// NewStatusInfo creates a StatusInfo struct.
+// If no additional info need to be set, an empty string may be passed.
+func NewStatusInfo(reasonCode string, additionalInfo string) *StatusInfo {
+	return &StatusInfo{ReasonCode: reasonCode, AdditionalInfo: additionalInfo}
+}
+
+// EVSE represents the Electric Vehicle Supply Equipment, formerly referred to as connector(s).
+type EVSE struct {
+	ID          int  `json:"id" validate:"gte=0"`                              // The EVSE Identifier. When 0, the ID references the Charging Station as a whole.
+	ConnectorID *int `json:"connectorId,omitempty" validate:"omitempty,gte=0"` // An id to designate a specific connector (on an EVSE) by connector index number.
+}
+
+// Component represents a physical or logical component.
+type Component struct {
+	Name     string `json:"name" validate:"required,max=50"`                // Name of the component. Name should be taken from the list of standardized component names whenever possible. Case Insensitive. strongly advised to use Camel Case.
+	Instance string `json:"instance,omitempty" validate:"omitempty,max=50"` // Name of instance in case the component exists as multiple instances. Case Insensitive. strongly advised to use Camel Case.
+	EVSE     *EVSE  `json:"evse,omitempty" validate:"omitempty"`            // Specifies the EVSE when component is located at EVSE level, also specifies the connector when component is located at Connector level.
+}
+
+// Variable is a reference key to a component-variable.
+type Variable struct {
+	Name     string `json:"name" validate:"required,max=50"`                // Name of the variable. Name should be taken from the list of standardized variable names whenever possible. Case Insensitive. strongly advised to use Camel Case.
+	Instance string `json:"instance,omitempty" validate:"omitempty,max=50"` // Name of instance in case the variable exists as multiple instances. Case Insensitive. strongly advised to use Camel Case.
+}
+
+// ComponentVariable is used to report components, variables and variable attributes and characteristics.
+type ComponentVariable struct {
+	Component Component `json:"component" validate:"required"` // Component for which a report of Variable is requested.
+	Variable  Variable  `json:"variable" validate:"required"`  // Variable for which report is requested.
+}
+
+// Charging Profiles
+type ChargingProfilePurposeType string
+type ChargingProfileKindType string
+type RecurrencyKindType string
+type ChargingRateUnitType string
+type ChargingLimitSourceType string
+
+const (
+	ChargingProfilePurposeChargingStationExternalConstraints ChargingProfilePurposeType = "ChargingStationExternalConstraints"
+	ChargingProfilePurposeChargingStationMaxProfile          ChargingProfilePurposeType = "ChargingStationMaxProfile"
+	ChargingProfilePurposeTxDefaultProfile                   ChargingProfilePurposeType = "TxDefaultProfile"
+	ChargingProfilePurposeTxProfile                          ChargingProfilePurposeType = "TxProfile"
+	ChargingProfileKindAbsolute                              ChargingProfileKindType    = "Absolute"
+	ChargingProfileKindRecurring                             ChargingProfileKindType    = "Recurring"
+	ChargingProfileKindRelative                              ChargingProfileKindType    = "Relative"
+	RecurrencyKindDaily                                      RecurrencyKindType         = "Daily"
+	RecurrencyKindWeekly                                     RecurrencyKindType         = "Weekly"
+	ChargingRateUnitWatts                                    ChargingRateUnitType       = "W"
+	ChargingRateUnitAmperes                                  ChargingRateUnitType       = "A"
+	ChargingLimitSourceEMS                                   ChargingLimitSourceType    = "EMS"
+	ChargingLimitSourceOther                                 ChargingLimitSourceType    = "Other"
+	ChargingLimitSourceSO                                    ChargingLimitSourceType    = "SO"
+	ChargingLimitSourceCSO                                   ChargingLimitSourceType    = "CSO"
+)
+
+func isValidChargingProfilePurpose(fl validator.FieldLevel) bool {
+	purposeType := ChargingProfilePurposeType(fl.Field().String())
+	switch purposeType {
+	case ChargingProfilePurposeChargingStationExternalConstraints, ChargingProfilePurposeChargingStationMaxProfile, ChargingProfilePurposeTxDefaultProfile, ChargingProfilePurposeTxProfile:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidChargingProfileKind(fl validator.FieldLevel) bool {
+	purposeType := ChargingProfileKindType(fl.Field().String())
+	switch purposeType {
+	case ChargingProfileKindAbsolute, ChargingProfileKindRecurring, ChargingProfileKindRelative:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidRecurrencyKind(fl validator.FieldLevel) bool {
+	purposeType := RecurrencyKindType(fl.Field().String())
+	switch purposeType {
+	case RecurrencyKindDaily, RecurrencyKindWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidChargingRateUnit(fl validator.FieldLevel) bool {
+	purposeType := ChargingRateUnitType(fl.Field().String())
+	switch purposeType {
+	case ChargingRateUnitWatts, ChargingRateUnitAmperes:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidChargingLimitSource(fl validator.FieldLevel) bool {
+	chargingLimitSource := ChargingLimitSourceType(fl.Field().String())
+	switch chargingLimitSource {
+	case ChargingLimitSourceEMS, ChargingLimitSourceOther, ChargingLimitSourceSO, ChargingLimitSourceCSO:
+		return true
+	default:
+		return false
+	}
+}
+
+type ChargingSchedulePeriod struct {
+	StartPeriod  int     `json:"startPeriod" validate:"gte=0"`
+	Limit        float64 `json:"limit" validate:"gte=0"`
+	NumberPhases *int    `json:"numberPhases,omitempty" validate:"omitempty,gte=0"`
+}
+
+func NewChargingSchedulePeriod(startPeriod int, limit float64) ChargingSchedulePeriod {
+	return ChargingSchedulePeriod{StartPeriod: startPeriod, Limit: limit}
+}
+
+type ChargingSchedule struct {
+	StartSchedule          *DateTime                `json:"startSchedule,omitempty" validate:"omitempty"`
+	Duration               *int                     `json:"duration,omitempty" validate:"omitempty,gte=0"`
+	ChargingRateUnit       ChargingRateUnitType     `json:"chargingRateUnit" validate:"required,chargingRateUnit201"`
+	MinChargingRate        *float64                 `json:"minChargingRate,omitempty" validate:"omitempty,gte=0"`
+	ChargingSchedulePeriod []ChargingSchedulePeriod `json:"chargingSchedulePeriod" validate:"required,min=1"`
+}
+
+func NewChargingSchedule(chargingRateUnit ChargingRateUnitType, schedulePeriod ...ChargingSchedulePeriod) *ChargingSchedule {
+	return &ChargingSchedule{ChargingRateUnit: chargingRateUnit, ChargingSchedulePeriod: schedulePeriod}
+}
+
+type ChargingProfile struct {
+	ChargingProfileId      int                        `json:"chargingProfileId" validate:"gte=0"`
+	TransactionId          string                     `json:"transactionId,omitempty" validate:"omitempty,max=36"`
+	StackLevel             int                        `json:"stackLevel" validate:"gt=0"`
+	ChargingProfilePurpose ChargingProfilePurposeType `json:"chargingProfilePurpose" validate:"required,chargingProfilePurpose201"`
+	ChargingProfileKind    ChargingProfileKindType    `json:"chargingProfileKind" validate:"required,chargingProfileKind201"`
+	RecurrencyKind         RecurrencyKindType         `json:"recurrencyKind,omitempty" validate:"omitempty,recurrencyKind201"`
+	ValidFrom              *DateTime                  `json:"validFrom,omitempty"`
+	ValidTo                *DateTime                  `json:"validTo,omitempty"`
+	ChargingSchedule       *ChargingSchedule          `json:"chargingSchedule" validate:"required"`
+}
+
+func NewChargingProfile(chargingProfileId int, stackLevel int, chargingProfilePurpose ChargingProfilePurposeType, chargingProfileKind ChargingProfileKindType, schedule *ChargingSchedule) *ChargingProfile {
+	return &ChargingProfile{ChargingProfileId: chargingProfileId, StackLevel: stackLevel, ChargingProfilePurpose: chargingProfilePurpose, ChargingProfileKind: chargingProfileKind, ChargingSchedule: schedule}
+}
+
+// Remote Start/Stop
+type RemoteStartStopStatus string
+
+const (
+	RemoteStartStopStatusAccepted RemoteStartStopStatus = "Accepted"
+	RemoteStartStopStatusRejected RemoteStartStopStatus = "Rejected"
+)
+
+func isValidRemoteStartStopStatus(fl validator.FieldLevel) bool {
+	status := RemoteStartStopStatus(fl.Field().String())
+	switch status {
+	case RemoteStartStopStatusAccepted, RemoteStartStopStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Meter Value
+type ReadingContext string
+type Measurand string
+type Phase string
+type Location string
+
+const (
+	ReadingContextInterruptionBegin       ReadingContext = "Interruption.Begin"
+	ReadingContextInterruptionEnd         ReadingContext = "Interruption.End"
+	ReadingContextOther                   ReadingContext = "Other"
+	ReadingContextSampleClock             ReadingContext = "Sample.Clock"
+	ReadingContextSamplePeriodic          ReadingContext = "Sample.Periodic"
+	ReadingContextTransactionBegin        ReadingContext = "Transaction.Begin"
+	ReadingContextTransactionEnd          ReadingContext = "Transaction.End"
+	ReadingContextTrigger                 ReadingContext = "Trigger"
+	MeasurandCurrentExport                Measurand      = "Current.Export"
+	MeasurandCurrentImport                Measurand      = "Current.Import"
+	MeasurandCurrentOffered               Measurand      = "Current.Offered"
+	MeasurandEnergyActiveExportRegister   Measurand      = "Energy.Active.Export.Register"
+	MeasurandEnergyActiveImportRegister   Measurand      = "Energy.Active.Import.Register"
+	MeasurandEnergyReactiveExportRegister Measurand      = "Energy.Reactive.Export.Register"
+	MeasurandEnergyReactiveImportRegister Measurand      = "Energy.Reactive.Import.Register"
+	MeasurandEnergyActiveExportInterval   Measurand      = "Energy.Active.Export.Interval"
+	MeasurandEnergyActiveImportInterval   Measurand      = "Energy.Active.Import.Interval"
+	MeasurandEnergyReactiveExportInterval Measurand      = "Energy.Reactive.Export.Interval"
+	MeasurandEnergyReactiveImportInterval Measurand      = "Energy.Reactive.Import.Interval"
+	MeasurandEnergyActiveNet              Measurand      = "Energy.Active.Net"
+	MeasurandEnergyReactiveNet            Measurand      = "Energy.Reactive.Net"
+	MeasurandEnergyApparentNet            Measurand      = "Energy.Apparent.Net"
+	MeasurandEnergyApparentImport         Measurand      = "Energy.Apparent.Import"
+	MeasurandEnergyApparentExport         Measurand      = "Energy.Apparent.Export"
+	MeasurandFrequency                    Measurand      = "Frequency"
+	MeasurandPowerActiveExport            Measurand      = "Power.Active.Export"
+	MeasurandPowerActiveImport            Measurand      = "Power.Active.Import"
+	MeasurandPowerFactor                  Measurand      = "Power.Factor"
+	MeasurandPowerOffered                 Measurand      = "Power.Offered"
+	MeasurandPowerReactiveExport          Measurand      = "Power.Reactive.Export"
+	MeasurandPowerReactiveImport          Measurand      = "Power.Reactive.Import"
+	MeasueandSoC                          Measurand      = "SoC"
+	MeasurandVoltage                      Measurand      = "Voltage"
+	PhaseL1                               Phase          = "L1"
+	PhaseL2                               Phase          = "L2"
+	PhaseL3                               Phase          = "L3"
+	PhaseN                                Phase          = "N"
+	PhaseL1N                              Phase          = "L1-N"
+	PhaseL2N                              Phase          = "L2-N"
+	PhaseL3N                              Phase          = "L3-N"
+	PhaseL1L2                             Phase          = "L1-L2"
+	PhaseL2L3                             Phase          = "L2-L3"
+	PhaseL3L1                             Phase          = "L3-L1"
+	LocationBody                          Location       = "Body"
+	LocationCable                         Location       = "Cable"
+	LocationEV                            Location       = "EV"
+	LocationInlet                         Location       = "Inlet"
+	LocationOutlet                        Location       = "Outlet"
+)
+
+// Standardized units of measurement. The list is not exhaustive, see OCPP 2.0.1 Part 2 - Appendices for all allowed values.
+const (
+	UnitOfMeasureWh         = "Wh"
+	UnitOfMeasureKWh        = "kWh"
+	UnitOfMeasureVarh       = "varh"
+	UnitOfMeasureKvarh      = "kvarh"
+	UnitOfMeasureW          = "W"
+	UnitOfMeasureKW         = "kW"
+	UnitOfMeasureVA         = "VA"
+	UnitOfMeasureKVA        = "kVA"
+	UnitOfMeasureVar        = "var"
+	UnitOfMeasureKvar       = "kvar"
+	UnitOfMeasureA          = "A"
+	UnitOfMeasureV          = "V"
+	UnitOfMeasureCelsius    = "Celsius"
+	UnitOfMeasureFahrenheit = "Fahrenheit"
+	UnitOfMeasureK          = "K"
+	UnitOfMeasurePercent    = "Percent"
+)
+
+func isValidReadingContext(fl validator.FieldLevel) bool {
+	readingContext := ReadingContext(fl.Field().String())
+	switch readingContext {
+	case ReadingContextInterruptionBegin, ReadingContextInterruptionEnd, ReadingContextOther, ReadingContextSampleClock, ReadingContextSamplePeriodic, ReadingContextTransactionBegin, ReadingContextTransactionEnd, ReadingContextTrigger:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidMeasurand(fl validator.FieldLevel) bool {
+	measurand := Measurand(fl.Field().String())
+	switch measurand {
+	case MeasueandSoC, MeasurandCurrentExport, MeasurandCurrentImport, MeasurandCurrentOffered, MeasurandEnergyActiveExportInterval, MeasurandEnergyActiveExportRegister, MeasurandEnergyReactiveExportInterval, MeasurandEnergyReactiveExportRegister, MeasurandEnergyReactiveImportRegister, MeasurandEnergyReactiveImportInterval, MeasurandEnergyActiveImportInterval, MeasurandEnergyActiveImportRegister, MeasurandFrequency, MeasurandPowerActiveExport, MeasurandPowerActiveImport, MeasurandPowerReactiveImport, MeasurandPowerReactiveExport, MeasurandPowerOffered, MeasurandPowerFactor, MeasurandVoltage, MeasurandEnergyActiveNet, MeasurandEnergyReactiveNet, MeasurandEnergyApparentNet, MeasurandEnergyApparentImport, MeasurandEnergyApparentExport:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidPhase(fl validator.FieldLevel) bool {
+	phase := Phase(fl.Field().String())
+	switch phase {
+	case PhaseL1, PhaseL2, PhaseL3, PhaseN, PhaseL1N, PhaseL2N, PhaseL3N, PhaseL1L2, PhaseL2L3, PhaseL3L1:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidLocation(fl validator.FieldLevel) bool {
+	location := Location(fl.Field().String())
+	switch location {
+	case LocationBody, LocationCable, LocationEV, LocationInlet, LocationOutlet:
+		return true
+	default:
+		return false
+	}
+}
+
+// Represents a signed version of the meter value.
+type SignedMeterValue struct {
+	SignedMeterData string `json:"signedMeterData" validate:"required,max=2500"` // Base64 encoded, contains the signed data which might contain more then just the meter value. It can contain information like timestamps, reference to a customer etc.
+	SigningMethod   string `json:"signingMethod" validate:"required,max=50"`     // Method used to create the digital signature.
+	EncodingMethod  string `json:"encodingMethod" validate:"required,max=50"`    // Method used to encode the meter values before applying the digital signature algorithm.
+	PublicKey       string `json:"publicKey" validate:"required,max=2500"`       // Base64 encoded, sending depends on configuration variable PublicKeyWithSignedMeterValue.
+}
+
+// Represents a UnitOfMeasure with a multiplier.
+type UnitOfMeasure struct {
+	Unit       string `json:"unit,omitempty" validate:"omitempty,max=20"` // Unit of the value. Default = "Wh" if the (default) measurand is an "Energy" type.
+	Multiplier *int   `json:"multiplier,omitempty" validate:"omitempty"`  // Multiplier, this value represents the exponent to base 10. I.e. multiplier 3 means 10 raised to the 3rd power. Default is 0.
+}
+
+// Creates a new UnitOfMeasure struct. The multiplier is optional and may be set afterwards.
+func NewUnitOfMeasure(unit string) *UnitOfMeasure {
+	return &UnitOfMeasure{Unit: unit}
+}
+
+// Single sampled value in MeterValues. Each value can be accompanied by optional fields.
+type SampledValue struct {
+	Value            float64           `json:"value"`                                                    // Value as a “Raw” (decimal) number or “SignedData”.
+	Context          ReadingContext    `json:"context,omitempty" validate:"omitempty,readingContext201"` // Type of detail value: start, end or sample. Default = "Sample.Periodic"
+	Measurand        Measurand         `json:"measurand,omitempty" validate:"omitempty,measurand201"`    // Type of measurement. Default = "Energy.Active.Import.Register"
+	Phase            Phase             `json:"phase,omitempty" validate:"omitempty,phase201"`            // Indicates how the measured value is to be interpreted.
+	Location         Location          `json:"location,omitempty" validate:"omitempty,location201"`      // Indicates where the measured value has been sampled. Default = "Outlet"
+	SignedMeterValue *SignedMeterValue `json:"signedMeterValue,omitempty" validate:"omitempty"`          // Contains the MeterValueSignature with sign/encoding method information.
+	UnitOfMeasure    *UnitOfMeasure    `json:"unitOfMeasure,omitempty" validate:"omitempty"`             // Represents a UnitOfMeasure including a multiplier.
+}
+
+type MeterValue struct {
+	Timestamp    *DateTime      `json:"timestamp" validate:"required"`
+	SampledValue []SampledValue `json:"sampledValue" validate:"required,min=1,dive"`
+}
+
+// Validator used for validating all OCPP 2.0.1 messages.
+// Any additional custom validations must be added to this object for automatic validation.
+var Validate = ocppj.Validate
+
+func init() {
+	_ = Validate.RegisterValidation("idTokenType201", isValidIdTokenType)
+	_ = Validate.RegisterValidation("genericDeviceModelStatus201", isValidGenericDeviceModelStatus)
+	_ = Validate.RegisterValidation("genericStatus201", isValidGenericStatus)
+	_ = Validate.RegisterValidation("hashAlgorithm201", isValidHashAlgorithmType)
+	_ = Validate.RegisterValidation("certificateStatus201", isValidCertificateStatus)
+	_ = Validate.RegisterValidation("messageFormat201", isValidMessageFormatType)
+	_ = Validate.RegisterValidation("authorizationStatus201", isValidAuthorizationStatus)
+	_ = Validate.RegisterValidation("chargingProfilePurpose201", isValidChargingProfilePurpose)
+	_ = Validate.RegisterValidation("chargingProfileKind201", isValidChargingProfileKind)
+	_ = Validate.RegisterValidation("recurrencyKind201", isValidRecurrencyKind)
+	_ = Validate.RegisterValidation("chargingRateUnit201", isValidChargingRateUnit)
+	_ = Validate.RegisterValidation("chargingLimitSource201", isValidChargingLimitSource)
+	_ = Validate.RegisterValidation("remoteStartStopStatus201", isValidRemoteStartStopStatus)
+	_ = Validate.RegisterValidation("readingContext201", isValidReadingContext)
+	_ = Validate.RegisterValidation("measurand201", isValidMeasurand)
+	_ = Validate.RegisterValidation("phase201", isValidPhase)
+	_ = Validate.RegisterValidation("location201", isValidLocation)
+	_ = Validate.RegisterValidation("certificateSigningUse201", isValidCertificateSigningUse)
+	_ = Validate.RegisterValidation("certificateUse201", isValidCertificateUse)
+	_ = Validate.RegisterValidation("15118EVCertificate201", isValidCertificate15118EVStatus)
+}
@@ -0,0 +1,64 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestHeartbeatRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{provisioning.HeartbeatRequest{}, true},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestHeartbeatResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{provisioning.HeartbeatResponse{CurrentTime: types.NewDateTime(time.Now())}, true},
+		{provisioning.HeartbeatResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestHeartbeatE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	currentTime := types.NewDateTime(time.Now())
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	responseJson := fmt.Sprintf(`[3,"%v",{"currentTime":"%v"}]`, messageId, currentTime.FormatTimestamp())
+	heartbeatResponse := provisioning.NewHeartbeatResponse(currentTime)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSProvisioningHandler{}
+	handler.On("OnHeartbeat", mock.AnythingOfType("string"), mock.Anything).Return(heartbeatResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*provisioning.HeartbeatRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.Heartbeat()
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assertDateTimeEquality(t, currentTime, response.CurrentTime)
+}
+
+func (suite *OcppV2TestSuite) TestHeartbeatInvalidEndpoint() {
+	messageId := defaultMessageId
+	heartbeatRequest := provisioning.NewHeartbeatRequest()
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	testUnsupportedRequestFromCentralSystem(suite, heartbeatRequest, requestJson, messageId)
+}
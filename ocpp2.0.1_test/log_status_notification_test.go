@@ -0,0 +1,72 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestLogStatusNotificationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{diagnostics.LogStatusNotificationRequest{Status: diagnostics.UploadLogStatusUploaded, RequestID: newInt(42)}, true},
+		{diagnostics.LogStatusNotificationRequest{Status: diagnostics.UploadLogStatusIdle}, true},
+		{diagnostics.LogStatusNotificationRequest{}, false},
+		{diagnostics.LogStatusNotificationRequest{Status: "invalidUploadLogStatus"}, false},
+		{diagnostics.LogStatusNotificationRequest{Status: diagnostics.UploadLogStatusUploaded, RequestID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestLogStatusNotificationResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{diagnostics.LogStatusNotificationResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestLogStatusNotificationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	status := diagnostics.UploadLogStatusUploading
+	requestID := 42
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v","requestId":%v}]`, messageId, diagnostics.LogStatusNotificationFeatureName, status, requestID)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	logStatusNotificationResponse := diagnostics.NewLogStatusNotificationResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSDiagnosticsHandler{}
+	handler.On("OnLogStatusNotification", mock.AnythingOfType("string"), mock.Anything).Return(logStatusNotificationResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*diagnostics.LogStatusNotificationRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, status, request.Status)
+		require.NotNil(t, request.RequestID)
+		assert.Equal(t, requestID, *request.RequestID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.LogStatusNotification(status, func(request *diagnostics.LogStatusNotificationRequest) {
+		request.RequestID = &requestID
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestLogStatusNotificationInvalidEndpoint() {
+	messageId := defaultMessageId
+	status := diagnostics.UploadLogStatusUploading
+	request := diagnostics.NewLogStatusNotificationRequest(status)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v"}]`, messageId, diagnostics.LogStatusNotificationFeatureName, status)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,94 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestMeterValuesRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{meter.MeterValuesRequest{EvseID: 1, MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14}}}}}, true},
+		{meter.MeterValuesRequest{MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14}}}}}, true},
+		{meter.MeterValuesRequest{EvseID: 1, MeterValue: []types.MeterValue{}}, false},
+		{meter.MeterValuesRequest{EvseID: 1}, false},
+		{meter.MeterValuesRequest{EvseID: -1, MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14}}}}}, false},
+		{meter.MeterValuesRequest{EvseID: 1, MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{}}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestMeterValuesResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{meter.MeterValuesResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestMeterValuesE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	timestamp := types.NewDateTime(time.Now())
+	signedMeterValue := types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}
+	unitOfMeasure := types.UnitOfMeasure{Unit: types.UnitOfMeasureKWh, Multiplier: newInt(3)}
+	sampledValue := types.SampledValue{Value: 3.14, Context: types.ReadingContextSamplePeriodic, Measurand: types.MeasurandEnergyActiveImportRegister, SignedMeterValue: &signedMeterValue, UnitOfMeasure: &unitOfMeasure}
+	meterValues := []types.MeterValue{{Timestamp: timestamp, SampledValue: []types.SampledValue{sampledValue}}}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"meterValue":[{"timestamp":"%v","sampledValue":[{"value":%v,"context":"%v","measurand":"%v","signedMeterValue":{"signedMeterData":"%v","signingMethod":"%v","encodingMethod":"%v","publicKey":"%v"},"unitOfMeasure":{"unit":"%v","multiplier":%v}}]}]}]`,
+		messageId, meter.MeterValuesFeatureName, evseID, timestamp.FormatTimestamp(), sampledValue.Value, sampledValue.Context, sampledValue.Measurand, signedMeterValue.SignedMeterData, signedMeterValue.SigningMethod, signedMeterValue.EncodingMethod, signedMeterValue.PublicKey, unitOfMeasure.Unit, *unitOfMeasure.Multiplier)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	meterValuesResponse := meter.NewMeterValuesResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSMeterHandler{}
+	handler.On("OnMeterValues", mock.AnythingOfType("string"), mock.Anything).Return(meterValuesResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*meter.MeterValuesRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, evseID, request.EvseID)
+		require.Len(t, request.MeterValue, 1)
+		mv := request.MeterValue[0]
+		assertDateTimeEquality(t, timestamp, mv.Timestamp)
+		require.Len(t, mv.SampledValue, 1)
+		sv := mv.SampledValue[0]
+		assert.Equal(t, sampledValue.Value, sv.Value)
+		assert.Equal(t, sampledValue.Context, sv.Context)
+		assert.Equal(t, sampledValue.Measurand, sv.Measurand)
+		require.NotNil(t, sv.SignedMeterValue)
+		assert.Equal(t, signedMeterValue, *sv.SignedMeterValue)
+		require.NotNil(t, sv.UnitOfMeasure)
+		assert.Equal(t, unitOfMeasure.Unit, sv.UnitOfMeasure.Unit)
+		require.NotNil(t, sv.UnitOfMeasure.Multiplier)
+		assert.Equal(t, *unitOfMeasure.Multiplier, *sv.UnitOfMeasure.Multiplier)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.MeterValues(evseID, meterValues)
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestMeterValuesInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	timestamp := types.NewDateTime(time.Now())
+	sampledValue := types.SampledValue{Value: 3.14}
+	meterValues := []types.MeterValue{{Timestamp: timestamp, SampledValue: []types.SampledValue{sampledValue}}}
+	meterValuesRequest := meter.NewMeterValuesRequest(evseID, meterValues)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"meterValue":[{"timestamp":"%v","sampledValue":[{"value":%v}]}]}]`,
+		messageId, meter.MeterValuesFeatureName, evseID, timestamp.FormatTimestamp(), sampledValue.Value)
+	testUnsupportedRequestFromCentralSystem(suite, meterValuesRequest, requestJson, messageId)
+}
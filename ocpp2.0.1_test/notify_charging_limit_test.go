@@ -0,0 +1,89 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyChargingLimitRequestValidation() {
+	t := suite.T()
+	chargingLimit := smartcharging.ChargingLimit{ChargingLimitSource: types.ChargingLimitSourceEMS, IsGridCritical: newBool(true)}
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 200.0))
+	var requestTable = []GenericTestEntry{
+		{smartcharging.NotifyChargingLimitRequest{EvseID: newInt(1), ChargingLimit: chargingLimit, ChargingSchedule: []types.ChargingSchedule{*schedule}}, true},
+		{smartcharging.NotifyChargingLimitRequest{EvseID: newInt(1), ChargingLimit: chargingLimit, ChargingSchedule: []types.ChargingSchedule{}}, true},
+		{smartcharging.NotifyChargingLimitRequest{EvseID: newInt(1), ChargingLimit: chargingLimit}, true},
+		{smartcharging.NotifyChargingLimitRequest{ChargingLimit: chargingLimit}, true},
+		{smartcharging.NotifyChargingLimitRequest{ChargingLimit: smartcharging.ChargingLimit{ChargingLimitSource: types.ChargingLimitSourceSO}}, true},
+		{smartcharging.NotifyChargingLimitRequest{}, false},
+		{smartcharging.NotifyChargingLimitRequest{ChargingLimit: smartcharging.ChargingLimit{ChargingLimitSource: "invalidChargingLimitSource"}}, false},
+		{smartcharging.NotifyChargingLimitRequest{EvseID: newInt(-1), ChargingLimit: chargingLimit}, false},
+		{smartcharging.NotifyChargingLimitRequest{ChargingLimit: chargingLimit, ChargingSchedule: []types.ChargingSchedule{*types.NewChargingSchedule("invalidChargingRateUnit", types.NewChargingSchedulePeriod(0, 200.0))}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyChargingLimitResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{smartcharging.NotifyChargingLimitResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyChargingLimitE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	chargingLimit := smartcharging.ChargingLimit{ChargingLimitSource: types.ChargingLimitSourceEMS, IsGridCritical: newBool(true)}
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"chargingLimit":{"chargingLimitSource":"%v","isGridCritical":%v},"chargingSchedule":[{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}]}]`,
+		messageId, smartcharging.NotifyChargingLimitFeatureName, evseID, chargingLimit.ChargingLimitSource, *chargingLimit.IsGridCritical, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyChargingLimitResponse := smartcharging.NewNotifyChargingLimitResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSSmartChargingHandler{}
+	handler.On("OnNotifyChargingLimit", mock.AnythingOfType("string"), mock.Anything).Return(notifyChargingLimitResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*smartcharging.NotifyChargingLimitRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, evseID, *request.EvseID)
+		assert.Equal(t, chargingLimit.ChargingLimitSource, request.ChargingLimit.ChargingLimitSource)
+		require.NotNil(t, request.ChargingLimit.IsGridCritical)
+		assert.Equal(t, *chargingLimit.IsGridCritical, *request.ChargingLimit.IsGridCritical)
+		require.Len(t, request.ChargingSchedule, 1)
+		assert.Equal(t, schedule.ChargingRateUnit, request.ChargingSchedule[0].ChargingRateUnit)
+		require.Len(t, request.ChargingSchedule[0].ChargingSchedulePeriod, 1)
+		assert.Equal(t, schedulePeriod, request.ChargingSchedule[0].ChargingSchedulePeriod[0])
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyChargingLimit(chargingLimit, func(request *smartcharging.NotifyChargingLimitRequest) {
+		request.EvseID = &evseID
+		request.ChargingSchedule = []types.ChargingSchedule{*schedule}
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyChargingLimitInvalidEndpoint() {
+	messageId := defaultMessageId
+	chargingLimit := smartcharging.ChargingLimit{ChargingLimitSource: types.ChargingLimitSourceEMS}
+	request := smartcharging.NewNotifyChargingLimitRequest(chargingLimit)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"chargingLimit":{"chargingLimitSource":"%v"}}]`, messageId, smartcharging.NotifyChargingLimitFeatureName, chargingLimit.ChargingLimitSource)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
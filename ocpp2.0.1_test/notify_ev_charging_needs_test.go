@@ -0,0 +1,123 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyEVChargingNeedsRequestValidation() {
+	t := suite.T()
+	chargingNeeds := smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACThreePhase, DepartureTime: types.NewDateTime(time.Now().Add(time.Hour)), ACChargingParameters: &smartcharging.ACChargingParameters{EnergyAmount: 42, EVMinCurrent: 6, EVMaxCurrent: 20, EVMaxVoltage: 400}}
+	var requestTable = []GenericTestEntry{
+		{smartcharging.NotifyEVChargingNeedsRequest{MaxScheduleTuples: newInt(5), EvseID: 1, ChargingNeeds: chargingNeeds}, true},
+		{smartcharging.NotifyEVChargingNeedsRequest{EvseID: 1, ChargingNeeds: chargingNeeds}, true},
+		{smartcharging.NotifyEVChargingNeedsRequest{EvseID: 1, ChargingNeeds: smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC}}, true},
+		{smartcharging.NotifyEVChargingNeedsRequest{ChargingNeeds: chargingNeeds}, false},
+		{smartcharging.NotifyEVChargingNeedsRequest{EvseID: 1}, false},
+		{smartcharging.NotifyEVChargingNeedsRequest{}, false},
+		{smartcharging.NotifyEVChargingNeedsRequest{MaxScheduleTuples: newInt(-1), EvseID: 1, ChargingNeeds: chargingNeeds}, false},
+		{smartcharging.NotifyEVChargingNeedsRequest{EvseID: 1, ChargingNeeds: smartcharging.ChargingNeeds{RequestedEnergyTransfer: "invalidEnergyTransferMode"}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestChargingNeedsValidation() {
+	t := suite.T()
+	acParams := smartcharging.ACChargingParameters{EnergyAmount: 42, EVMinCurrent: 6, EVMaxCurrent: 20, EVMaxVoltage: 400}
+	dcParams := smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, EnergyAmount: newInt(42), EVMaxPower: newInt(150000), StateOfCharge: newInt(50), EVEnergyCapacity: newInt(70000), FullSoC: newInt(90), BulkSoC: newInt(80)}
+	var requestTable = []GenericTestEntry{
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACSinglePhase, DepartureTime: types.NewDateTime(time.Now()), ACChargingParameters: &acParams}, true},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DepartureTime: types.NewDateTime(time.Now()), DCChargingParameters: &dcParams}, true},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800}}, true},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACTwoPhase}, true},
+		{smartcharging.ChargingNeeds{}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: "invalidEnergyTransferMode"}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACThreePhase, ACChargingParameters: &smartcharging.ACChargingParameters{EnergyAmount: -1, EVMinCurrent: 6, EVMaxCurrent: 20, EVMaxVoltage: 400}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACThreePhase, ACChargingParameters: &smartcharging.ACChargingParameters{EnergyAmount: 42, EVMinCurrent: -1, EVMaxCurrent: 20, EVMaxVoltage: 400}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACThreePhase, ACChargingParameters: &smartcharging.ACChargingParameters{EnergyAmount: 42, EVMinCurrent: 6, EVMaxCurrent: -1, EVMaxVoltage: 400}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACThreePhase, ACChargingParameters: &smartcharging.ACChargingParameters{EnergyAmount: 42, EVMinCurrent: 6, EVMaxCurrent: 20, EVMaxVoltage: -1}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: -1, EVMaxVoltage: 800}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: -1}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, EnergyAmount: newInt(-1)}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, EVMaxPower: newInt(-1)}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, StateOfCharge: newInt(101)}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, EVEnergyCapacity: newInt(-1)}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, FullSoC: newInt(101)}}, false},
+		{smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DCChargingParameters: &smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, BulkSoC: newInt(-1)}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingNeedsResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{smartcharging.NotifyEVChargingNeedsResponse{Status: smartcharging.EVChargingNeedsStatusAccepted}, true},
+		{smartcharging.NotifyEVChargingNeedsResponse{Status: smartcharging.EVChargingNeedsStatusRejected}, true},
+		{smartcharging.NotifyEVChargingNeedsResponse{Status: smartcharging.EVChargingNeedsStatusProcessing}, true},
+		{smartcharging.NotifyEVChargingNeedsResponse{Status: "invalidEVChargingNeedsStatus"}, false},
+		{smartcharging.NotifyEVChargingNeedsResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingNeedsE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	maxScheduleTuples := 5
+	evseID := 1
+	dcParams := smartcharging.DCChargingParameters{EVMaxCurrent: 200, EVMaxVoltage: 800, StateOfCharge: newInt(50)}
+	chargingNeeds := smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeDC, DepartureTime: types.NewDateTime(time.Now().Add(time.Hour)), DCChargingParameters: &dcParams}
+	status := smartcharging.EVChargingNeedsStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"maxScheduleTuples":%v,"evseId":%v,"chargingNeeds":{"requestedEnergyTransfer":"%v","departureTime":"%v","dcChargingParameters":{"evMaxCurrent":%v,"evMaxVoltage":%v,"stateOfCharge":%v}}}]`,
+		messageId, smartcharging.NotifyEVChargingNeedsFeatureName, maxScheduleTuples, evseID, chargingNeeds.RequestedEnergyTransfer, chargingNeeds.DepartureTime.FormatTimestamp(), dcParams.EVMaxCurrent, dcParams.EVMaxVoltage, *dcParams.StateOfCharge)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	notifyEVChargingNeedsResponse := smartcharging.NewNotifyEVChargingNeedsResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSSmartChargingHandler{}
+	handler.On("OnNotifyEVChargingNeeds", mock.AnythingOfType("string"), mock.Anything).Return(notifyEVChargingNeedsResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*smartcharging.NotifyEVChargingNeedsRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.MaxScheduleTuples)
+		assert.Equal(t, maxScheduleTuples, *request.MaxScheduleTuples)
+		assert.Equal(t, evseID, request.EvseID)
+		assert.Equal(t, chargingNeeds.RequestedEnergyTransfer, request.ChargingNeeds.RequestedEnergyTransfer)
+		assertDateTimeEquality(t, chargingNeeds.DepartureTime, request.ChargingNeeds.DepartureTime)
+		assert.Nil(t, request.ChargingNeeds.ACChargingParameters)
+		require.NotNil(t, request.ChargingNeeds.DCChargingParameters)
+		assert.Equal(t, dcParams.EVMaxCurrent, request.ChargingNeeds.DCChargingParameters.EVMaxCurrent)
+		assert.Equal(t, dcParams.EVMaxVoltage, request.ChargingNeeds.DCChargingParameters.EVMaxVoltage)
+		require.NotNil(t, request.ChargingNeeds.DCChargingParameters.StateOfCharge)
+		assert.Equal(t, *dcParams.StateOfCharge, *request.ChargingNeeds.DCChargingParameters.StateOfCharge)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyEVChargingNeeds(evseID, chargingNeeds, func(request *smartcharging.NotifyEVChargingNeedsRequest) {
+		request.MaxScheduleTuples = &maxScheduleTuples
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, status, response.Status)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingNeedsInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	chargingNeeds := smartcharging.ChargingNeeds{RequestedEnergyTransfer: smartcharging.EnergyTransferModeACSinglePhase}
+	request := smartcharging.NewNotifyEVChargingNeedsRequest(evseID, chargingNeeds)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"chargingNeeds":{"requestedEnergyTransfer":"%v"}}]`, messageId, smartcharging.NotifyEVChargingNeedsFeatureName, evseID, chargingNeeds.RequestedEnergyTransfer)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,116 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyEventRequestValidation() {
+	t := suite.T()
+	eventData := diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}
+	var requestTable = []GenericTestEntry{
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), Tbc: true, SeqNo: 1, EventData: []diagnostics.EventData{eventData}}, true},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), SeqNo: 1, EventData: []diagnostics.EventData{eventData}}, true},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), EventData: []diagnostics.EventData{eventData}}, true},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), EventData: []diagnostics.EventData{}}, false},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now())}, false},
+		{diagnostics.NotifyEventRequest{EventData: []diagnostics.EventData{eventData}}, false},
+		{diagnostics.NotifyEventRequest{}, false},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), SeqNo: -1, EventData: []diagnostics.EventData{eventData}}, false},
+		{diagnostics.NotifyEventRequest{GeneratedAt: types.NewDateTime(time.Now()), EventData: []diagnostics.EventData{{Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, EventNotificationType: diagnostics.EventHardWiredNotification}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestEventDataValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	var requestTable = []GenericTestEntry{
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, Cause: newInt(42), ActualValue: "someValue", TechCode: "742", TechInfo: "stacktrace", Cleared: true, TransactionID: "1234", VariableMonitoringID: newInt(7), EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, true},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerDelta, ActualValue: "someValue", EventNotificationType: diagnostics.EventCustomMonitor, Component: component, Variable: variable}, true},
+		{diagnostics.EventData{Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerPeriodic, ActualValue: "someValue", EventNotificationType: diagnostics.EventPreconfiguredMonitor, Component: component, Variable: variable}, true},
+		{diagnostics.EventData{EventID: -1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: "invalidEventTrigger", ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: newLongString(2501), EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", TechCode: newLongString(51), EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", TechInfo: newLongString(501), EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", TransactionID: newLongString(37), EventNotificationType: diagnostics.EventHardWiredNotification, Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: "invalidEventNotification", Component: component, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Variable: variable}, false},
+		{diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: component}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEventResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{diagnostics.NotifyEventResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEventE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	generatedAt := types.NewDateTime(time.Now())
+	seqNo := 0
+	tbc := false
+	eventData := diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"generatedAt":"%v","seqNo":%v,"eventData":[{"eventId":%v,"timestamp":"%v","trigger":"%v","actualValue":"%v","eventNotificationType":"%v","component":{"name":"%v"},"variable":{"name":"%v"}}]}]`,
+		messageId, diagnostics.NotifyEventFeatureName, generatedAt.FormatTimestamp(), seqNo, eventData.EventID, eventData.Timestamp.FormatTimestamp(), eventData.Trigger, eventData.ActualValue, eventData.EventNotificationType, eventData.Component.Name, eventData.Variable.Name)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyEventResponse := diagnostics.NewNotifyEventResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSDiagnosticsHandler{}
+	handler.On("OnNotifyEvent", mock.AnythingOfType("string"), mock.Anything).Return(notifyEventResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*diagnostics.NotifyEventRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assertDateTimeEquality(t, generatedAt, request.GeneratedAt)
+		assert.Equal(t, seqNo, request.SeqNo)
+		assert.Equal(t, tbc, request.Tbc)
+		require.Len(t, request.EventData, 1)
+		assert.Equal(t, eventData.EventID, request.EventData[0].EventID)
+		assertDateTimeEquality(t, eventData.Timestamp, request.EventData[0].Timestamp)
+		assert.Equal(t, eventData.Trigger, request.EventData[0].Trigger)
+		assert.Equal(t, eventData.ActualValue, request.EventData[0].ActualValue)
+		assert.Equal(t, eventData.EventNotificationType, request.EventData[0].EventNotificationType)
+		assert.Equal(t, eventData.Component.Name, request.EventData[0].Component.Name)
+		assert.Equal(t, eventData.Variable.Name, request.EventData[0].Variable.Name)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyEvent(generatedAt, seqNo, []diagnostics.EventData{eventData})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEventInvalidEndpoint() {
+	messageId := defaultMessageId
+	generatedAt := types.NewDateTime(time.Now())
+	seqNo := 0
+	eventData := diagnostics.EventData{EventID: 1, Timestamp: types.NewDateTime(time.Now()), Trigger: diagnostics.EventTriggerAlerting, ActualValue: "someValue", EventNotificationType: diagnostics.EventHardWiredNotification, Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}
+	request := diagnostics.NewNotifyEventRequest(generatedAt, seqNo, []diagnostics.EventData{eventData})
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"generatedAt":"%v","seqNo":%v,"eventData":[{"eventId":%v,"timestamp":"%v","trigger":"%v","actualValue":"%v","eventNotificationType":"%v","component":{"name":"%v"},"variable":{"name":"%v"}}]}]`,
+		messageId, diagnostics.NotifyEventFeatureName, generatedAt.FormatTimestamp(), seqNo, eventData.EventID, eventData.Timestamp.FormatTimestamp(), eventData.Trigger, eventData.ActualValue, eventData.EventNotificationType, eventData.Component.Name, eventData.Variable.Name)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,110 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyMonitoringReportRequestValidation() {
+	t := suite.T()
+	monitoringData := diagnostics.MonitoringData{Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}, VariableMonitoring: []diagnostics.VariableMonitoring{{ID: 1, Transaction: true, Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5}}}
+	var requestTable = []GenericTestEntry{
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: 42, Tbc: true, SeqNo: 1, GeneratedAt: types.NewDateTime(time.Now()), Monitor: []diagnostics.MonitoringData{monitoringData}}, true},
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: 42, SeqNo: 1, GeneratedAt: types.NewDateTime(time.Now()), Monitor: []diagnostics.MonitoringData{}}, true},
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: 42, SeqNo: 1, GeneratedAt: types.NewDateTime(time.Now())}, true},
+		{diagnostics.NotifyMonitoringReportRequest{GeneratedAt: types.NewDateTime(time.Now())}, true},
+		{diagnostics.NotifyMonitoringReportRequest{}, false},
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: -1, SeqNo: 1, GeneratedAt: types.NewDateTime(time.Now())}, false},
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: 42, SeqNo: -1, GeneratedAt: types.NewDateTime(time.Now())}, false},
+		{diagnostics.NotifyMonitoringReportRequest{RequestID: 42, SeqNo: 1, GeneratedAt: types.NewDateTime(time.Now()), Monitor: []diagnostics.MonitoringData{{Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestMonitoringDataValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	variableMonitoring := diagnostics.VariableMonitoring{ID: 1, Transaction: true, Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5}
+	var requestTable = []GenericTestEntry{
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{variableMonitoring}}, true},
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{}}, false},
+		{diagnostics.MonitoringData{Component: component, Variable: variable}, false},
+		{diagnostics.MonitoringData{Component: component, VariableMonitoring: []diagnostics.VariableMonitoring{variableMonitoring}}, false},
+		{diagnostics.MonitoringData{Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{variableMonitoring}}, false},
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{{ID: -1, Type: diagnostics.MonitorUpperThreshold}}}, false},
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{{ID: 1, Type: "invalidMonitorType"}}}, false},
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{{ID: 1, Type: diagnostics.MonitorUpperThreshold, Severity: -1}}}, false},
+		{diagnostics.MonitoringData{Component: component, Variable: variable, VariableMonitoring: []diagnostics.VariableMonitoring{{ID: 1, Type: diagnostics.MonitorUpperThreshold, Severity: 10}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyMonitoringReportResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{diagnostics.NotifyMonitoringReportResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyMonitoringReportE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestID := 42
+	tbc := true
+	seqNo := 0
+	generatedAt := types.NewDateTime(time.Now())
+	variableMonitoring := diagnostics.VariableMonitoring{ID: 1, Transaction: true, Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5}
+	monitoringData := diagnostics.MonitoringData{Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}, VariableMonitoring: []diagnostics.VariableMonitoring{variableMonitoring}}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"tbc":%v,"seqNo":%v,"generatedAt":"%v","monitor":[{"component":{"name":"%v"},"variable":{"name":"%v"},"variableMonitoring":[{"id":%v,"transaction":%v,"value":%v,"type":"%v","severity":%v}]}]}]`,
+		messageId, diagnostics.NotifyMonitoringReportFeatureName, requestID, tbc, seqNo, generatedAt.FormatTimestamp(), monitoringData.Component.Name, monitoringData.Variable.Name, variableMonitoring.ID, variableMonitoring.Transaction, variableMonitoring.Value, variableMonitoring.Type, variableMonitoring.Severity)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyMonitoringReportResponse := diagnostics.NewNotifyMonitoringReportResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSDiagnosticsHandler{}
+	handler.On("OnNotifyMonitoringReport", mock.AnythingOfType("string"), mock.Anything).Return(notifyMonitoringReportResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*diagnostics.NotifyMonitoringReportRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.Equal(t, tbc, request.Tbc)
+		assert.Equal(t, seqNo, request.SeqNo)
+		assertDateTimeEquality(t, generatedAt, request.GeneratedAt)
+		require.Len(t, request.Monitor, 1)
+		assert.Equal(t, monitoringData.Component.Name, request.Monitor[0].Component.Name)
+		assert.Equal(t, monitoringData.Variable.Name, request.Monitor[0].Variable.Name)
+		require.Len(t, request.Monitor[0].VariableMonitoring, 1)
+		assert.Equal(t, variableMonitoring, request.Monitor[0].VariableMonitoring[0])
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyMonitoringReport(requestID, seqNo, generatedAt, []diagnostics.MonitoringData{monitoringData}, func(request *diagnostics.NotifyMonitoringReportRequest) {
+		request.Tbc = tbc
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyMonitoringReportInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	seqNo := 0
+	generatedAt := types.NewDateTime(time.Now())
+	request := diagnostics.NewNotifyMonitoringReportRequest(requestID, seqNo, generatedAt, nil)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"seqNo":%v,"generatedAt":"%v"}]`, messageId, diagnostics.NotifyMonitoringReportFeatureName, requestID, seqNo, generatedAt.FormatTimestamp())
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
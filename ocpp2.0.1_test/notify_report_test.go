@@ -0,0 +1,139 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestVariableAttributeValidation() {
+	var testTable = []GenericTestEntry{
+		{provisioning.VariableAttribute{Type: provisioning.AttributeTarget, Value: "dummyValue", Mutability: provisioning.MutabilityReadWrite, Persistent: true, Constant: false}, true},
+		{provisioning.VariableAttribute{Type: provisioning.AttributeTarget, Value: "dummyValue", Mutability: provisioning.MutabilityReadWrite}, true},
+		{provisioning.VariableAttribute{Type: provisioning.AttributeTarget, Value: "dummyValue"}, true},
+		{provisioning.VariableAttribute{Type: provisioning.AttributeTarget}, true},
+		{provisioning.VariableAttribute{}, true},
+		{provisioning.VariableAttribute{Type: "invalidAttribute"}, false},
+		{provisioning.VariableAttribute{Value: newLongString(1001)}, false},
+		{provisioning.VariableAttribute{Mutability: "invalidMutability"}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestVariableCharacteristicsValidation() {
+	var testTable = []GenericTestEntry{
+		{provisioning.VariableCharacteristics{Unit: "KWh", DataType: provisioning.DataTypeDecimal, MinLimit: newFloat(1.0), MaxLimit: newFloat(22.0), ValuesList: "7.0,11.0,22.0", SupportsMonitoring: true}, true},
+		{provisioning.VariableCharacteristics{DataType: provisioning.DataTypeDecimal, SupportsMonitoring: true}, true},
+		{provisioning.VariableCharacteristics{DataType: provisioning.DataTypeString}, true},
+		{provisioning.VariableCharacteristics{}, false},
+		{provisioning.VariableCharacteristics{DataType: "invalidDataType"}, false},
+		{provisioning.VariableCharacteristics{Unit: ">16..............", DataType: provisioning.DataTypeDecimal}, false},
+		{provisioning.VariableCharacteristics{DataType: provisioning.DataTypeOptionList, ValuesList: newLongString(1001)}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyReportRequestValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	variableAttribute := provisioning.VariableAttribute{Type: provisioning.AttributeTarget, Value: "dummyValue", Mutability: provisioning.MutabilityReadWrite}
+	variableCharacteristics := &provisioning.VariableCharacteristics{DataType: provisioning.DataTypeString, SupportsMonitoring: true}
+	reportData := provisioning.ReportData{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}, VariableCharacteristics: variableCharacteristics}
+	var requestTable = []GenericTestEntry{
+		{provisioning.NotifyReportRequest{RequestID: newInt(42), GeneratedAt: types.NewDateTime(time.Now()), Tbc: true, SeqNo: 0, ReportData: []provisioning.ReportData{reportData}}, true},
+		{provisioning.NotifyReportRequest{RequestID: newInt(42), GeneratedAt: types.NewDateTime(time.Now()), SeqNo: 0, ReportData: []provisioning.ReportData{reportData}}, true},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), SeqNo: 0, ReportData: []provisioning.ReportData{{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}}}}, true},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), SeqNo: 0, ReportData: []provisioning.ReportData{}}, true},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now())}, true},
+		{provisioning.NotifyReportRequest{}, false},
+		{provisioning.NotifyReportRequest{RequestID: newInt(-1), GeneratedAt: types.NewDateTime(time.Now())}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), SeqNo: -1}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Component: component, Variable: variable}}}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute, variableAttribute, variableAttribute, variableAttribute, variableAttribute}}}}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{{Type: "invalidAttribute"}}}}}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}, VariableCharacteristics: &provisioning.VariableCharacteristics{}}}}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}}}}, false},
+		{provisioning.NotifyReportRequest{GeneratedAt: types.NewDateTime(time.Now()), ReportData: []provisioning.ReportData{{Component: component, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyReportResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{provisioning.NotifyReportResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyReportE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestID := newInt(42)
+	generatedAt := types.NewDateTime(time.Now())
+	seqNo := 0
+	tbc := false
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	variableAttribute := provisioning.VariableAttribute{Type: provisioning.AttributeTarget, Value: "dummyValue", Mutability: provisioning.MutabilityReadWrite, Persistent: true}
+	variableCharacteristics := provisioning.VariableCharacteristics{DataType: provisioning.DataTypeString, SupportsMonitoring: true}
+	reportData := provisioning.ReportData{Component: component, Variable: variable, VariableAttribute: []provisioning.VariableAttribute{variableAttribute}, VariableCharacteristics: &variableCharacteristics}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"generatedAt":"%v","seqNo":%v,"reportData":[{"component":{"name":"%v","instance":"%v","evse":{"id":%v,"connectorId":%v}},"variable":{"name":"%v","instance":"%v"},"variableAttribute":[{"type":"%v","value":"%v","mutability":"%v","persistent":%v}],"variableCharacteristics":{"dataType":"%v","supportsMonitoring":%v}}]}]`,
+		messageId, provisioning.NotifyReportFeatureName, *requestID, generatedAt.FormatTimestamp(), seqNo, component.Name, component.Instance, component.EVSE.ID, *component.EVSE.ConnectorID, variable.Name, variable.Instance, variableAttribute.Type, variableAttribute.Value, variableAttribute.Mutability, variableAttribute.Persistent, variableCharacteristics.DataType, variableCharacteristics.SupportsMonitoring)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyReportResponse := provisioning.NewNotifyReportResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSProvisioningHandler{}
+	handler.On("OnNotifyReport", mock.AnythingOfType("string"), mock.Anything).Return(notifyReportResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*provisioning.NotifyReportRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.RequestID)
+		assert.Equal(t, *requestID, *request.RequestID)
+		assertDateTimeEquality(t, generatedAt, request.GeneratedAt)
+		assert.Equal(t, seqNo, request.SeqNo)
+		assert.Equal(t, tbc, request.Tbc)
+		require.Len(t, request.ReportData, 1)
+		assert.Equal(t, component.Name, request.ReportData[0].Component.Name)
+		assert.Equal(t, component.Instance, request.ReportData[0].Component.Instance)
+		require.NotNil(t, request.ReportData[0].Component.EVSE)
+		assert.Equal(t, component.EVSE.ID, request.ReportData[0].Component.EVSE.ID)
+		assert.Equal(t, *component.EVSE.ConnectorID, *request.ReportData[0].Component.EVSE.ConnectorID)
+		assert.Equal(t, variable, request.ReportData[0].Variable)
+		require.Len(t, request.ReportData[0].VariableAttribute, 1)
+		assert.Equal(t, variableAttribute, request.ReportData[0].VariableAttribute[0])
+		require.NotNil(t, request.ReportData[0].VariableCharacteristics)
+		assert.Equal(t, variableCharacteristics, *request.ReportData[0].VariableCharacteristics)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyReport(generatedAt, seqNo, func(request *provisioning.NotifyReportRequest) {
+		request.RequestID = requestID
+		request.Tbc = tbc
+		request.ReportData = []provisioning.ReportData{reportData}
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyReportInvalidEndpoint() {
+	messageId := defaultMessageId
+	generatedAt := types.NewDateTime(time.Now())
+	seqNo := 0
+	request := provisioning.NewNotifyReportRequest(generatedAt, seqNo)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"generatedAt":"%v","seqNo":%v}]`, messageId, provisioning.NotifyReportFeatureName, generatedAt.FormatTimestamp(), seqNo)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,77 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestPublishFirmwareStatusNotificationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusPublished, Location: []string{"https://someurl", "ftp://someurl"}, RequestID: newInt(42)}, true},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusPublished, Location: []string{"https://someurl"}}, true},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusDownloading, Location: []string{}}, true},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusDownloading}, true},
+		{firmware.PublishFirmwareStatusNotificationRequest{}, false},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: "invalidPublishFirmwareStatus"}, false},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusPublished, Location: []string{newLongString(513)}}, false},
+		{firmware.PublishFirmwareStatusNotificationRequest{Status: firmware.PublishFirmwareStatusPublished, RequestID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareStatusNotificationResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{firmware.PublishFirmwareStatusNotificationResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareStatusNotificationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	status := firmware.PublishFirmwareStatusPublished
+	location := []string{"https://someurl"}
+	requestID := 42
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v","location":["%v"],"requestId":%v}]`, messageId, firmware.PublishFirmwareStatusNotificationFeatureName, status, location[0], requestID)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	publishFirmwareStatusNotificationResponse := firmware.NewPublishFirmwareStatusNotificationResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSFirmwareHandler{}
+	handler.On("OnPublishFirmwareStatusNotification", mock.AnythingOfType("string"), mock.Anything).Return(publishFirmwareStatusNotificationResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*firmware.PublishFirmwareStatusNotificationRequest)
+		require.True(t, ok)
+		assert.Equal(t, status, request.Status)
+		assert.Equal(t, location, request.Location)
+		require.NotNil(t, request.RequestID)
+		assert.Equal(t, requestID, *request.RequestID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.PublishFirmwareStatusNotification(status, func(request *firmware.PublishFirmwareStatusNotificationRequest) {
+		request.Location = location
+		request.RequestID = &requestID
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareStatusNotificationInvalidEndpoint() {
+	messageId := defaultMessageId
+	status := firmware.PublishFirmwareStatusDownloading
+	request := firmware.NewPublishFirmwareStatusNotificationRequest(status)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"status":"%v"}]`, messageId, firmware.PublishFirmwareStatusNotificationFeatureName, status)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
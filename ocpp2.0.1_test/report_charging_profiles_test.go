@@ -0,0 +1,103 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestReportChargingProfilesRequestValidation() {
+	t := suite.T()
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 200.0))
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeChargingStationMaxProfile, types.ChargingProfileKindAbsolute, schedule)
+	var requestTable = []GenericTestEntry{
+		{smartcharging.ReportChargingProfilesRequest{RequestID: 42, ChargingLimitSource: types.ChargingLimitSourceEMS, Tbc: true, EvseID: 1, ChargingProfile: []types.ChargingProfile{*chargingProfile}}, true},
+		{smartcharging.ReportChargingProfilesRequest{RequestID: 42, ChargingLimitSource: types.ChargingLimitSourceCSO, EvseID: 1, ChargingProfile: []types.ChargingProfile{*chargingProfile}}, true},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: types.ChargingLimitSourceCSO, ChargingProfile: []types.ChargingProfile{*chargingProfile}}, true},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: types.ChargingLimitSourceCSO, ChargingProfile: []types.ChargingProfile{}}, false},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: types.ChargingLimitSourceCSO}, false},
+		{smartcharging.ReportChargingProfilesRequest{ChargingProfile: []types.ChargingProfile{*chargingProfile}}, false},
+		{smartcharging.ReportChargingProfilesRequest{}, false},
+		{smartcharging.ReportChargingProfilesRequest{RequestID: -1, ChargingLimitSource: types.ChargingLimitSourceCSO, ChargingProfile: []types.ChargingProfile{*chargingProfile}}, false},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: "invalidChargingLimitSource", ChargingProfile: []types.ChargingProfile{*chargingProfile}}, false},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: types.ChargingLimitSourceCSO, EvseID: -1, ChargingProfile: []types.ChargingProfile{*chargingProfile}}, false},
+		{smartcharging.ReportChargingProfilesRequest{ChargingLimitSource: types.ChargingLimitSourceCSO, ChargingProfile: []types.ChargingProfile{*types.NewChargingProfile(1, 1, "invalidChargingProfilePurpose", types.ChargingProfileKindAbsolute, schedule)}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestReportChargingProfilesResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{smartcharging.ReportChargingProfilesResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestReportChargingProfilesE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestID := 42
+	chargingLimitSource := types.ChargingLimitSourceEMS
+	tbc := true
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeChargingStationMaxProfile, types.ChargingProfileKindAbsolute, schedule)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"chargingLimitSource":"%v","tbc":%v,"evseId":%v,"chargingProfile":[{"chargingProfileId":%v,"stackLevel":%v,"chargingProfilePurpose":"%v","chargingProfileKind":"%v","chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}]}]`,
+		messageId, smartcharging.ReportChargingProfilesFeatureName, requestID, chargingLimitSource, tbc, evseID, chargingProfile.ChargingProfileId, chargingProfile.StackLevel, chargingProfile.ChargingProfilePurpose, chargingProfile.ChargingProfileKind, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	reportChargingProfilesResponse := smartcharging.NewReportChargingProfilesResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSSmartChargingHandler{}
+	handler.On("OnReportChargingProfiles", mock.AnythingOfType("string"), mock.Anything).Return(reportChargingProfilesResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*smartcharging.ReportChargingProfilesRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.Equal(t, chargingLimitSource, request.ChargingLimitSource)
+		assert.Equal(t, tbc, request.Tbc)
+		assert.Equal(t, evseID, request.EvseID)
+		require.Len(t, request.ChargingProfile, 1)
+		assert.Equal(t, chargingProfile.ChargingProfileId, request.ChargingProfile[0].ChargingProfileId)
+		assert.Equal(t, chargingProfile.StackLevel, request.ChargingProfile[0].StackLevel)
+		assert.Equal(t, chargingProfile.ChargingProfilePurpose, request.ChargingProfile[0].ChargingProfilePurpose)
+		assert.Equal(t, chargingProfile.ChargingProfileKind, request.ChargingProfile[0].ChargingProfileKind)
+		require.NotNil(t, request.ChargingProfile[0].ChargingSchedule)
+		assert.Equal(t, schedule.ChargingRateUnit, request.ChargingProfile[0].ChargingSchedule.ChargingRateUnit)
+		require.Len(t, request.ChargingProfile[0].ChargingSchedule.ChargingSchedulePeriod, 1)
+		assert.Equal(t, schedulePeriod, request.ChargingProfile[0].ChargingSchedule.ChargingSchedulePeriod[0])
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.ReportChargingProfiles(requestID, chargingLimitSource, evseID, []types.ChargingProfile{*chargingProfile}, func(request *smartcharging.ReportChargingProfilesRequest) {
+		request.Tbc = tbc
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestReportChargingProfilesInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	chargingLimitSource := types.ChargingLimitSourceEMS
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeChargingStationMaxProfile, types.ChargingProfileKindAbsolute, schedule)
+	request := smartcharging.NewReportChargingProfilesRequest(requestID, chargingLimitSource, evseID, []types.ChargingProfile{*chargingProfile})
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"chargingLimitSource":"%v","evseId":%v,"chargingProfile":[{"chargingProfileId":%v,"stackLevel":%v,"chargingProfilePurpose":"%v","chargingProfileKind":"%v","chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}]}]`,
+		messageId, smartcharging.ReportChargingProfilesFeatureName, requestID, chargingLimitSource, evseID, chargingProfile.ChargingProfileId, chargingProfile.StackLevel, chargingProfile.ChargingProfilePurpose, chargingProfile.ChargingProfileKind, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,78 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSecurityEventNotificationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.SecurityEventNotificationRequest{Type: "SettingSystemTime", Timestamp: types.NewDateTime(time.Now()), TechInfo: "someTechInfo"}, true},
+		{security.SecurityEventNotificationRequest{Type: "SettingSystemTime", Timestamp: types.NewDateTime(time.Now())}, true},
+		{security.SecurityEventNotificationRequest{Type: "SettingSystemTime"}, false},
+		{security.SecurityEventNotificationRequest{Timestamp: types.NewDateTime(time.Now())}, false},
+		{security.SecurityEventNotificationRequest{}, false},
+		{security.SecurityEventNotificationRequest{Type: newLongString(51), Timestamp: types.NewDateTime(time.Now())}, false},
+		{security.SecurityEventNotificationRequest{Type: "SettingSystemTime", Timestamp: types.NewDateTime(time.Now()), TechInfo: newLongString(256)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSecurityEventNotificationResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{security.SecurityEventNotificationResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSecurityEventNotificationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	typ := "SettingSystemTime"
+	timestamp := types.NewDateTime(time.Now())
+	techInfo := "someTechInfo"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","timestamp":"%v","techInfo":"%v"}]`, messageId, security.SecurityEventNotificationFeatureName, typ, timestamp.FormatTimestamp(), techInfo)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	securityEventNotificationResponse := security.NewSecurityEventNotificationResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSSecurityHandler{}
+	handler.On("OnSecurityEventNotification", mock.AnythingOfType("string"), mock.Anything).Return(securityEventNotificationResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*security.SecurityEventNotificationRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, typ, request.Type)
+		assertDateTimeEquality(t, timestamp, request.Timestamp)
+		assert.Equal(t, techInfo, request.TechInfo)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.SecurityEventNotification(typ, timestamp, func(request *security.SecurityEventNotificationRequest) {
+		request.TechInfo = techInfo
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestSecurityEventNotificationInvalidEndpoint() {
+	messageId := defaultMessageId
+	typ := "SettingSystemTime"
+	timestamp := types.NewDateTime(time.Now())
+	request := security.NewSecurityEventNotificationRequest(typ, timestamp)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","timestamp":"%v"}]`, messageId, security.SecurityEventNotificationFeatureName, typ, timestamp.FormatTimestamp())
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,99 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSendLocalListRequestValidation() {
+	t := suite.T()
+	authData := localauth.AuthorizationData{IdToken: types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}, IdTokenInfo: types.NewIdTokenInfo(types.AuthorizationStatusAccepted)}
+	var requestTable = []GenericTestEntry{
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeDifferential, LocalAuthorizationList: []localauth.AuthorizationData{authData}}, true},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeFull, LocalAuthorizationList: []localauth.AuthorizationData{authData}}, true},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeDifferential, LocalAuthorizationList: []localauth.AuthorizationData{{IdToken: types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}}}}, true},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeFull, LocalAuthorizationList: []localauth.AuthorizationData{}}, true},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeFull}, true},
+		{localauth.SendLocalListRequest{UpdateType: localauth.UpdateTypeFull}, true},
+		{localauth.SendLocalListRequest{VersionNumber: -1, UpdateType: localauth.UpdateTypeFull}, false},
+		{localauth.SendLocalListRequest{VersionNumber: 1}, false},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: "invalidUpdateType"}, false},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeFull, LocalAuthorizationList: []localauth.AuthorizationData{{IdToken: types.IdToken{IdToken: "12345", Type: "invalidIdTokenType"}}}}, false},
+		{localauth.SendLocalListRequest{VersionNumber: 1, UpdateType: localauth.UpdateTypeFull, LocalAuthorizationList: []localauth.AuthorizationData{{IdToken: types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}, IdTokenInfo: types.NewIdTokenInfo("invalidAuthorizationStatus")}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSendLocalListResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{localauth.SendLocalListResponse{Status: localauth.SendLocalListStatusAccepted}, true},
+		{localauth.SendLocalListResponse{Status: localauth.SendLocalListStatusFailed}, true},
+		{localauth.SendLocalListResponse{Status: localauth.SendLocalListStatusVersionMismatch}, true},
+		{localauth.SendLocalListResponse{Status: "invalidSendLocalListStatus"}, false},
+		{localauth.SendLocalListResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSendLocalListE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	versionNumber := 1
+	updateType := localauth.UpdateTypeDifferential
+	authData := localauth.AuthorizationData{IdToken: types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}, IdTokenInfo: types.NewIdTokenInfo(types.AuthorizationStatusAccepted)}
+	status := localauth.SendLocalListStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"versionNumber":%v,"updateType":"%v","localAuthorizationList":[{"idTokenInfo":{"status":"%v"},"idToken":{"idToken":"%v","type":"%v"}}]}]`,
+		messageId, localauth.SendLocalListFeatureName, versionNumber, updateType, authData.IdTokenInfo.Status, authData.IdToken.IdToken, authData.IdToken.Type)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	sendLocalListResponse := localauth.NewSendLocalListResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationLocalAuthHandler{}
+	handler.On("OnSendLocalList", mock.Anything).Return(sendLocalListResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*localauth.SendLocalListRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, versionNumber, request.VersionNumber)
+		assert.Equal(t, updateType, request.UpdateType)
+		require.Len(t, request.LocalAuthorizationList, 1)
+		assert.Equal(t, authData.IdToken.IdToken, request.LocalAuthorizationList[0].IdToken.IdToken)
+		assert.Equal(t, authData.IdToken.Type, request.LocalAuthorizationList[0].IdToken.Type)
+		require.NotNil(t, request.LocalAuthorizationList[0].IdTokenInfo)
+		assert.Equal(t, authData.IdTokenInfo.Status, request.LocalAuthorizationList[0].IdTokenInfo.Status)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SendLocalList(wsId, func(response *localauth.SendLocalListResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, versionNumber, updateType, func(request *localauth.SendLocalListRequest) {
+		request.LocalAuthorizationList = []localauth.AuthorizationData{authData}
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSendLocalListInvalidEndpoint() {
+	messageId := defaultMessageId
+	versionNumber := 1
+	updateType := localauth.UpdateTypeFull
+	request := localauth.NewSendLocalListRequest(versionNumber, updateType)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"versionNumber":%v,"updateType":"%v"}]`, messageId, localauth.SendLocalListFeatureName, versionNumber, updateType)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,102 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSetChargingProfileRequestValidation() {
+	t := suite.T()
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 200.0))
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeTxProfile, types.ChargingProfileKindAbsolute, schedule)
+	var requestTable = []GenericTestEntry{
+		{smartcharging.SetChargingProfileRequest{EvseID: 1, ChargingProfile: chargingProfile}, true},
+		{smartcharging.SetChargingProfileRequest{ChargingProfile: chargingProfile}, true},
+		{smartcharging.SetChargingProfileRequest{EvseID: 1}, false},
+		{smartcharging.SetChargingProfileRequest{}, false},
+		{smartcharging.SetChargingProfileRequest{EvseID: -1, ChargingProfile: chargingProfile}, false},
+		{smartcharging.SetChargingProfileRequest{EvseID: 1, ChargingProfile: types.NewChargingProfile(1, 1, "invalidChargingProfilePurpose", types.ChargingProfileKindAbsolute, schedule)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetChargingProfileResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{smartcharging.SetChargingProfileResponse{Status: smartcharging.ChargingProfileStatusAccepted}, true},
+		{smartcharging.SetChargingProfileResponse{Status: smartcharging.ChargingProfileStatusRejected}, true},
+		{smartcharging.SetChargingProfileResponse{Status: "invalidChargingProfileStatus"}, false},
+		{smartcharging.SetChargingProfileResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetChargingProfileE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeTxProfile, types.ChargingProfileKindAbsolute, schedule)
+	chargingProfile.TransactionId = "1234"
+	status := smartcharging.ChargingProfileStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"chargingProfile":{"chargingProfileId":%v,"transactionId":"%v","stackLevel":%v,"chargingProfilePurpose":"%v","chargingProfileKind":"%v","chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}}]`,
+		messageId, smartcharging.SetChargingProfileFeatureName, evseID, chargingProfile.ChargingProfileId, chargingProfile.TransactionId, chargingProfile.StackLevel, chargingProfile.ChargingProfilePurpose, chargingProfile.ChargingProfileKind, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	setChargingProfileResponse := smartcharging.NewSetChargingProfileResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationSmartChargingHandler{}
+	handler.On("OnSetChargingProfile", mock.Anything).Return(setChargingProfileResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*smartcharging.SetChargingProfileRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, evseID, request.EvseID)
+		require.NotNil(t, request.ChargingProfile)
+		assert.Equal(t, chargingProfile.ChargingProfileId, request.ChargingProfile.ChargingProfileId)
+		assert.Equal(t, chargingProfile.TransactionId, request.ChargingProfile.TransactionId)
+		assert.Equal(t, chargingProfile.StackLevel, request.ChargingProfile.StackLevel)
+		assert.Equal(t, chargingProfile.ChargingProfilePurpose, request.ChargingProfile.ChargingProfilePurpose)
+		assert.Equal(t, chargingProfile.ChargingProfileKind, request.ChargingProfile.ChargingProfileKind)
+		require.NotNil(t, request.ChargingProfile.ChargingSchedule)
+		assert.Equal(t, schedule.ChargingRateUnit, request.ChargingProfile.ChargingSchedule.ChargingRateUnit)
+		require.Len(t, request.ChargingProfile.ChargingSchedule.ChargingSchedulePeriod, 1)
+		assert.Equal(t, schedulePeriod.StartPeriod, request.ChargingProfile.ChargingSchedule.ChargingSchedulePeriod[0].StartPeriod)
+		assert.Equal(t, schedulePeriod.Limit, request.ChargingProfile.ChargingSchedule.ChargingSchedulePeriod[0].Limit)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetChargingProfile(wsId, func(response *smartcharging.SetChargingProfileResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, evseID, chargingProfile)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSetChargingProfileInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	chargingProfile := types.NewChargingProfile(1, 1, types.ChargingProfilePurposeTxProfile, types.ChargingProfileKindAbsolute, schedule)
+	request := smartcharging.NewSetChargingProfileRequest(evseID, chargingProfile)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"chargingProfile":{"chargingProfileId":%v,"stackLevel":%v,"chargingProfilePurpose":"%v","chargingProfileKind":"%v","chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}}]`,
+		messageId, smartcharging.SetChargingProfileFeatureName, evseID, chargingProfile.ChargingProfileId, chargingProfile.StackLevel, chargingProfile.ChargingProfilePurpose, chargingProfile.ChargingProfileKind, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
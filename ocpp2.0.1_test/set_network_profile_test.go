@@ -0,0 +1,150 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNetworkConnectionProfileValidation() {
+	apn := &provisioning.APN{APN: "internet.t-mobile", APNUserName: "tmobile", APNPassword: "tmobile", SimPin: newInt(1234), PreferredNetwork: "26201", UseOnlyPreferredNetwork: true, APNAuthentication: provisioning.APNAuthenticationAUTO}
+	vpn := &provisioning.VPN{Server: "vpn.example.com", User: "user1", Group: "group1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}
+	var testTable = []GenericTestEntry{
+		{provisioning.NetworkConnectionProfile{APN: apn, OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWireless0, VPN: vpn}, true},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, true},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", OCPPInterface: provisioning.OCPPInterfaceWired0}, true},
+		{provisioning.NetworkConnectionProfile{}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: "invalidVersion", OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: "invalidTransport", OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: newLongString(513), MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: -1, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: "invalidInterface"}, false},
+		{provisioning.NetworkConnectionProfile{APN: &provisioning.APN{}, OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}, false},
+		{provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0, VPN: &provisioning.VPN{}}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestAPNValidation() {
+	var testTable = []GenericTestEntry{
+		{provisioning.APN{APN: "internet.t-mobile", APNUserName: "tmobile", APNPassword: "tmobile", SimPin: newInt(1234), PreferredNetwork: "26201", UseOnlyPreferredNetwork: true, APNAuthentication: provisioning.APNAuthenticationAUTO}, true},
+		{provisioning.APN{APN: "internet.t-mobile", APNAuthentication: provisioning.APNAuthenticationNONE}, true},
+		{provisioning.APN{APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+		{provisioning.APN{APN: "internet.t-mobile"}, false},
+		{provisioning.APN{APN: "internet.t-mobile", APNAuthentication: "invalidAuthentication"}, false},
+		{provisioning.APN{APN: newLongString(513), APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+		{provisioning.APN{APN: "internet.t-mobile", APNUserName: ">20..................", APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+		{provisioning.APN{APN: "internet.t-mobile", APNPassword: ">20..................", APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+		{provisioning.APN{APN: "internet.t-mobile", SimPin: newInt(-1), APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+		{provisioning.APN{APN: "internet.t-mobile", PreferredNetwork: ">6.....", APNAuthentication: provisioning.APNAuthenticationNONE}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestVPNValidation() {
+	var testTable = []GenericTestEntry{
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Group: "group1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, true},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, true},
+		{provisioning.VPN{User: "user1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Key: "secretKey"}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Key: "secretKey", Type: "invalidVPNType"}, false},
+		{provisioning.VPN{Server: newLongString(513), User: "user1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: ">20..................", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Group: ">20..................", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: ">20..................", Key: "secretKey", Type: provisioning.VPNTypeIPSec}, false},
+		{provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Key: newLongString(256), Type: provisioning.VPNTypeIPSec}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetNetworkProfileRequestValidation() {
+	t := suite.T()
+	connectionData := provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}
+	var requestTable = []GenericTestEntry{
+		{provisioning.SetNetworkProfileRequest{ConfigurationSlot: 2, ConnectionData: connectionData}, true},
+		{provisioning.SetNetworkProfileRequest{ConnectionData: connectionData}, true},
+		{provisioning.SetNetworkProfileRequest{}, false},
+		{provisioning.SetNetworkProfileRequest{ConfigurationSlot: -1, ConnectionData: connectionData}, false},
+		{provisioning.SetNetworkProfileRequest{ConfigurationSlot: 2, ConnectionData: provisioning.NetworkConnectionProfile{}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetNetworkProfileResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{provisioning.SetNetworkProfileResponse{Status: provisioning.SetNetworkProfileStatusAccepted}, true},
+		{provisioning.SetNetworkProfileResponse{Status: provisioning.SetNetworkProfileStatusRejected}, true},
+		{provisioning.SetNetworkProfileResponse{Status: provisioning.SetNetworkProfileStatusFailed}, true},
+		{provisioning.SetNetworkProfileResponse{}, false},
+		{provisioning.SetNetworkProfileResponse{Status: "invalidStatus"}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetNetworkProfileE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	configurationSlot := 2
+	apn := provisioning.APN{APN: "internet.t-mobile", APNAuthentication: provisioning.APNAuthenticationAUTO}
+	vpn := provisioning.VPN{Server: "vpn.example.com", User: "user1", Password: "password1", Key: "secretKey", Type: provisioning.VPNTypeIPSec}
+	connectionData := provisioning.NetworkConnectionProfile{APN: &apn, OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWireless0, VPN: &vpn}
+	status := provisioning.SetNetworkProfileStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"configurationSlot":%v,"connectionData":{"apn":{"apn":"%v","apnAuthentication":"%v"},"ocppVersion":"%v","ocppTransport":"%v","ocppCsmsUrl":"%v","messageTimeout":%v,"ocppInterface":"%v","vpn":{"server":"%v","user":"%v","password":"%v","key":"%v","type":"%v"}}}]`,
+		messageId, provisioning.SetNetworkProfileFeatureName, configurationSlot, apn.APN, apn.APNAuthentication, connectionData.OCPPVersion, connectionData.OCPPTransport, connectionData.OCPPCsmsURL, connectionData.MessageTimeout, connectionData.OCPPInterface, vpn.Server, vpn.User, vpn.Password, vpn.Key, vpn.Type)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	setNetworkProfileResponse := provisioning.NewSetNetworkProfileResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationProvisioningHandler{}
+	handler.On("OnSetNetworkProfile", mock.Anything).Return(setNetworkProfileResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*provisioning.SetNetworkProfileRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, configurationSlot, request.ConfigurationSlot)
+		require.NotNil(t, request.ConnectionData.APN)
+		assert.Equal(t, apn, *request.ConnectionData.APN)
+		assert.Equal(t, connectionData.OCPPVersion, request.ConnectionData.OCPPVersion)
+		assert.Equal(t, connectionData.OCPPTransport, request.ConnectionData.OCPPTransport)
+		assert.Equal(t, connectionData.OCPPCsmsURL, request.ConnectionData.OCPPCsmsURL)
+		assert.Equal(t, connectionData.MessageTimeout, request.ConnectionData.MessageTimeout)
+		assert.Equal(t, connectionData.OCPPInterface, request.ConnectionData.OCPPInterface)
+		require.NotNil(t, request.ConnectionData.VPN)
+		assert.Equal(t, vpn, *request.ConnectionData.VPN)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetNetworkProfile(wsId, func(response *provisioning.SetNetworkProfileResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, configurationSlot, connectionData)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSetNetworkProfileInvalidEndpoint() {
+	messageId := defaultMessageId
+	configurationSlot := 2
+	connectionData := provisioning.NetworkConnectionProfile{OCPPVersion: provisioning.OCPPVersion20, OCPPTransport: provisioning.OCPPTransportJSON, OCPPCsmsURL: "wss://csms.example.com/ocpp", MessageTimeout: 30, OCPPInterface: provisioning.OCPPInterfaceWired0}
+	request := provisioning.NewSetNetworkProfileRequest(configurationSlot, connectionData)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"configurationSlot":%v,"connectionData":{"ocppVersion":"%v","ocppTransport":"%v","ocppCsmsUrl":"%v","messageTimeout":%v,"ocppInterface":"%v"}}]`,
+		messageId, provisioning.SetNetworkProfileFeatureName, configurationSlot, connectionData.OCPPVersion, connectionData.OCPPTransport, connectionData.OCPPCsmsURL, connectionData.MessageTimeout, connectionData.OCPPInterface)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,110 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSetVariableMonitoringRequestValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	var requestTable = []GenericTestEntry{
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{ID: newInt(2), Transaction: true, Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: component, Variable: variable}}}, true},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: diagnostics.MonitorDelta, Severity: 5, Component: component, Variable: variable}}}, true},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Type: diagnostics.MonitorPeriodic, Component: component, Variable: variable}}}, true},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{}}, false},
+		{diagnostics.SetVariableMonitoringRequest{}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Severity: 5, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: "invalidMonitorType", Severity: 5, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: diagnostics.MonitorDelta, Severity: -1, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: diagnostics.MonitorDelta, Severity: 10, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: diagnostics.MonitorDelta, Severity: 5, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringRequest{MonitoringData: []diagnostics.SetMonitoringData{{Value: 42.0, Type: diagnostics.MonitorDelta, Severity: 5, Component: component}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariableMonitoringResponseValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	var responseTable = []GenericTestEntry{
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{ID: newInt(2), Status: diagnostics.SetMonitoringStatusAccepted, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: component, Variable: variable}}}, true},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: diagnostics.SetMonitoringStatusUnknownVariable, Type: diagnostics.MonitorUpperThreshold, Component: component, Variable: variable}}}, true},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{}}, false},
+		{diagnostics.SetVariableMonitoringResponse{}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: "invalidSetMonitoringStatus", Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: diagnostics.SetMonitoringStatusAccepted, Severity: 5, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: diagnostics.SetMonitoringStatusAccepted, Type: diagnostics.MonitorUpperThreshold, Severity: 10, Component: component, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: diagnostics.SetMonitoringStatusAccepted, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Variable: variable}}}, false},
+		{diagnostics.SetVariableMonitoringResponse{MonitoringResult: []diagnostics.SetMonitoringResult{{Status: diagnostics.SetMonitoringStatusAccepted, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: component}}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariableMonitoringE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	monitoringData := diagnostics.SetMonitoringData{Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}
+	monitoringResult := diagnostics.SetMonitoringResult{ID: newInt(2), Status: diagnostics.SetMonitoringStatusAccepted, Type: monitoringData.Type, Severity: monitoringData.Severity, Component: monitoringData.Component, Variable: monitoringData.Variable}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"setMonitoringData":[{"value":%v,"type":"%v","severity":%v,"component":{"name":"%v"},"variable":{"name":"%v"}}]}]`,
+		messageId, diagnostics.SetVariableMonitoringFeatureName, monitoringData.Value, monitoringData.Type, monitoringData.Severity, monitoringData.Component.Name, monitoringData.Variable.Name)
+	responseJson := fmt.Sprintf(`[3,"%v",{"setMonitoringResult":[{"id":%v,"status":"%v","type":"%v","severity":%v,"component":{"name":"%v"},"variable":{"name":"%v"}}]}]`,
+		messageId, *monitoringResult.ID, monitoringResult.Status, monitoringResult.Type, monitoringResult.Severity, monitoringResult.Component.Name, monitoringResult.Variable.Name)
+	setVariableMonitoringResponse := diagnostics.NewSetVariableMonitoringResponse([]diagnostics.SetMonitoringResult{monitoringResult})
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationDiagnosticsHandler{}
+	handler.On("OnSetVariableMonitoring", mock.Anything).Return(setVariableMonitoringResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*diagnostics.SetVariableMonitoringRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.Len(t, request.MonitoringData, 1)
+		assert.Equal(t, monitoringData.Value, request.MonitoringData[0].Value)
+		assert.Equal(t, monitoringData.Type, request.MonitoringData[0].Type)
+		assert.Equal(t, monitoringData.Severity, request.MonitoringData[0].Severity)
+		assert.Equal(t, monitoringData.Component.Name, request.MonitoringData[0].Component.Name)
+		assert.Equal(t, monitoringData.Variable.Name, request.MonitoringData[0].Variable.Name)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetVariableMonitoring(wsId, func(response *diagnostics.SetVariableMonitoringResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		require.Len(t, response.MonitoringResult, 1)
+		require.NotNil(t, response.MonitoringResult[0].ID)
+		assert.Equal(t, *monitoringResult.ID, *response.MonitoringResult[0].ID)
+		assert.Equal(t, monitoringResult.Status, response.MonitoringResult[0].Status)
+		assert.Equal(t, monitoringResult.Type, response.MonitoringResult[0].Type)
+		assert.Equal(t, monitoringResult.Severity, response.MonitoringResult[0].Severity)
+		assert.Equal(t, monitoringResult.Component.Name, response.MonitoringResult[0].Component.Name)
+		assert.Equal(t, monitoringResult.Variable.Name, response.MonitoringResult[0].Variable.Name)
+		resultChannel <- true
+	}, []diagnostics.SetMonitoringData{monitoringData})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariableMonitoringInvalidEndpoint() {
+	messageId := defaultMessageId
+	monitoringData := diagnostics.SetMonitoringData{Value: 42.0, Type: diagnostics.MonitorUpperThreshold, Severity: 5, Component: types.Component{Name: "component1"}, Variable: types.Variable{Name: "variable1"}}
+	request := diagnostics.NewSetVariableMonitoringRequest([]diagnostics.SetMonitoringData{monitoringData})
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"setMonitoringData":[{"value":%v,"type":"%v","severity":%v,"component":{"name":"%v"},"variable":{"name":"%v"}}]}]`,
+		messageId, diagnostics.SetVariableMonitoringFeatureName, monitoringData.Value, monitoringData.Type, monitoringData.Severity, monitoringData.Component.Name, monitoringData.Variable.Name)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,131 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestTransactionEventRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, SequenceNo: 1, Offline: true, NumberOfPhasesUsed: newInt(3), CableMaxCurrent: newInt(20), ReservationID: newInt(42), TransactionInfo: transactions.Transaction{TransactionID: "1234", ChargingState: transactions.ChargingStateCharging, TimeSpentCharging: newInt(100), StoppedReason: transactions.ReasonLocal, RemoteStartID: newInt(7)}, IDToken: &types.IdToken{IdToken: "1234", Type: types.IdTokenTypeKeyCode}, Evse: &types.EVSE{ID: 1}, MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 64.0}}}}}, true},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, SequenceNo: 1, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, true},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, true},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{}, false},
+		{transactions.TransactionEventRequest{EventType: "invalidTransactionEvent", Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: "invalidTriggerReason", TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, SequenceNo: -1, TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, NumberOfPhasesUsed: newInt(-1), TransactionInfo: transactions.Transaction{TransactionID: "1234"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: ">36.................................."}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234", ChargingState: "invalidChargingState"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234", StoppedReason: "invalidReason"}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}, IDToken: &types.IdToken{Type: types.IdTokenTypeKeyCode}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}, Evse: &types.EVSE{ID: -1}}, false},
+		{transactions.TransactionEventRequest{EventType: transactions.TransactionEventStarted, Timestamp: types.NewDateTime(time.Now()), TriggerReason: transactions.TriggerReasonAuthorized, TransactionInfo: transactions.Transaction{TransactionID: "1234"}, MeterValue: []types.MeterValue{{Timestamp: types.NewDateTime(time.Now())}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestTransactionEventResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{transactions.TransactionEventResponse{TotalCost: newFloat(8.42), ChargingPriority: newInt(2), IDTokenInfo: types.NewIdTokenInfo(types.AuthorizationStatusAccepted), UpdatedPersonalMessage: &types.MessageContent{Format: types.MessageFormatUTF8, Content: "someMessage"}}, true},
+		{transactions.TransactionEventResponse{TotalCost: newFloat(8.42), ChargingPriority: newInt(2), IDTokenInfo: types.NewIdTokenInfo(types.AuthorizationStatusAccepted)}, true},
+		{transactions.TransactionEventResponse{TotalCost: newFloat(8.42), ChargingPriority: newInt(2)}, true},
+		{transactions.TransactionEventResponse{TotalCost: newFloat(8.42)}, true},
+		{transactions.TransactionEventResponse{}, true},
+		{transactions.TransactionEventResponse{TotalCost: newFloat(-1.0)}, false},
+		{transactions.TransactionEventResponse{ChargingPriority: newInt(10)}, false},
+		{transactions.TransactionEventResponse{IDTokenInfo: types.NewIdTokenInfo("invalidAuthorizationStatus")}, false},
+		{transactions.TransactionEventResponse{UpdatedPersonalMessage: &types.MessageContent{Format: "invalidMessageFormat", Content: "someMessage"}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestTransactionEventE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	eventType := transactions.TransactionEventStarted
+	timestamp := types.NewDateTime(time.Now())
+	triggerReason := transactions.TriggerReasonAuthorized
+	seqNo := 1
+	info := transactions.Transaction{TransactionID: "42", ChargingState: transactions.ChargingStateEVConnected}
+	idToken := types.IdToken{IdToken: "1234", Type: types.IdTokenTypeKeyCode}
+	evse := types.EVSE{ID: 1, ConnectorID: newInt(1)}
+	totalCost := 8.42
+	chargingPriority := 2
+	idTokenInfo := types.IdTokenInfo{Status: types.AuthorizationStatusAccepted}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"eventType":"%v","timestamp":"%v","triggerReason":"%v","seqNo":%v,"offline":true,"transactionInfo":{"transactionId":"%v","chargingState":"%v"},"idToken":{"idToken":"%v","type":"%v"},"evse":{"id":%v,"connectorId":%v}}]`,
+		messageId, transactions.TransactionEventFeatureName, eventType, timestamp.FormatTimestamp(), triggerReason, seqNo, info.TransactionID, info.ChargingState, idToken.IdToken, idToken.Type, evse.ID, *evse.ConnectorID)
+	responseJson := fmt.Sprintf(`[3,"%v",{"totalCost":%v,"chargingPriority":%v,"idTokenInfo":{"status":"%v"}}]`, messageId, totalCost, chargingPriority, idTokenInfo.Status)
+	transactionEventResponse := transactions.NewTransactionEventResponse()
+	transactionEventResponse.TotalCost = &totalCost
+	transactionEventResponse.ChargingPriority = &chargingPriority
+	transactionEventResponse.IDTokenInfo = &idTokenInfo
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSTransactionsHandler{}
+	handler.On("OnTransactionEvent", mock.AnythingOfType("string"), mock.Anything).Return(transactionEventResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*transactions.TransactionEventRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, eventType, request.EventType)
+		assertDateTimeEquality(t, timestamp, request.Timestamp)
+		assert.Equal(t, triggerReason, request.TriggerReason)
+		assert.Equal(t, seqNo, request.SequenceNo)
+		assert.True(t, request.Offline)
+		assert.Equal(t, info.TransactionID, request.TransactionInfo.TransactionID)
+		assert.Equal(t, info.ChargingState, request.TransactionInfo.ChargingState)
+		require.NotNil(t, request.IDToken)
+		assert.Equal(t, idToken.IdToken, request.IDToken.IdToken)
+		assert.Equal(t, idToken.Type, request.IDToken.Type)
+		require.NotNil(t, request.Evse)
+		assert.Equal(t, evse.ID, request.Evse.ID)
+		require.NotNil(t, request.Evse.ConnectorID)
+		assert.Equal(t, *evse.ConnectorID, *request.Evse.ConnectorID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.TransactionEvent(eventType, timestamp, triggerReason, seqNo, info, func(request *transactions.TransactionEventRequest) {
+		request.Offline = true
+		request.IDToken = &idToken
+		request.Evse = &evse
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	require.NotNil(t, response.TotalCost)
+	assert.Equal(t, totalCost, *response.TotalCost)
+	require.NotNil(t, response.ChargingPriority)
+	assert.Equal(t, chargingPriority, *response.ChargingPriority)
+	require.NotNil(t, response.IDTokenInfo)
+	assert.Equal(t, idTokenInfo.Status, response.IDTokenInfo.Status)
+}
+
+func (suite *OcppV2TestSuite) TestTransactionEventInvalidEndpoint() {
+	messageId := defaultMessageId
+	eventType := transactions.TransactionEventStarted
+	timestamp := types.NewDateTime(time.Now())
+	triggerReason := transactions.TriggerReasonAuthorized
+	seqNo := 1
+	info := transactions.Transaction{TransactionID: "42"}
+	request := transactions.NewTransactionEventRequest(eventType, timestamp, triggerReason, seqNo, info)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"eventType":"%v","timestamp":"%v","triggerReason":"%v","seqNo":%v,"transactionInfo":{"transactionId":"%v"}}]`,
+		messageId, transactions.TransactionEventFeatureName, eventType, timestamp.FormatTimestamp(), triggerReason, seqNo, info.TransactionID)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,124 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestUpdateFirmwareRequestValidation() {
+	t := suite.T()
+	fw := firmware.Firmware{
+		Location:           "https://someurl",
+		RetrieveDateTime:   types.NewDateTime(time.Now()),
+		InstallDateTime:    types.NewDateTime(time.Now()),
+		SigningCertificate: "1337c0de",
+		Signature:          "deadc0de",
+	}
+	var requestTable = []GenericTestEntry{
+		{firmware.UpdateFirmwareRequest{Retries: newInt(5), RetryInterval: newInt(300), RequestID: 42, Firmware: fw}, true},
+		{firmware.UpdateFirmwareRequest{Retries: newInt(5), RequestID: 42, Firmware: fw}, true},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: fw}, true},
+		{firmware.UpdateFirmwareRequest{Firmware: fw}, true},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl", RetrieveDateTime: types.NewDateTime(time.Now())}}, true},
+		{firmware.UpdateFirmwareRequest{RequestID: 42}, false},
+		{firmware.UpdateFirmwareRequest{}, false},
+		{firmware.UpdateFirmwareRequest{Retries: newInt(-1), RequestID: 42, Firmware: fw}, false},
+		{firmware.UpdateFirmwareRequest{RetryInterval: newInt(-1), RequestID: 42, Firmware: fw}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: -1, Firmware: fw}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{RetrieveDateTime: types.NewDateTime(time.Now())}}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl"}}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: newLongString(513), RetrieveDateTime: types.NewDateTime(time.Now())}}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl", RetrieveDateTime: types.NewDateTime(time.Now()), SigningCertificate: newLongString(5501)}}, false},
+		{firmware.UpdateFirmwareRequest{RequestID: 42, Firmware: firmware.Firmware{Location: "https://someurl", RetrieveDateTime: types.NewDateTime(time.Now()), Signature: newLongString(801)}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestUpdateFirmwareResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{firmware.UpdateFirmwareResponse{Status: firmware.UpdateFirmwareStatusAccepted}, true},
+		{firmware.UpdateFirmwareResponse{Status: firmware.UpdateFirmwareStatusInvalidCertificate}, true},
+		{firmware.UpdateFirmwareResponse{Status: "invalidUpdateFirmwareStatus"}, false},
+		{firmware.UpdateFirmwareResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestUpdateFirmwareE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	retries := 5
+	retryInterval := 300
+	requestID := 42
+	fw := firmware.Firmware{
+		Location:           "https://someurl",
+		RetrieveDateTime:   types.NewDateTime(time.Now()),
+		InstallDateTime:    types.NewDateTime(time.Now().Add(time.Hour)),
+		SigningCertificate: "1337c0de",
+		Signature:          "deadc0de",
+	}
+	status := firmware.UpdateFirmwareStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"retries":%v,"retryInterval":%v,"requestId":%v,"firmware":{"location":"%v","retrieveDateTime":"%v","installDateTime":"%v","signingCertificate":"%v","signature":"%v"}}]`,
+		messageId, firmware.UpdateFirmwareFeatureName, retries, retryInterval, requestID, fw.Location, fw.RetrieveDateTime.FormatTimestamp(), fw.InstallDateTime.FormatTimestamp(), fw.SigningCertificate, fw.Signature)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	updateFirmwareResponse := firmware.NewUpdateFirmwareResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationFirmwareHandler{}
+	handler.On("OnUpdateFirmware", mock.Anything).Return(updateFirmwareResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*firmware.UpdateFirmwareRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.Retries)
+		assert.Equal(t, retries, *request.Retries)
+		require.NotNil(t, request.RetryInterval)
+		assert.Equal(t, retryInterval, *request.RetryInterval)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.Equal(t, fw.Location, request.Firmware.Location)
+		assertDateTimeEquality(t, fw.RetrieveDateTime, request.Firmware.RetrieveDateTime)
+		assertDateTimeEquality(t, fw.InstallDateTime, request.Firmware.InstallDateTime)
+		assert.Equal(t, fw.SigningCertificate, request.Firmware.SigningCertificate)
+		assert.Equal(t, fw.Signature, request.Firmware.Signature)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.UpdateFirmware(wsId, func(response *firmware.UpdateFirmwareResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, requestID, fw, func(request *firmware.UpdateFirmwareRequest) {
+		request.Retries = &retries
+		request.RetryInterval = &retryInterval
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestUpdateFirmwareInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	fw := firmware.Firmware{
+		Location:         "https://someurl",
+		RetrieveDateTime: types.NewDateTime(time.Now()),
+	}
+	request := firmware.NewUpdateFirmwareRequest(requestID, fw)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"firmware":{"location":"%v","retrieveDateTime":"%v"}}]`,
+		messageId, firmware.UpdateFirmwareFeatureName, requestID, fw.Location, fw.RetrieveDateTime.FormatTimestamp())
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
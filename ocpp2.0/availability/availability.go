@@ -6,6 +6,8 @@ import "github.com/lorenzodonini/ocpp-go/ocpp"
 
 // Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0 Availability profile.
 type CSMSHandler interface {
+	// OnStatusNotification is called on the CSMS whenever a StatusNotificationRequest is received from a charging station.
+	OnStatusNotification(chargingStationID string, request *StatusNotificationRequest) (confirmation *StatusNotificationResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Availability profile.
@@ -19,4 +21,5 @@ const ProfileName = "availability"
 var Profile = ocpp.NewProfile(
 	ProfileName,
 	ChangeAvailabilityFeature{},
+	StatusNotificationFeature{},
 )
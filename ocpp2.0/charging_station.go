@@ -1,6 +1,7 @@
 package ocpp2
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lorenzodonini/ocpp-go/internal/callbackqueue"
@@ -43,13 +44,19 @@ type chargingStation struct {
 	diagnosticsHandler   diagnostics.ChargingStationHandler
 	displayHandler       display.ChargingStationHandler
 	dataHandler          data.ChargingStationHandler
-	responseHandler      chan ocpp.Response
-	errorHandler         chan error
+	responseHandler      chan receivedResponse
+	errorHandler         chan *ocpp.Error
 	callbacks            callbackqueue.CallbackQueue
 	stopC                chan struct{}
 	errC                 chan error // external error channel
 }
 
+// A response received from the server, together with the ID of the request it refers to.
+type receivedResponse struct {
+	requestId string
+	response  ocpp.Response
+}
+
 func (cs *chargingStation) error(err error) {
 	if cs.errC != nil {
 		cs.errC <- err
@@ -162,6 +169,240 @@ func (cs *chargingStation) GetCertificateStatus(ocspRequestData types.OCSPReques
 	}
 }
 
+func (cs *chargingStation) Heartbeat(props ...func(request *provisioning.HeartbeatRequest)) (*provisioning.HeartbeatResponse, error) {
+	request := provisioning.NewHeartbeatRequest()
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*provisioning.HeartbeatResponse), err
+	}
+}
+
+func (cs *chargingStation) LogStatusNotification(status diagnostics.UploadLogStatus, props ...func(request *diagnostics.LogStatusNotificationRequest)) (*diagnostics.LogStatusNotificationResponse, error) {
+	request := diagnostics.NewLogStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.LogStatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) MeterValues(evseID int, meterValues []types.MeterValue, props ...func(request *meter.MeterValuesRequest)) (*meter.MeterValuesResponse, error) {
+	request := meter.NewMeterValuesRequest(evseID, meterValues)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*meter.MeterValuesResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyChargingLimit(chargingLimit smartcharging.ChargingLimit, props ...func(request *smartcharging.NotifyChargingLimitRequest)) (*smartcharging.NotifyChargingLimitResponse, error) {
+	request := smartcharging.NewNotifyChargingLimitRequest(chargingLimit)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyChargingLimitResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyCustomerInformation(data string, seqNo int, generatedAt *types.DateTime, requestID int, props ...func(request *diagnostics.NotifyCustomerInformationRequest)) (*diagnostics.NotifyCustomerInformationResponse, error) {
+	request := diagnostics.NewNotifyCustomerInformationRequest(data, seqNo, generatedAt, requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyCustomerInformationResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyDisplayMessages(requestID int, props ...func(request *display.NotifyDisplayMessagesRequest)) (*display.NotifyDisplayMessagesResponse, error) {
+	request := display.NewNotifyDisplayMessagesRequest(requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*display.NotifyDisplayMessagesResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEVChargingNeeds(evseID int, chargingNeeds smartcharging.ChargingNeeds, props ...func(request *smartcharging.NotifyEVChargingNeedsRequest)) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	request := smartcharging.NewNotifyEVChargingNeedsRequest(evseID, chargingNeeds)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyEVChargingNeedsResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEVChargingSchedule(timeBase *types.DateTime, evseID int, schedule types.ChargingSchedule, props ...func(request *smartcharging.NotifyEVChargingScheduleRequest)) (*smartcharging.NotifyEVChargingScheduleResponse, error) {
+	request := smartcharging.NewNotifyEVChargingScheduleRequest(timeBase, evseID, schedule)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyEVChargingScheduleResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEvent(generatedAt *types.DateTime, seqNo int, eventData []diagnostics.EventData, props ...func(request *diagnostics.NotifyEventRequest)) (*diagnostics.NotifyEventResponse, error) {
+	request := diagnostics.NewNotifyEventRequest(generatedAt, seqNo, eventData)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyEventResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyMonitoringReport(requestID int, seqNo int, generatedAt *types.DateTime, monitorData []diagnostics.MonitoringData, props ...func(request *diagnostics.NotifyMonitoringReportRequest)) (*diagnostics.NotifyMonitoringReportResponse, error) {
+	request := diagnostics.NewNotifyMonitoringReportRequest(requestID, seqNo, generatedAt, monitorData)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyMonitoringReportResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyReport(generatedAt *types.DateTime, seqNo int, props ...func(request *provisioning.NotifyReportRequest)) (*provisioning.NotifyReportResponse, error) {
+	request := provisioning.NewNotifyReportRequest(generatedAt, seqNo)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*provisioning.NotifyReportResponse), err
+	}
+}
+
+func (cs *chargingStation) PublishFirmwareStatusNotification(status firmware.PublishFirmwareStatus, props ...func(request *firmware.PublishFirmwareStatusNotificationRequest)) (*firmware.PublishFirmwareStatusNotificationResponse, error) {
+	request := firmware.NewPublishFirmwareStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*firmware.PublishFirmwareStatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) ReportChargingProfiles(requestID int, chargingLimitSource types.ChargingLimitSourceType, evseID int, chargingProfile []types.ChargingProfile, props ...func(request *smartcharging.ReportChargingProfilesRequest)) (*smartcharging.ReportChargingProfilesResponse, error) {
+	request := smartcharging.NewReportChargingProfilesRequest(requestID, chargingLimitSource, evseID, chargingProfile)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.ReportChargingProfilesResponse), err
+	}
+}
+
+func (cs *chargingStation) ReservationStatusUpdate(reservationID int, status reservation.ReservationUpdateStatus, props ...func(request *reservation.ReservationStatusUpdateRequest)) (*reservation.ReservationStatusUpdateResponse, error) {
+	request := reservation.NewReservationStatusUpdateRequest(reservationID, status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*reservation.ReservationStatusUpdateResponse), err
+	}
+}
+
+func (cs *chargingStation) SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationResponse, error) {
+	request := security.NewSecurityEventNotificationRequest(typ, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*security.SecurityEventNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateResponse, error) {
+	request := security.NewSignCertificateRequest(csr)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*security.SignCertificateResponse), err
+	}
+}
+
+func (cs *chargingStation) StatusNotification(timestamp *types.DateTime, status availability.ConnectorStatus, evseID int, connectorID int, props ...func(request *availability.StatusNotificationRequest)) (*availability.StatusNotificationResponse, error) {
+	request := availability.NewStatusNotificationRequest(timestamp, status, evseID, connectorID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*availability.StatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) TransactionEvent(t transactions.TransactionEvent, timestamp *types.DateTime, reason transactions.TriggerReason, seqNo int, info transactions.Transaction, props ...func(request *transactions.TransactionEventRequest)) (*transactions.TransactionEventResponse, error) {
+	request := transactions.NewTransactionEventRequest(t, timestamp, reason, seqNo, info)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*transactions.TransactionEventResponse), err
+	}
+}
+
 func (cs *chargingStation) SetSecurityHandler(handler security.ChargingStationHandler) {
 	cs.securityHandler = handler
 }
@@ -227,6 +468,13 @@ func (cs *chargingStation) SetDataHandler(handler data.ChargingStationHandler) {
 }
 
 func (cs *chargingStation) SendRequest(request ocpp.Request) (ocpp.Response, error) {
+	return cs.SendRequestWithContext(context.Background(), request)
+}
+
+func (cs *chargingStation) SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	featureName := request.GetFeatureName()
 	if _, found := cs.client.GetProfileForFeature(featureName); !found {
 		return nil, fmt.Errorf("feature %v is unsupported on charging station (missing profile), cannot send request", featureName)
@@ -239,8 +487,8 @@ func (cs *chargingStation) SendRequest(request ocpp.Request) (ocpp.Response, err
 	}
 	// Create channel and pass it to a callback function, for retrieving asynchronous response
 	asyncResponseC := make(chan asyncResponse, 1)
-	send := func() error {
-		return cs.client.SendRequest(request)
+	send := func() (string, error) {
+		return cs.client.SendRequestWithContext(ctx, request)
 	}
 	err := cs.callbacks.TryQueue("main", send, func(confirmation ocpp.Response, err error) {
 		asyncResponseC <- asyncResponse{r: confirmation, e: err}
@@ -248,11 +496,15 @@ func (cs *chargingStation) SendRequest(request ocpp.Request) (ocpp.Response, err
 	if err != nil {
 		return nil, err
 	}
-	asyncResult, ok := <-asyncResponseC
-	if !ok {
-		return nil, fmt.Errorf("internal error while receiving result for %v request", request.GetFeatureName())
+	select {
+	case asyncResult, ok := <-asyncResponseC:
+		if !ok {
+			return nil, fmt.Errorf("internal error while receiving result for %v request", request.GetFeatureName())
+		}
+		return asyncResult.r, asyncResult.e
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return asyncResult.r, asyncResult.e
 }
 
 func (cs *chargingStation) SendRequestAsync(request ocpp.Request, callback func(response ocpp.Response, err error)) error {
@@ -261,14 +513,14 @@ func (cs *chargingStation) SendRequestAsync(request ocpp.Request, callback func(
 		return fmt.Errorf("feature %v is unsupported on charging station (missing profile), cannot send request", featureName)
 	}
 	switch featureName {
-	case authorization.AuthorizeFeatureName, provisioning.BootNotificationFeatureName, smartcharging.ClearedChargingLimitFeatureName, data.DataTransferFeatureName, firmware.FirmwareStatusNotificationFeatureName, iso15118.Get15118EVCertificateFeatureName, iso15118.GetCertificateStatusFeatureName:
+	case authorization.AuthorizeFeatureName, provisioning.BootNotificationFeatureName, smartcharging.ClearedChargingLimitFeatureName, data.DataTransferFeatureName, firmware.FirmwareStatusNotificationFeatureName, iso15118.Get15118EVCertificateFeatureName, iso15118.GetCertificateStatusFeatureName, transactions.TransactionEventFeatureName, meter.MeterValuesFeatureName, provisioning.HeartbeatFeatureName, provisioning.NotifyReportFeatureName, availability.StatusNotificationFeatureName, reservation.ReservationStatusUpdateFeatureName, security.SecurityEventNotificationFeatureName, security.SignCertificateFeatureName, diagnostics.LogStatusNotificationFeatureName, diagnostics.NotifyCustomerInformationFeatureName, diagnostics.NotifyEventFeatureName, diagnostics.NotifyMonitoringReportFeatureName, smartcharging.NotifyChargingLimitFeatureName, smartcharging.NotifyEVChargingNeedsFeatureName, smartcharging.NotifyEVChargingScheduleFeatureName, smartcharging.ReportChargingProfilesFeatureName, firmware.PublishFirmwareStatusNotificationFeatureName, display.NotifyDisplayMessagesFeatureName:
 		break
 	default:
 		return fmt.Errorf("unsupported action %v on charging station, cannot send request", featureName)
 	}
 	// Response will be retrieved asynchronously via asyncHandler
-	send := func() error {
-		return cs.client.SendRequest(request)
+	send := func() (string, error) {
+		return cs.client.SendRequestWithContext(context.Background(), request)
 	}
 	err := cs.callbacks.TryQueue("main", send, callback)
 	return err
@@ -277,16 +529,16 @@ func (cs *chargingStation) SendRequestAsync(request ocpp.Request, callback func(
 func (cs *chargingStation) asyncCallbackHandler() {
 	for {
 		select {
-		case confirmation := <-cs.responseHandler:
+		case received := <-cs.responseHandler:
 			// Get and invoke callback
-			if callback, ok := cs.callbacks.Dequeue("main"); ok {
-				callback(confirmation, nil)
+			if callback, ok := cs.callbacks.DequeueRequest("main", received.requestId); ok {
+				callback(received.response, nil)
 			} else {
-				cs.error(fmt.Errorf("no callback available for incoming response %v", confirmation.GetFeatureName()))
+				cs.error(fmt.Errorf("no callback available for incoming response %v", received.response.GetFeatureName()))
 			}
 		case protoError := <-cs.errorHandler:
 			// Get and invoke callback
-			if callback, ok := cs.callbacks.Dequeue("main"); ok {
+			if callback, ok := cs.callbacks.DequeueRequest("main", protoError.MessageId); ok {
 				callback(nil, protoError)
 			} else {
 				cs.error(fmt.Errorf("no callback available for incoming error %w", protoError))
@@ -300,9 +552,14 @@ func (cs *chargingStation) asyncCallbackHandler() {
 func (cs *chargingStation) sendResponse(response ocpp.Response, err error, requestId string) {
 	// send error response
 	if err != nil {
-		err = cs.client.SendError(requestId, ocppj.ProtocolError, err.Error(), nil)
+		// OCPP errors returned by a handler are forwarded with their own error code
+		errorCode, description := ocppj.ProtocolError, err.Error()
+		if ocppErr, ok := err.(*ocpp.Error); ok {
+			errorCode, description = ocppErr.Code, ocppErr.Description
+		}
+		err = cs.client.SendError(requestId, errorCode, description, nil)
 		if err != nil {
-			cs.error(fmt.Errorf("replying cs to request %s with 'protocol error': %w", requestId, err))
+			cs.error(fmt.Errorf("replying cs to request %s with '%v': %w", requestId, errorCode, err))
 		}
 		return
 	}
@@ -470,6 +727,48 @@ func (cs *chargingStation) handleIncomingRequest(request ocpp.Request, requestId
 		response, err = cs.diagnosticsHandler.OnGetLog(request.(*diagnostics.GetLogRequest))
 	case diagnostics.GetMonitoringReportFeatureName:
 		response, err = cs.diagnosticsHandler.OnGetMonitoringReport(request.(*diagnostics.GetMonitoringReportRequest))
+	case provisioning.GetReportFeatureName:
+		response, err = cs.provisioningHandler.OnGetReport(request.(*provisioning.GetReportRequest))
+	case transactions.GetTransactionStatusFeatureName:
+		response, err = cs.transactionsHandler.OnGetTransactionStatus(request.(*transactions.GetTransactionStatusRequest))
+	case provisioning.GetVariablesFeatureName:
+		response, err = cs.provisioningHandler.OnGetVariables(request.(*provisioning.GetVariablesRequest))
+	case iso15118.InstallCertificateFeatureName:
+		response, err = cs.iso15118Handler.OnInstallCertificate(request.(*iso15118.InstallCertificateRequest))
+	case firmware.PublishFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnPublishFirmware(request.(*firmware.PublishFirmwareRequest))
+	case remotecontrol.RequestStartTransactionFeatureName:
+		response, err = cs.remoteControlHandler.OnRequestStartTransaction(request.(*remotecontrol.RequestStartTransactionRequest))
+	case remotecontrol.RequestStopTransactionFeatureName:
+		response, err = cs.remoteControlHandler.OnRequestStopTransaction(request.(*remotecontrol.RequestStopTransactionRequest))
+	case reservation.ReserveNowFeatureName:
+		response, err = cs.reservationHandler.OnReserveNow(request.(*reservation.ReserveNowRequest))
+	case provisioning.ResetFeatureName:
+		response, err = cs.provisioningHandler.OnReset(request.(*provisioning.ResetRequest))
+	case localauth.SendLocalListFeatureName:
+		response, err = cs.localAuthListHandler.OnSendLocalList(request.(*localauth.SendLocalListRequest))
+	case smartcharging.SetChargingProfileFeatureName:
+		response, err = cs.smartChargingHandler.OnSetChargingProfile(request.(*smartcharging.SetChargingProfileRequest))
+	case display.SetDisplayMessageFeatureName:
+		response, err = cs.displayHandler.OnSetDisplayMessage(request.(*display.SetDisplayMessageRequest))
+	case diagnostics.SetMonitoringBaseFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetMonitoringBase(request.(*diagnostics.SetMonitoringBaseRequest))
+	case diagnostics.SetMonitoringLevelFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetMonitoringLevel(request.(*diagnostics.SetMonitoringLevelRequest))
+	case provisioning.SetNetworkProfileFeatureName:
+		response, err = cs.provisioningHandler.OnSetNetworkProfile(request.(*provisioning.SetNetworkProfileRequest))
+	case diagnostics.SetVariableMonitoringFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetVariableMonitoring(request.(*diagnostics.SetVariableMonitoringRequest))
+	case provisioning.SetVariablesFeatureName:
+		response, err = cs.provisioningHandler.OnSetVariables(request.(*provisioning.SetVariablesRequest))
+	case remotecontrol.TriggerMessageFeatureName:
+		response, err = cs.remoteControlHandler.OnTriggerMessage(request.(*remotecontrol.TriggerMessageRequest))
+	case remotecontrol.UnlockConnectorFeatureName:
+		response, err = cs.remoteControlHandler.OnUnlockConnector(request.(*remotecontrol.UnlockConnectorRequest))
+	case firmware.UnpublishFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnUnpublishFirmware(request.(*firmware.UnpublishFirmwareRequest))
+	case firmware.UpdateFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnUpdateFirmware(request.(*firmware.UpdateFirmwareRequest))
 	default:
 		cs.notSupportedError(requestId, action)
 		return
@@ -1,7 +1,9 @@
 package ocpp2
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/lorenzodonini/ocpp-go/internal/callbackqueue"
 	"github.com/lorenzodonini/ocpp-go/ocpp"
@@ -356,6 +358,321 @@ func (cs *csms) GetMonitoringReport(clientId string, callback func(*diagnostics.
 	return cs.SendRequestAsync(clientId, request, genericCallback)
 }
 
+func (cs *csms) GetReport(clientId string, callback func(*provisioning.GetReportResponse, error), props ...func(*provisioning.GetReportRequest)) error {
+	request := provisioning.NewGetReportRequest()
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*provisioning.GetReportResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) GetTransactionStatus(clientId string, callback func(*transactions.GetTransactionStatusResponse, error), props ...func(*transactions.GetTransactionStatusRequest)) error {
+	request := transactions.NewGetTransactionStatusRequest()
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*transactions.GetTransactionStatusResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) GetVariables(clientId string, callback func(*provisioning.GetVariablesResponse, error), variableData []provisioning.GetVariableData, props ...func(*provisioning.GetVariablesRequest)) error {
+	request := provisioning.NewGetVariablesRequest(variableData)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*provisioning.GetVariablesResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) InstallCertificate(clientId string, callback func(*iso15118.InstallCertificateResponse, error), certificateType types.CertificateUse, certificate string, props ...func(*iso15118.InstallCertificateRequest)) error {
+	request := iso15118.NewInstallCertificateRequest(certificateType, certificate)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*iso15118.InstallCertificateResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) PublishFirmware(clientId string, callback func(*firmware.PublishFirmwareResponse, error), location string, checksum string, requestID int, props ...func(*firmware.PublishFirmwareRequest)) error {
+	request := firmware.NewPublishFirmwareRequest(location, checksum, requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*firmware.PublishFirmwareResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) RequestStartTransaction(clientId string, callback func(*remotecontrol.RequestStartTransactionResponse, error), remoteStartID int, idToken types.IdToken, props ...func(*remotecontrol.RequestStartTransactionRequest)) error {
+	request := remotecontrol.NewRequestStartTransactionRequest(remoteStartID, idToken)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*remotecontrol.RequestStartTransactionResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) RequestStopTransaction(clientId string, callback func(*remotecontrol.RequestStopTransactionResponse, error), transactionID string, props ...func(*remotecontrol.RequestStopTransactionRequest)) error {
+	request := remotecontrol.NewRequestStopTransactionRequest(transactionID)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*remotecontrol.RequestStopTransactionResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) ReserveNow(clientId string, callback func(*reservation.ReserveNowResponse, error), id int, expiryDateTime *types.DateTime, idToken types.IdToken, props ...func(*reservation.ReserveNowRequest)) error {
+	request := reservation.NewReserveNowRequest(id, expiryDateTime, idToken)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*reservation.ReserveNowResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) Reset(clientId string, callback func(*provisioning.ResetResponse, error), t provisioning.ResetType, props ...func(*provisioning.ResetRequest)) error {
+	request := provisioning.NewResetRequest(t)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*provisioning.ResetResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SendLocalList(clientId string, callback func(*localauth.SendLocalListResponse, error), versionNumber int, updateType localauth.UpdateType, props ...func(*localauth.SendLocalListRequest)) error {
+	request := localauth.NewSendLocalListRequest(versionNumber, updateType)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*localauth.SendLocalListResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetChargingProfile(clientId string, callback func(*smartcharging.SetChargingProfileResponse, error), evseID int, chargingProfile *types.ChargingProfile, props ...func(*smartcharging.SetChargingProfileRequest)) error {
+	request := smartcharging.NewSetChargingProfileRequest(evseID, chargingProfile)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*smartcharging.SetChargingProfileResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetDisplayMessage(clientId string, callback func(*display.SetDisplayMessageResponse, error), message display.MessageInfo, props ...func(*display.SetDisplayMessageRequest)) error {
+	request := display.NewSetDisplayMessageRequest(message)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*display.SetDisplayMessageResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetMonitoringBase(clientId string, callback func(*diagnostics.SetMonitoringBaseResponse, error), monitoringBase diagnostics.MonitoringBase, props ...func(*diagnostics.SetMonitoringBaseRequest)) error {
+	request := diagnostics.NewSetMonitoringBaseRequest(monitoringBase)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*diagnostics.SetMonitoringBaseResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetMonitoringLevel(clientId string, callback func(*diagnostics.SetMonitoringLevelResponse, error), severity int, props ...func(*diagnostics.SetMonitoringLevelRequest)) error {
+	request := diagnostics.NewSetMonitoringLevelRequest(severity)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*diagnostics.SetMonitoringLevelResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetNetworkProfile(clientId string, callback func(*provisioning.SetNetworkProfileResponse, error), configurationSlot int, connectionData provisioning.NetworkConnectionProfile, props ...func(*provisioning.SetNetworkProfileRequest)) error {
+	request := provisioning.NewSetNetworkProfileRequest(configurationSlot, connectionData)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*provisioning.SetNetworkProfileResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetVariableMonitoring(clientId string, callback func(*diagnostics.SetVariableMonitoringResponse, error), data []diagnostics.SetMonitoringData, props ...func(*diagnostics.SetVariableMonitoringRequest)) error {
+	request := diagnostics.NewSetVariableMonitoringRequest(data)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*diagnostics.SetVariableMonitoringResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) SetVariables(clientId string, callback func(*provisioning.SetVariablesResponse, error), variableData []provisioning.SetVariableData, props ...func(*provisioning.SetVariablesRequest)) error {
+	request := provisioning.NewSetVariablesRequest(variableData)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*provisioning.SetVariablesResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) TriggerMessage(clientId string, callback func(*remotecontrol.TriggerMessageResponse, error), requestedMessage remotecontrol.MessageTrigger, props ...func(*remotecontrol.TriggerMessageRequest)) error {
+	request := remotecontrol.NewTriggerMessageRequest(requestedMessage)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*remotecontrol.TriggerMessageResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) UnlockConnector(clientId string, callback func(*remotecontrol.UnlockConnectorResponse, error), evseID int, connectorID int, props ...func(*remotecontrol.UnlockConnectorRequest)) error {
+	request := remotecontrol.NewUnlockConnectorRequest(evseID, connectorID)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*remotecontrol.UnlockConnectorResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) UnpublishFirmware(clientId string, callback func(*firmware.UnpublishFirmwareResponse, error), checksum string, props ...func(*firmware.UnpublishFirmwareRequest)) error {
+	request := firmware.NewUnpublishFirmwareRequest(checksum)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*firmware.UnpublishFirmwareResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
+func (cs *csms) UpdateFirmware(clientId string, callback func(*firmware.UpdateFirmwareResponse, error), requestID int, fw firmware.Firmware, props ...func(*firmware.UpdateFirmwareRequest)) error {
+	request := firmware.NewUpdateFirmwareRequest(requestID, fw)
+	for _, fn := range props {
+		fn(request)
+	}
+	genericCallback := func(response ocpp.Response, protoError error) {
+		if response != nil {
+			callback(response.(*firmware.UpdateFirmwareResponse), protoError)
+		} else {
+			callback(nil, protoError)
+		}
+	}
+	return cs.SendRequestAsync(clientId, request, genericCallback)
+}
+
 func (cs *csms) SetSecurityHandler(handler security.CSMSHandler) {
 	cs.securityHandler = handler
 }
@@ -432,22 +749,45 @@ func (cs *csms) SetChargingStationDisconnectedHandler(handler ChargingStationCon
 	})
 }
 
+func (cs *csms) SetRequestTimeout(timeout time.Duration) error {
+	return cs.server.SetRequestTimeout(timeout)
+}
+
+func (cs *csms) SetFeatureRequestTimeout(featureName string, timeout time.Duration) error {
+	return cs.server.SetFeatureRequestTimeout(featureName, timeout)
+}
+
 func (cs *csms) SendRequestAsync(clientId string, request ocpp.Request, callback func(response ocpp.Response, err error)) error {
+	return cs.SendRequestAsyncWithContext(context.Background(), clientId, request, callback)
+}
+
+func (cs *csms) SendRequestAsyncWithContext(ctx context.Context, clientId string, request ocpp.Request, callback func(response ocpp.Response, err error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	featureName := request.GetFeatureName()
 	if _, found := cs.server.GetProfileForFeature(featureName); !found {
 		return fmt.Errorf("feature %v is unsupported on CSMS (missing profile), cannot send request", featureName)
 	}
 	switch featureName {
-	case reservation.CancelReservationFeatureName, security.CertificateSignedFeatureName, availability.ChangeAvailabilityFeatureName, authorization.ClearCacheFeatureName, smartcharging.ClearChargingProfileFeatureName, display.ClearDisplayFeatureName, diagnostics.ClearVariableMonitoringFeatureName, tariffcost.CostUpdatedFeatureName, diagnostics.CustomerInformationFeatureName, data.DataTransferFeatureName, iso15118.DeleteCertificateFeatureName, provisioning.GetBaseReportFeatureName, smartcharging.GetChargingProfilesFeatureName, smartcharging.GetCompositeScheduleFeatureName, display.GetDisplayMessagesFeatureName, iso15118.GetInstalledCertificateIdsFeatureName, localauth.GetLocalListVersionFeatureName, diagnostics.GetLogFeatureName, diagnostics.GetMonitoringReportFeatureName:
+	case reservation.CancelReservationFeatureName, security.CertificateSignedFeatureName, availability.ChangeAvailabilityFeatureName, authorization.ClearCacheFeatureName, smartcharging.ClearChargingProfileFeatureName, display.ClearDisplayFeatureName, diagnostics.ClearVariableMonitoringFeatureName, tariffcost.CostUpdatedFeatureName, diagnostics.CustomerInformationFeatureName, data.DataTransferFeatureName, iso15118.DeleteCertificateFeatureName, provisioning.GetBaseReportFeatureName, smartcharging.GetChargingProfilesFeatureName, smartcharging.GetCompositeScheduleFeatureName, display.GetDisplayMessagesFeatureName, iso15118.GetInstalledCertificateIdsFeatureName, localauth.GetLocalListVersionFeatureName, diagnostics.GetLogFeatureName, diagnostics.GetMonitoringReportFeatureName, transactions.GetTransactionStatusFeatureName, remotecontrol.RequestStartTransactionFeatureName, remotecontrol.RequestStopTransactionFeatureName, remotecontrol.TriggerMessageFeatureName, remotecontrol.UnlockConnectorFeatureName, provisioning.GetReportFeatureName, provisioning.GetVariablesFeatureName, provisioning.ResetFeatureName, provisioning.SetNetworkProfileFeatureName, provisioning.SetVariablesFeatureName, reservation.ReserveNowFeatureName, localauth.SendLocalListFeatureName, iso15118.InstallCertificateFeatureName, diagnostics.SetMonitoringBaseFeatureName, diagnostics.SetMonitoringLevelFeatureName, diagnostics.SetVariableMonitoringFeatureName, smartcharging.SetChargingProfileFeatureName, firmware.PublishFirmwareFeatureName, firmware.UnpublishFirmwareFeatureName, firmware.UpdateFirmwareFeatureName, display.SetDisplayMessageFeatureName:
 		break
 	default:
 		return fmt.Errorf("unsupported action %v on CSMS, cannot send request", featureName)
 	}
 
-	send := func() error {
-		return cs.server.SendRequest(clientId, request)
+	var release func()
+	if ctx.Done() != nil {
+		callback, release = callbackqueue.WithContext(ctx, callback)
+	}
+	send := func() (string, error) {
+		return cs.server.SendRequestWithContext(ctx, clientId, request)
 	}
-	return cs.callbackQueue.TryQueue(clientId, send, callback)
+	err := cs.callbackQueue.TryQueue(clientId, send, callback)
+	if err != nil && release != nil {
+		release()
+	}
+	return err
 }
 
 func (cs *csms) Start(listenPort int, listenPath string) {
@@ -456,9 +796,14 @@ func (cs *csms) Start(listenPort int, listenPath string) {
 
 func (cs *csms) sendResponse(chargingStationID string, response ocpp.Response, err error, requestId string) {
 	if err != nil {
-		err := cs.server.SendError(chargingStationID, requestId, ocppj.ProtocolError, "Couldn't generate valid confirmation", nil)
+		// OCPP errors returned by a handler are forwarded with their own error code
+		errorCode, description := ocppj.ProtocolError, "Couldn't generate valid confirmation"
+		if ocppErr, ok := err.(*ocpp.Error); ok {
+			errorCode, description = ocppErr.Code, ocppErr.Description
+		}
+		err := cs.server.SendError(chargingStationID, requestId, errorCode, description, nil)
 		if err != nil {
-			err = fmt.Errorf("replying cs %s to request %s with 'protocol error': %w", chargingStationID, requestId, err)
+			err = fmt.Errorf("replying cs %s to request %s with '%v': %w", chargingStationID, requestId, errorCode, err)
 			cs.error(err)
 		}
 		return
@@ -590,6 +935,42 @@ func (cs *csms) handleIncomingRequest(chargingStation ChargingStationConnection,
 			response, err = cs.iso15118Handler.OnGet15118EVCertificate(chargingStation.ID(), request.(*iso15118.Get15118EVCertificateRequest))
 		case iso15118.GetCertificateStatusFeatureName:
 			response, err = cs.iso15118Handler.OnGetCertificateStatus(chargingStation.ID(), request.(*iso15118.GetCertificateStatusRequest))
+		case provisioning.HeartbeatFeatureName:
+			response, err = cs.provisioningHandler.OnHeartbeat(chargingStation.ID(), request.(*provisioning.HeartbeatRequest))
+		case diagnostics.LogStatusNotificationFeatureName:
+			response, err = cs.diagnosticsHandler.OnLogStatusNotification(chargingStation.ID(), request.(*diagnostics.LogStatusNotificationRequest))
+		case meter.MeterValuesFeatureName:
+			response, err = cs.meterHandler.OnMeterValues(chargingStation.ID(), request.(*meter.MeterValuesRequest))
+		case smartcharging.NotifyChargingLimitFeatureName:
+			response, err = cs.smartChargingHandler.OnNotifyChargingLimit(chargingStation.ID(), request.(*smartcharging.NotifyChargingLimitRequest))
+		case diagnostics.NotifyCustomerInformationFeatureName:
+			response, err = cs.diagnosticsHandler.OnNotifyCustomerInformation(chargingStation.ID(), request.(*diagnostics.NotifyCustomerInformationRequest))
+		case display.NotifyDisplayMessagesFeatureName:
+			response, err = cs.displayHandler.OnNotifyDisplayMessages(chargingStation.ID(), request.(*display.NotifyDisplayMessagesRequest))
+		case smartcharging.NotifyEVChargingNeedsFeatureName:
+			response, err = cs.smartChargingHandler.OnNotifyEVChargingNeeds(chargingStation.ID(), request.(*smartcharging.NotifyEVChargingNeedsRequest))
+		case smartcharging.NotifyEVChargingScheduleFeatureName:
+			response, err = cs.smartChargingHandler.OnNotifyEVChargingSchedule(chargingStation.ID(), request.(*smartcharging.NotifyEVChargingScheduleRequest))
+		case diagnostics.NotifyEventFeatureName:
+			response, err = cs.diagnosticsHandler.OnNotifyEvent(chargingStation.ID(), request.(*diagnostics.NotifyEventRequest))
+		case diagnostics.NotifyMonitoringReportFeatureName:
+			response, err = cs.diagnosticsHandler.OnNotifyMonitoringReport(chargingStation.ID(), request.(*diagnostics.NotifyMonitoringReportRequest))
+		case provisioning.NotifyReportFeatureName:
+			response, err = cs.provisioningHandler.OnNotifyReport(chargingStation.ID(), request.(*provisioning.NotifyReportRequest))
+		case firmware.PublishFirmwareStatusNotificationFeatureName:
+			response, err = cs.firmwareHandler.OnPublishFirmwareStatusNotification(chargingStation.ID(), request.(*firmware.PublishFirmwareStatusNotificationRequest))
+		case smartcharging.ReportChargingProfilesFeatureName:
+			response, err = cs.smartChargingHandler.OnReportChargingProfiles(chargingStation.ID(), request.(*smartcharging.ReportChargingProfilesRequest))
+		case reservation.ReservationStatusUpdateFeatureName:
+			response, err = cs.reservationHandler.OnReservationStatusUpdate(chargingStation.ID(), request.(*reservation.ReservationStatusUpdateRequest))
+		case security.SecurityEventNotificationFeatureName:
+			response, err = cs.securityHandler.OnSecurityEventNotification(chargingStation.ID(), request.(*security.SecurityEventNotificationRequest))
+		case security.SignCertificateFeatureName:
+			response, err = cs.securityHandler.OnSignCertificate(chargingStation.ID(), request.(*security.SignCertificateRequest))
+		case availability.StatusNotificationFeatureName:
+			response, err = cs.availabilityHandler.OnStatusNotification(chargingStation.ID(), request.(*availability.StatusNotificationRequest))
+		case transactions.TransactionEventFeatureName:
+			response, err = cs.transactionsHandler.OnTransactionEvent(chargingStation.ID(), request.(*transactions.TransactionEventRequest))
 		default:
 			cs.notSupportedError(chargingStation.ID(), requestId, action)
 			return
@@ -599,7 +980,7 @@ func (cs *csms) handleIncomingRequest(chargingStation ChargingStationConnection,
 }
 
 func (cs *csms) handleIncomingResponse(chargingStation ChargingStationConnection, response ocpp.Response, requestId string) {
-	if callback, ok := cs.callbackQueue.Dequeue(chargingStation.ID()); ok {
+	if callback, ok := cs.callbackQueue.DequeueRequest(chargingStation.ID(), requestId); ok {
 		callback(response, nil)
 	} else {
 		err := fmt.Errorf("no handler available for call of type %v from client %s for request %s", response.GetFeatureName(), chargingStation.ID(), requestId)
@@ -608,7 +989,7 @@ func (cs *csms) handleIncomingResponse(chargingStation ChargingStationConnection
 }
 
 func (cs *csms) handleIncomingError(chargingStation ChargingStationConnection, err *ocpp.Error, details interface{}) {
-	if callback, ok := cs.callbackQueue.Dequeue(chargingStation.ID()); ok {
+	if callback, ok := cs.callbackQueue.DequeueRequest(chargingStation.ID(), err.MessageId); ok {
 		callback(nil, err)
 	} else {
 		cs.error(fmt.Errorf("no handler available for call error %w from client %s", err, chargingStation.ID()))
@@ -5,6 +5,14 @@ import "github.com/lorenzodonini/ocpp-go/ocpp"
 
 // Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0 Diagnostics profile.
 type CSMSHandler interface {
+	// OnLogStatusNotification is called on the CSMS whenever a LogStatusNotificationRequest is received from a charging station.
+	OnLogStatusNotification(chargingStationID string, request *LogStatusNotificationRequest) (confirmation *LogStatusNotificationResponse, err error)
+	// OnNotifyCustomerInformation is called on the CSMS whenever a NotifyCustomerInformationRequest is received from a charging station.
+	OnNotifyCustomerInformation(chargingStationID string, request *NotifyCustomerInformationRequest) (confirmation *NotifyCustomerInformationResponse, err error)
+	// OnNotifyEvent is called on the CSMS whenever a NotifyEventRequest is received from a charging station.
+	OnNotifyEvent(chargingStationID string, request *NotifyEventRequest) (confirmation *NotifyEventResponse, err error)
+	// OnNotifyMonitoringReport is called on the CSMS whenever a NotifyMonitoringReportRequest is received from a charging station.
+	OnNotifyMonitoringReport(chargingStationID string, request *NotifyMonitoringReportRequest) (confirmation *NotifyMonitoringReportResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Diagnostics profile.
@@ -17,6 +25,12 @@ type ChargingStationHandler interface {
 	OnGetLog(request *GetLogRequest) (confirmation *GetLogResponse, err error)
 	// OnGetMonitoringReport is called on a charging station whenever a GetMonitoringReportRequest is received from the CSMS.
 	OnGetMonitoringReport(request *GetMonitoringReportRequest) (confirmation *GetMonitoringReportResponse, err error)
+	// OnSetMonitoringBase is called on a charging station whenever a SetMonitoringBaseRequest is received from the CSMS.
+	OnSetMonitoringBase(request *SetMonitoringBaseRequest) (confirmation *SetMonitoringBaseResponse, err error)
+	// OnSetMonitoringLevel is called on a charging station whenever a SetMonitoringLevelRequest is received from the CSMS.
+	OnSetMonitoringLevel(request *SetMonitoringLevelRequest) (confirmation *SetMonitoringLevelResponse, err error)
+	// OnSetVariableMonitoring is called on a charging station whenever a SetVariableMonitoringRequest is received from the CSMS.
+	OnSetVariableMonitoring(request *SetVariableMonitoringRequest) (confirmation *SetVariableMonitoringResponse, err error)
 }
 
 const ProfileName = "diagnostics"
@@ -27,4 +41,11 @@ var Profile = ocpp.NewProfile(
 	CustomerInformationFeature{},
 	GetLogFeature{},
 	GetMonitoringReportFeature{},
+	LogStatusNotificationFeature{},
+	NotifyCustomerInformationFeature{},
+	NotifyEventFeature{},
+	NotifyMonitoringReportFeature{},
+	SetMonitoringBaseFeature{},
+	SetMonitoringLevelFeature{},
+	SetVariableMonitoringFeature{},
 )
@@ -0,0 +1,120 @@
+package diagnostics
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Notify Event (CS -> CSMS) --------------------
+
+const NotifyEventFeatureName = "NotifyEvent"
+
+// EventTrigger defines the type of monitor that triggered an event.
+type EventTrigger string
+
+// EventNotification specifies the event notification type of the message.
+type EventNotification string
+
+const (
+	EventTriggerAlerting EventTrigger = "Alerting" // Monitored variable has passed an Lower or Upper Threshold.
+	EventTriggerDelta    EventTrigger = "Delta"    // Delta Monitored Variable value has changed by more than specified amount.
+	EventTriggerPeriodic EventTrigger = "Periodic" // Periodic Monitored Variable has been sampled for reporting at the specified interval.
+
+	EventHardWiredNotification EventNotification = "HardWiredNotification" // The software implemented by the manufacturer triggered a hardwired notification.
+	EventHardWiredMonitor      EventNotification = "HardWiredMonitor"      // Triggered by a monitor, which is hardwired by the manufacturer.
+	EventPreconfiguredMonitor  EventNotification = "PreconfiguredMonitor"  // Triggered by a monitor, which is preconfigured by the manufacturer.
+	EventCustomMonitor         EventNotification = "CustomMonitor"         // Triggered by a monitor, which is set with the SetVariableMonitoringRequest message by the Charging Station Operator.
+)
+
+func isValidEventTrigger(fl validator.FieldLevel) bool {
+	status := EventTrigger(fl.Field().String())
+	switch status {
+	case EventTriggerAlerting, EventTriggerDelta, EventTriggerPeriodic:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidEventNotification(fl validator.FieldLevel) bool {
+	status := EventNotification(fl.Field().String())
+	switch status {
+	case EventHardWiredNotification, EventHardWiredMonitor, EventPreconfiguredMonitor, EventCustomMonitor:
+		return true
+	default:
+		return false
+	}
+}
+
+// An EventData element contains only the Component, Variable and VariableMonitoring data that caused the event.
+type EventData struct {
+	EventID               int               `json:"eventId" validate:"gte=0"`                                    // Identifies the event. This field can be referred to as a cause by other events.
+	Timestamp             *types.DateTime   `json:"timestamp" validate:"required"`                               // Timestamp of the moment the report was generated.
+	Trigger               EventTrigger      `json:"trigger" validate:"required,eventTrigger"`                    // Type of monitor that triggered this event, e.g. exceeding a threshold value.
+	Cause                 *int              `json:"cause,omitempty" validate:"omitempty"`                        // Refers to the Id of an event that is considered to be the cause for this event.
+	ActualValue           string            `json:"actualValue" validate:"required,max=2500"`                    // Actual value (attributeType Actual) of the variable.
+	TechCode              string            `json:"techCode,omitempty" validate:"omitempty,max=50"`              // Technical (error) code as reported by component.
+	TechInfo              string            `json:"techInfo,omitempty" validate:"omitempty,max=500"`             // Technical detail information as reported by component.
+	Cleared               bool              `json:"cleared,omitempty"`                                           // Cleared is set to true to report the clearing of a monitored situation, i.e. a 'return to normal'.
+	TransactionID         string            `json:"transactionId,omitempty" validate:"omitempty,max=36"`         // If an event notification is linked to a specific transaction, this field can be used to specify its transactionId.
+	VariableMonitoringID  *int              `json:"variableMonitoringId,omitempty" validate:"omitempty"`         // Identifies the VariableMonitoring which triggered the event.
+	EventNotificationType EventNotification `json:"eventNotificationType" validate:"required,eventNotification"` // Specifies the event notification type of the message.
+	Component             types.Component   `json:"component" validate:"required"`                               // Component for which event is notified.
+	Variable              types.Variable    `json:"variable" validate:"required"`                                // Variable for which event is notified.
+}
+
+// The field definition of the NotifyEvent request payload sent by a Charging Station to the CSMS.
+type NotifyEventRequest struct {
+	GeneratedAt *types.DateTime `json:"generatedAt" validate:"required"`          // Timestamp of the moment this message was generated at the Charging Station.
+	Tbc         bool            `json:"tbc,omitempty" validate:"omitempty"`       // “to be continued” indicator. Indicates whether another part of the report follows in an upcoming NotifyEventRequest message. Default value when omitted is false.
+	SeqNo       int             `json:"seqNo" validate:"gte=0"`                   // Sequence number of this message. First message starts at 0.
+	EventData   []EventData     `json:"eventData" validate:"required,min=1,dive"` // The list of EventData will usually contain one eventData element, but the Charging Station may decide to group multiple events in one notification.
+}
+
+// This field definition of the NotifyEvent response payload, sent by the CSMS to the Charging Station in response to a NotifyEventRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyEventResponse struct {
+}
+
+// The NotifyEvent feature gives Charging Stations the ability to notify the CSMS (periodically) about monitoring events.
+// If a threshold or a delta value has exceeded, the Charging Station sends a NotifyEventRequest to the CSMS.
+// The CSMS responds with a NotifyEventResponse.
+//
+// The Charging Station may split the notification into multiple parts, by setting the tbc flag and incrementing the seqNo.
+type NotifyEventFeature struct{}
+
+func (f NotifyEventFeature) GetFeatureName() string {
+	return NotifyEventFeatureName
+}
+
+func (f NotifyEventFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyEventRequest{})
+}
+
+func (f NotifyEventFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyEventResponse{})
+}
+
+func (r NotifyEventRequest) GetFeatureName() string {
+	return NotifyEventFeatureName
+}
+
+func (c NotifyEventResponse) GetFeatureName() string {
+	return NotifyEventFeatureName
+}
+
+// Creates a new NotifyEventRequest, containing all required fields. Optional fields may be set afterwards.
+func NewNotifyEventRequest(generatedAt *types.DateTime, seqNo int, eventData []EventData) *NotifyEventRequest {
+	return &NotifyEventRequest{GeneratedAt: generatedAt, SeqNo: seqNo, EventData: eventData}
+}
+
+// Creates a new NotifyEventResponse, which doesn't contain any required or optional fields.
+func NewNotifyEventResponse() *NotifyEventResponse {
+	return &NotifyEventResponse{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("eventTrigger", isValidEventTrigger)
+	_ = types.Validate.RegisterValidation("eventNotification", isValidEventNotification)
+}
@@ -0,0 +1,82 @@
+package diagnostics
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Set Monitoring Base (CSMS -> CS) --------------------
+
+const SetMonitoringBaseFeatureName = "SetMonitoringBase"
+
+// Monitoring base to be set within the Charging Station.
+type MonitoringBase string
+
+const (
+	MonitoringBaseAll            MonitoringBase = "All"            // Activate all pre-configured monitors.
+	MonitoringBaseFactoryDefault MonitoringBase = "FactoryDefault" // Activate the default monitoring settings as recommended by the manufacturer. This is a subset of all pre-configured monitors.
+	MonitoringBaseHardWiredOnly  MonitoringBase = "HardWiredOnly"  // Clears all custom monitors and disables all pre-configured monitors.
+)
+
+func isValidMonitoringBase(fl validator.FieldLevel) bool {
+	status := MonitoringBase(fl.Field().String())
+	switch status {
+	case MonitoringBaseAll, MonitoringBaseFactoryDefault, MonitoringBaseHardWiredOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the SetMonitoringBase request payload sent by the CSMS to the Charging Station.
+type SetMonitoringBaseRequest struct {
+	MonitoringBase MonitoringBase `json:"monitoringBase" validate:"required,monitoringBase"` // Specify which monitoring base will be set.
+}
+
+// This field definition of the SetMonitoringBase response payload, sent by the Charging Station to the CSMS in response to a SetMonitoringBaseRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetMonitoringBaseResponse struct {
+	Status types.GenericDeviceModelStatus `json:"status" validate:"required,genericDeviceModelStatus"` // Indicates whether the Charging Station was able to accept the request.
+}
+
+// A CSMS has the ability to request the Charging Station to activate a set of preconfigured monitoring settings,
+// as denoted by the value of MonitoringBase.
+//
+// The CSMS sends a SetMonitoringBaseRequest to the Charging Station, which responds with a SetMonitoringBaseResponse.
+// It is up to the manufacturer of the Charging Station to define which monitoring settings are activated by All, FactoryDefault and HardWiredOnly.
+type SetMonitoringBaseFeature struct{}
+
+func (f SetMonitoringBaseFeature) GetFeatureName() string {
+	return SetMonitoringBaseFeatureName
+}
+
+func (f SetMonitoringBaseFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetMonitoringBaseRequest{})
+}
+
+func (f SetMonitoringBaseFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetMonitoringBaseResponse{})
+}
+
+func (r SetMonitoringBaseRequest) GetFeatureName() string {
+	return SetMonitoringBaseFeatureName
+}
+
+func (c SetMonitoringBaseResponse) GetFeatureName() string {
+	return SetMonitoringBaseFeatureName
+}
+
+// Creates a new SetMonitoringBaseRequest, containing all required fields. There are no optional fields for this message.
+func NewSetMonitoringBaseRequest(monitoringBase MonitoringBase) *SetMonitoringBaseRequest {
+	return &SetMonitoringBaseRequest{MonitoringBase: monitoringBase}
+}
+
+// Creates a new SetMonitoringBaseResponse, containing all required fields. There are no optional fields for this message.
+func NewSetMonitoringBaseResponse(status types.GenericDeviceModelStatus) *SetMonitoringBaseResponse {
+	return &SetMonitoringBaseResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("monitoringBase", isValidMonitoringBase)
+}
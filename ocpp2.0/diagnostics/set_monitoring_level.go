@@ -0,0 +1,60 @@
+package diagnostics
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Set Monitoring Level (CSMS -> CS) --------------------
+
+const SetMonitoringLevelFeatureName = "SetMonitoringLevel"
+
+// The field definition of the SetMonitoringLevel request payload sent by the CSMS to the Charging Station.
+type SetMonitoringLevelRequest struct {
+	// The Charging Station SHALL only report events with a severity number lower than or equal to this severity.
+	// The severity range is 0-9, with 0 as the highest and 9 as the lowest severity level.
+	Severity int `json:"severity" validate:"min=0,max=9"`
+}
+
+// This field definition of the SetMonitoringLevel response payload, sent by the Charging Station to the CSMS in response to a SetMonitoringLevelRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetMonitoringLevelResponse struct {
+	Status types.GenericStatus `json:"status" validate:"required,genericStatus"` // Indicates whether the Charging Station was able to accept the request.
+}
+
+// It may be desirable to restrict the reporting of monitoring events, to only those monitors with a
+// severity number lower than or equal to a certain severity.
+// For example when the data-traffic between Charging Station and CSMS needs to be limited for some reason.
+//
+// The CSMS sends a SetMonitoringLevelRequest to the Charging Station, which responds with a SetMonitoringLevelResponse.
+type SetMonitoringLevelFeature struct{}
+
+func (f SetMonitoringLevelFeature) GetFeatureName() string {
+	return SetMonitoringLevelFeatureName
+}
+
+func (f SetMonitoringLevelFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetMonitoringLevelRequest{})
+}
+
+func (f SetMonitoringLevelFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetMonitoringLevelResponse{})
+}
+
+func (r SetMonitoringLevelRequest) GetFeatureName() string {
+	return SetMonitoringLevelFeatureName
+}
+
+func (c SetMonitoringLevelResponse) GetFeatureName() string {
+	return SetMonitoringLevelFeatureName
+}
+
+// Creates a new SetMonitoringLevelRequest, containing all required fields. There are no optional fields for this message.
+func NewSetMonitoringLevelRequest(severity int) *SetMonitoringLevelRequest {
+	return &SetMonitoringLevelRequest{Severity: severity}
+}
+
+// Creates a new SetMonitoringLevelResponse, containing all required fields. There are no optional fields for this message.
+func NewSetMonitoringLevelResponse(status types.GenericStatus) *SetMonitoringLevelResponse {
+	return &SetMonitoringLevelResponse{Status: status}
+}
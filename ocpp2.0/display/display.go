@@ -5,6 +5,8 @@ import "github.com/lorenzodonini/ocpp-go/ocpp"
 
 // Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0 Display profile.
 type CSMSHandler interface {
+	// OnNotifyDisplayMessages is called on the CSMS whenever a NotifyDisplayMessagesRequest is received from a charging station.
+	OnNotifyDisplayMessages(chargingStationID string, request *NotifyDisplayMessagesRequest) (confirmation *NotifyDisplayMessagesResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Display profile.
@@ -13,6 +15,8 @@ type ChargingStationHandler interface {
 	OnClearDisplay(request *ClearDisplayRequest) (confirmation *ClearDisplayResponse, err error)
 	// OnGetDisplayMessages is called on a charging station whenever a GetDisplayMessagesRequest is received from the CSMS.
 	OnGetDisplayMessages(request *GetDisplayMessagesRequest) (confirmation *GetDisplayMessagesResponse, err error)
+	// OnSetDisplayMessage is called on a charging station whenever a SetDisplayMessageRequest is received from the CSMS.
+	OnSetDisplayMessage(request *SetDisplayMessageRequest) (confirmation *SetDisplayMessageResponse, err error)
 }
 
 const ProfileName = "display"
@@ -21,4 +25,6 @@ var Profile = ocpp.NewProfile(
 	ProfileName,
 	ClearDisplayFeature{},
 	GetDisplayMessagesFeature{},
+	NotifyDisplayMessagesFeature{},
+	SetDisplayMessageFeature{},
 )
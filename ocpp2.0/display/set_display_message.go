@@ -0,0 +1,99 @@
+package display
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Set Display Message (CSMS -> CS) --------------------
+
+const SetDisplayMessageFeatureName = "SetDisplayMessage"
+
+// Result of a SetDisplayMessageRequest, as returned by the Charging Station.
+type DisplayMessageStatus string
+
+const (
+	DisplayMessageStatusAccepted                  DisplayMessageStatus = "Accepted"
+	DisplayMessageStatusNotSupportedMessageFormat DisplayMessageStatus = "NotSupportedMessageFormat"
+	DisplayMessageStatusRejected                  DisplayMessageStatus = "Rejected"
+	DisplayMessageStatusNotSupportedPriority      DisplayMessageStatus = "NotSupportedPriority"
+	DisplayMessageStatusNotSupportedState         DisplayMessageStatus = "NotSupportedState"
+	DisplayMessageStatusUnknownTransaction        DisplayMessageStatus = "UnknownTransaction"
+)
+
+func isValidDisplayMessageStatus(fl validator.FieldLevel) bool {
+	status := DisplayMessageStatus(fl.Field().String())
+	switch status {
+	case DisplayMessageStatusAccepted, DisplayMessageStatusNotSupportedMessageFormat, DisplayMessageStatusRejected, DisplayMessageStatusNotSupportedPriority, DisplayMessageStatusNotSupportedState, DisplayMessageStatusUnknownTransaction:
+		return true
+	default:
+		return false
+	}
+}
+
+// Contains message details, for a message to be displayed on a Charging Station.
+type MessageInfo struct {
+	ID            int                  `json:"id" validate:"gte=0"`                                 // Master resource identifier, unique within an exchange context. It is defined within the OCPP context as a positive Integer value (greater or equal to zero).
+	Priority      MessagePriority      `json:"priority" validate:"required,messagePriority"`        // With what priority should this message be shown.
+	State         MessageState         `json:"state,omitempty" validate:"omitempty,messageState"`   // During what state should this message be shown. When omitted this message should be shown in any state of the Charging Station.
+	StartDateTime *types.DateTime      `json:"startDateTime,omitempty" validate:"omitempty"`        // From what date-time should this message be shown. If omitted: directly.
+	EndDateTime   *types.DateTime      `json:"endDateTime,omitempty" validate:"omitempty"`          // Until what date-time should this message be shown, after this date/time this message SHALL be removed.
+	TransactionID string               `json:"transactionId,omitempty" validate:"omitempty,max=36"` // During which transaction shall this message be shown. Message SHALL be removed by the Charging Station after transaction has ended.
+	Message       types.MessageContent `json:"message" validate:"required"`                         // Contains message details for the message to be displayed on a Charging Station.
+	Display       *types.Component     `json:"display,omitempty" validate:"omitempty"`              // When a Charging Station has multiple Displays, this field can be used to define to which Display this message belongs.
+}
+
+// The field definition of the SetDisplayMessage request payload sent by the CSMS to the Charging Station.
+type SetDisplayMessageRequest struct {
+	Message MessageInfo `json:"message" validate:"required"`
+}
+
+// This field definition of the SetDisplayMessage response payload, sent by the Charging Station to the CSMS in response to a SetDisplayMessageRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetDisplayMessageResponse struct {
+	Status DisplayMessageStatus `json:"status" validate:"required,displayMessageStatus"`
+}
+
+// The CSMS may send a SetDisplayMessageRequest to a Charging Station, in order to display a message
+// on one of its displays. Messages may be configured to be shown only in a certain time window,
+// during a specific Charging Station state or during a specific transaction.
+//
+// The Charging Station responds with a SetDisplayMessageResponse, indicating whether it was able to
+// configure the message. If a message with the same ID already exists on the Charging Station,
+// it SHALL be replaced by the new message.
+type SetDisplayMessageFeature struct{}
+
+func (f SetDisplayMessageFeature) GetFeatureName() string {
+	return SetDisplayMessageFeatureName
+}
+
+func (f SetDisplayMessageFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetDisplayMessageRequest{})
+}
+
+func (f SetDisplayMessageFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetDisplayMessageResponse{})
+}
+
+func (r SetDisplayMessageRequest) GetFeatureName() string {
+	return SetDisplayMessageFeatureName
+}
+
+func (c SetDisplayMessageResponse) GetFeatureName() string {
+	return SetDisplayMessageFeatureName
+}
+
+// Creates a new SetDisplayMessageRequest, containing all required fields. There are no optional fields for this message.
+func NewSetDisplayMessageRequest(message MessageInfo) *SetDisplayMessageRequest {
+	return &SetDisplayMessageRequest{Message: message}
+}
+
+// Creates a new SetDisplayMessageResponse, containing all required fields. There are no optional fields for this message.
+func NewSetDisplayMessageResponse(status DisplayMessageStatus) *SetDisplayMessageResponse {
+	return &SetDisplayMessageResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("displayMessageStatus", isValidDisplayMessageStatus)
+}
@@ -0,0 +1,63 @@
+package firmware
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Publish Firmware (CSMS -> CS) --------------------
+
+const PublishFirmwareFeatureName = "PublishFirmware"
+
+// The field definition of the PublishFirmware request payload sent by the CSMS to the Charging Station.
+type PublishFirmwareRequest struct {
+	Location      string `json:"location" validate:"required,max=512"`               // This contains a string containing a URI pointing to a location from which to retrieve the firmware.
+	Retries       *int   `json:"retries,omitempty" validate:"omitempty,gte=0"`       // This specifies how many times Charging Station must try to download the firmware before giving up. If this field is not present, it is left to Charging Station to decide how many times it wants to retry.
+	Checksum      string `json:"checksum" validate:"required,max=32"`                // The MD5 checksum over the entire firmware file as a hexadecimal string of length 32.
+	RequestID     int    `json:"requestId" validate:"gte=0"`                         // The Id of the request.
+	RetryInterval *int   `json:"retryInterval,omitempty" validate:"omitempty,gte=0"` // The interval in seconds after which a retry may be attempted. If this field is not present, it is left to Charging Station to decide how long to wait between attempts.
+}
+
+// This field definition of the PublishFirmware response payload, sent by the Charging Station to the CSMS in response to a PublishFirmwareRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type PublishFirmwareResponse struct {
+	Status types.GenericStatus `json:"status" validate:"required,genericStatus"` // Indicates whether the request was accepted.
+}
+
+// The CSMS may instruct a Local Controller to download and publish a firmware update,
+// so that Charging Stations connected to it can download the firmware from the Local Controller,
+// instead of retrieving it from the CSMS.
+//
+// The CSMS sends a PublishFirmwareRequest to the Local Controller, which responds with a PublishFirmwareResponse.
+// The Local Controller then reports its progress via PublishFirmwareStatusNotification messages.
+type PublishFirmwareFeature struct{}
+
+func (f PublishFirmwareFeature) GetFeatureName() string {
+	return PublishFirmwareFeatureName
+}
+
+func (f PublishFirmwareFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(PublishFirmwareRequest{})
+}
+
+func (f PublishFirmwareFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(PublishFirmwareResponse{})
+}
+
+func (r PublishFirmwareRequest) GetFeatureName() string {
+	return PublishFirmwareFeatureName
+}
+
+func (c PublishFirmwareResponse) GetFeatureName() string {
+	return PublishFirmwareFeatureName
+}
+
+// Creates a new PublishFirmwareRequest, containing all required fields. Optional fields may be set afterwards.
+func NewPublishFirmwareRequest(location string, checksum string, requestID int) *PublishFirmwareRequest {
+	return &PublishFirmwareRequest{Location: location, Checksum: checksum, RequestID: requestID}
+}
+
+// Creates a new PublishFirmwareResponse, containing all required fields. There are no optional fields for this message.
+func NewPublishFirmwareResponse(status types.GenericStatus) *PublishFirmwareResponse {
+	return &PublishFirmwareResponse{Status: status}
+}
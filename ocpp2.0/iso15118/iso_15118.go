@@ -21,6 +21,8 @@ type ChargingStationHandler interface {
 	OnDeleteCertificate(request *DeleteCertificateRequest) (confirmation *DeleteCertificateResponse, err error)
 	// OnGetInstalledCertificateIds is called on a charging station whenever a GetInstalledCertificateIdsRequest is received from the CSMS.
 	OnGetInstalledCertificateIds(request *GetInstalledCertificateIdsRequest) (confirmation *GetInstalledCertificateIdsResponse, err error)
+	// OnInstallCertificate is called on a charging station whenever an InstallCertificateRequest is received from the CSMS.
+	OnInstallCertificate(request *InstallCertificateRequest) (confirmation *InstallCertificateResponse, err error)
 }
 
 const ProfileName = "iso15118"
@@ -31,4 +33,5 @@ var Profile = ocpp.NewProfile(
 	Get15118EVCertificateFeature{},
 	GetCertificateStatusFeature{},
 	GetInstalledCertificateIdsFeature{},
+	InstallCertificateFeature{},
 )
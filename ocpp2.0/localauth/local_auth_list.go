@@ -12,6 +12,8 @@ type CSMSHandler interface {
 type ChargingStationHandler interface {
 	// OnGetLocalListVersion is called on a charging station whenever a GetLocalListVersionRequest is received from the CSMS.
 	OnGetLocalListVersion(request *GetLocalListVersionRequest) (confirmation *GetLocalListVersionResponse, err error)
+	// OnSendLocalList is called on a charging station whenever a SendLocalListRequest is received from the CSMS.
+	OnSendLocalList(request *SendLocalListRequest) (confirmation *SendLocalListResponse, err error)
 }
 
 const ProfileName = "localAuthList"
@@ -19,4 +21,5 @@ const ProfileName = "localAuthList"
 var Profile = ocpp.NewProfile(
 	ProfileName,
 	GetLocalListVersionFeature{},
+	SendLocalListFeature{},
 )
@@ -5,6 +5,8 @@ import "github.com/lorenzodonini/ocpp-go/ocpp"
 
 // Needs to be implemented by a CSMS for handling messages part of the OCPP 2.0 Meter values profile.
 type CSMSHandler interface {
+	// OnMeterValues is called on the CSMS whenever a MeterValuesRequest is received from a charging station.
+	OnMeterValues(chargingStationID string, request *MeterValuesRequest) (confirmation *MeterValuesResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Meter values profile.
@@ -14,4 +16,6 @@ type ChargingStationHandler interface {
 const ProfileName = "meter"
 
 var Profile = ocpp.NewProfile(
-	ProfileName)
+	ProfileName,
+	MeterValuesFeature{},
+)
@@ -0,0 +1,68 @@
+package meter
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Meter Values (CS -> CSMS) --------------------
+
+const MeterValuesFeatureName = "MeterValues"
+
+// The field definition of the MeterValues request payload sent by the Charging Station to the CSMS.
+type MeterValuesRequest struct {
+	EvseID     int                `json:"evseId" validate:"gte=0"` // This contains a number (>0) designating an EVSE of the Charging Station. ‘0’ (zero) is used to designate the main power meter.
+	MeterValue []types.MeterValue `json:"meterValue" validate:"required,min=1,dive"`
+}
+
+// This field definition of the MeterValues response payload, sent by the CSMS to the Charging Station in response to a MeterValuesRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type MeterValuesResponse struct {
+}
+
+// A Charging Station MAY sample the electrical meter or other sensor/transducer hardware to provide extra information about its meter values.
+// It is up to the Charging Station to decide when it will send meter values.
+// This can be configured using the SetVariables message to data acquisition intervals and specify data to be acquired & reported.
+//
+// The Charging Station SHALL send a MeterValuesRequest for offloading meter values to the CSMS.
+// The request contains for each sample:
+//
+// 1. The id of the EVSE from which samples were taken. If the evseId is 0, it is associated with the entire Charging Station. If the evseId is 0 and the measurand is energy related, the sample SHOULD be taken from the main energy meter.
+//
+// 2. One or more meterValue elements, of type MeterValue, each representing a set of one or more data values taken at a particular point in time.
+//
+// The CSMS responds with a MeterValuesResponse.
+// The response payload is empty and is solely used for confirming reception of the request.
+//
+// Meter values related to a transaction are not sent with this message, but are instead part of the TransactionEvent message.
+type MeterValuesFeature struct{}
+
+func (f MeterValuesFeature) GetFeatureName() string {
+	return MeterValuesFeatureName
+}
+
+func (f MeterValuesFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(MeterValuesRequest{})
+}
+
+func (f MeterValuesFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(MeterValuesResponse{})
+}
+
+func (r MeterValuesRequest) GetFeatureName() string {
+	return MeterValuesFeatureName
+}
+
+func (c MeterValuesResponse) GetFeatureName() string {
+	return MeterValuesFeatureName
+}
+
+// Creates a new MeterValuesRequest, containing all required fields. There are no optional fields for this message.
+func NewMeterValuesRequest(evseID int, meterValues []types.MeterValue) *MeterValuesRequest {
+	return &MeterValuesRequest{EvseID: evseID, MeterValue: meterValues}
+}
+
+// Creates a new MeterValuesResponse, which doesn't contain any required or optional fields.
+func NewMeterValuesResponse() *MeterValuesResponse {
+	return &MeterValuesResponse{}
+}
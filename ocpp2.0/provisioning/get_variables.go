@@ -0,0 +1,116 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Get Variables (CSMS -> CS) --------------------
+
+const GetVariablesFeatureName = "GetVariables"
+
+// Attribute type of a variable.
+type Attribute string
+
+// Result status of a GetVariableData request.
+type GetVariableStatus string
+
+const (
+	AttributeActual                            Attribute         = "Actual"
+	AttributeTarget                            Attribute         = "Target"
+	AttributeMinSet                            Attribute         = "MinSet"
+	AttributeMaxSet                            Attribute         = "MaxSet"
+	GetVariableStatusAccepted                  GetVariableStatus = "Accepted"
+	GetVariableStatusRejected                  GetVariableStatus = "Rejected"
+	GetVariableStatusUnknownComponent          GetVariableStatus = "UnknownComponent"
+	GetVariableStatusUnknownVariable           GetVariableStatus = "UnknownVariable"
+	GetVariableStatusNotSupportedAttributeType GetVariableStatus = "NotSupportedAttributeType"
+)
+
+func isValidAttribute(fl validator.FieldLevel) bool {
+	attribute := Attribute(fl.Field().String())
+	switch attribute {
+	case AttributeActual, AttributeTarget, AttributeMinSet, AttributeMaxSet:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidGetVariableStatus(fl validator.FieldLevel) bool {
+	status := GetVariableStatus(fl.Field().String())
+	switch status {
+	case GetVariableStatusAccepted, GetVariableStatusRejected, GetVariableStatusUnknownComponent, GetVariableStatusUnknownVariable, GetVariableStatusNotSupportedAttributeType:
+		return true
+	default:
+		return false
+	}
+}
+
+// Specifies the component, variable and attribute type for which a value is requested.
+type GetVariableData struct {
+	AttributeType Attribute       `json:"attributeType,omitempty" validate:"omitempty,attribute"` // Attribute type for which value is requested. When absent, default Actual is assumed.
+	Component     types.Component `json:"component" validate:"required"`                          // Component for which the Variable is requested.
+	Variable      types.Variable  `json:"variable" validate:"required"`                           // Variable for which the attribute value is requested.
+}
+
+// Contains the result of a single GetVariableData request, including the requested value, if available.
+type GetVariableResult struct {
+	AttributeStatus GetVariableStatus `json:"attributeStatus" validate:"required,getVariableStatus"`  // Result status of getting the variable.
+	AttributeType   Attribute         `json:"attributeType,omitempty" validate:"omitempty,attribute"` // Attribute type for which value is requested. When absent, default Actual is assumed.
+	AttributeValue  string            `json:"attributeValue,omitempty" validate:"omitempty,max=1000"` // Value of requested attribute type of component-variable. This field can only be empty when the given status is NOT accepted.
+	Component       types.Component   `json:"component" validate:"required"`                          // Component for which the Variable is requested.
+	Variable        types.Variable    `json:"variable" validate:"required"`                           // Variable for which the attribute value is requested.
+}
+
+// The field definition of the GetVariables request payload sent by the CSMS to the Charging Station.
+type GetVariablesRequest struct {
+	GetVariableData []GetVariableData `json:"getVariableData" validate:"required,min=1,dive"` // List of requested variables.
+}
+
+// This field definition of the GetVariables response payload, sent by the Charging Station to the CSMS in response to a GetVariablesRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetVariablesResponse struct {
+	GetVariableResult []GetVariableResult `json:"getVariableResult" validate:"required,min=1,dive"` // List of requested variables and their values.
+}
+
+// The CSMS can request a Charging Station for the value of a specific set of variables, by sending a GetVariablesRequest.
+// The Charging Station responds with a GetVariablesResponse, containing the value (or status) for each requested variable.
+// If the Charging Station doesn't know a requested component/variable, it reports an UnknownComponent/UnknownVariable status respectively.
+type GetVariablesFeature struct{}
+
+func (f GetVariablesFeature) GetFeatureName() string {
+	return GetVariablesFeatureName
+}
+
+func (f GetVariablesFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetVariablesRequest{})
+}
+
+func (f GetVariablesFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetVariablesResponse{})
+}
+
+func (r GetVariablesRequest) GetFeatureName() string {
+	return GetVariablesFeatureName
+}
+
+func (c GetVariablesResponse) GetFeatureName() string {
+	return GetVariablesFeatureName
+}
+
+// Creates a new GetVariablesRequest, containing all required fields. There are no optional fields for this message.
+func NewGetVariablesRequest(variableData []GetVariableData) *GetVariablesRequest {
+	return &GetVariablesRequest{GetVariableData: variableData}
+}
+
+// Creates a new GetVariablesResponse, containing all required fields. There are no optional fields for this message.
+func NewGetVariablesResponse(result []GetVariableResult) *GetVariablesResponse {
+	return &GetVariablesResponse{GetVariableResult: result}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("attribute", isValidAttribute)
+	_ = types.Validate.RegisterValidation("getVariableStatus", isValidGetVariableStatus)
+}
@@ -0,0 +1,56 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Heartbeat (CS -> CSMS) --------------------
+
+const HeartbeatFeatureName = "Heartbeat"
+
+// The field definition of the Heartbeat request payload sent by the Charging Station to the CSMS.
+type HeartbeatRequest struct {
+}
+
+// This field definition of the Heartbeat response payload, sent by the CSMS to the Charging Station in response to a HeartbeatRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type HeartbeatResponse struct {
+	CurrentTime *types.DateTime `json:"currentTime" validate:"required"` // Contains the current time of the CSMS.
+}
+
+// To let the CSMS know that a Charging Station is still connected, a Charging Station sends a heartbeat after a configurable time interval.
+// The interval is received from the CSMS in the BootNotificationResponse.
+// Upon receipt of a HeartbeatRequest, the CSMS responds with a HeartbeatResponse, containing its current time.
+// It is recommended that the Charging Station uses this time to synchronize its internal clock.
+type HeartbeatFeature struct{}
+
+func (f HeartbeatFeature) GetFeatureName() string {
+	return HeartbeatFeatureName
+}
+
+func (f HeartbeatFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(HeartbeatRequest{})
+}
+
+func (f HeartbeatFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(HeartbeatResponse{})
+}
+
+func (r HeartbeatRequest) GetFeatureName() string {
+	return HeartbeatFeatureName
+}
+
+func (c HeartbeatResponse) GetFeatureName() string {
+	return HeartbeatFeatureName
+}
+
+// Creates a new HeartbeatRequest, which doesn't contain any required or optional fields.
+func NewHeartbeatRequest() *HeartbeatRequest {
+	return &HeartbeatRequest{}
+}
+
+// Creates a new HeartbeatResponse, containing all required fields. There are no optional fields for this message.
+func NewHeartbeatResponse(currentTime *types.DateTime) *HeartbeatResponse {
+	return &HeartbeatResponse{CurrentTime: currentTime}
+}
@@ -0,0 +1,133 @@
+package provisioning
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"gopkg.in/go-playground/validator.v9"
+	"reflect"
+)
+
+// -------------------- Notify Report (CS -> CSMS) --------------------
+
+const NotifyReportFeatureName = "NotifyReport"
+
+// Mutability defines whether a value of a variable attribute can be read/written.
+type Mutability string
+
+// DataType defines the data type of a variable.
+type DataType string
+
+const (
+	MutabilityReadOnly   Mutability = "ReadOnly"
+	MutabilityWriteOnly  Mutability = "WriteOnly"
+	MutabilityReadWrite  Mutability = "ReadWrite"
+	DataTypeString       DataType   = "string"
+	DataTypeDecimal      DataType   = "decimal"
+	DataTypeInteger      DataType   = "integer"
+	DataTypeDateTime     DataType   = "dateTime"
+	DataTypeBoolean      DataType   = "boolean"
+	DataTypeOptionList   DataType   = "OptionList"
+	DataTypeSequenceList DataType   = "SequenceList"
+	DataTypeMemberList   DataType   = "MemberList"
+)
+
+func isValidMutability(fl validator.FieldLevel) bool {
+	mutability := Mutability(fl.Field().String())
+	switch mutability {
+	case MutabilityReadOnly, MutabilityWriteOnly, MutabilityReadWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidDataType(fl validator.FieldLevel) bool {
+	dataType := DataType(fl.Field().String())
+	switch dataType {
+	case DataTypeString, DataTypeDecimal, DataTypeInteger, DataTypeDateTime, DataTypeBoolean, DataTypeOptionList, DataTypeSequenceList, DataTypeMemberList:
+		return true
+	default:
+		return false
+	}
+}
+
+// Attribute data of a variable.
+type VariableAttribute struct {
+	Type       Attribute  `json:"type,omitempty" validate:"omitempty,attribute"`        // Attribute: Actual, MinSet, MaxSet, etc. Defaults to Actual if absent.
+	Value      string     `json:"value,omitempty" validate:"omitempty,max=1000"`        // Value of the attribute. May only be omitted when mutability is set to 'WriteOnly'.
+	Mutability Mutability `json:"mutability,omitempty" validate:"omitempty,mutability"` // Defines the mutability of this attribute. Default is ReadWrite when omitted.
+	Persistent bool       `json:"persistent,omitempty"`                                 // If true, value will be persistent across system reboots or power down. Default when omitted is false.
+	Constant   bool       `json:"constant,omitempty"`                                   // If true, value that will never be changed by the Charging Station at runtime. Default when omitted is false.
+}
+
+// Fixed read-only parameters of a variable.
+type VariableCharacteristics struct {
+	Unit               string   `json:"unit,omitempty" validate:"omitempty,max=16"`         // Unit of the variable. When the transmitted value has a unit, this field SHALL be included.
+	DataType           DataType `json:"dataType" validate:"required,dataType"`              // Data type of this variable.
+	MinLimit           *float64 `json:"minLimit,omitempty" validate:"omitempty"`            // Minimum possible value of this variable.
+	MaxLimit           *float64 `json:"maxLimit,omitempty" validate:"omitempty"`            // Maximum possible value of this variable. When the datatype of this Variable is String, OptionList, SequenceList or MemberList, this field defines the maximum length of the (CSV) string.
+	ValuesList         string   `json:"valuesList,omitempty" validate:"omitempty,max=1000"` // A (comma separated) list of allowed values, mandatory for OptionList, SequenceList and MemberList data types.
+	SupportsMonitoring bool     `json:"supportsMonitoring"`                                 // Flag indicating if this variable supports monitoring.
+}
+
+// Class to report components, variables and variable attributes and characteristics.
+type ReportData struct {
+	Component               types.Component          `json:"component" validate:"required"`                          // Component for which a report of Variable is requested.
+	Variable                types.Variable           `json:"variable" validate:"required"`                           // Variable for which report is requested.
+	VariableAttribute       []VariableAttribute      `json:"variableAttribute" validate:"required,min=1,max=4,dive"` // Attribute data of a variable.
+	VariableCharacteristics *VariableCharacteristics `json:"variableCharacteristics,omitempty" validate:"omitempty"` // Fixed read-only parameters of a variable.
+}
+
+// The field definition of the NotifyReport request payload sent by the Charging Station to the CSMS.
+type NotifyReportRequest struct {
+	RequestID   *int            `json:"requestId,omitempty" validate:"omitempty,gte=0"` // The id of the GetReportRequest or GetBaseReportRequest that requested this report.
+	GeneratedAt *types.DateTime `json:"generatedAt" validate:"required"`                // Timestamp of the moment this message was generated at the Charging Station.
+	Tbc         bool            `json:"tbc,omitempty"`                                  // “to be continued” indicator. Indicates whether another part of the report follows in an upcoming NotifyReportRequest message. Default value when omitted is false.
+	SeqNo       int             `json:"seqNo" validate:"gte=0"`                         // Sequence number of this message. First message starts at 0.
+	ReportData  []ReportData    `json:"reportData,omitempty" validate:"omitempty,dive"` // List of ReportData.
+}
+
+// This field definition of the NotifyReport response payload, sent by the CSMS to the Charging Station in response to a NotifyReportRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyReportResponse struct {
+}
+
+// A Charging Station sends a report to the CSMS, in response to a GetBaseReportRequest or a GetReportRequest.
+// Since reports may be very large, they are split into multiple parts. Each part is sent in a separate NotifyReportRequest,
+// carrying an incremental seqNo. The tbc flag signals whether more report parts will follow.
+// The CSMS responds to each request with a NotifyReportResponse.
+type NotifyReportFeature struct{}
+
+func (f NotifyReportFeature) GetFeatureName() string {
+	return NotifyReportFeatureName
+}
+
+func (f NotifyReportFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyReportRequest{})
+}
+
+func (f NotifyReportFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyReportResponse{})
+}
+
+func (r NotifyReportRequest) GetFeatureName() string {
+	return NotifyReportFeatureName
+}
+
+func (c NotifyReportResponse) GetFeatureName() string {
+	return NotifyReportFeatureName
+}
+
+// Creates a new NotifyReportRequest, containing all required fields. Optional fields may be set afterwards.
+func NewNotifyReportRequest(generatedAt *types.DateTime, seqNo int) *NotifyReportRequest {
+	return &NotifyReportRequest{GeneratedAt: generatedAt, SeqNo: seqNo}
+}
+
+// Creates a new NotifyReportResponse, which doesn't contain any required or optional fields.
+func NewNotifyReportResponse() *NotifyReportResponse {
+	return &NotifyReportResponse{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("mutability", isValidMutability)
+	_ = types.Validate.RegisterValidation("dataType", isValidDataType)
+}
@@ -8,12 +8,26 @@ import "github.com/lorenzodonini/ocpp-go/ocpp"
 type CSMSHandler interface {
 	// OnBootNotification is called on the CSMS whenever a BootNotificationRequest is received from a charging station.
 	OnBootNotification(chargingStationID string, request *BootNotificationRequest) (confirmation *BootNotificationResponse, err error)
+	// OnHeartbeat is called on the CSMS whenever a HeartbeatRequest is received from a charging station.
+	OnHeartbeat(chargingStationID string, request *HeartbeatRequest) (confirmation *HeartbeatResponse, err error)
+	// OnNotifyReport is called on the CSMS whenever a NotifyReportRequest is received from a charging station.
+	OnNotifyReport(chargingStationID string, request *NotifyReportRequest) (confirmation *NotifyReportResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Provisioning profile.
 type ChargingStationHandler interface {
 	// OnGetBaseReport is called on a charging station whenever a GetBaseReportRequest is received from the CSMS.
 	OnGetBaseReport(request *GetBaseReportRequest) (confirmation *GetBaseReportResponse, err error)
+	// OnGetReport is called on a charging station whenever a GetReportRequest is received from the CSMS.
+	OnGetReport(request *GetReportRequest) (confirmation *GetReportResponse, err error)
+	// OnGetVariables is called on a charging station whenever a GetVariablesRequest is received from the CSMS.
+	OnGetVariables(request *GetVariablesRequest) (confirmation *GetVariablesResponse, err error)
+	// OnReset is called on a charging station whenever a ResetRequest is received from the CSMS.
+	OnReset(request *ResetRequest) (confirmation *ResetResponse, err error)
+	// OnSetNetworkProfile is called on a charging station whenever a SetNetworkProfileRequest is received from the CSMS.
+	OnSetNetworkProfile(request *SetNetworkProfileRequest) (confirmation *SetNetworkProfileResponse, err error)
+	// OnSetVariables is called on a charging station whenever a SetVariablesRequest is received from the CSMS.
+	OnSetVariables(request *SetVariablesRequest) (confirmation *SetVariablesResponse, err error)
 }
 
 const ProfileName = "provisioning"
@@ -22,5 +36,11 @@ var Profile = ocpp.NewProfile(
 	ProfileName,
 	BootNotificationFeature{},
 	GetBaseReportFeature{},
-	// SetVariables
+	GetReportFeature{},
+	GetVariablesFeature{},
+	HeartbeatFeature{},
+	NotifyReportFeature{},
+	ResetFeature{},
+	SetNetworkProfileFeature{},
+	SetVariablesFeature{},
 )
@@ -0,0 +1,59 @@
+package smartcharging
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Notify EV Charging Schedule (CS -> CSMS) --------------------
+
+const NotifyEVChargingScheduleFeatureName = "NotifyEVChargingSchedule"
+
+// The field definition of the NotifyEVChargingSchedule request payload sent by the Charging Station to the CSMS.
+type NotifyEVChargingScheduleRequest struct {
+	TimeBase         *types.DateTime        `json:"timeBase" validate:"required"`         // Periods contained in the charging profile are relative to this point in time.
+	EvseID           int                    `json:"evseId" validate:"gt=0"`               // The charging schedule contained in this notification applies to an EVSE. EvseId must be > 0.
+	ChargingSchedule types.ChargingSchedule `json:"chargingSchedule" validate:"required"` // Planned energy consumption of the EV over time. Always relative to timeBase.
+}
+
+// This field definition of the NotifyEVChargingSchedule response payload, sent by the CSMS to the Charging Station in response to a NotifyEVChargingScheduleRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyEVChargingScheduleResponse struct {
+	Status types.GenericStatus `json:"status" validate:"required,genericStatus"` // Returns whether the CSMS has been able to process the message successfully. It does not imply any approval of the charging schedule.
+}
+
+// Once an EV has received a charging schedule from the Charging Station over ISO 15118, it may compute its own
+// charging schedule and send it back to the Charging Station.
+// The Charging Station forwards this schedule to the CSMS via a NotifyEVChargingScheduleRequest,
+// to which the CSMS responds with a NotifyEVChargingScheduleResponse.
+type NotifyEVChargingScheduleFeature struct{}
+
+func (f NotifyEVChargingScheduleFeature) GetFeatureName() string {
+	return NotifyEVChargingScheduleFeatureName
+}
+
+func (f NotifyEVChargingScheduleFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyEVChargingScheduleRequest{})
+}
+
+func (f NotifyEVChargingScheduleFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyEVChargingScheduleResponse{})
+}
+
+func (r NotifyEVChargingScheduleRequest) GetFeatureName() string {
+	return NotifyEVChargingScheduleFeatureName
+}
+
+func (c NotifyEVChargingScheduleResponse) GetFeatureName() string {
+	return NotifyEVChargingScheduleFeatureName
+}
+
+// Creates a new NotifyEVChargingScheduleRequest, containing all required fields. There are no optional fields for this message.
+func NewNotifyEVChargingScheduleRequest(timeBase *types.DateTime, evseID int, chargingSchedule types.ChargingSchedule) *NotifyEVChargingScheduleRequest {
+	return &NotifyEVChargingScheduleRequest{TimeBase: timeBase, EvseID: evseID, ChargingSchedule: chargingSchedule}
+}
+
+// Creates a new NotifyEVChargingScheduleResponse, containing all required fields. There are no optional fields for this message.
+func NewNotifyEVChargingScheduleResponse(status types.GenericStatus) *NotifyEVChargingScheduleResponse {
+	return &NotifyEVChargingScheduleResponse{Status: status}
+}
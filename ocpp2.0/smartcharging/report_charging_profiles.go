@@ -0,0 +1,59 @@
+package smartcharging
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"reflect"
+)
+
+// -------------------- Report Charging Profiles (CS -> CSMS) --------------------
+
+const ReportChargingProfilesFeatureName = "ReportChargingProfiles"
+
+// The field definition of the ReportChargingProfiles request payload sent by the Charging Station to the CSMS.
+type ReportChargingProfilesRequest struct {
+	RequestID           int                           `json:"requestId" validate:"gte=0"`                                  // Id used to match the GetChargingProfilesRequest message with the resulting ReportChargingProfilesRequest messages.
+	ChargingLimitSource types.ChargingLimitSourceType `json:"chargingLimitSource" validate:"required,chargingLimitSource"` // Source that has installed this charging profile.
+	Tbc                 bool                          `json:"tbc,omitempty" validate:"omitempty"`                          // To Be Continued. Default value when omitted: false. false indicates that there are no further messages as part of this report.
+	EvseID              int                           `json:"evseId" validate:"gte=0"`                                     // The evse to which the charging profile applies. If evseId = 0, the message contains an overall limit for the Charging Station.
+	ChargingProfile     []types.ChargingProfile       `json:"chargingProfile" validate:"required,min=1,dive"`              // The charging profile as configured in the Charging Station.
+}
+
+// This field definition of the ReportChargingProfiles response payload, sent by the CSMS to the Charging Station in response to a ReportChargingProfilesRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ReportChargingProfilesResponse struct {
+}
+
+// After a CSMS has requested the installed charging profiles via a GetChargingProfilesRequest, the Charging Station
+// reports them asynchronously, by sending one or more ReportChargingProfilesRequest messages to the CSMS.
+// The CSMS responds to each of them with a ReportChargingProfilesResponse.
+type ReportChargingProfilesFeature struct{}
+
+func (f ReportChargingProfilesFeature) GetFeatureName() string {
+	return ReportChargingProfilesFeatureName
+}
+
+func (f ReportChargingProfilesFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ReportChargingProfilesRequest{})
+}
+
+func (f ReportChargingProfilesFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ReportChargingProfilesResponse{})
+}
+
+func (r ReportChargingProfilesRequest) GetFeatureName() string {
+	return ReportChargingProfilesFeatureName
+}
+
+func (c ReportChargingProfilesResponse) GetFeatureName() string {
+	return ReportChargingProfilesFeatureName
+}
+
+// Creates a new ReportChargingProfilesRequest, containing all required fields. Optional fields may be set afterwards.
+func NewReportChargingProfilesRequest(requestID int, chargingLimitSource types.ChargingLimitSourceType, evseID int, chargingProfile []types.ChargingProfile) *ReportChargingProfilesRequest {
+	return &ReportChargingProfilesRequest{RequestID: requestID, ChargingLimitSource: chargingLimitSource, EvseID: evseID, ChargingProfile: chargingProfile}
+}
+
+// Creates a new ReportChargingProfilesResponse, which doesn't contain any required or optional fields.
+func NewReportChargingProfilesResponse() *ReportChargingProfilesResponse {
+	return &ReportChargingProfilesResponse{}
+}
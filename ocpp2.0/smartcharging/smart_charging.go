@@ -9,6 +9,14 @@ import (
 type CSMSHandler interface {
 	// OnClearedChargingLimit is called on the CSMS whenever a ClearedChargingLimitRequest is received from a charging station.
 	OnClearedChargingLimit(chargingStationID string, request *ClearedChargingLimitRequest) (confirmation *ClearedChargingLimitResponse, err error)
+	// OnNotifyChargingLimit is called on the CSMS whenever a NotifyChargingLimitRequest is received from a charging station.
+	OnNotifyChargingLimit(chargingStationID string, request *NotifyChargingLimitRequest) (confirmation *NotifyChargingLimitResponse, err error)
+	// OnNotifyEVChargingNeeds is called on the CSMS whenever a NotifyEVChargingNeedsRequest is received from a charging station.
+	OnNotifyEVChargingNeeds(chargingStationID string, request *NotifyEVChargingNeedsRequest) (confirmation *NotifyEVChargingNeedsResponse, err error)
+	// OnNotifyEVChargingSchedule is called on the CSMS whenever a NotifyEVChargingScheduleRequest is received from a charging station.
+	OnNotifyEVChargingSchedule(chargingStationID string, request *NotifyEVChargingScheduleRequest) (confirmation *NotifyEVChargingScheduleResponse, err error)
+	// OnReportChargingProfiles is called on the CSMS whenever a ReportChargingProfilesRequest is received from a charging station.
+	OnReportChargingProfiles(chargingStationID string, request *ReportChargingProfilesRequest) (confirmation *ReportChargingProfilesResponse, err error)
 }
 
 // Needs to be implemented by Charging stations for handling messages part of the OCPP 2.0 Smart charging profile.
@@ -19,6 +27,8 @@ type ChargingStationHandler interface {
 	OnGetChargingProfiles(request *GetChargingProfilesRequest) (confirmation *GetChargingProfilesResponse, err error)
 	// OnGetCompositeSchedule is called on a charging station whenever a GetCompositeScheduleRequest is received from the CSMS.
 	OnGetCompositeSchedule(request *GetCompositeScheduleRequest) (confirmation *GetCompositeScheduleResponse, err error)
+	// OnSetChargingProfile is called on a charging station whenever a SetChargingProfileRequest is received from the CSMS.
+	OnSetChargingProfile(request *SetChargingProfileRequest) (confirmation *SetChargingProfileResponse, err error)
 }
 
 const ProfileName = "smartCharging"
@@ -29,4 +39,9 @@ var Profile = ocpp.NewProfile(
 	ClearedChargingLimitFeature{},
 	GetChargingProfilesFeature{},
 	GetCompositeScheduleFeature{},
+	NotifyChargingLimitFeature{},
+	NotifyEVChargingNeedsFeature{},
+	NotifyEVChargingScheduleFeature{},
+	ReportChargingProfilesFeature{},
+	SetChargingProfileFeature{},
 )
@@ -0,0 +1,57 @@
+package transactions
+
+import (
+	"reflect"
+)
+
+// -------------------- Get Transaction Status (CSMS -> CS) --------------------
+
+const GetTransactionStatusFeatureName = "GetTransactionStatus"
+
+// The field definition of the GetTransactionStatus request payload sent by the CSMS to the Charging Station.
+type GetTransactionStatusRequest struct {
+	TransactionID string `json:"transactionId,omitempty" validate:"omitempty,max=36"` // The Id of the transaction for which the status is requested.
+}
+
+// This field definition of the GetTransactionStatus response payload, sent by the Charging Station to the CSMS in response to a GetTransactionStatusRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetTransactionStatusResponse struct {
+	OngoingIndicator *bool `json:"ongoingIndicator,omitempty" validate:"omitempty"` // Whether the transaction is still ongoing.
+	MessagesInQueue  bool  `json:"messagesInQueue"`                                 // Whether there are still message to be delivered.
+}
+
+// In some scenarios a CSMS needs to know whether there are still messages for a transaction that need to be delivered.
+// The CSMS shall ask if the Charging Station has still messages in the queue for this transaction with the GetTransactionStatusRequest.
+// It MAY optionally specify a transactionId, to know if a transaction is still ongoing.
+// Upon receiving a GetTransactionStatusRequest, the Charging Station SHALL respond with a GetTransactionStatusResponse payload.
+type GetTransactionStatusFeature struct{}
+
+func (f GetTransactionStatusFeature) GetFeatureName() string {
+	return GetTransactionStatusFeatureName
+}
+
+func (f GetTransactionStatusFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetTransactionStatusRequest{})
+}
+
+func (f GetTransactionStatusFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetTransactionStatusResponse{})
+}
+
+func (r GetTransactionStatusRequest) GetFeatureName() string {
+	return GetTransactionStatusFeatureName
+}
+
+func (c GetTransactionStatusResponse) GetFeatureName() string {
+	return GetTransactionStatusFeatureName
+}
+
+// Creates a new GetTransactionStatusRequest. All fields are optional and may be set afterwards.
+func NewGetTransactionStatusRequest() *GetTransactionStatusRequest {
+	return &GetTransactionStatusRequest{}
+}
+
+// Creates a new GetTransactionStatusResponse, containing all required fields. Optional fields may be set afterwards.
+func NewGetTransactionStatusResponse(messagesInQueue bool) *GetTransactionStatusResponse {
+	return &GetTransactionStatusResponse{MessagesInQueue: messagesInQueue}
+}
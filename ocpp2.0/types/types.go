@@ -405,7 +405,7 @@ func NewChargingSchedule(chargingRateUnit ChargingRateUnitType, schedulePeriod .
 
 type ChargingProfile struct {
 	ChargingProfileId      int                        `json:"chargingProfileId" validate:"gte=0"`
-	TransactionId          int                        `json:"transactionId,omitempty"`
+	TransactionId          string                     `json:"transactionId,omitempty" validate:"omitempty,max=36"`
 	StackLevel             int                        `json:"stackLevel" validate:"gt=0"`
 	ChargingProfilePurpose ChargingProfilePurposeType `json:"chargingProfilePurpose" validate:"required,chargingProfilePurpose"`
 	ChargingProfileKind    ChargingProfileKindType    `json:"chargingProfileKind" validate:"required,chargingProfileKind"`
@@ -439,11 +439,9 @@ func isValidRemoteStartStopStatus(fl validator.FieldLevel) bool {
 
 // Meter Value
 type ReadingContext string
-type ValueFormat string
 type Measurand string
 type Phase string
 type Location string
-type UnitOfMeasure string
 
 const (
 	ReadingContextInterruptionBegin       ReadingContext = "Interruption.Begin"
@@ -454,8 +452,6 @@ const (
 	ReadingContextTransactionBegin        ReadingContext = "Transaction.Begin"
 	ReadingContextTransactionEnd          ReadingContext = "Transaction.End"
 	ReadingContextTrigger                 ReadingContext = "Trigger"
-	ValueFormatRaw                        ValueFormat    = "Raw"
-	ValueFormatSignedData                 ValueFormat    = "SignedData"
 	MeasurandCurrentExport                Measurand      = "Current.Export"
 	MeasurandCurrentImport                Measurand      = "Current.Import"
 	MeasurandCurrentOffered               Measurand      = "Current.Offered"
@@ -493,22 +489,26 @@ const (
 	LocationEV                            Location       = "EV"
 	LocationInlet                         Location       = "Inlet"
 	LocationOutlet                        Location       = "Outlet"
-	UnitOfMeasureWh                       UnitOfMeasure  = "Wh"
-	UnitOfMeasureKWh                      UnitOfMeasure  = "kWh"
-	UnitOfMeasureVarh                     UnitOfMeasure  = "varh"
-	UnitOfMeasureKvarh                    UnitOfMeasure  = "kvarh"
-	UnitOfMeasureW                        UnitOfMeasure  = "W"
-	UnitOfMeasureKW                       UnitOfMeasure  = "kW"
-	UnitOfMeasureVA                       UnitOfMeasure  = "VA"
-	UnitOfMeasureKVA                      UnitOfMeasure  = "kVA"
-	UnitOfMeasureVar                      UnitOfMeasure  = "var"
-	UnitOfMeasureKvar                     UnitOfMeasure  = "kvar"
-	UnitOfMeasureA                        UnitOfMeasure  = "A"
-	UnitOfMeasureV                        UnitOfMeasure  = "V"
-	UnitOfMeasureCelsius                  UnitOfMeasure  = "Celsius"
-	UnitOfMeasureFahrenheit               UnitOfMeasure  = "Fahrenheit"
-	UnitOfMeasureK                        UnitOfMeasure  = "K"
-	UnitOfMeasurePercent                  UnitOfMeasure  = "Percent"
+)
+
+// Standardized units of measurement. The list is not exhaustive, see OCPP 2.0 Part 2 - Appendices for all allowed values.
+const (
+	UnitOfMeasureWh         = "Wh"
+	UnitOfMeasureKWh        = "kWh"
+	UnitOfMeasureVarh       = "varh"
+	UnitOfMeasureKvarh      = "kvarh"
+	UnitOfMeasureW          = "W"
+	UnitOfMeasureKW         = "kW"
+	UnitOfMeasureVA         = "VA"
+	UnitOfMeasureKVA        = "kVA"
+	UnitOfMeasureVar        = "var"
+	UnitOfMeasureKvar       = "kvar"
+	UnitOfMeasureA          = "A"
+	UnitOfMeasureV          = "V"
+	UnitOfMeasureCelsius    = "Celsius"
+	UnitOfMeasureFahrenheit = "Fahrenheit"
+	UnitOfMeasureK          = "K"
+	UnitOfMeasurePercent    = "Percent"
 )
 
 func isValidReadingContext(fl validator.FieldLevel) bool {
@@ -521,16 +521,6 @@ func isValidReadingContext(fl validator.FieldLevel) bool {
 	}
 }
 
-func isValidValueFormat(fl validator.FieldLevel) bool {
-	valueFormat := ValueFormat(fl.Field().String())
-	switch valueFormat {
-	case ValueFormatRaw, ValueFormatSignedData:
-		return true
-	default:
-		return false
-	}
-}
-
 func isValidMeasurand(fl validator.FieldLevel) bool {
 	measurand := Measurand(fl.Field().String())
 	switch measurand {
@@ -561,24 +551,34 @@ func isValidLocation(fl validator.FieldLevel) bool {
 	}
 }
 
-func isValidUnitOfMeasure(fl validator.FieldLevel) bool {
-	unitOfMeasure := UnitOfMeasure(fl.Field().String())
-	switch unitOfMeasure {
-	case UnitOfMeasureA, UnitOfMeasureWh, UnitOfMeasureKWh, UnitOfMeasureVarh, UnitOfMeasureKvarh, UnitOfMeasureW, UnitOfMeasureKW, UnitOfMeasureVA, UnitOfMeasureKVA, UnitOfMeasureVar, UnitOfMeasureKvar, UnitOfMeasureV, UnitOfMeasureCelsius, UnitOfMeasureFahrenheit, UnitOfMeasureK, UnitOfMeasurePercent:
-		return true
-	default:
-		return false
-	}
+// Represents a signed version of the meter value.
+type SignedMeterValue struct {
+	SignedMeterData string `json:"signedMeterData" validate:"required,max=2500"` // Base64 encoded, contains the signed data which might contain more then just the meter value. It can contain information like timestamps, reference to a customer etc.
+	SigningMethod   string `json:"signingMethod" validate:"required,max=50"`     // Method used to create the digital signature.
+	EncodingMethod  string `json:"encodingMethod" validate:"required,max=50"`    // Method used to encode the meter values before applying the digital signature algorithm.
+	PublicKey       string `json:"publicKey" validate:"required,max=2500"`       // Base64 encoded, sending depends on configuration variable PublicKeyWithSignedMeterValue.
+}
+
+// Represents a UnitOfMeasure with a multiplier.
+type UnitOfMeasure struct {
+	Unit       string `json:"unit,omitempty" validate:"omitempty,max=20"` // Unit of the value. Default = "Wh" if the (default) measurand is an "Energy" type.
+	Multiplier *int   `json:"multiplier,omitempty" validate:"omitempty"`  // Multiplier, this value represents the exponent to base 10. I.e. multiplier 3 means 10 raised to the 3rd power. Default is 0.
+}
+
+// Creates a new UnitOfMeasure struct. The multiplier is optional and may be set afterwards.
+func NewUnitOfMeasure(unit string) *UnitOfMeasure {
+	return &UnitOfMeasure{Unit: unit}
 }
 
+// Single sampled value in MeterValues. Each value can be accompanied by optional fields.
 type SampledValue struct {
-	Value     string         `json:"value" validate:"required"`
-	Context   ReadingContext `json:"context,omitempty" validate:"omitempty,readingContext"`
-	Format    ValueFormat    `json:"format,omitempty" validate:"omitempty,valueFormat"`
-	Measurand Measurand      `json:"measurand,omitempty" validate:"omitempty,measurand"`
-	Phase     Phase          `json:"phase,omitempty" validate:"omitempty,phase"`
-	Location  Location       `json:"location,omitempty" validate:"omitempty,location"`
-	Unit      UnitOfMeasure  `json:"unit,omitempty" validate:"omitempty,unitOfMeasure"`
+	Value            float64           `json:"value"`                                                 // Value as a “Raw” (decimal) number or “SignedData”.
+	Context          ReadingContext    `json:"context,omitempty" validate:"omitempty,readingContext"` // Type of detail value: start, end or sample. Default = "Sample.Periodic"
+	Measurand        Measurand         `json:"measurand,omitempty" validate:"omitempty,measurand"`    // Type of measurement. Default = "Energy.Active.Import.Register"
+	Phase            Phase             `json:"phase,omitempty" validate:"omitempty,phase"`            // Indicates how the measured value is to be interpreted.
+	Location         Location          `json:"location,omitempty" validate:"omitempty,location"`      // Indicates where the measured value has been sampled. Default = "Outlet"
+	SignedMeterValue *SignedMeterValue `json:"signedMeterValue,omitempty" validate:"omitempty"`       // Contains the MeterValueSignature with sign/encoding method information.
+	UnitOfMeasure    *UnitOfMeasure    `json:"unitOfMeasure,omitempty" validate:"omitempty"`          // Represents a UnitOfMeasure including a multiplier.
 }
 
 type MeterValue struct {
@@ -605,11 +605,9 @@ func init() {
 	_ = Validate.RegisterValidation("chargingLimitSource", isValidChargingLimitSource)
 	_ = Validate.RegisterValidation("remoteStartStopStatus", isValidRemoteStartStopStatus)
 	_ = Validate.RegisterValidation("readingContext", isValidReadingContext)
-	_ = Validate.RegisterValidation("valueFormat", isValidValueFormat)
 	_ = Validate.RegisterValidation("measurand", isValidMeasurand)
 	_ = Validate.RegisterValidation("phase", isValidPhase)
 	_ = Validate.RegisterValidation("location", isValidLocation)
-	_ = Validate.RegisterValidation("unitOfMeasure", isValidUnitOfMeasure)
 	_ = Validate.RegisterValidation("certificateSigningUse", isValidCertificateSigningUse)
 	_ = Validate.RegisterValidation("certificateUse", isValidCertificateUse)
 	_ = Validate.RegisterValidation("15118EVCertificate", isValidCertificate15118EVStatus)
@@ -2,7 +2,9 @@
 package ocpp2
 
 import (
+	"context"
 	"crypto/tls"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -71,6 +73,42 @@ type ChargingStation interface {
 	Get15118EVCertificate(schemaVersion string, exiRequest string, props ...func(request *iso15118.Get15118EVCertificateRequest)) (*iso15118.Get15118EVCertificateResponse, error)
 	// Requests the CSMS to provide OCSP certificate status for the charging station's 15118 certificates.
 	GetCertificateStatus(ocspRequestData types.OCSPRequestDataType, props ...func(request *iso15118.GetCertificateStatusRequest)) (*iso15118.GetCertificateStatusResponse, error)
+	// Notifies the CSMS that the charging station is still connected. The returned current time may be used by the charging station to synchronize its internal clock.
+	Heartbeat(props ...func(request *provisioning.HeartbeatRequest)) (*provisioning.HeartbeatResponse, error)
+	// Notifies the CSMS about the status of a log upload.
+	LogStatusNotification(status diagnostics.UploadLogStatus, props ...func(request *diagnostics.LogStatusNotificationRequest)) (*diagnostics.LogStatusNotificationResponse, error)
+	// Sends a set of meter values, sampled on the given EVSE, to the CSMS. Meter values related to a transaction should be sent via TransactionEvent instead.
+	MeterValues(evseID int, meterValues []types.MeterValue, props ...func(request *meter.MeterValuesRequest)) (*meter.MeterValuesResponse, error)
+	// Notifies the CSMS about a charging limit, imposed on the charging station by an external system.
+	NotifyChargingLimit(chargingLimit smartcharging.ChargingLimit, props ...func(request *smartcharging.NotifyChargingLimitRequest)) (*smartcharging.NotifyChargingLimitResponse, error)
+	// Sends (part of) the customer information, previously requested by the CSMS, to the CSMS.
+	NotifyCustomerInformation(data string, seqNo int, generatedAt *types.DateTime, requestID int, props ...func(request *diagnostics.NotifyCustomerInformationRequest)) (*diagnostics.NotifyCustomerInformationResponse, error)
+	// Sends to the CSMS the display messages currently configured on a charging station, as requested by a previous GetDisplayMessagesRequest.
+	NotifyDisplayMessages(requestID int, props ...func(request *display.NotifyDisplayMessagesRequest)) (*display.NotifyDisplayMessagesResponse, error)
+	// Forwards the charging needs of an EV, received over ISO 15118, to the CSMS.
+	NotifyEVChargingNeeds(evseID int, chargingNeeds smartcharging.ChargingNeeds, props ...func(request *smartcharging.NotifyEVChargingNeedsRequest)) (*smartcharging.NotifyEVChargingNeedsResponse, error)
+	// Forwards the charging schedule computed by an EV, received over ISO 15118, to the CSMS.
+	NotifyEVChargingSchedule(timeBase *types.DateTime, evseID int, schedule types.ChargingSchedule, props ...func(request *smartcharging.NotifyEVChargingScheduleRequest)) (*smartcharging.NotifyEVChargingScheduleResponse, error)
+	// Notifies the CSMS about monitoring events that occurred on the charging station.
+	NotifyEvent(generatedAt *types.DateTime, seqNo int, eventData []diagnostics.EventData, props ...func(request *diagnostics.NotifyEventRequest)) (*diagnostics.NotifyEventResponse, error)
+	// Sends (part of) a monitoring report, previously requested by the CSMS, to the CSMS.
+	NotifyMonitoringReport(requestID int, seqNo int, generatedAt *types.DateTime, monitorData []diagnostics.MonitoringData, props ...func(request *diagnostics.NotifyMonitoringReportRequest)) (*diagnostics.NotifyMonitoringReportResponse, error)
+	// Sends a part of a report, requested via GetReportRequest or GetBaseReportRequest, to the CSMS.
+	NotifyReport(generatedAt *types.DateTime, seqNo int, props ...func(request *provisioning.NotifyReportRequest)) (*provisioning.NotifyReportResponse, error)
+	// Notifies the CSMS about the progress of a firmware publishing procedure, performed by a local controller.
+	PublishFirmwareStatusNotification(status firmware.PublishFirmwareStatus, props ...func(request *firmware.PublishFirmwareStatusNotificationRequest)) (*firmware.PublishFirmwareStatusNotificationResponse, error)
+	// Reports the charging profiles installed on the charging station, as requested previously by the CSMS.
+	ReportChargingProfiles(requestID int, chargingLimitSource types.ChargingLimitSourceType, evseID int, chargingProfile []types.ChargingProfile, props ...func(request *smartcharging.ReportChargingProfilesRequest)) (*smartcharging.ReportChargingProfilesResponse, error)
+	// Informs the CSMS that a reservation was terminated, because it expired or was removed.
+	ReservationStatusUpdate(reservationID int, status reservation.ReservationUpdateStatus, props ...func(request *reservation.ReservationStatusUpdateRequest)) (*reservation.ReservationStatusUpdateResponse, error)
+	// Notifies the CSMS about a critical security event that occurred on the charging station.
+	SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationResponse, error)
+	// Requests the CSMS to issue a new certificate, by sending a Certificate Signing Request.
+	SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateResponse, error)
+	// Notifies the CSMS about a connector status change.
+	StatusNotification(timestamp *types.DateTime, status availability.ConnectorStatus, evseID int, connectorID int, props ...func(request *availability.StatusNotificationRequest)) (*availability.StatusNotificationResponse, error)
+	// Sends information to the CSMS about a transaction, used for billing purposes.
+	TransactionEvent(t transactions.TransactionEvent, timestamp *types.DateTime, reason transactions.TriggerReason, seqNo int, info transactions.Transaction, props ...func(request *transactions.TransactionEventRequest)) (*transactions.TransactionEventResponse, error)
 
 	// Registers a handler for incoming security profile messages
 	SetSecurityHandler(handler security.ChargingStationHandler)
@@ -110,6 +148,13 @@ type ChargingStation interface {
 	//
 	// The request is synchronous blocking.
 	SendRequest(request ocpp.Request) (ocpp.Response, error)
+	// Sends a request to the CSMS, like SendRequest.
+	// If the context is done before a response is received, ctx.Err() is returned right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	//
+	// Typed helpers such as BootNotification may be replaced by passing the respective request to this function, e.g.:
+	//	response, err := chargingStation.SendRequestWithContext(ctx, provisioning.NewBootNotificationRequest(provisioning.BootReasonPowerUp, "model1", "vendor1"))
+	SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error)
 	// Sends an asynchronous request to the CSMS.
 	// The CSMS will respond with a confirmation message, or with an error if the request was invalid or could not be processed.
 	// This result is propagated via a callback, called asynchronously.
@@ -155,6 +200,9 @@ type ChargingStation interface {
 //
 // For more advanced options, or if a custom networking/occpj layer is required,
 // please refer to ocppj.Client and ws.WsClient.
+//
+// Requests that can't be written to the network are canceled right away. To retransmit them instead,
+// pass an endpoint whose ocppj.DefaultClientDispatcher has a retry policy (see SetRetryPolicy).
 func NewChargingStation(id string, endpoint *ocppj.Client, client ws.WsClient) ChargingStation {
 	if client == nil {
 		client = ws.NewClient()
@@ -172,7 +220,7 @@ func NewChargingStation(id string, endpoint *ocppj.Client, client ws.WsClient) C
 			dialer.Subprotocols = append(dialer.Subprotocols, types.V2Subprotocol)
 		}
 	})
-	cs := chargingStation{responseHandler: make(chan ocpp.Response, 1), errorHandler: make(chan error, 1), callbacks: callbackqueue.New()}
+	cs := chargingStation{responseHandler: make(chan receivedResponse, 1), errorHandler: make(chan *ocpp.Error, 1), callbacks: callbackqueue.New()}
 
 	if endpoint == nil {
 		dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
@@ -184,7 +232,7 @@ func NewChargingStation(id string, endpoint *ocppj.Client, client ws.WsClient) C
 	cs.client = endpoint
 
 	cs.client.SetResponseHandler(func(confirmation ocpp.Response, requestId string) {
-		cs.responseHandler <- confirmation
+		cs.responseHandler <- receivedResponse{requestId: requestId, response: confirmation}
 	})
 	cs.client.SetErrorHandler(func(err *ocpp.Error, details interface{}) {
 		cs.errorHandler <- err
@@ -255,19 +303,51 @@ type CSMS interface {
 	GetLog(clientId string, callback func(*diagnostics.GetLogResponse, error), logType diagnostics.LogType, requestID int, logParameters diagnostics.LogParameters, props ...func(*diagnostics.GetLogRequest)) error
 	// Requests a report about configured monitoring settings per component and variable from a charging station. The reports will be uploaded asynchronously using NotifyMonitoringReport messages.
 	GetMonitoringReport(clientId string, callback func(*diagnostics.GetMonitoringReportResponse, error), props ...func(*diagnostics.GetMonitoringReportRequest)) error
-	//GetConfiguration(clientId string, callback func(*GetConfigurationConfirmation, error), keys []string, props ...func(*GetConfigurationRequest)) error
-	//RemoteStartTransaction(clientId string, callback func(*RemoteStartTransactionConfirmation, error), idTag string, props ...func(*RemoteStartTransactionRequest)) error
-	//RemoteStopTransaction(clientId string, callback func(*RemoteStopTransactionConfirmation, error), transactionId int, props ...func(request *RemoteStopTransactionRequest)) error
-	//Reset(clientId string, callback func(*ResetConfirmation, error), resetType ResetType, props ...func(*ResetRequest)) error
-	//UnlockConnector(clientId string, callback func(*UnlockConnectorConfirmation, error), connectorId int, props ...func(*UnlockConnectorRequest)) error
+	// Requests a report from a charging station. The charging station will asynchronously send the report in chunks using NotifyReportRequest messages.
+	GetReport(clientId string, callback func(*provisioning.GetReportResponse, error), props ...func(*provisioning.GetReportRequest)) error
+	// Requests the status of a transaction from a charging station. The charging station will report whether it still has transaction-related messages queued, to be delivered to the CSMS.
+	GetTransactionStatus(clientId string, callback func(*transactions.GetTransactionStatusResponse, error), props ...func(*transactions.GetTransactionStatusRequest)) error
+	// Requests the values of one or more variables from a charging station.
+	GetVariables(clientId string, callback func(*provisioning.GetVariablesResponse, error), variableData []provisioning.GetVariableData, props ...func(*provisioning.GetVariablesRequest)) error
+	// Installs a new certificate (chain), signed by the CA, on the charging station.
+	InstallCertificate(clientId string, callback func(*iso15118.InstallCertificateResponse, error), certificateType types.CertificateUse, certificate string, props ...func(*iso15118.InstallCertificateRequest)) error
+	// Instructs a local controller to download and publish a firmware update, so that connected charging stations may retrieve it locally.
+	PublishFirmware(clientId string, callback func(*firmware.PublishFirmwareResponse, error), location string, checksum string, requestID int, props ...func(*firmware.PublishFirmwareRequest)) error
+	// Asks a charging station to start a transaction, on behalf of a user (e.g. via app or CSO help-desk). The transaction may be associated to a remote start ID, which allows the CSMS to correlate it with the following TransactionEvent.
+	RequestStartTransaction(clientId string, callback func(*remotecontrol.RequestStartTransactionResponse, error), remoteStartID int, idToken types.IdToken, props ...func(*remotecontrol.RequestStartTransactionRequest)) error
+	// Asks a charging station to stop an ongoing transaction, identified by the given transactionID.
+	RequestStopTransaction(clientId string, callback func(*remotecontrol.RequestStopTransactionResponse, error), transactionID string, props ...func(*remotecontrol.RequestStopTransactionRequest)) error
+	// Instructs the charging station to reserve an EVSE (or a connector type) for use by a specific user, until the given expiry time.
+	ReserveNow(clientId string, callback func(*reservation.ReserveNowResponse, error), id int, expiryDateTime *types.DateTime, idToken types.IdToken, props ...func(*reservation.ReserveNowRequest)) error
+	// Asks a charging station to reset itself, or a specific EVSE.
+	Reset(clientId string, callback func(*provisioning.ResetResponse, error), t provisioning.ResetType, props ...func(*provisioning.ResetRequest)) error
+	// Sends a local authorization list to a charging station, which can be used for the authorization of idTokens.
+	SendLocalList(clientId string, callback func(*localauth.SendLocalListResponse, error), versionNumber int, updateType localauth.UpdateType, props ...func(*localauth.SendLocalListRequest)) error
+	// Installs a charging profile on a charging station, to influence the power or current drawn by an EVSE or the charging station as a whole.
+	SetChargingProfile(clientId string, callback func(*smartcharging.SetChargingProfileResponse, error), evseID int, chargingProfile *types.ChargingProfile, props ...func(*smartcharging.SetChargingProfileRequest)) error
+	// Sends a message to a charging station, to be shown on one of its displays.
+	SetDisplayMessage(clientId string, callback func(*display.SetDisplayMessageResponse, error), message display.MessageInfo, props ...func(*display.SetDisplayMessageRequest)) error
+	// Activates a set of preconfigured monitoring settings on a charging station.
+	SetMonitoringBase(clientId string, callback func(*diagnostics.SetMonitoringBaseResponse, error), monitoringBase diagnostics.MonitoringBase, props ...func(*diagnostics.SetMonitoringBaseRequest)) error
+	// Restricts the reporting of monitoring events on a charging station to those with a severity lower than or equal to the given one.
+	SetMonitoringLevel(clientId string, callback func(*diagnostics.SetMonitoringLevelResponse, error), severity int, props ...func(*diagnostics.SetMonitoringLevelRequest)) error
+	// Updates the connection details on a charging station, for the given configuration slot.
+	SetNetworkProfile(clientId string, callback func(*provisioning.SetNetworkProfileResponse, error), configurationSlot int, connectionData provisioning.NetworkConnectionProfile, props ...func(*provisioning.SetNetworkProfileRequest)) error
+	// Sets monitoring triggers on one or more variables of a charging station.
+	SetVariableMonitoring(clientId string, callback func(*diagnostics.SetVariableMonitoringResponse, error), data []diagnostics.SetMonitoringData, props ...func(*diagnostics.SetVariableMonitoringRequest)) error
+	// Sets the values of one or more variables on a charging station.
+	SetVariables(clientId string, callback func(*provisioning.SetVariablesResponse, error), variableData []provisioning.SetVariableData, props ...func(*provisioning.SetVariablesRequest)) error
+	// Requests a charging station to send a charging station-initiated message.
+	TriggerMessage(clientId string, callback func(*remotecontrol.TriggerMessageResponse, error), requestedMessage remotecontrol.MessageTrigger, props ...func(*remotecontrol.TriggerMessageRequest)) error
+	// Instructs a charging station to unlock a connector, to help out a user, whose cable could not be unplugged.
+	UnlockConnector(clientId string, callback func(*remotecontrol.UnlockConnectorResponse, error), evseID int, connectorID int, props ...func(*remotecontrol.UnlockConnectorRequest)) error
+	// Instructs a local controller to stop publishing a previously published firmware update.
+	UnpublishFirmware(clientId string, callback func(*firmware.UnpublishFirmwareResponse, error), checksum string, props ...func(*firmware.UnpublishFirmwareRequest)) error
+	// Instructs a charging station to download and install a new firmware version.
+	UpdateFirmware(clientId string, callback func(*firmware.UpdateFirmwareResponse, error), requestID int, fw firmware.Firmware, props ...func(*firmware.UpdateFirmwareRequest)) error
 	//GetLocalListVersion(clientId string, callback func(*GetLocalListVersionResponse, error), props ...func(request *GetLocalListVersionRequest)) error
-	//SendLocalList(clientId string, callback func(*SendLocalListConfirmation, error), version int, updateType UpdateType, props ...func(request *SendLocalListRequest)) error
 	//GetDiagnostics(clientId string, callback func(*GetDiagnosticsConfirmation, error), location string, props ...func(request *GetDiagnosticsRequest)) error
-	//UpdateFirmware(clientId string, callback func(*UpdateFirmwareConfirmation, error), location string, retrieveDate *DateTime, props ...func(request *UpdateFirmwareRequest)) error
-	//ReserveNow(clientId string, callback func(*ReserveNowConfirmation, error), connectorId int, expiryDate *DateTime, idTag string, reservationId int, props ...func(request *ReserveNowRequest)) error
 	//CancelReservation(clientId string, callback func(*CancelReservationResponse, error), reservationId int, props ...func(request *CancelReservationRequest)) error
-	//TriggerMessage(clientId string, callback func(*TriggerMessageConfirmation, error), requestedMessage MessageTrigger, props ...func(request *TriggerMessageRequest)) error
-	//SetChargingProfile(clientId string, callback func(*SetChargingProfileConfirmation, error), connectorId int, chargingProfile *ChargingProfile, props ...func(request *SetChargingProfileRequest)) error
 	//GetCompositeSchedule(clientId string, callback func(*GetCompositeScheduleResponse, error), connectorId int, duration int, props ...func(request *GetCompositeScheduleRequest)) error
 
 	// Registers a handler for incoming security profile messages.
@@ -306,11 +386,24 @@ type CSMS interface {
 	SetNewChargingStationHandler(handler ChargingStationConnectionHandler)
 	// Registers a handler for Charging station disconnections.
 	SetChargingStationDisconnectedHandler(handler ChargingStationConnectionHandler)
+	// Sets the default timeout for responses to requests sent to charging stations. A timeout of zero disables the timeout, which is the default.
+	// If a charging station doesn't respond in time, the request is discarded and its callback is invoked with a GenericError.
+	//
+	// This function must be called before starting the CSMS. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetRequestTimeout(timeout time.Duration) error
+	// Sets the timeout for responses to requests of a specific feature (e.g. a slow firmware update), overriding the default request timeout for that feature only.
+	//
+	// This function must be called before starting the CSMS. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetFeatureRequestTimeout(featureName string, timeout time.Duration) error
 	// Sends an asynchronous request to a Charging Station, identified by the clientId.
 	// The charging station will respond with a confirmation message, or with an error if the request was invalid or could not be processed.
 	// This result is propagated via a callback, called asynchronously.
 	// In case of network issues (i.e. the remote host couldn't be reached), the function returns an error directly. In this case, the callback is never invoked.
 	SendRequestAsync(clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
+	// Sends an asynchronous request to a Charging Station, like SendRequestAsync.
+	// If the context is done before a response is received, the callback is invoked with ctx.Err() right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	SendRequestAsyncWithContext(ctx context.Context, clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
 	// Starts running the CSMS on the specified port and URL.
 	// The central system runs as a daemon and handles incoming charge point connections and messages.
 
@@ -349,5 +442,7 @@ func NewCSMS(endpoint *ocppj.Server, server ws.WsServer) CSMS {
 	cs.server.SetErrorHandler(func(client ws.Channel, err *ocpp.Error, details interface{}) {
 		cs.handleIncomingError(client, err, details)
 	})
+	// Callback invoked by dispatcher, whenever a queued request is canceled, due to timeout.
+	cs.server.SetOnRequestCanceled(ocppj.NewRequestTimeoutHandler(cs.callbackQueue.DequeueRequest, cs.error))
 	return &cs
 }
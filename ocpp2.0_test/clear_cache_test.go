@@ -1,8 +1,13 @@
 package ocpp2_test
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
 	"github.com/lorenzodonini/ocpp-go/ocpp2.0/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -59,9 +64,79 @@ func (suite *OcppV2TestSuite) TestClearCacheE2EMocked() {
 	assert.True(t, result)
 }
 
+func (suite *OcppV2TestSuite) TestClearCacheTimeout() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, authorization.ClearCacheFeatureName)
+	channel := NewMockWebSocket(wsId)
+	// The charging station never receives the request, hence never responds
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, forwardWrittenMessage: true})
+	err := suite.csms.SetRequestTimeout(500 * time.Millisecond)
+	require.NoError(t, err)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err = suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan error, 1)
+	err = suite.csms.ClearCache(wsId, func(confirmation *authorization.ClearCacheResponse, err error) {
+		assert.Nil(t, confirmation)
+		resultChannel <- err
+	})
+	require.Nil(t, err)
+	select {
+	case err = <-resultChannel:
+		require.Error(t, err)
+		ocppErr, ok := err.(*ocpp.Error)
+		require.True(t, ok)
+		assert.Equal(t, ocppj.GenericError, ocppErr.Code)
+		assert.Equal(t, messageId, ocppErr.MessageId)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "request didn't time out")
+	}
+}
+
 func (suite *OcppV2TestSuite) TestClearCacheInvalidEndpoint() {
 	messageId := defaultMessageId
 	clearCacheRequest := authorization.NewClearCacheRequest()
 	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, authorization.ClearCacheFeatureName)
 	testUnsupportedRequestFromChargingStation(suite, clearCacheRequest, requestJson, messageId)
 }
+
+func (suite *OcppV2TestSuite) TestClearCacheContextCanceled() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, authorization.ClearCacheFeatureName)
+	channel := NewMockWebSocket(wsId)
+	// The charging station never receives the request, hence never responds
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	resultChannel := make(chan error, 2)
+	err = suite.csms.SendRequestAsyncWithContext(ctx, wsId, authorization.NewClearCacheRequest(), func(response ocpp.Response, err error) {
+		assert.Nil(t, response)
+		resultChannel <- err
+	})
+	require.Nil(t, err)
+	cancel()
+	select {
+	case err = <-resultChannel:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "request wasn't canceled")
+	}
+	// The callback is invoked only once
+	select {
+	case err = <-resultChannel:
+		assert.Fail(t, "unexpected callback invocation", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -2,6 +2,7 @@ package ocpp2_test
 
 import (
 	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,14 @@ func newFloat(f float64) *float64 {
 	return &f
 }
 
+func newBool(b bool) *bool {
+	return &b
+}
+
+func newLongString(length int) string {
+	return strings.Repeat(".", length)
+}
+
 // Test
 func (suite *OcppV2TestSuite) TestIdTokenInfoValidation() {
 	var testTable = []GenericTestEntry{
@@ -103,7 +112,7 @@ func (suite *OcppV2TestSuite) TestChargingProfileValidation() {
 	t := suite.T()
 	chargingSchedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 10.0), types.NewChargingSchedulePeriod(100, 8.0))
 	var testTable = []GenericTestEntry{
-		{types.ChargingProfile{ChargingProfileId: 1, TransactionId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, RecurrencyKind: types.RecurrencyKindDaily, ValidFrom: types.NewDateTime(time.Now()), ValidTo: types.NewDateTime(time.Now().Add(8 * time.Hour)), ChargingSchedule: chargingSchedule}, true},
+		{types.ChargingProfile{ChargingProfileId: 1, TransactionId: "1234", StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, RecurrencyKind: types.RecurrencyKindDaily, ValidFrom: types.NewDateTime(time.Now()), ValidTo: types.NewDateTime(time.Now().Add(8 * time.Hour)), ChargingSchedule: chargingSchedule}, true},
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, ChargingSchedule: chargingSchedule}, true},
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute}, false},
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingSchedule: chargingSchedule}, false},
@@ -115,37 +124,66 @@ func (suite *OcppV2TestSuite) TestChargingProfileValidation() {
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 0, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, ChargingSchedule: chargingSchedule}, false},
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, RecurrencyKind: "invalidRecurrencyKind", ChargingSchedule: chargingSchedule}, false},
 		{types.ChargingProfile{ChargingProfileId: 1, StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, ChargingSchedule: types.NewChargingSchedule(types.ChargingRateUnitWatts)}, false},
+		{types.ChargingProfile{ChargingProfileId: 1, TransactionId: newLongString(37), StackLevel: 1, ChargingProfilePurpose: types.ChargingProfilePurposeChargingStationMaxProfile, ChargingProfileKind: types.ChargingProfileKindAbsolute, ChargingSchedule: chargingSchedule}, false},
 	}
 	ExecuteGenericTestTable(t, testTable)
 }
 
+func (suite *OcppV2TestSuite) TestSignedMeterValueValidation() {
+	var testTable = []GenericTestEntry{
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}, true},
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", EncodingMethod: "DLMS Message"}, false},
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", PublicKey: "0xd34dc0de"}, false},
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}, false},
+		{types.SignedMeterValue{SigningMethod: "ECDSAP256SHA256", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}, false},
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: ">50................................................", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}, false},
+		{types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", EncodingMethod: ">50................................................", PublicKey: "0xd34dc0de"}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+func (suite *OcppV2TestSuite) TestUnitOfMeasureValidation() {
+	var testTable = []GenericTestEntry{
+		{types.UnitOfMeasure{Unit: types.UnitOfMeasureKW, Multiplier: newInt(3)}, true},
+		{types.UnitOfMeasure{Unit: types.UnitOfMeasureKW, Multiplier: newInt(-3)}, true},
+		{types.UnitOfMeasure{Unit: types.UnitOfMeasureKW}, true},
+		{types.UnitOfMeasure{}, true},
+		{types.UnitOfMeasure{Unit: ">20.................."}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
 func (suite *OcppV2TestSuite) TestSampledValueValidation() {
 	t := suite.T()
+	signedMeterValue := types.SignedMeterValue{SignedMeterData: "0xdeadbeef", SigningMethod: "ECDSAP256SHA256", EncodingMethod: "DLMS Message", PublicKey: "0xd34dc0de"}
 	var testTable = []GenericTestEntry{
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd, Format: types.ValueFormatRaw, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2, Location: types.LocationBody, Unit: types.UnitOfMeasureKW}, true},
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd, Format: types.ValueFormatRaw, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2, Location: types.LocationBody}, true},
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd, Format: types.ValueFormatRaw, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2}, true},
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd, Format: types.ValueFormatRaw, Measurand: types.MeasurandPowerActiveExport}, true},
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd, Format: types.ValueFormatRaw}, true},
-		{types.SampledValue{Value: "value", Context: types.ReadingContextTransactionEnd}, true},
-		{types.SampledValue{Value: "value"}, true},
-		{types.SampledValue{Value: "value", Context: "invalidContext"}, false},
-		{types.SampledValue{Value: "value", Format: "invalidFormat"}, false},
-		{types.SampledValue{Value: "value", Measurand: "invalidMeasurand"}, false},
-		{types.SampledValue{Value: "value", Phase: "invalidPhase"}, false},
-		{types.SampledValue{Value: "value", Location: "invalidLocation"}, false},
-		{types.SampledValue{Value: "value", Unit: "invalidUnit"}, false},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2, Location: types.LocationBody, SignedMeterValue: &signedMeterValue, UnitOfMeasure: types.NewUnitOfMeasure(types.UnitOfMeasureKW)}, true},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2, Location: types.LocationBody, SignedMeterValue: &signedMeterValue}, true},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2, Location: types.LocationBody}, true},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd, Measurand: types.MeasurandPowerActiveExport, Phase: types.PhaseL2}, true},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd, Measurand: types.MeasurandPowerActiveExport}, true},
+		{types.SampledValue{Value: 3.14, Context: types.ReadingContextTransactionEnd}, true},
+		{types.SampledValue{Value: 3.14}, true},
+		{types.SampledValue{Value: -3.14}, true},
+		{types.SampledValue{}, true},
+		{types.SampledValue{Value: 3.14, Context: "invalidContext"}, false},
+		{types.SampledValue{Value: 3.14, Measurand: "invalidMeasurand"}, false},
+		{types.SampledValue{Value: 3.14, Phase: "invalidPhase"}, false},
+		{types.SampledValue{Value: 3.14, Location: "invalidLocation"}, false},
+		{types.SampledValue{Value: 3.14, SignedMeterValue: &types.SignedMeterValue{}}, false},
+		{types.SampledValue{Value: 3.14, UnitOfMeasure: types.NewUnitOfMeasure(">20..................")}, false},
 	}
 	ExecuteGenericTestTable(t, testTable)
 }
 
 func (suite *OcppV2TestSuite) TestMeterValueValidation() {
 	var testTable = []GenericTestEntry{
-		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: "value"}, {Value: "value2", Unit: types.UnitOfMeasureKW}}}, true},
-		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: "value"}}}, true},
+		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14}, {Value: 7.0, UnitOfMeasure: types.NewUnitOfMeasure(types.UnitOfMeasureKW)}}}, true},
+		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14}}}, true},
 		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{}}, false},
 		{types.MeterValue{Timestamp: types.NewDateTime(time.Now())}, false},
-		{types.MeterValue{SampledValue: []types.SampledValue{{Value: "value"}}}, false},
+		{types.MeterValue{SampledValue: []types.SampledValue{{Value: 3.14}}}, false},
+		{types.MeterValue{Timestamp: types.NewDateTime(time.Now()), SampledValue: []types.SampledValue{{Value: 3.14, Context: "invalidContext"}}}, false},
 	}
 	ExecuteGenericTestTable(suite.T(), testTable)
 }
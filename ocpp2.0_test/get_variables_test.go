@@ -0,0 +1,111 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestGetVariablesRequestValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	var requestTable = []GenericTestEntry{
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{AttributeType: provisioning.AttributeTarget, Component: component, Variable: variable}}}, true},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{Component: component, Variable: variable}}}, true},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{}}, false},
+		{provisioning.GetVariablesRequest{}, false},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{AttributeType: "invalidAttribute", Component: component, Variable: variable}}}, false},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{Variable: variable}}}, false},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{Component: component}}}, false},
+		{provisioning.GetVariablesRequest{GetVariableData: []provisioning.GetVariableData{{Component: types.Component{Name: "component1", EVSE: &types.EVSE{ID: -1}}, Variable: variable}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestGetVariablesResponseValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	var responseTable = []GenericTestEntry{
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, AttributeType: provisioning.AttributeTarget, AttributeValue: "dummyValue", Component: component, Variable: variable}}}, true},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, AttributeValue: "dummyValue", Component: component, Variable: variable}}}, true},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusUnknownVariable, Component: component, Variable: variable}}}, true},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{}}, false},
+		{provisioning.GetVariablesResponse{}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: "invalidStatus", Component: component, Variable: variable}}}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{Component: component, Variable: variable}}}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, AttributeType: "invalidAttribute", Component: component, Variable: variable}}}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, AttributeValue: newLongString(1001), Component: component, Variable: variable}}}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, Variable: variable}}}, false},
+		{provisioning.GetVariablesResponse{GetVariableResult: []provisioning.GetVariableResult{{AttributeStatus: provisioning.GetVariableStatusAccepted, Component: component}}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestGetVariablesE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	variableData := provisioning.GetVariableData{AttributeType: provisioning.AttributeTarget, Component: component, Variable: variable}
+	variableResult := provisioning.GetVariableResult{AttributeStatus: provisioning.GetVariableStatusAccepted, AttributeType: provisioning.AttributeTarget, AttributeValue: "dummyValue", Component: component, Variable: variable}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"getVariableData":[{"attributeType":"%v","component":{"name":"%v","instance":"%v","evse":{"id":%v,"connectorId":%v}},"variable":{"name":"%v","instance":"%v"}}]}]`,
+		messageId, provisioning.GetVariablesFeatureName, variableData.AttributeType, component.Name, component.Instance, component.EVSE.ID, *component.EVSE.ConnectorID, variable.Name, variable.Instance)
+	responseJson := fmt.Sprintf(`[3,"%v",{"getVariableResult":[{"attributeStatus":"%v","attributeType":"%v","attributeValue":"%v","component":{"name":"%v","instance":"%v","evse":{"id":%v,"connectorId":%v}},"variable":{"name":"%v","instance":"%v"}}]}]`,
+		messageId, variableResult.AttributeStatus, variableResult.AttributeType, variableResult.AttributeValue, component.Name, component.Instance, component.EVSE.ID, *component.EVSE.ConnectorID, variable.Name, variable.Instance)
+	getVariablesResponse := provisioning.NewGetVariablesResponse([]provisioning.GetVariableResult{variableResult})
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationProvisioningHandler{}
+	handler.On("OnGetVariables", mock.Anything).Return(getVariablesResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*provisioning.GetVariablesRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.Len(t, request.GetVariableData, 1)
+		assert.Equal(t, variableData.AttributeType, request.GetVariableData[0].AttributeType)
+		assert.Equal(t, component.Name, request.GetVariableData[0].Component.Name)
+		assert.Equal(t, component.Instance, request.GetVariableData[0].Component.Instance)
+		require.NotNil(t, request.GetVariableData[0].Component.EVSE)
+		assert.Equal(t, component.EVSE.ID, request.GetVariableData[0].Component.EVSE.ID)
+		assert.Equal(t, *component.EVSE.ConnectorID, *request.GetVariableData[0].Component.EVSE.ConnectorID)
+		assert.Equal(t, variable, request.GetVariableData[0].Variable)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.GetVariables(wsId, func(response *provisioning.GetVariablesResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		require.Len(t, response.GetVariableResult, 1)
+		result := response.GetVariableResult[0]
+		assert.Equal(t, variableResult.AttributeStatus, result.AttributeStatus)
+		assert.Equal(t, variableResult.AttributeType, result.AttributeType)
+		assert.Equal(t, variableResult.AttributeValue, result.AttributeValue)
+		assert.Equal(t, component.Name, result.Component.Name)
+		assert.Equal(t, variable, result.Variable)
+		resultChannel <- true
+	}, []provisioning.GetVariableData{variableData})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestGetVariablesInvalidEndpoint() {
+	messageId := defaultMessageId
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	request := provisioning.NewGetVariablesRequest([]provisioning.GetVariableData{{Component: component, Variable: variable}})
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"getVariableData":[{"component":{"name":"%v"},"variable":{"name":"%v"}}]}]`, messageId, provisioning.GetVariablesFeatureName, component.Name, variable.Name)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
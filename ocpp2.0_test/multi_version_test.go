@@ -0,0 +1,21 @@
+package ocpp2_test
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+)
+
+// Both protocol versions share the same validator, so importing both packages must not alter the validation rules of either.
+func (suite *OcppV2TestSuite) TestMultipleVersionsValidation() {
+	t := suite.T()
+	var testTable = []GenericTestEntry{
+		{authorization.AuthorizeResponse{IdTokenInfo: types.IdTokenInfo{Status: types.AuthorizationStatusUnknown}}, true},
+		{authorization.AuthorizeResponse{IdTokenInfo: types.IdTokenInfo{Status: types.AuthorizationStatusNoCredit}}, true},
+		{core.AuthorizeConfirmation{IdTagInfo: &types16.IdTagInfo{Status: types16.AuthorizationStatusConcurrentTx}}, true},
+		{core.AuthorizeConfirmation{IdTagInfo: &types16.IdTagInfo{Status: "Unknown"}}, false},
+		{core.AuthorizeConfirmation{IdTagInfo: &types16.IdTagInfo{Status: "NoCredit"}}, false},
+	}
+	ExecuteGenericTestTable(t, testTable)
+}
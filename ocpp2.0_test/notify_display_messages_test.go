@@ -0,0 +1,87 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/display"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyDisplayMessagesRequestValidation() {
+	t := suite.T()
+	messageInfo := display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, Message: types.MessageContent{Format: types.MessageFormatUTF8, Content: "hello world"}}
+	var requestTable = []GenericTestEntry{
+		{display.NotifyDisplayMessagesRequest{RequestID: 42, Tbc: true, MessageInfo: []display.MessageInfo{messageInfo}}, true},
+		{display.NotifyDisplayMessagesRequest{RequestID: 42, MessageInfo: []display.MessageInfo{messageInfo}}, true},
+		{display.NotifyDisplayMessagesRequest{RequestID: 42, MessageInfo: []display.MessageInfo{}}, true},
+		{display.NotifyDisplayMessagesRequest{RequestID: 42}, true},
+		{display.NotifyDisplayMessagesRequest{}, true},
+		{display.NotifyDisplayMessagesRequest{RequestID: -1}, false},
+		{display.NotifyDisplayMessagesRequest{RequestID: 42, MessageInfo: []display.MessageInfo{{ID: 42, Message: types.MessageContent{Format: types.MessageFormatUTF8, Content: "hello world"}}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyDisplayMessagesResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{display.NotifyDisplayMessagesResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyDisplayMessagesE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestID := 42
+	tbc := true
+	messageInfo := display.MessageInfo{
+		ID:       1,
+		Priority: display.MessagePriorityNormalCycle,
+		State:    display.MessageStateIdle,
+		Message:  types.MessageContent{Format: types.MessageFormatASCII, Content: "hello world"},
+	}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"tbc":%v,"messageInfo":[{"id":%v,"priority":"%v","state":"%v","message":{"format":"%v","content":"%v"}}]}]`,
+		messageId, display.NotifyDisplayMessagesFeatureName, requestID, tbc, messageInfo.ID, messageInfo.Priority, messageInfo.State, messageInfo.Message.Format, messageInfo.Message.Content)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyDisplayMessagesResponse := display.NewNotifyDisplayMessagesResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockCSMSDisplayHandler{}
+	handler.On("OnNotifyDisplayMessages", mock.AnythingOfType("string"), mock.Anything).Return(notifyDisplayMessagesResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*display.NotifyDisplayMessagesRequest)
+		require.True(t, ok)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.Equal(t, tbc, request.Tbc)
+		require.Len(t, request.MessageInfo, 1)
+		assert.Equal(t, messageInfo.ID, request.MessageInfo[0].ID)
+		assert.Equal(t, messageInfo.Priority, request.MessageInfo[0].Priority)
+		assert.Equal(t, messageInfo.State, request.MessageInfo[0].State)
+		assert.Equal(t, messageInfo.Message, request.MessageInfo[0].Message)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyDisplayMessages(requestID, func(request *display.NotifyDisplayMessagesRequest) {
+		request.Tbc = tbc
+		request.MessageInfo = []display.MessageInfo{messageInfo}
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyDisplayMessagesInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	request := display.NewNotifyDisplayMessagesRequest(requestID)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v}]`, messageId, display.NotifyDisplayMessagesFeatureName, requestID)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,89 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestNotifyEVChargingScheduleRequestValidation() {
+	t := suite.T()
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 200.0))
+	var requestTable = []GenericTestEntry{
+		{smartcharging.NotifyEVChargingScheduleRequest{TimeBase: types.NewDateTime(time.Now()), EvseID: 1, ChargingSchedule: *schedule}, true},
+		{smartcharging.NotifyEVChargingScheduleRequest{TimeBase: types.NewDateTime(time.Now()), ChargingSchedule: *schedule}, false},
+		{smartcharging.NotifyEVChargingScheduleRequest{TimeBase: types.NewDateTime(time.Now()), EvseID: 1}, false},
+		{smartcharging.NotifyEVChargingScheduleRequest{EvseID: 1, ChargingSchedule: *schedule}, false},
+		{smartcharging.NotifyEVChargingScheduleRequest{}, false},
+		{smartcharging.NotifyEVChargingScheduleRequest{TimeBase: types.NewDateTime(time.Now()), EvseID: -1, ChargingSchedule: *schedule}, false},
+		{smartcharging.NotifyEVChargingScheduleRequest{TimeBase: types.NewDateTime(time.Now()), EvseID: 1, ChargingSchedule: *types.NewChargingSchedule("invalidChargingRateUnit", types.NewChargingSchedulePeriod(0, 200.0))}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingScheduleResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{smartcharging.NotifyEVChargingScheduleResponse{Status: types.GenericStatusAccepted}, true},
+		{smartcharging.NotifyEVChargingScheduleResponse{Status: types.GenericStatusRejected}, true},
+		{smartcharging.NotifyEVChargingScheduleResponse{Status: "invalidGenericStatus"}, false},
+		{smartcharging.NotifyEVChargingScheduleResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingScheduleE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	timeBase := types.NewDateTime(time.Now())
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	status := types.GenericStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"timeBase":"%v","evseId":%v,"chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}]`,
+		messageId, smartcharging.NotifyEVChargingScheduleFeatureName, timeBase.FormatTimestamp(), evseID, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	notifyEVChargingScheduleResponse := smartcharging.NewNotifyEVChargingScheduleResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockCSMSSmartChargingHandler{}
+	handler.On("OnNotifyEVChargingSchedule", mock.AnythingOfType("string"), mock.Anything).Return(notifyEVChargingScheduleResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*smartcharging.NotifyEVChargingScheduleRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assertDateTimeEquality(t, timeBase, request.TimeBase)
+		assert.Equal(t, evseID, request.EvseID)
+		assert.Equal(t, schedule.ChargingRateUnit, request.ChargingSchedule.ChargingRateUnit)
+		require.Len(t, request.ChargingSchedule.ChargingSchedulePeriod, 1)
+		assert.Equal(t, schedulePeriod, request.ChargingSchedule.ChargingSchedulePeriod[0])
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifyEVChargingSchedule(timeBase, evseID, *schedule)
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, status, response.Status)
+}
+
+func (suite *OcppV2TestSuite) TestNotifyEVChargingScheduleInvalidEndpoint() {
+	messageId := defaultMessageId
+	timeBase := types.NewDateTime(time.Now())
+	evseID := 1
+	schedulePeriod := types.NewChargingSchedulePeriod(0, 200.0)
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, schedulePeriod)
+	request := smartcharging.NewNotifyEVChargingScheduleRequest(timeBase, evseID, *schedule)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"timeBase":"%v","evseId":%v,"chargingSchedule":{"chargingRateUnit":"%v","chargingSchedulePeriod":[{"startPeriod":%v,"limit":%v}]}}]`,
+		messageId, smartcharging.NotifyEVChargingScheduleFeatureName, timeBase.FormatTimestamp(), evseID, schedule.ChargingRateUnit, schedulePeriod.StartPeriod, schedulePeriod.Limit)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -48,6 +48,10 @@ func (websocket MockWebSocket) TLSConnectionState() *tls.ConnectionState {
 	return nil
 }
 
+func (websocket MockWebSocket) Subprotocol() string {
+	return ""
+}
+
 func NewMockWebSocket(id string) MockWebSocket {
 	return MockWebSocket{id: id}
 }
@@ -203,6 +207,18 @@ type MockCSMSSecurityHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSSecurityHandler) OnSecurityEventNotification(chargingStationID string, request *security.SecurityEventNotificationRequest) (response *security.SecurityEventNotificationResponse, err error) {
+	args := handler.MethodCalled("OnSecurityEventNotification", chargingStationID, request)
+	conf := args.Get(0).(*security.SecurityEventNotificationResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSSecurityHandler) OnSignCertificate(chargingStationID string, request *security.SignCertificateRequest) (response *security.SignCertificateResponse, err error) {
+	args := handler.MethodCalled("OnSignCertificate", chargingStationID, request)
+	conf := args.Get(0).(*security.SignCertificateResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS SECURITY HANDLER ----------------------
 
 type MockChargingStationSecurityHandler struct {
@@ -227,6 +243,18 @@ func (handler MockCSMSProvisioningHandler) OnBootNotification(chargingStationId
 	return conf, args.Error(1)
 }
 
+func (handler MockCSMSProvisioningHandler) OnHeartbeat(chargingStationID string, request *provisioning.HeartbeatRequest) (confirmation *provisioning.HeartbeatResponse, err error) {
+	args := handler.MethodCalled("OnHeartbeat", chargingStationID, request)
+	conf := args.Get(0).(*provisioning.HeartbeatResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSProvisioningHandler) OnNotifyReport(chargingStationID string, request *provisioning.NotifyReportRequest) (confirmation *provisioning.NotifyReportResponse, err error) {
+	args := handler.MethodCalled("OnNotifyReport", chargingStationID, request)
+	conf := args.Get(0).(*provisioning.NotifyReportResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS PROVISIONING HANDLER ----------------------
 
 type MockChargingStationProvisioningHandler struct {
@@ -239,6 +267,36 @@ func (handler MockChargingStationProvisioningHandler) OnGetBaseReport(request *p
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationProvisioningHandler) OnGetReport(request *provisioning.GetReportRequest) (confirmation *provisioning.GetReportResponse, err error) {
+	args := handler.MethodCalled("OnGetReport", request)
+	conf := args.Get(0).(*provisioning.GetReportResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationProvisioningHandler) OnGetVariables(request *provisioning.GetVariablesRequest) (confirmation *provisioning.GetVariablesResponse, err error) {
+	args := handler.MethodCalled("OnGetVariables", request)
+	conf := args.Get(0).(*provisioning.GetVariablesResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationProvisioningHandler) OnReset(request *provisioning.ResetRequest) (confirmation *provisioning.ResetResponse, err error) {
+	args := handler.MethodCalled("OnReset", request)
+	conf := args.Get(0).(*provisioning.ResetResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationProvisioningHandler) OnSetNetworkProfile(request *provisioning.SetNetworkProfileRequest) (confirmation *provisioning.SetNetworkProfileResponse, err error) {
+	args := handler.MethodCalled("OnSetNetworkProfile", request)
+	conf := args.Get(0).(*provisioning.SetNetworkProfileResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationProvisioningHandler) OnSetVariables(request *provisioning.SetVariablesRequest) (confirmation *provisioning.SetVariablesResponse, err error) {
+	args := handler.MethodCalled("OnSetVariables", request)
+	conf := args.Get(0).(*provisioning.SetVariablesResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS AUTHORIZATION HANDLER ----------------------
 
 type MockCSMSAuthorizationHandler struct {
@@ -275,12 +333,24 @@ func (handler MockChargingStationReservationHandler) OnCancelReservation(request
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationReservationHandler) OnReserveNow(request *reservation.ReserveNowRequest) (confirmation *reservation.ReserveNowResponse, err error) {
+	args := handler.MethodCalled("OnReserveNow", request)
+	conf := args.Get(0).(*reservation.ReserveNowResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS RESERVATION HANDLER ----------------------
 
 type MockCSMSReservationHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSReservationHandler) OnReservationStatusUpdate(chargingStationID string, request *reservation.ReservationStatusUpdateRequest) (confirmation *reservation.ReservationStatusUpdateResponse, err error) {
+	args := handler.MethodCalled("OnReservationStatusUpdate", chargingStationID, request)
+	conf := args.Get(0).(*reservation.ReservationStatusUpdateResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS AVAILABILITY HANDLER ----------------------
 
 type MockChargingStationAvailabilityHandler struct {
@@ -299,6 +369,12 @@ type MockCSMSAvailabilityHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSAvailabilityHandler) OnStatusNotification(chargingStationID string, request *availability.StatusNotificationRequest) (confirmation *availability.StatusNotificationResponse, err error) {
+	args := handler.MethodCalled("OnStatusNotification", chargingStationID, request)
+	conf := args.Get(0).(*availability.StatusNotificationResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS DATA HANDLER ----------------------
 
 type MockChargingStationDataHandler struct {
@@ -353,12 +429,54 @@ func (handler MockChargingStationDiagnosticsHandler) OnGetMonitoringReport(reque
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationDiagnosticsHandler) OnSetMonitoringBase(request *diagnostics.SetMonitoringBaseRequest) (confirmation *diagnostics.SetMonitoringBaseResponse, err error) {
+	args := handler.MethodCalled("OnSetMonitoringBase", request)
+	conf := args.Get(0).(*diagnostics.SetMonitoringBaseResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationDiagnosticsHandler) OnSetMonitoringLevel(request *diagnostics.SetMonitoringLevelRequest) (confirmation *diagnostics.SetMonitoringLevelResponse, err error) {
+	args := handler.MethodCalled("OnSetMonitoringLevel", request)
+	conf := args.Get(0).(*diagnostics.SetMonitoringLevelResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationDiagnosticsHandler) OnSetVariableMonitoring(request *diagnostics.SetVariableMonitoringRequest) (confirmation *diagnostics.SetVariableMonitoringResponse, err error) {
+	args := handler.MethodCalled("OnSetVariableMonitoring", request)
+	conf := args.Get(0).(*diagnostics.SetVariableMonitoringResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS DIAGNOSTICS HANDLER ----------------------
 
 type MockCSMSDiagnosticsHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSDiagnosticsHandler) OnLogStatusNotification(chargingStationID string, request *diagnostics.LogStatusNotificationRequest) (confirmation *diagnostics.LogStatusNotificationResponse, err error) {
+	args := handler.MethodCalled("OnLogStatusNotification", chargingStationID, request)
+	conf := args.Get(0).(*diagnostics.LogStatusNotificationResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSDiagnosticsHandler) OnNotifyCustomerInformation(chargingStationID string, request *diagnostics.NotifyCustomerInformationRequest) (confirmation *diagnostics.NotifyCustomerInformationResponse, err error) {
+	args := handler.MethodCalled("OnNotifyCustomerInformation", chargingStationID, request)
+	conf := args.Get(0).(*diagnostics.NotifyCustomerInformationResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSDiagnosticsHandler) OnNotifyEvent(chargingStationID string, request *diagnostics.NotifyEventRequest) (confirmation *diagnostics.NotifyEventResponse, err error) {
+	args := handler.MethodCalled("OnNotifyEvent", chargingStationID, request)
+	conf := args.Get(0).(*diagnostics.NotifyEventResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSDiagnosticsHandler) OnNotifyMonitoringReport(chargingStationID string, request *diagnostics.NotifyMonitoringReportRequest) (confirmation *diagnostics.NotifyMonitoringReportResponse, err error) {
+	args := handler.MethodCalled("OnNotifyMonitoringReport", chargingStationID, request)
+	conf := args.Get(0).(*diagnostics.NotifyMonitoringReportResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS DISPLAY HANDLER ----------------------
 
 type MockChargingStationDisplayHandler struct {
@@ -377,18 +495,48 @@ func (handler MockChargingStationDisplayHandler) OnGetDisplayMessages(request *d
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationDisplayHandler) OnSetDisplayMessage(request *display.SetDisplayMessageRequest) (confirmation *display.SetDisplayMessageResponse, err error) {
+	args := handler.MethodCalled("OnSetDisplayMessage", request)
+	conf := args.Get(0).(*display.SetDisplayMessageResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS DISPLAY HANDLER ----------------------
 
 type MockCSMSDisplayHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSDisplayHandler) OnNotifyDisplayMessages(chargingStationID string, request *display.NotifyDisplayMessagesRequest) (confirmation *display.NotifyDisplayMessagesResponse, err error) {
+	args := handler.MethodCalled("OnNotifyDisplayMessages", chargingStationID, request)
+	conf := args.Get(0).(*display.NotifyDisplayMessagesResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS FIRMWARE HANDLER ----------------------
 
 type MockChargingStationFirmwareHandler struct {
 	mock.Mock
 }
 
+func (handler MockChargingStationFirmwareHandler) OnPublishFirmware(request *firmware.PublishFirmwareRequest) (confirmation *firmware.PublishFirmwareResponse, err error) {
+	args := handler.MethodCalled("OnPublishFirmware", request)
+	conf := args.Get(0).(*firmware.PublishFirmwareResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationFirmwareHandler) OnUnpublishFirmware(request *firmware.UnpublishFirmwareRequest) (confirmation *firmware.UnpublishFirmwareResponse, err error) {
+	args := handler.MethodCalled("OnUnpublishFirmware", request)
+	conf := args.Get(0).(*firmware.UnpublishFirmwareResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationFirmwareHandler) OnUpdateFirmware(request *firmware.UpdateFirmwareRequest) (confirmation *firmware.UpdateFirmwareResponse, err error) {
+	args := handler.MethodCalled("OnUpdateFirmware", request)
+	conf := args.Get(0).(*firmware.UpdateFirmwareResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS FIRMWARE HANDLER ----------------------
 
 type MockCSMSFirmwareHandler struct {
@@ -401,6 +549,12 @@ func (handler MockCSMSFirmwareHandler) OnFirmwareStatusNotification(chargingStat
 	return conf, args.Error(1)
 }
 
+func (handler MockCSMSFirmwareHandler) OnPublishFirmwareStatusNotification(chargingStationID string, request *firmware.PublishFirmwareStatusNotificationRequest) (confirmation *firmware.PublishFirmwareStatusNotificationResponse, err error) {
+	args := handler.MethodCalled("OnPublishFirmwareStatusNotification", chargingStationID, request)
+	conf := args.Get(0).(*firmware.PublishFirmwareStatusNotificationResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS ISO15118 HANDLER ----------------------
 
 type MockChargingStationIso15118Handler struct {
@@ -419,6 +573,12 @@ func (handler MockChargingStationIso15118Handler) OnGetInstalledCertificateIds(r
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationIso15118Handler) OnInstallCertificate(request *iso15118.InstallCertificateRequest) (confirmation *iso15118.InstallCertificateResponse, err error) {
+	args := handler.MethodCalled("OnInstallCertificate", request)
+	conf := args.Get(0).(*iso15118.InstallCertificateResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS ISO15118 HANDLER ----------------------
 
 type MockCSMSIso15118Handler struct {
@@ -449,6 +609,12 @@ func (handler MockChargingStationLocalAuthHandler) OnGetLocalListVersion(request
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationLocalAuthHandler) OnSendLocalList(request *localauth.SendLocalListRequest) (confirmation *localauth.SendLocalListResponse, err error) {
+	args := handler.MethodCalled("OnSendLocalList", request)
+	conf := args.Get(0).(*localauth.SendLocalListResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS LOCAL AUTH HANDLER ----------------------
 
 type MockCSMSLocalAuthHandler struct {
@@ -467,12 +633,42 @@ type MockCSMSMeterHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSMeterHandler) OnMeterValues(chargingStationID string, request *meter.MeterValuesRequest) (confirmation *meter.MeterValuesResponse, err error) {
+	args := handler.MethodCalled("OnMeterValues", chargingStationID, request)
+	conf := args.Get(0).(*meter.MeterValuesResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS REMOTE CONTROL HANDLER ----------------------
 
 type MockChargingStationRemoteControlHandler struct {
 	mock.Mock
 }
 
+func (handler MockChargingStationRemoteControlHandler) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (confirmation *remotecontrol.RequestStartTransactionResponse, err error) {
+	args := handler.MethodCalled("OnRequestStartTransaction", request)
+	conf := args.Get(0).(*remotecontrol.RequestStartTransactionResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationRemoteControlHandler) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (confirmation *remotecontrol.RequestStopTransactionResponse, err error) {
+	args := handler.MethodCalled("OnRequestStopTransaction", request)
+	conf := args.Get(0).(*remotecontrol.RequestStopTransactionResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationRemoteControlHandler) OnTriggerMessage(request *remotecontrol.TriggerMessageRequest) (confirmation *remotecontrol.TriggerMessageResponse, err error) {
+	args := handler.MethodCalled("OnTriggerMessage", request)
+	conf := args.Get(0).(*remotecontrol.TriggerMessageResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockChargingStationRemoteControlHandler) OnUnlockConnector(request *remotecontrol.UnlockConnectorRequest) (confirmation *remotecontrol.UnlockConnectorResponse, err error) {
+	args := handler.MethodCalled("OnUnlockConnector", request)
+	conf := args.Get(0).(*remotecontrol.UnlockConnectorResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS REMOTE CONTROL HANDLER ----------------------
 
 type MockCSMSRemoteControlHandler struct {
@@ -503,6 +699,12 @@ func (handler MockChargingStationSmartChargingHandler) OnGetCompositeSchedule(re
 	return conf, args.Error(1)
 }
 
+func (handler MockChargingStationSmartChargingHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (confirmation *smartcharging.SetChargingProfileResponse, err error) {
+	args := handler.MethodCalled("OnSetChargingProfile", request)
+	conf := args.Get(0).(*smartcharging.SetChargingProfileResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS SMART CHARGING HANDLER ----------------------
 
 type MockCSMSSmartChargingHandler struct {
@@ -515,6 +717,30 @@ func (handler MockCSMSSmartChargingHandler) OnClearedChargingLimit(chargingStati
 	return conf, args.Error(1)
 }
 
+func (handler MockCSMSSmartChargingHandler) OnNotifyChargingLimit(chargingStationID string, request *smartcharging.NotifyChargingLimitRequest) (confirmation *smartcharging.NotifyChargingLimitResponse, err error) {
+	args := handler.MethodCalled("OnNotifyChargingLimit", chargingStationID, request)
+	conf := args.Get(0).(*smartcharging.NotifyChargingLimitResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSSmartChargingHandler) OnNotifyEVChargingNeeds(chargingStationID string, request *smartcharging.NotifyEVChargingNeedsRequest) (confirmation *smartcharging.NotifyEVChargingNeedsResponse, err error) {
+	args := handler.MethodCalled("OnNotifyEVChargingNeeds", chargingStationID, request)
+	conf := args.Get(0).(*smartcharging.NotifyEVChargingNeedsResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSSmartChargingHandler) OnNotifyEVChargingSchedule(chargingStationID string, request *smartcharging.NotifyEVChargingScheduleRequest) (confirmation *smartcharging.NotifyEVChargingScheduleResponse, err error) {
+	args := handler.MethodCalled("OnNotifyEVChargingSchedule", chargingStationID, request)
+	conf := args.Get(0).(*smartcharging.NotifyEVChargingScheduleResponse)
+	return conf, args.Error(1)
+}
+
+func (handler MockCSMSSmartChargingHandler) OnReportChargingProfiles(chargingStationID string, request *smartcharging.ReportChargingProfilesRequest) (confirmation *smartcharging.ReportChargingProfilesResponse, err error) {
+	args := handler.MethodCalled("OnReportChargingProfiles", chargingStationID, request)
+	conf := args.Get(0).(*smartcharging.ReportChargingProfilesResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CS TARIFF COST HANDLER ----------------------
 
 type MockChargingStationTariffCostHandler struct {
@@ -539,12 +765,24 @@ type MockChargingStationTransactionHandler struct {
 	mock.Mock
 }
 
+func (handler MockChargingStationTransactionHandler) OnGetTransactionStatus(request *transactions.GetTransactionStatusRequest) (confirmation *transactions.GetTransactionStatusResponse, err error) {
+	args := handler.MethodCalled("OnGetTransactionStatus", request)
+	conf := args.Get(0).(*transactions.GetTransactionStatusResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- MOCK CSMS TRANSACTIONS HANDLER ----------------------
 
 type MockCSMSTransactionsHandler struct {
 	mock.Mock
 }
 
+func (handler MockCSMSTransactionsHandler) OnTransactionEvent(chargingStationID string, request *transactions.TransactionEventRequest) (confirmation *transactions.TransactionEventResponse, err error) {
+	args := handler.MethodCalled("OnTransactionEvent", chargingStationID, request)
+	conf := args.Get(0).(*transactions.TransactionEventResponse)
+	return conf, args.Error(1)
+}
+
 // ---------------------- COMMON UTILITY METHODS ----------------------
 
 func NewWebsocketServer(t *testing.T, onMessage func(data []byte) ([]byte, error)) *ws.Server {
@@ -636,7 +874,7 @@ func setupDefaultCSMSHandlers(suite *OcppV2TestSuite, options expectedCSMSOption
 		case MockCSMSTariffCostHandler:
 			suite.csms.SetTariffCostHandler(h.(MockCSMSTariffCostHandler))
 		case MockCSMSTransactionsHandler:
-			suite.csms.SetTransactionsHandler(h.(MockChargingStationTransactionHandler))
+			suite.csms.SetTransactionsHandler(h.(MockCSMSTransactionsHandler))
 		}
 	}
 	suite.csms.SetNewChargingStationHandler(func(chargingStation ocpp2.ChargingStationConnection) {
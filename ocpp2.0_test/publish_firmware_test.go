@@ -0,0 +1,102 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestPublishFirmwareRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Retries: newInt(5), Checksum: "deadc0de", RequestID: 42, RetryInterval: newInt(300)}, true},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Retries: newInt(5), Checksum: "deadc0de", RequestID: 42}, true},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Checksum: "deadc0de", RequestID: 42}, true},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Checksum: "deadc0de"}, true},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl"}, false},
+		{firmware.PublishFirmwareRequest{Checksum: "deadc0de"}, false},
+		{firmware.PublishFirmwareRequest{}, false},
+		{firmware.PublishFirmwareRequest{Location: newLongString(513), Checksum: "deadc0de", RequestID: 42}, false},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Checksum: newLongString(33), RequestID: 42}, false},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Checksum: "deadc0de", RequestID: -1}, false},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Retries: newInt(-1), Checksum: "deadc0de", RequestID: 42}, false},
+		{firmware.PublishFirmwareRequest{Location: "https://someurl", Checksum: "deadc0de", RequestID: 42, RetryInterval: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{firmware.PublishFirmwareResponse{Status: types.GenericStatusAccepted}, true},
+		{firmware.PublishFirmwareResponse{Status: "invalidGenericStatus"}, false},
+		{firmware.PublishFirmwareResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	location := "https://someurl"
+	retries := 5
+	checksum := "deadc0de"
+	requestID := 42
+	retryInterval := 300
+	status := types.GenericStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"location":"%v","retries":%v,"checksum":"%v","requestId":%v,"retryInterval":%v}]`,
+		messageId, firmware.PublishFirmwareFeatureName, location, retries, checksum, requestID, retryInterval)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	publishFirmwareResponse := firmware.NewPublishFirmwareResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationFirmwareHandler{}
+	handler.On("OnPublishFirmware", mock.Anything).Return(publishFirmwareResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*firmware.PublishFirmwareRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, location, request.Location)
+		require.NotNil(t, request.Retries)
+		assert.Equal(t, retries, *request.Retries)
+		assert.Equal(t, checksum, request.Checksum)
+		assert.Equal(t, requestID, request.RequestID)
+		require.NotNil(t, request.RetryInterval)
+		assert.Equal(t, retryInterval, *request.RetryInterval)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.PublishFirmware(wsId, func(response *firmware.PublishFirmwareResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, location, checksum, requestID, func(request *firmware.PublishFirmwareRequest) {
+		request.Retries = &retries
+		request.RetryInterval = &retryInterval
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestPublishFirmwareInvalidEndpoint() {
+	messageId := defaultMessageId
+	location := "https://someurl"
+	checksum := "deadc0de"
+	requestID := 42
+	request := firmware.NewPublishFirmwareRequest(location, checksum, requestID)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"location":"%v","checksum":"%v","requestId":%v}]`,
+		messageId, firmware.PublishFirmwareFeatureName, location, checksum, requestID)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,102 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestRequestStartTransactionRequestValidation() {
+	t := suite.T()
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	var requestTable = []GenericTestEntry{
+		{remotecontrol.RequestStartTransactionRequest{EvseID: newInt(1), RemoteStartID: 42, IDToken: idToken, ChargingProfile: types.NewChargingProfile(1, 1, types.ChargingProfilePurposeTxProfile, types.ChargingProfileKindAbsolute, types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 200.0))), GroupIdToken: &idToken}, true},
+		{remotecontrol.RequestStartTransactionRequest{EvseID: newInt(1), RemoteStartID: 42, IDToken: idToken}, true},
+		{remotecontrol.RequestStartTransactionRequest{RemoteStartID: 42, IDToken: idToken}, true},
+		{remotecontrol.RequestStartTransactionRequest{IDToken: idToken}, true},
+		{remotecontrol.RequestStartTransactionRequest{}, false},
+		{remotecontrol.RequestStartTransactionRequest{EvseID: newInt(0), RemoteStartID: 42, IDToken: idToken}, false},
+		{remotecontrol.RequestStartTransactionRequest{RemoteStartID: -1, IDToken: idToken}, false},
+		{remotecontrol.RequestStartTransactionRequest{RemoteStartID: 42, IDToken: types.IdToken{Type: types.IdTokenTypeKeyCode}}, false},
+		{remotecontrol.RequestStartTransactionRequest{RemoteStartID: 42, IDToken: idToken, GroupIdToken: &types.IdToken{IdToken: "12345", Type: "invalidType"}}, false},
+		{remotecontrol.RequestStartTransactionRequest{RemoteStartID: 42, IDToken: idToken, ChargingProfile: &types.ChargingProfile{}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestRequestStartTransactionResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{remotecontrol.RequestStartTransactionResponse{Status: remotecontrol.RequestStartStopStatusAccepted, TransactionID: "12345"}, true},
+		{remotecontrol.RequestStartTransactionResponse{Status: remotecontrol.RequestStartStopStatusRejected}, true},
+		{remotecontrol.RequestStartTransactionResponse{}, false},
+		{remotecontrol.RequestStartTransactionResponse{Status: "invalidStatus"}, false},
+		{remotecontrol.RequestStartTransactionResponse{Status: remotecontrol.RequestStartStopStatusAccepted, TransactionID: ">36.................................."}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestRequestStartTransactionE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := newInt(1)
+	remoteStartID := 42
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	status := remotecontrol.RequestStartStopStatusAccepted
+	transactionID := "0987654321"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"remoteStartId":%v,"idToken":{"idToken":"%v","type":"%v"}}]`, messageId, remotecontrol.RequestStartTransactionFeatureName, *evseID, remoteStartID, idToken.IdToken, idToken.Type)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v","transactionId":"%v"}]`, messageId, status, transactionID)
+	requestStartTransactionResponse := remotecontrol.NewRequestStartTransactionResponse(status)
+	requestStartTransactionResponse.TransactionID = transactionID
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationRemoteControlHandler{}
+	handler.On("OnRequestStartTransaction", mock.Anything).Return(requestStartTransactionResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*remotecontrol.RequestStartTransactionRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, *evseID, *request.EvseID)
+		assert.Equal(t, remoteStartID, request.RemoteStartID)
+		assert.Equal(t, idToken.IdToken, request.IDToken.IdToken)
+		assert.Equal(t, idToken.Type, request.IDToken.Type)
+		assert.Nil(t, request.ChargingProfile)
+		assert.Nil(t, request.GroupIdToken)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.RequestStartTransaction(wsId, func(response *remotecontrol.RequestStartTransactionResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		assert.Equal(t, transactionID, response.TransactionID)
+		resultChannel <- true
+	}, remoteStartID, idToken, func(request *remotecontrol.RequestStartTransactionRequest) {
+		request.EvseID = evseID
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestRequestStartTransactionInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := newInt(1)
+	remoteStartID := 42
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	request := remotecontrol.NewRequestStartTransactionRequest(remoteStartID, idToken)
+	request.EvseID = evseID
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"remoteStartId":%v,"idToken":{"idToken":"%v","type":"%v"}}]`, messageId, remotecontrol.RequestStartTransactionFeatureName, *evseID, remoteStartID, idToken.IdToken, idToken.Type)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
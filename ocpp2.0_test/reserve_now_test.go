@@ -0,0 +1,109 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestReserveNowRequestValidation() {
+	t := suite.T()
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	var requestTable = []GenericTestEntry{
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), ConnectorType: reservation.ConnectorTypeCCS2, EvseID: newInt(1), IdToken: idToken, GroupIdToken: &idToken}, true},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), ConnectorType: reservation.ConnectorTypeCCS2, EvseID: newInt(1), IdToken: idToken}, true},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), ConnectorType: reservation.ConnectorTypeCCS2, IdToken: idToken}, true},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), IdToken: idToken}, true},
+		{reservation.ReserveNowRequest{ExpiryDateTime: types.NewDateTime(time.Now()), IdToken: idToken}, true},
+		{reservation.ReserveNowRequest{ID: 42, IdToken: idToken}, false},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now())}, false},
+		{reservation.ReserveNowRequest{ID: -1, ExpiryDateTime: types.NewDateTime(time.Now()), IdToken: idToken}, false},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), ConnectorType: "invalidConnectorType", IdToken: idToken}, false},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), EvseID: newInt(-1), IdToken: idToken}, false},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), IdToken: types.IdToken{IdToken: "12345", Type: "invalidIdTokenType"}}, false},
+		{reservation.ReserveNowRequest{ID: 42, ExpiryDateTime: types.NewDateTime(time.Now()), IdToken: idToken, GroupIdToken: &types.IdToken{IdToken: "12345", Type: "invalidIdTokenType"}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestReserveNowResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{reservation.ReserveNowResponse{Status: reservation.ReserveNowStatusAccepted}, true},
+		{reservation.ReserveNowResponse{Status: "invalidReserveNowStatus"}, false},
+		{reservation.ReserveNowResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestReserveNowE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	id := 42
+	expiryDateTime := types.NewDateTime(time.Now().Add(time.Hour))
+	connectorType := reservation.ConnectorTypeCType2
+	evseID := 1
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	groupIdToken := types.IdToken{IdToken: "group1", Type: types.IdTokenTypeCentral}
+	status := reservation.ReserveNowStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"id":%v,"expiryDateTime":"%v","connectorType":"%v","evseId":%v,"idToken":{"idToken":"%v","type":"%v"},"groupIdToken":{"idToken":"%v","type":"%v"}}]`,
+		messageId, reservation.ReserveNowFeatureName, id, expiryDateTime.FormatTimestamp(), connectorType, evseID, idToken.IdToken, idToken.Type, groupIdToken.IdToken, groupIdToken.Type)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	reserveNowResponse := reservation.NewReserveNowResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationReservationHandler{}
+	handler.On("OnReserveNow", mock.Anything).Return(reserveNowResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*reservation.ReserveNowRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, id, request.ID)
+		assertDateTimeEquality(t, expiryDateTime, request.ExpiryDateTime)
+		assert.Equal(t, connectorType, request.ConnectorType)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, evseID, *request.EvseID)
+		assert.Equal(t, idToken.IdToken, request.IdToken.IdToken)
+		assert.Equal(t, idToken.Type, request.IdToken.Type)
+		require.NotNil(t, request.GroupIdToken)
+		assert.Equal(t, groupIdToken.IdToken, request.GroupIdToken.IdToken)
+		assert.Equal(t, groupIdToken.Type, request.GroupIdToken.Type)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.ReserveNow(wsId, func(response *reservation.ReserveNowResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, id, expiryDateTime, idToken, func(request *reservation.ReserveNowRequest) {
+		request.ConnectorType = connectorType
+		request.EvseID = &evseID
+		request.GroupIdToken = &groupIdToken
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestReserveNowInvalidEndpoint() {
+	messageId := defaultMessageId
+	id := 42
+	expiryDateTime := types.NewDateTime(time.Now().Add(time.Hour))
+	idToken := types.IdToken{IdToken: "12345", Type: types.IdTokenTypeKeyCode}
+	request := reservation.NewReserveNowRequest(id, expiryDateTime, idToken)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"id":%v,"expiryDateTime":"%v","idToken":{"idToken":"%v","type":"%v"}}]`,
+		messageId, reservation.ReserveNowFeatureName, id, expiryDateTime.FormatTimestamp(), idToken.IdToken, idToken.Type)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
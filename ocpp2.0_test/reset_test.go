@@ -0,0 +1,87 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestResetRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{provisioning.ResetRequest{Type: provisioning.ResetTypeImmediate, EvseID: newInt(1)}, true},
+		{provisioning.ResetRequest{Type: provisioning.ResetTypeOnIdle, EvseID: newInt(0)}, true},
+		{provisioning.ResetRequest{Type: provisioning.ResetTypeOnIdle}, true},
+		{provisioning.ResetRequest{}, false},
+		{provisioning.ResetRequest{Type: "invalidResetType"}, false},
+		{provisioning.ResetRequest{Type: provisioning.ResetTypeImmediate, EvseID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestResetResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{provisioning.ResetResponse{Status: provisioning.ResetStatusAccepted}, true},
+		{provisioning.ResetResponse{Status: provisioning.ResetStatusRejected}, true},
+		{provisioning.ResetResponse{Status: provisioning.ResetStatusScheduled}, true},
+		{provisioning.ResetResponse{}, false},
+		{provisioning.ResetResponse{Status: "invalidResetStatus"}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestResetE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	resetType := provisioning.ResetTypeOnIdle
+	evseID := newInt(1)
+	status := provisioning.ResetStatusScheduled
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","evseId":%v}]`, messageId, provisioning.ResetFeatureName, resetType, *evseID)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	resetResponse := provisioning.NewResetResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationProvisioningHandler{}
+	handler.On("OnReset", mock.Anything).Return(resetResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*provisioning.ResetRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, resetType, request.Type)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, *evseID, *request.EvseID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.Reset(wsId, func(response *provisioning.ResetResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, resetType, func(request *provisioning.ResetRequest) {
+		request.EvseID = evseID
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestResetInvalidEndpoint() {
+	messageId := defaultMessageId
+	resetType := provisioning.ResetTypeOnIdle
+	evseID := newInt(1)
+	request := provisioning.NewResetRequest(resetType)
+	request.EvseID = evseID
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"type":"%v","evseId":%v}]`, messageId, provisioning.ResetFeatureName, resetType, *evseID)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,113 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/display"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSetDisplayMessageRequestValidation() {
+	t := suite.T()
+	messageContent := types.MessageContent{Format: types.MessageFormatUTF8, Language: "en", Content: "hello world"}
+	var requestTable = []GenericTestEntry{
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, State: display.MessageStateCharging, StartDateTime: types.NewDateTime(time.Now()), EndDateTime: types.NewDateTime(time.Now().Add(time.Hour)), TransactionID: "1234", Message: messageContent, Display: &types.Component{Name: "display1"}}}, true},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, State: display.MessageStateCharging, StartDateTime: types.NewDateTime(time.Now()), EndDateTime: types.NewDateTime(time.Now().Add(time.Hour)), TransactionID: "1234", Message: messageContent}}, true},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, State: display.MessageStateCharging, StartDateTime: types.NewDateTime(time.Now()), Message: messageContent}}, true},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, Message: messageContent}}, true},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{Priority: display.MessagePriorityAlwaysFront, Message: messageContent}}, true},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Message: messageContent}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront}}, false},
+		{display.SetDisplayMessageRequest{}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: -1, Priority: display.MessagePriorityAlwaysFront, Message: messageContent}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: "invalidMessagePriority", Message: messageContent}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, State: "invalidMessageState", Message: messageContent}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, TransactionID: newLongString(37), Message: messageContent}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, Message: types.MessageContent{Format: "invalidMessageFormat", Content: "hello world"}}}, false},
+		{display.SetDisplayMessageRequest{Message: display.MessageInfo{ID: 42, Priority: display.MessagePriorityAlwaysFront, Message: messageContent, Display: &types.Component{}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetDisplayMessageResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{display.SetDisplayMessageResponse{Status: display.DisplayMessageStatusAccepted}, true},
+		{display.SetDisplayMessageResponse{Status: display.DisplayMessageStatusUnknownTransaction}, true},
+		{display.SetDisplayMessageResponse{Status: "invalidDisplayMessageStatus"}, false},
+		{display.SetDisplayMessageResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetDisplayMessageE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	message := display.MessageInfo{
+		ID:            42,
+		Priority:      display.MessagePriorityInFront,
+		State:         display.MessageStateCharging,
+		StartDateTime: types.NewDateTime(time.Now()),
+		EndDateTime:   types.NewDateTime(time.Now().Add(time.Hour)),
+		TransactionID: "1234",
+		Message:       types.MessageContent{Format: types.MessageFormatUTF8, Language: "en", Content: "hello world"},
+		Display:       &types.Component{Name: "display1"},
+	}
+	status := display.DisplayMessageStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"message":{"id":%v,"priority":"%v","state":"%v","startDateTime":"%v","endDateTime":"%v","transactionId":"%v","message":{"format":"%v","language":"%v","content":"%v"},"display":{"name":"%v"}}}]`,
+		messageId, display.SetDisplayMessageFeatureName, message.ID, message.Priority, message.State, message.StartDateTime.FormatTimestamp(), message.EndDateTime.FormatTimestamp(), message.TransactionID, message.Message.Format, message.Message.Language, message.Message.Content, message.Display.Name)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	setDisplayMessageResponse := display.NewSetDisplayMessageResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationDisplayHandler{}
+	handler.On("OnSetDisplayMessage", mock.Anything).Return(setDisplayMessageResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*display.SetDisplayMessageRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, message.ID, request.Message.ID)
+		assert.Equal(t, message.Priority, request.Message.Priority)
+		assert.Equal(t, message.State, request.Message.State)
+		assertDateTimeEquality(t, message.StartDateTime, request.Message.StartDateTime)
+		assertDateTimeEquality(t, message.EndDateTime, request.Message.EndDateTime)
+		assert.Equal(t, message.TransactionID, request.Message.TransactionID)
+		assert.Equal(t, message.Message, request.Message.Message)
+		require.NotNil(t, request.Message.Display)
+		assert.Equal(t, message.Display.Name, request.Message.Display.Name)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetDisplayMessage(wsId, func(response *display.SetDisplayMessageResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, message)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSetDisplayMessageInvalidEndpoint() {
+	messageId := defaultMessageId
+	message := display.MessageInfo{
+		ID:       42,
+		Priority: display.MessagePriorityInFront,
+		Message:  types.MessageContent{Format: types.MessageFormatUTF8, Content: "hello world"},
+	}
+	request := display.NewSetDisplayMessageRequest(message)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"message":{"id":%v,"priority":"%v","message":{"format":"%v","content":"%v"}}}]`,
+		messageId, display.SetDisplayMessageFeatureName, message.ID, message.Priority, message.Message.Format, message.Message.Content)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
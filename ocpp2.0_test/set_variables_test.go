@@ -0,0 +1,111 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSetVariablesRequestValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	var requestTable = []GenericTestEntry{
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeType: provisioning.AttributeTarget, AttributeValue: "dummyValue", Component: component, Variable: variable}}}, true},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeValue: "dummyValue", Component: component, Variable: variable}}}, true},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{}}, false},
+		{provisioning.SetVariablesRequest{}, false},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeType: "invalidAttribute", AttributeValue: "dummyValue", Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeValue: newLongString(1001), Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeValue: "dummyValue", Variable: variable}}}, false},
+		{provisioning.SetVariablesRequest{SetVariableData: []provisioning.SetVariableData{{AttributeValue: "dummyValue", Component: component}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariablesResponseValidation() {
+	t := suite.T()
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	var responseTable = []GenericTestEntry{
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeType: provisioning.AttributeTarget, AttributeStatus: provisioning.SetVariableStatusAccepted, Component: component, Variable: variable}}}, true},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeStatus: provisioning.SetVariableStatusRebootRequired, Component: component, Variable: variable}}}, true},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{}}, false},
+		{provisioning.SetVariablesResponse{}, false},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeStatus: "invalidStatus", Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeType: "invalidAttribute", AttributeStatus: provisioning.SetVariableStatusAccepted, Component: component, Variable: variable}}}, false},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeStatus: provisioning.SetVariableStatusAccepted, Variable: variable}}}, false},
+		{provisioning.SetVariablesResponse{SetVariableResult: []provisioning.SetVariableResult{{AttributeStatus: provisioning.SetVariableStatusAccepted, Component: component}}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariablesE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	component := types.Component{Name: "component1", Instance: "instance1", EVSE: &types.EVSE{ID: 2, ConnectorID: newInt(2)}}
+	variable := types.Variable{Name: "variable1", Instance: "instance1"}
+	variableData := provisioning.SetVariableData{AttributeType: provisioning.AttributeTarget, AttributeValue: "dummyValue", Component: component, Variable: variable}
+	variableResult := provisioning.SetVariableResult{AttributeType: provisioning.AttributeTarget, AttributeStatus: provisioning.SetVariableStatusAccepted, Component: component, Variable: variable}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"setVariableData":[{"attributeType":"%v","attributeValue":"%v","component":{"name":"%v","instance":"%v","evse":{"id":%v,"connectorId":%v}},"variable":{"name":"%v","instance":"%v"}}]}]`,
+		messageId, provisioning.SetVariablesFeatureName, variableData.AttributeType, variableData.AttributeValue, component.Name, component.Instance, component.EVSE.ID, *component.EVSE.ConnectorID, variable.Name, variable.Instance)
+	responseJson := fmt.Sprintf(`[3,"%v",{"setVariableResult":[{"attributeType":"%v","attributeStatus":"%v","component":{"name":"%v","instance":"%v","evse":{"id":%v,"connectorId":%v}},"variable":{"name":"%v","instance":"%v"}}]}]`,
+		messageId, variableResult.AttributeType, variableResult.AttributeStatus, component.Name, component.Instance, component.EVSE.ID, *component.EVSE.ConnectorID, variable.Name, variable.Instance)
+	setVariablesResponse := provisioning.NewSetVariablesResponse([]provisioning.SetVariableResult{variableResult})
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockChargingStationProvisioningHandler{}
+	handler.On("OnSetVariables", mock.Anything).Return(setVariablesResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*provisioning.SetVariablesRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		require.Len(t, request.SetVariableData, 1)
+		assert.Equal(t, variableData.AttributeType, request.SetVariableData[0].AttributeType)
+		assert.Equal(t, variableData.AttributeValue, request.SetVariableData[0].AttributeValue)
+		assert.Equal(t, component.Name, request.SetVariableData[0].Component.Name)
+		assert.Equal(t, component.Instance, request.SetVariableData[0].Component.Instance)
+		require.NotNil(t, request.SetVariableData[0].Component.EVSE)
+		assert.Equal(t, component.EVSE.ID, request.SetVariableData[0].Component.EVSE.ID)
+		assert.Equal(t, *component.EVSE.ConnectorID, *request.SetVariableData[0].Component.EVSE.ConnectorID)
+		assert.Equal(t, variable, request.SetVariableData[0].Variable)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetVariables(wsId, func(response *provisioning.SetVariablesResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		require.Len(t, response.SetVariableResult, 1)
+		result := response.SetVariableResult[0]
+		assert.Equal(t, variableResult.AttributeType, result.AttributeType)
+		assert.Equal(t, variableResult.AttributeStatus, result.AttributeStatus)
+		assert.Equal(t, component.Name, result.Component.Name)
+		assert.Equal(t, variable, result.Variable)
+		resultChannel <- true
+	}, []provisioning.SetVariableData{variableData})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV2TestSuite) TestSetVariablesInvalidEndpoint() {
+	messageId := defaultMessageId
+	component := types.Component{Name: "component1"}
+	variable := types.Variable{Name: "variable1"}
+	attributeValue := "dummyValue"
+	request := provisioning.NewSetVariablesRequest([]provisioning.SetVariableData{{AttributeValue: attributeValue, Component: component, Variable: variable}})
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"setVariableData":[{"attributeValue":"%v","component":{"name":"%v"},"variable":{"name":"%v"}}]}]`, messageId, provisioning.SetVariablesFeatureName, attributeValue, component.Name, variable.Name)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
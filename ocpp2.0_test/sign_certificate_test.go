@@ -0,0 +1,80 @@
+package ocpp2_test
+
+import (
+	"fmt"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV2TestSuite) TestSignCertificateRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{security.SignCertificateRequest{CSR: "deadc0de", CertificateType: types.ChargingStationCert}, true},
+		{security.SignCertificateRequest{CSR: "deadc0de", CertificateType: types.V2GCertificate}, true},
+		{security.SignCertificateRequest{CSR: "deadc0de"}, true},
+		{security.SignCertificateRequest{}, false},
+		{security.SignCertificateRequest{CSR: newLongString(5501)}, false},
+		{security.SignCertificateRequest{CSR: "deadc0de", CertificateType: "invalidCertificateType"}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV2TestSuite) TestSignCertificateResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{security.SignCertificateResponse{Status: types.GenericStatusAccepted}, true},
+		{security.SignCertificateResponse{Status: types.GenericStatusRejected}, true},
+		{security.SignCertificateResponse{Status: "invalidGenericStatus"}, false},
+		{security.SignCertificateResponse{}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV2TestSuite) TestSignCertificateE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	csr := "deadc0de"
+	certificateType := types.ChargingStationCert
+	status := types.GenericStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"csr":"%v","certificateType":"%v"}]`, messageId, security.SignCertificateFeatureName, csr, certificateType)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	signCertificateResponse := security.NewSignCertificateResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := MockCSMSSecurityHandler{}
+	handler.On("OnSignCertificate", mock.AnythingOfType("string"), mock.Anything).Return(signCertificateResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*security.SignCertificateRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, csr, request.CSR)
+		assert.Equal(t, certificateType, request.CertificateType)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.SignCertificate(csr, func(request *security.SignCertificateRequest) {
+		request.CertificateType = certificateType
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, status, response.Status)
+}
+
+func (suite *OcppV2TestSuite) TestSignCertificateInvalidEndpoint() {
+	messageId := defaultMessageId
+	csr := "deadc0de"
+	certificateType := types.ChargingStationCert
+	request := security.NewSignCertificateRequest(csr)
+	request.CertificateType = certificateType
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"csr":"%v","certificateType":"%v"}]`, messageId, security.SignCertificateFeatureName, csr, certificateType)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
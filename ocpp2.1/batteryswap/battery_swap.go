@@ -0,0 +1,92 @@
+package batteryswap
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Battery Swap (CS -> CSMS) --------------------
+
+const BatterySwapFeatureName = "BatterySwap"
+
+// Event reported by a BatterySwapRequest.
+type BatterySwapEvent string
+
+const (
+	BatterySwapEventBatteryIn         BatterySwapEvent = "BatteryIn"
+	BatterySwapEventBatteryOut        BatterySwapEvent = "BatteryOut"
+	BatterySwapEventBatteryOutTimeout BatterySwapEvent = "BatteryOutTimeout"
+)
+
+func isValidBatterySwapEvent(fl validator.FieldLevel) bool {
+	event := BatterySwapEvent(fl.Field().String())
+	switch event {
+	case BatterySwapEventBatteryIn, BatterySwapEventBatteryOut, BatterySwapEventBatteryOutTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Information about a single battery, which was inserted into or taken out of a battery swap station.
+type BatteryData struct {
+	EvseID         int             `json:"evseId" validate:"gte=0"`                           // The slot of the battery.
+	SerialNumber   string          `json:"serialNumber" validate:"required,max=50"`           // Serial number of the battery.
+	SoC            float64         `json:"soC" validate:"gte=0,lte=100"`                      // State of charge in percent.
+	SoH            float64         `json:"soH" validate:"gte=0,lte=100"`                      // State of health in percent.
+	ProductionDate *types.DateTime `json:"productionDate,omitempty" validate:"omitempty"`     // Production date of the battery.
+	VendorInfo     string          `json:"vendorInfo,omitempty" validate:"omitempty,max=500"` // Vendor-specific information.
+}
+
+// The field definition of the BatterySwap request payload sent by the Charging Station to the CSMS.
+type BatterySwapRequest struct {
+	BatteryData []BatteryData    `json:"batteryData" validate:"required,min=1,dive"`
+	EventType   BatterySwapEvent `json:"eventType" validate:"required,batterySwapEvent21"`
+	IdToken     types.IdToken    `json:"idToken" validate:"required"`
+	RequestID   int              `json:"requestId"` // ID of the RequestBatterySwapRequest, which triggered the swap.
+}
+
+// This field definition of the BatterySwap response payload, sent by the CSMS to the Charging Station in response to a BatterySwapRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type BatterySwapResponse struct {
+}
+
+// A battery swap station reports every battery that is inserted or taken out during a swap
+// by sending a BatterySwapRequest to the CSMS. The CSMS responds with a BatterySwapResponse.
+type BatterySwapFeature struct{}
+
+func (f BatterySwapFeature) GetFeatureName() string {
+	return BatterySwapFeatureName
+}
+
+func (f BatterySwapFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(BatterySwapRequest{})
+}
+
+func (f BatterySwapFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(BatterySwapResponse{})
+}
+
+func (r BatterySwapRequest) GetFeatureName() string {
+	return BatterySwapFeatureName
+}
+
+func (c BatterySwapResponse) GetFeatureName() string {
+	return BatterySwapFeatureName
+}
+
+// Creates a new BatterySwapRequest, containing all required fields. There are no optional fields for this message.
+func NewBatterySwapRequest(eventType BatterySwapEvent, idToken types.IdToken, requestID int, batteryData ...BatteryData) *BatterySwapRequest {
+	return &BatterySwapRequest{BatteryData: batteryData, EventType: eventType, IdToken: idToken, RequestID: requestID}
+}
+
+// Creates a new BatterySwapResponse, which doesn't contain any required or optional fields.
+func NewBatterySwapResponse() *BatterySwapResponse {
+	return &BatterySwapResponse{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("batterySwapEvent21", isValidBatterySwapEvent)
+}
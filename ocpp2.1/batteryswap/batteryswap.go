@@ -0,0 +1,25 @@
+// The battery swap functional block contains OCPP 2.1 features for battery swap stations,
+// in which a depleted EV battery is exchanged for a charged one.
+package batteryswap
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.1 Battery swap profile.
+type CSMSHandler interface {
+	// OnBatterySwap is called on the CSMS whenever a BatterySwapRequest is received from a charging station.
+	OnBatterySwap(chargingStationID string, request *BatterySwapRequest) (response *BatterySwapResponse, err error)
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.1 Battery swap profile.
+type ChargingStationHandler interface {
+	// OnRequestBatterySwap is called on a charging station whenever a RequestBatterySwapRequest is received from the CSMS.
+	OnRequestBatterySwap(request *RequestBatterySwapRequest) (response *RequestBatterySwapResponse, err error)
+}
+
+const ProfileName = "batterySwap"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	BatterySwapFeature{},
+	RequestBatterySwapFeature{},
+)
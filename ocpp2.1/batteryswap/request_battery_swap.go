@@ -0,0 +1,59 @@
+package batteryswap
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+)
+
+// -------------------- Request Battery Swap (CSMS -> CS) --------------------
+
+const RequestBatterySwapFeatureName = "RequestBatterySwap"
+
+// The field definition of the RequestBatterySwap request payload sent by the CSMS to the Charging Station.
+type RequestBatterySwapRequest struct {
+	IdToken   types.IdToken `json:"idToken" validate:"required"` // The driver, for whom the swap was requested.
+	RequestID int           `json:"requestId"`                   // ID of the request, which is repeated in the following BatterySwapRequest messages.
+}
+
+// This field definition of the RequestBatterySwap response payload, sent by the Charging Station to the CSMS in response to a RequestBatterySwapRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type RequestBatterySwapResponse struct {
+	Status     types.GenericStatus `json:"status" validate:"required,genericStatus21"`
+	StatusInfo *types.StatusInfo   `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The CSMS remotely starts a battery swap for a driver, e.g. after an authorization via an app,
+// by sending a RequestBatterySwapRequest to the battery swap station.
+// The station responds with a RequestBatterySwapResponse, then reports the swap via BatterySwapRequest messages.
+type RequestBatterySwapFeature struct{}
+
+func (f RequestBatterySwapFeature) GetFeatureName() string {
+	return RequestBatterySwapFeatureName
+}
+
+func (f RequestBatterySwapFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(RequestBatterySwapRequest{})
+}
+
+func (f RequestBatterySwapFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(RequestBatterySwapResponse{})
+}
+
+func (r RequestBatterySwapRequest) GetFeatureName() string {
+	return RequestBatterySwapFeatureName
+}
+
+func (c RequestBatterySwapResponse) GetFeatureName() string {
+	return RequestBatterySwapFeatureName
+}
+
+// Creates a new RequestBatterySwapRequest, containing all required fields. There are no optional fields for this message.
+func NewRequestBatterySwapRequest(idToken types.IdToken, requestID int) *RequestBatterySwapRequest {
+	return &RequestBatterySwapRequest{IdToken: idToken, RequestID: requestID}
+}
+
+// Creates a new RequestBatterySwapResponse, containing all required fields. Optional fields may be set afterwards.
+func NewRequestBatterySwapResponse(status types.GenericStatus) *RequestBatterySwapResponse {
+	return &RequestBatterySwapResponse{Status: status}
+}
@@ -0,0 +1,975 @@
+package ocpp21
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/internal/callbackqueue"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/display"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/iso15118"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/tariffcost"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/batteryswap"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/der"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/v2x"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+type chargingStation struct {
+	client               *ocppj.Client
+	securityHandler      security.ChargingStationHandler
+	provisioningHandler  provisioning.ChargingStationHandler
+	authorizationHandler authorization.ChargingStationHandler
+	localAuthListHandler localauth.ChargingStationHandler
+	transactionsHandler  transactions.ChargingStationHandler
+	remoteControlHandler remotecontrol.ChargingStationHandler
+	availabilityHandler  availability.ChargingStationHandler
+	reservationHandler   reservation.ChargingStationHandler
+	tariffCostHandler    tariffcost.ChargingStationHandler
+	meterHandler         meter.ChargingStationHandler
+	smartChargingHandler smartcharging.ChargingStationHandler
+	firmwareHandler      firmware.ChargingStationHandler
+	iso15118Handler      iso15118.ChargingStationHandler
+	diagnosticsHandler   diagnostics.ChargingStationHandler
+	displayHandler       display.ChargingStationHandler
+	dataHandler          data.ChargingStationHandler
+	v2xHandler           v2x.ChargingStationHandler
+	derHandler           der.ChargingStationHandler
+	tariffHandler        tariff.ChargingStationHandler
+	paymentHandler       payment.ChargingStationHandler
+	batterySwapHandler   batteryswap.ChargingStationHandler
+	unconfirmedHandler   func(request ocpp.Request)
+	resultErrorHandler   func(err *ocpp.Error, details interface{})
+	responseHandler      chan receivedResponse
+	errorHandler         chan *ocpp.Error
+	callbacks            callbackqueue.CallbackQueue
+	stopC                chan struct{}
+	errC                 chan error // external error channel
+}
+
+// A response received from the server, together with the ID of the request it refers to.
+type receivedResponse struct {
+	requestId string
+	response  ocpp.Response
+}
+
+func (cs *chargingStation) error(err error) {
+	if cs.errC != nil {
+		cs.errC <- err
+	}
+}
+
+// Errors returns a channel for error messages. If it doesn't exist it es created.
+func (cs *chargingStation) Errors() <-chan error {
+	if cs.errC == nil {
+		cs.errC = make(chan error, 1)
+	}
+	return cs.errC
+}
+
+// Callback invoked whenever a queued request is canceled, due to timeout.
+// By default, the callback returns a GenericError to the caller, who sent the original request.
+func (cs *chargingStation) onRequestTimeout(rID string, action string, request ocpp.Request) {
+	err := ocpp.NewError(ocppj.GenericError, "request timed out, no response received from server", rID)
+	cs.errorHandler <- err
+}
+
+func (cs *chargingStation) BootNotification(reason provisioning.BootReason, model string, vendor string, props ...func(request *provisioning.BootNotificationRequest)) (*provisioning.BootNotificationResponse, error) {
+	request := provisioning.NewBootNotificationRequest(reason, model, vendor)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*provisioning.BootNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) Authorize(idToken string, tokenType types.IdTokenType, props ...func(request *authorization.AuthorizeRequest)) (*authorization.AuthorizeResponse, error) {
+	request := authorization.NewAuthorizationRequest(idToken, tokenType)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*authorization.AuthorizeResponse), err
+	}
+}
+
+func (cs *chargingStation) ClearedChargingLimit(chargingLimitSource types.ChargingLimitSourceType, props ...func(request *smartcharging.ClearedChargingLimitRequest)) (*smartcharging.ClearedChargingLimitResponse, error) {
+	request := smartcharging.NewClearedChargingLimitRequest(chargingLimitSource)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.ClearedChargingLimitResponse), err
+	}
+}
+
+func (cs *chargingStation) DataTransfer(vendorId string, props ...func(request *data.DataTransferRequest)) (*data.DataTransferResponse, error) {
+	request := data.NewDataTransferRequest(vendorId)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*data.DataTransferResponse), err
+	}
+}
+
+func (cs *chargingStation) FirmwareStatusNotification(status firmware.FirmwareStatus, requestID int, props ...func(request *firmware.FirmwareStatusNotificationRequest)) (*firmware.FirmwareStatusNotificationResponse, error) {
+	request := firmware.NewFirmwareStatusNotificationRequest(status, requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*firmware.FirmwareStatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) Get15118EVCertificate(schemaVersion string, action iso15118.CertificateAction, exiRequest string, props ...func(request *iso15118.Get15118EVCertificateRequest)) (*iso15118.Get15118EVCertificateResponse, error) {
+	request := iso15118.NewGet15118EVCertificateRequest(schemaVersion, action, exiRequest)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*iso15118.Get15118EVCertificateResponse), err
+	}
+}
+
+func (cs *chargingStation) GetCertificateStatus(ocspRequestData types.OCSPRequestDataType, props ...func(request *iso15118.GetCertificateStatusRequest)) (*iso15118.GetCertificateStatusResponse, error) {
+	request := iso15118.NewGetCertificateStatusRequest(ocspRequestData)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*iso15118.GetCertificateStatusResponse), err
+	}
+}
+
+func (cs *chargingStation) Heartbeat(props ...func(request *provisioning.HeartbeatRequest)) (*provisioning.HeartbeatResponse, error) {
+	request := provisioning.NewHeartbeatRequest()
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*provisioning.HeartbeatResponse), err
+	}
+}
+
+func (cs *chargingStation) LogStatusNotification(status diagnostics.UploadLogStatus, props ...func(request *diagnostics.LogStatusNotificationRequest)) (*diagnostics.LogStatusNotificationResponse, error) {
+	request := diagnostics.NewLogStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.LogStatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) MeterValues(evseID int, meterValues []types.MeterValue, props ...func(request *meter.MeterValuesRequest)) (*meter.MeterValuesResponse, error) {
+	request := meter.NewMeterValuesRequest(evseID, meterValues)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*meter.MeterValuesResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyChargingLimit(chargingLimit smartcharging.ChargingLimit, props ...func(request *smartcharging.NotifyChargingLimitRequest)) (*smartcharging.NotifyChargingLimitResponse, error) {
+	request := smartcharging.NewNotifyChargingLimitRequest(chargingLimit)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyChargingLimitResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyCustomerInformation(data string, seqNo int, generatedAt *types.DateTime, requestID int, props ...func(request *diagnostics.NotifyCustomerInformationRequest)) (*diagnostics.NotifyCustomerInformationResponse, error) {
+	request := diagnostics.NewNotifyCustomerInformationRequest(data, seqNo, generatedAt, requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyCustomerInformationResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyDisplayMessages(requestID int, props ...func(request *display.NotifyDisplayMessagesRequest)) (*display.NotifyDisplayMessagesResponse, error) {
+	request := display.NewNotifyDisplayMessagesRequest(requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*display.NotifyDisplayMessagesResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEVChargingNeeds(evseID int, chargingNeeds smartcharging.ChargingNeeds, props ...func(request *smartcharging.NotifyEVChargingNeedsRequest)) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	request := smartcharging.NewNotifyEVChargingNeedsRequest(evseID, chargingNeeds)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyEVChargingNeedsResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEVChargingSchedule(timeBase *types.DateTime, evseID int, schedule types.ChargingSchedule, props ...func(request *smartcharging.NotifyEVChargingScheduleRequest)) (*smartcharging.NotifyEVChargingScheduleResponse, error) {
+	request := smartcharging.NewNotifyEVChargingScheduleRequest(timeBase, evseID, schedule)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.NotifyEVChargingScheduleResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyEvent(generatedAt *types.DateTime, seqNo int, eventData []diagnostics.EventData, props ...func(request *diagnostics.NotifyEventRequest)) (*diagnostics.NotifyEventResponse, error) {
+	request := diagnostics.NewNotifyEventRequest(generatedAt, seqNo, eventData)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyEventResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyMonitoringReport(requestID int, seqNo int, generatedAt *types.DateTime, monitorData []diagnostics.MonitoringData, props ...func(request *diagnostics.NotifyMonitoringReportRequest)) (*diagnostics.NotifyMonitoringReportResponse, error) {
+	request := diagnostics.NewNotifyMonitoringReportRequest(requestID, seqNo, generatedAt, monitorData)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*diagnostics.NotifyMonitoringReportResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyReport(generatedAt *types.DateTime, seqNo int, props ...func(request *provisioning.NotifyReportRequest)) (*provisioning.NotifyReportResponse, error) {
+	request := provisioning.NewNotifyReportRequest(generatedAt, seqNo)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*provisioning.NotifyReportResponse), err
+	}
+}
+
+func (cs *chargingStation) PublishFirmwareStatusNotification(status firmware.PublishFirmwareStatus, props ...func(request *firmware.PublishFirmwareStatusNotificationRequest)) (*firmware.PublishFirmwareStatusNotificationResponse, error) {
+	request := firmware.NewPublishFirmwareStatusNotificationRequest(status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*firmware.PublishFirmwareStatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) ReportChargingProfiles(requestID int, chargingLimitSource types.ChargingLimitSourceType, evseID int, chargingProfile []types.ChargingProfile, props ...func(request *smartcharging.ReportChargingProfilesRequest)) (*smartcharging.ReportChargingProfilesResponse, error) {
+	request := smartcharging.NewReportChargingProfilesRequest(requestID, chargingLimitSource, evseID, chargingProfile)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*smartcharging.ReportChargingProfilesResponse), err
+	}
+}
+
+func (cs *chargingStation) ReservationStatusUpdate(reservationID int, status reservation.ReservationUpdateStatus, props ...func(request *reservation.ReservationStatusUpdateRequest)) (*reservation.ReservationStatusUpdateResponse, error) {
+	request := reservation.NewReservationStatusUpdateRequest(reservationID, status)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*reservation.ReservationStatusUpdateResponse), err
+	}
+}
+
+func (cs *chargingStation) SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationResponse, error) {
+	request := security.NewSecurityEventNotificationRequest(typ, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*security.SecurityEventNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateResponse, error) {
+	request := security.NewSignCertificateRequest(csr)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*security.SignCertificateResponse), err
+	}
+}
+
+func (cs *chargingStation) StatusNotification(timestamp *types.DateTime, status availability.ConnectorStatus, evseID int, connectorID int, props ...func(request *availability.StatusNotificationRequest)) (*availability.StatusNotificationResponse, error) {
+	request := availability.NewStatusNotificationRequest(timestamp, status, evseID, connectorID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*availability.StatusNotificationResponse), err
+	}
+}
+
+func (cs *chargingStation) TransactionEvent(t transactions.TransactionEvent, timestamp *types.DateTime, reason transactions.TriggerReason, seqNo int, info transactions.Transaction, props ...func(request *transactions.TransactionEventRequest)) (*transactions.TransactionEventResponse, error) {
+	request := transactions.NewTransactionEventRequest(t, timestamp, reason, seqNo, info)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*transactions.TransactionEventResponse), err
+	}
+}
+
+func (cs *chargingStation) ReportDERControl(requestID int, props ...func(request *der.ReportDERControlRequest)) (*der.ReportDERControlResponse, error) {
+	request := der.NewReportDERControlRequest(requestID)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*der.ReportDERControlResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyDERAlarm(controlType der.DERControlType, timestamp *types.DateTime, props ...func(request *der.NotifyDERAlarmRequest)) (*der.NotifyDERAlarmResponse, error) {
+	request := der.NewNotifyDERAlarmRequest(controlType, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*der.NotifyDERAlarmResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyDERStartStop(controlID string, started bool, timestamp *types.DateTime, props ...func(request *der.NotifyDERStartStopRequest)) (*der.NotifyDERStartStopResponse, error) {
+	request := der.NewNotifyDERStartStopRequest(controlID, started, timestamp)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*der.NotifyDERStartStopResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifySettlement(pspRef string, status payment.PaymentStatus, settlementAmount float64, settlementTime *types.DateTime, props ...func(request *payment.NotifySettlementRequest)) (*payment.NotifySettlementResponse, error) {
+	request := payment.NewNotifySettlementRequest(pspRef, status, settlementAmount, settlementTime)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*payment.NotifySettlementResponse), err
+	}
+}
+
+func (cs *chargingStation) VatNumberValidation(vatNumber string, props ...func(request *payment.VatNumberValidationRequest)) (*payment.VatNumberValidationResponse, error) {
+	request := payment.NewVatNumberValidationRequest(vatNumber)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*payment.VatNumberValidationResponse), err
+	}
+}
+
+func (cs *chargingStation) NotifyQRCodeScanned(evseID int, timeout int, props ...func(request *payment.NotifyQRCodeScannedRequest)) (*payment.NotifyQRCodeScannedResponse, error) {
+	request := payment.NewNotifyQRCodeScannedRequest(evseID, timeout)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*payment.NotifyQRCodeScannedResponse), err
+	}
+}
+
+func (cs *chargingStation) BatterySwap(eventType batteryswap.BatterySwapEvent, idToken types.IdToken, requestID int, batteryData []batteryswap.BatteryData, props ...func(request *batteryswap.BatterySwapRequest)) (*batteryswap.BatterySwapResponse, error) {
+	request := batteryswap.NewBatterySwapRequest(eventType, idToken, requestID, batteryData...)
+	for _, fn := range props {
+		fn(request)
+	}
+	response, err := cs.SendRequest(request)
+	if err != nil {
+		return nil, err
+	} else {
+		return response.(*batteryswap.BatterySwapResponse), err
+	}
+}
+
+func (cs *chargingStation) SetSecurityHandler(handler security.ChargingStationHandler) {
+	cs.securityHandler = handler
+}
+
+func (cs *chargingStation) SetProvisioningHandler(handler provisioning.ChargingStationHandler) {
+	cs.provisioningHandler = handler
+}
+
+func (cs *chargingStation) SetAuthorizationHandler(handler authorization.ChargingStationHandler) {
+	cs.authorizationHandler = handler
+}
+
+func (cs *chargingStation) SetLocalAuthListHandler(handler localauth.ChargingStationHandler) {
+	cs.localAuthListHandler = handler
+}
+
+func (cs *chargingStation) SetTransactionsHandler(handler transactions.ChargingStationHandler) {
+	cs.transactionsHandler = handler
+}
+
+func (cs *chargingStation) SetRemoteControlHandler(handler remotecontrol.ChargingStationHandler) {
+	cs.remoteControlHandler = handler
+}
+
+func (cs *chargingStation) SetAvailabilityHandler(handler availability.ChargingStationHandler) {
+	cs.availabilityHandler = handler
+}
+
+func (cs *chargingStation) SetReservationHandler(handler reservation.ChargingStationHandler) {
+	cs.reservationHandler = handler
+}
+
+func (cs *chargingStation) SetTariffCostHandler(handler tariffcost.ChargingStationHandler) {
+	cs.tariffCostHandler = handler
+}
+
+func (cs *chargingStation) SetMeterHandler(handler meter.ChargingStationHandler) {
+	cs.meterHandler = handler
+}
+
+func (cs *chargingStation) SetSmartChargingHandler(handler smartcharging.ChargingStationHandler) {
+	cs.smartChargingHandler = handler
+}
+
+func (cs *chargingStation) SetFirmwareHandler(handler firmware.ChargingStationHandler) {
+	cs.firmwareHandler = handler
+}
+
+func (cs *chargingStation) SetISO15118Handler(handler iso15118.ChargingStationHandler) {
+	cs.iso15118Handler = handler
+}
+
+func (cs *chargingStation) SetDiagnosticsHandler(handler diagnostics.ChargingStationHandler) {
+	cs.diagnosticsHandler = handler
+}
+
+func (cs *chargingStation) SetDisplayHandler(handler display.ChargingStationHandler) {
+	cs.displayHandler = handler
+}
+
+func (cs *chargingStation) SetDataHandler(handler data.ChargingStationHandler) {
+	cs.dataHandler = handler
+}
+
+func (cs *chargingStation) SetV2XHandler(handler v2x.ChargingStationHandler) {
+	cs.v2xHandler = handler
+}
+
+func (cs *chargingStation) SetDERControlHandler(handler der.ChargingStationHandler) {
+	cs.derHandler = handler
+}
+
+func (cs *chargingStation) SetTariffHandler(handler tariff.ChargingStationHandler) {
+	cs.tariffHandler = handler
+}
+
+func (cs *chargingStation) SetPaymentHandler(handler payment.ChargingStationHandler) {
+	cs.paymentHandler = handler
+}
+
+func (cs *chargingStation) SetBatterySwapHandler(handler batteryswap.ChargingStationHandler) {
+	cs.batterySwapHandler = handler
+}
+
+func (cs *chargingStation) SetUnconfirmedRequestHandler(handler func(request ocpp.Request)) {
+	cs.unconfirmedHandler = handler
+}
+
+func (cs *chargingStation) SetCallResultErrorHandler(handler func(err *ocpp.Error, details interface{})) {
+	cs.resultErrorHandler = handler
+}
+
+func (cs *chargingStation) SendRequest(request ocpp.Request) (ocpp.Response, error) {
+	return cs.SendRequestWithContext(context.Background(), request)
+}
+
+func (cs *chargingStation) SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	featureName := request.GetFeatureName()
+	if _, found := cs.client.GetProfileForFeature(featureName); !found {
+		return nil, fmt.Errorf("feature %v is unsupported on charging station (missing profile), cannot send request", featureName)
+	}
+
+	// Wraps an asynchronous response
+	type asyncResponse struct {
+		r ocpp.Response
+		e error
+	}
+	// Create channel and pass it to a callback function, for retrieving asynchronous response
+	asyncResponseC := make(chan asyncResponse, 1)
+	send := func() (string, error) {
+		return cs.client.SendRequestWithContext(ctx, request)
+	}
+	err := cs.callbacks.TryQueue("main", send, func(confirmation ocpp.Response, err error) {
+		asyncResponseC <- asyncResponse{r: confirmation, e: err}
+	})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case asyncResult, ok := <-asyncResponseC:
+		if !ok {
+			return nil, fmt.Errorf("internal error while receiving result for %v request", request.GetFeatureName())
+		}
+		return asyncResult.r, asyncResult.e
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (cs *chargingStation) SendRequestAsync(request ocpp.Request, callback func(response ocpp.Response, err error)) error {
+	featureName := request.GetFeatureName()
+	if _, found := cs.client.GetProfileForFeature(featureName); !found {
+		return fmt.Errorf("feature %v is unsupported on charging station (missing profile), cannot send request", featureName)
+	}
+	switch featureName {
+	case authorization.AuthorizeFeatureName, provisioning.BootNotificationFeatureName, smartcharging.ClearedChargingLimitFeatureName, data.DataTransferFeatureName, firmware.FirmwareStatusNotificationFeatureName, iso15118.Get15118EVCertificateFeatureName, iso15118.GetCertificateStatusFeatureName, transactions.TransactionEventFeatureName, meter.MeterValuesFeatureName, provisioning.HeartbeatFeatureName, provisioning.NotifyReportFeatureName, availability.StatusNotificationFeatureName, reservation.ReservationStatusUpdateFeatureName, security.SecurityEventNotificationFeatureName, security.SignCertificateFeatureName, diagnostics.LogStatusNotificationFeatureName, diagnostics.NotifyCustomerInformationFeatureName, diagnostics.NotifyEventFeatureName, diagnostics.NotifyMonitoringReportFeatureName, smartcharging.NotifyChargingLimitFeatureName, smartcharging.NotifyEVChargingNeedsFeatureName, smartcharging.NotifyEVChargingScheduleFeatureName, smartcharging.ReportChargingProfilesFeatureName, firmware.PublishFirmwareStatusNotificationFeatureName, display.NotifyDisplayMessagesFeatureName, der.ReportDERControlFeatureName, der.NotifyDERAlarmFeatureName, der.NotifyDERStartStopFeatureName, payment.NotifySettlementFeatureName, payment.VatNumberValidationFeatureName, payment.NotifyQRCodeScannedFeatureName, batteryswap.BatterySwapFeatureName:
+		break
+	default:
+		return fmt.Errorf("unsupported action %v on charging station, cannot send request", featureName)
+	}
+	// Response will be retrieved asynchronously via asyncHandler
+	send := func() (string, error) {
+		return cs.client.SendRequestWithContext(context.Background(), request)
+	}
+	err := cs.callbacks.TryQueue("main", send, callback)
+	return err
+}
+
+func (cs *chargingStation) SendUnconfirmedRequest(request ocpp.Request) error {
+	featureName := request.GetFeatureName()
+	if _, found := cs.client.GetProfileForFeature(featureName); !found {
+		return fmt.Errorf("feature %v is unsupported on charging station (missing profile), cannot send request", featureName)
+	}
+	return cs.client.SendUnconfirmedRequest(request)
+}
+
+func (cs *chargingStation) asyncCallbackHandler() {
+	for {
+		select {
+		case received := <-cs.responseHandler:
+			// Get and invoke callback
+			if callback, ok := cs.callbacks.DequeueRequest("main", received.requestId); ok {
+				callback(received.response, nil)
+			} else {
+				cs.error(fmt.Errorf("no callback available for incoming response %v", received.response.GetFeatureName()))
+			}
+		case protoError := <-cs.errorHandler:
+			// Get and invoke callback
+			if callback, ok := cs.callbacks.DequeueRequest("main", protoError.MessageId); ok {
+				callback(nil, protoError)
+			} else {
+				cs.error(fmt.Errorf("no callback available for incoming error %w", protoError))
+			}
+		case _, _ = <-cs.stopC:
+			return
+		}
+	}
+}
+
+func (cs *chargingStation) sendResponse(response ocpp.Response, err error, requestId string) {
+	// send error response
+	if err != nil {
+		// OCPP errors returned by a handler are forwarded with their own error code
+		errorCode, description := ocppj.ProtocolError, err.Error()
+		if ocppErr, ok := err.(*ocpp.Error); ok {
+			errorCode, description = ocppErr.Code, ocppErr.Description
+		}
+		err = cs.client.SendError(requestId, errorCode, description, nil)
+		if err != nil {
+			cs.error(fmt.Errorf("replying cs to request %s with '%v': %w", requestId, errorCode, err))
+		}
+		return
+	}
+
+	if response == nil {
+		err = fmt.Errorf("empty response to request %s", requestId)
+		cs.error(err)
+		return
+	}
+
+	// send response
+	err = cs.client.SendResponse(requestId, response)
+	if err != nil {
+		cs.error(fmt.Errorf("replying to request %s with 'protocol error': %w", requestId, err))
+	}
+}
+
+func (cs *chargingStation) Start(csmsUrl string) error {
+	cs.stopC = make(chan struct{}, 1)
+	// Async response handler receives incoming responses/errors and triggers callbacks
+	err := cs.client.Start(csmsUrl)
+	if err == nil {
+		go cs.asyncCallbackHandler()
+	}
+	return err
+}
+
+func (cs *chargingStation) Stop() {
+	cs.client.Stop()
+}
+
+func (cs *chargingStation) notImplementedError(requestId string, action string) {
+	err := cs.client.SendError(requestId, ocppj.NotImplemented, fmt.Sprintf("no handler for action %v implemented", action), nil)
+	if err != nil {
+		cs.error(fmt.Errorf("replying csms to request %v with error: %w", requestId, err))
+	}
+}
+
+func (cs *chargingStation) notSupportedError(requestId string, action string) {
+	err := cs.client.SendError(requestId, ocppj.NotSupported, fmt.Sprintf("unsupported action %v on charging station", action), nil)
+	if err != nil {
+		cs.error(fmt.Errorf("replying csms to request %s with 'not supported': %w", requestId, err))
+	}
+}
+
+func (cs *chargingStation) handleIncomingRequest(request ocpp.Request, requestId string, action string) {
+	profile, found := cs.client.GetProfileForFeature(action)
+	// Check whether action is supported and a listener for it exists
+	if !found {
+		cs.notImplementedError(requestId, action)
+		return
+	} else {
+		supported := true
+		switch profile.Name {
+		case authorization.ProfileName:
+			if cs.authorizationHandler == nil {
+				supported = false
+			}
+		case availability.ProfileName:
+			if cs.availabilityHandler == nil {
+				supported = false
+			}
+		case data.ProfileName:
+			if cs.dataHandler == nil {
+				supported = false
+			}
+		case diagnostics.ProfileName:
+			if cs.diagnosticsHandler == nil {
+				supported = false
+			}
+		case display.ProfileName:
+			if cs.displayHandler == nil {
+				supported = false
+			}
+		case firmware.ProfileName:
+			if cs.firmwareHandler == nil {
+				supported = false
+			}
+		case iso15118.ProfileName:
+			if cs.iso15118Handler == nil {
+				supported = false
+			}
+		case localauth.ProfileName:
+			if cs.localAuthListHandler == nil {
+				supported = false
+			}
+		case meter.ProfileName:
+			if cs.meterHandler == nil {
+				supported = false
+			}
+		case provisioning.ProfileName:
+			if cs.provisioningHandler == nil {
+				supported = false
+			}
+		case remotecontrol.ProfileName:
+			if cs.remoteControlHandler == nil {
+				supported = false
+			}
+		case reservation.ProfileName:
+			if cs.reservationHandler == nil {
+				supported = false
+			}
+		case security.ProfileName:
+			if cs.securityHandler == nil {
+				supported = false
+			}
+		case smartcharging.ProfileName:
+			if cs.smartChargingHandler == nil {
+				supported = false
+			}
+		case tariffcost.ProfileName:
+			if cs.tariffCostHandler == nil {
+				supported = false
+			}
+		case transactions.ProfileName:
+			if cs.transactionsHandler == nil {
+				supported = false
+			}
+		case v2x.ProfileName:
+			if cs.v2xHandler == nil {
+				supported = false
+			}
+		case der.ProfileName:
+			if cs.derHandler == nil {
+				supported = false
+			}
+		case tariff.ProfileName:
+			if cs.tariffHandler == nil {
+				supported = false
+			}
+		case payment.ProfileName:
+			if cs.paymentHandler == nil {
+				supported = false
+			}
+		case batteryswap.ProfileName:
+			if cs.batterySwapHandler == nil {
+				supported = false
+			}
+		}
+		if !supported {
+			cs.notSupportedError(requestId, action)
+			return
+		}
+	}
+	// Process request
+	var response ocpp.Response = nil
+	cs.client.GetProfileForFeature(action)
+	var err error = nil
+	switch action {
+	case reservation.CancelReservationFeatureName:
+		response, err = cs.reservationHandler.OnCancelReservation(request.(*reservation.CancelReservationRequest))
+	case security.CertificateSignedFeatureName:
+		response, err = cs.securityHandler.OnCertificateSigned(request.(*security.CertificateSignedRequest))
+	case availability.ChangeAvailabilityFeatureName:
+		response, err = cs.availabilityHandler.OnChangeAvailability(request.(*availability.ChangeAvailabilityRequest))
+	case authorization.ClearCacheFeatureName:
+		response, err = cs.authorizationHandler.OnClearCache(request.(*authorization.ClearCacheRequest))
+	case smartcharging.ClearChargingProfileFeatureName:
+		response, err = cs.smartChargingHandler.OnClearChargingProfile(request.(*smartcharging.ClearChargingProfileRequest))
+	case display.ClearDisplayFeatureName:
+		response, err = cs.displayHandler.OnClearDisplay(request.(*display.ClearDisplayRequest))
+	case diagnostics.ClearVariableMonitoringFeatureName:
+		response, err = cs.diagnosticsHandler.OnClearVariableMonitoring(request.(*diagnostics.ClearVariableMonitoringRequest))
+	case tariffcost.CostUpdatedFeatureName:
+		response, err = cs.tariffCostHandler.OnCostUpdated(request.(*tariffcost.CostUpdatedRequest))
+	case diagnostics.CustomerInformationFeatureName:
+		response, err = cs.diagnosticsHandler.OnCustomerInformation(request.(*diagnostics.CustomerInformationRequest))
+	case data.DataTransferFeatureName:
+		response, err = cs.dataHandler.OnDataTransfer(request.(*data.DataTransferRequest))
+	case iso15118.DeleteCertificateFeatureName:
+		response, err = cs.iso15118Handler.OnDeleteCertificate(request.(*iso15118.DeleteCertificateRequest))
+	case provisioning.GetBaseReportFeatureName:
+		response, err = cs.provisioningHandler.OnGetBaseReport(request.(*provisioning.GetBaseReportRequest))
+	case smartcharging.GetChargingProfilesFeatureName:
+		response, err = cs.smartChargingHandler.OnGetChargingProfiles(request.(*smartcharging.GetChargingProfilesRequest))
+	case smartcharging.GetCompositeScheduleFeatureName:
+		response, err = cs.smartChargingHandler.OnGetCompositeSchedule(request.(*smartcharging.GetCompositeScheduleRequest))
+	case display.GetDisplayMessagesFeatureName:
+		response, err = cs.displayHandler.OnGetDisplayMessages(request.(*display.GetDisplayMessagesRequest))
+	case iso15118.GetInstalledCertificateIdsFeatureName:
+		response, err = cs.iso15118Handler.OnGetInstalledCertificateIds(request.(*iso15118.GetInstalledCertificateIdsRequest))
+	case localauth.GetLocalListVersionFeatureName:
+		response, err = cs.localAuthListHandler.OnGetLocalListVersion(request.(*localauth.GetLocalListVersionRequest))
+	case diagnostics.GetLogFeatureName:
+		response, err = cs.diagnosticsHandler.OnGetLog(request.(*diagnostics.GetLogRequest))
+	case diagnostics.GetMonitoringReportFeatureName:
+		response, err = cs.diagnosticsHandler.OnGetMonitoringReport(request.(*diagnostics.GetMonitoringReportRequest))
+	case provisioning.GetReportFeatureName:
+		response, err = cs.provisioningHandler.OnGetReport(request.(*provisioning.GetReportRequest))
+	case transactions.GetTransactionStatusFeatureName:
+		response, err = cs.transactionsHandler.OnGetTransactionStatus(request.(*transactions.GetTransactionStatusRequest))
+	case provisioning.GetVariablesFeatureName:
+		response, err = cs.provisioningHandler.OnGetVariables(request.(*provisioning.GetVariablesRequest))
+	case iso15118.InstallCertificateFeatureName:
+		response, err = cs.iso15118Handler.OnInstallCertificate(request.(*iso15118.InstallCertificateRequest))
+	case firmware.PublishFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnPublishFirmware(request.(*firmware.PublishFirmwareRequest))
+	case remotecontrol.RequestStartTransactionFeatureName:
+		response, err = cs.remoteControlHandler.OnRequestStartTransaction(request.(*remotecontrol.RequestStartTransactionRequest))
+	case remotecontrol.RequestStopTransactionFeatureName:
+		response, err = cs.remoteControlHandler.OnRequestStopTransaction(request.(*remotecontrol.RequestStopTransactionRequest))
+	case reservation.ReserveNowFeatureName:
+		response, err = cs.reservationHandler.OnReserveNow(request.(*reservation.ReserveNowRequest))
+	case provisioning.ResetFeatureName:
+		response, err = cs.provisioningHandler.OnReset(request.(*provisioning.ResetRequest))
+	case localauth.SendLocalListFeatureName:
+		response, err = cs.localAuthListHandler.OnSendLocalList(request.(*localauth.SendLocalListRequest))
+	case smartcharging.SetChargingProfileFeatureName:
+		response, err = cs.smartChargingHandler.OnSetChargingProfile(request.(*smartcharging.SetChargingProfileRequest))
+	case display.SetDisplayMessageFeatureName:
+		response, err = cs.displayHandler.OnSetDisplayMessage(request.(*display.SetDisplayMessageRequest))
+	case diagnostics.SetMonitoringBaseFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetMonitoringBase(request.(*diagnostics.SetMonitoringBaseRequest))
+	case diagnostics.SetMonitoringLevelFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetMonitoringLevel(request.(*diagnostics.SetMonitoringLevelRequest))
+	case provisioning.SetNetworkProfileFeatureName:
+		response, err = cs.provisioningHandler.OnSetNetworkProfile(request.(*provisioning.SetNetworkProfileRequest))
+	case diagnostics.SetVariableMonitoringFeatureName:
+		response, err = cs.diagnosticsHandler.OnSetVariableMonitoring(request.(*diagnostics.SetVariableMonitoringRequest))
+	case provisioning.SetVariablesFeatureName:
+		response, err = cs.provisioningHandler.OnSetVariables(request.(*provisioning.SetVariablesRequest))
+	case remotecontrol.TriggerMessageFeatureName:
+		response, err = cs.remoteControlHandler.OnTriggerMessage(request.(*remotecontrol.TriggerMessageRequest))
+	case remotecontrol.UnlockConnectorFeatureName:
+		response, err = cs.remoteControlHandler.OnUnlockConnector(request.(*remotecontrol.UnlockConnectorRequest))
+	case firmware.UnpublishFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnUnpublishFirmware(request.(*firmware.UnpublishFirmwareRequest))
+	case firmware.UpdateFirmwareFeatureName:
+		response, err = cs.firmwareHandler.OnUpdateFirmware(request.(*firmware.UpdateFirmwareRequest))
+	case v2x.NotifyAllowedEnergyTransferFeatureName:
+		response, err = cs.v2xHandler.OnNotifyAllowedEnergyTransfer(request.(*v2x.NotifyAllowedEnergyTransferRequest))
+	case v2x.AFRRSignalFeatureName:
+		response, err = cs.v2xHandler.OnAFRRSignal(request.(*v2x.AFRRSignalRequest))
+	case der.SetDERControlFeatureName:
+		response, err = cs.derHandler.OnSetDERControl(request.(*der.SetDERControlRequest))
+	case der.GetDERControlFeatureName:
+		response, err = cs.derHandler.OnGetDERControl(request.(*der.GetDERControlRequest))
+	case der.ClearDERControlFeatureName:
+		response, err = cs.derHandler.OnClearDERControl(request.(*der.ClearDERControlRequest))
+	case tariff.SetDefaultTariffFeatureName:
+		response, err = cs.tariffHandler.OnSetDefaultTariff(request.(*tariff.SetDefaultTariffRequest))
+	case tariff.GetTariffsFeatureName:
+		response, err = cs.tariffHandler.OnGetTariffs(request.(*tariff.GetTariffsRequest))
+	case tariff.ClearTariffsFeatureName:
+		response, err = cs.tariffHandler.OnClearTariffs(request.(*tariff.ClearTariffsRequest))
+	case tariff.ChangeTransactionTariffFeatureName:
+		response, err = cs.tariffHandler.OnChangeTransactionTariff(request.(*tariff.ChangeTransactionTariffRequest))
+	case payment.NotifyWebPaymentStartedFeatureName:
+		response, err = cs.paymentHandler.OnNotifyWebPaymentStarted(request.(*payment.NotifyWebPaymentStartedRequest))
+	case batteryswap.RequestBatterySwapFeatureName:
+		response, err = cs.batterySwapHandler.OnRequestBatterySwap(request.(*batteryswap.RequestBatterySwapRequest))
+	default:
+		cs.notSupportedError(requestId, action)
+		return
+	}
+	cs.sendResponse(response, err, requestId)
+}
+
+// Incoming SEND messages don't expect a response, hence no error is replied if they cannot be processed.
+func (cs *chargingStation) handleIncomingUnconfirmedRequest(request ocpp.Request, requestId string, action string) {
+	if cs.unconfirmedHandler == nil {
+		cs.error(fmt.Errorf("no handler available for unconfirmed request %s of type %v", requestId, action))
+		return
+	}
+	cs.unconfirmedHandler(request)
+}
+
+func (cs *chargingStation) handleIncomingCallResultError(err *ocpp.Error, details interface{}) {
+	if cs.resultErrorHandler == nil {
+		cs.error(fmt.Errorf("response to request %s was rejected by the CSMS: %w", err.MessageId, err))
+		return
+	}
+	cs.resultErrorHandler(err, details)
+}
@@ -0,0 +1,37 @@
+// The DER control functional block contains OCPP 2.1 features for controlling charging stations,
+// which act as a distributed energy resource (DER) by discharging an EV into the grid.
+package der
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.1 DER control profile.
+type CSMSHandler interface {
+	// OnReportDERControl is called on the CSMS whenever a ReportDERControlRequest is received from a charging station.
+	OnReportDERControl(chargingStationID string, request *ReportDERControlRequest) (response *ReportDERControlResponse, err error)
+	// OnNotifyDERAlarm is called on the CSMS whenever a NotifyDERAlarmRequest is received from a charging station.
+	OnNotifyDERAlarm(chargingStationID string, request *NotifyDERAlarmRequest) (response *NotifyDERAlarmResponse, err error)
+	// OnNotifyDERStartStop is called on the CSMS whenever a NotifyDERStartStopRequest is received from a charging station.
+	OnNotifyDERStartStop(chargingStationID string, request *NotifyDERStartStopRequest) (response *NotifyDERStartStopResponse, err error)
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.1 DER control profile.
+type ChargingStationHandler interface {
+	// OnSetDERControl is called on a charging station whenever a SetDERControlRequest is received from the CSMS.
+	OnSetDERControl(request *SetDERControlRequest) (response *SetDERControlResponse, err error)
+	// OnGetDERControl is called on a charging station whenever a GetDERControlRequest is received from the CSMS.
+	OnGetDERControl(request *GetDERControlRequest) (response *GetDERControlResponse, err error)
+	// OnClearDERControl is called on a charging station whenever a ClearDERControlRequest is received from the CSMS.
+	OnClearDERControl(request *ClearDERControlRequest) (response *ClearDERControlResponse, err error)
+}
+
+const ProfileName = "derControl"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	SetDERControlFeature{},
+	GetDERControlFeature{},
+	ClearDERControlFeature{},
+	ReportDERControlFeature{},
+	NotifyDERAlarmFeature{},
+	NotifyDERStartStopFeature{},
+)
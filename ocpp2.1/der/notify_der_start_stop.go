@@ -0,0 +1,58 @@
+package der
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+)
+
+// -------------------- Notify DER Start Stop (CS -> CSMS) --------------------
+
+const NotifyDERStartStopFeatureName = "NotifyDERStartStop"
+
+// The field definition of the NotifyDERStartStop request payload sent by the Charging Station to the CSMS.
+type NotifyDERStartStopRequest struct {
+	ControlID     string          `json:"controlId" validate:"required,max=36"`                            // ID of the control, which was started or stopped.
+	Started       bool            `json:"started"`                                                         // True if the control was started, false if it was stopped.
+	Timestamp     *types.DateTime `json:"timestamp" validate:"required"`                                   // Time of the start or stop.
+	SupersededIDs []string        `json:"supersededIds,omitempty" validate:"omitempty,max=24,dive,max=36"` // IDs of the controls, which were superseded by the started control.
+}
+
+// This field definition of the NotifyDERStartStop response payload, sent by the CSMS to the Charging Station in response to a NotifyDERStartStopRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyDERStartStopResponse struct {
+}
+
+// A Charging Station notifies the CSMS whenever a scheduled DER control starts or stops, by sending a NotifyDERStartStopRequest.
+// The CSMS responds with a NotifyDERStartStopResponse.
+type NotifyDERStartStopFeature struct{}
+
+func (f NotifyDERStartStopFeature) GetFeatureName() string {
+	return NotifyDERStartStopFeatureName
+}
+
+func (f NotifyDERStartStopFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyDERStartStopRequest{})
+}
+
+func (f NotifyDERStartStopFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyDERStartStopResponse{})
+}
+
+func (r NotifyDERStartStopRequest) GetFeatureName() string {
+	return NotifyDERStartStopFeatureName
+}
+
+func (c NotifyDERStartStopResponse) GetFeatureName() string {
+	return NotifyDERStartStopFeatureName
+}
+
+// Creates a new NotifyDERStartStopRequest, containing all required fields. Optional fields may be set afterwards.
+func NewNotifyDERStartStopRequest(controlID string, started bool, timestamp *types.DateTime) *NotifyDERStartStopRequest {
+	return &NotifyDERStartStopRequest{ControlID: controlID, Started: started, Timestamp: timestamp}
+}
+
+// Creates a new NotifyDERStartStopResponse, which doesn't contain any required or optional fields.
+func NewNotifyDERStartStopResponse() *NotifyDERStartStopResponse {
+	return &NotifyDERStartStopResponse{}
+}
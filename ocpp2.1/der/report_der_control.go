@@ -0,0 +1,92 @@
+package der
+
+import (
+	"reflect"
+)
+
+// -------------------- Report DER Control (CS -> CSMS) --------------------
+
+const ReportDERControlFeatureName = "ReportDERControl"
+
+// A curve-based DER control, as reported by a Charging Station.
+type DERCurveGet struct {
+	ID           string         `json:"id" validate:"required,max=36"`                  // ID of the control.
+	CurveType    DERControlType `json:"curveType" validate:"required,derControlType21"` // Type of the control.
+	IsDefault    bool           `json:"isDefault"`                                      // True if this is a default control.
+	IsSuperseded bool           `json:"isSuperseded"`                                   // True if the control is superseded by a control with a higher priority.
+	Curve        DERCurve       `json:"curve" validate:"required"`                      // The curve settings.
+}
+
+// A fixed power factor control, as reported by a Charging Station.
+type FixedPFGet struct {
+	ID           string  `json:"id" validate:"required,max=36"` // ID of the control.
+	IsDefault    bool    `json:"isDefault"`                     // True if this is a default control.
+	IsSuperseded bool    `json:"isSuperseded"`                  // True if the control is superseded by a control with a higher priority.
+	FixedPF      FixedPF `json:"fixedPF" validate:"required"`   // The power factor settings.
+}
+
+// A fixed reactive power control, as reported by a Charging Station.
+type FixedVarGet struct {
+	ID           string   `json:"id" validate:"required,max=36"` // ID of the control.
+	IsDefault    bool     `json:"isDefault"`                     // True if this is a default control.
+	IsSuperseded bool     `json:"isSuperseded"`                  // True if the control is superseded by a control with a higher priority.
+	FixedVar     FixedVar `json:"fixedVar" validate:"required"`  // The reactive power settings.
+}
+
+// A discharge limit control, as reported by a Charging Station.
+type LimitMaxDischargeGet struct {
+	ID                string            `json:"id" validate:"required,max=36"`         // ID of the control.
+	IsDefault         bool              `json:"isDefault"`                             // True if this is a default control.
+	IsSuperseded      bool              `json:"isSuperseded"`                          // True if the control is superseded by a control with a higher priority.
+	LimitMaxDischarge LimitMaxDischarge `json:"limitMaxDischarge" validate:"required"` // The discharge limit settings.
+}
+
+// The field definition of the ReportDERControl request payload sent by the Charging Station to the CSMS.
+type ReportDERControlRequest struct {
+	RequestID         int                    `json:"requestId"`                                                    // ID of the GetDERControlRequest, that requested this report.
+	Tbc               bool                   `json:"tbc,omitempty" validate:"omitempty"`                           // “to be continued” indicator. Indicates whether another part of the report follows in an upcoming ReportDERControlRequest message. Default value when omitted is false.
+	Curve             []DERCurveGet          `json:"curve,omitempty" validate:"omitempty,max=24,dive"`             // Curve-based controls.
+	FixedPFAbsorb     []FixedPFGet           `json:"fixedPFAbsorb,omitempty" validate:"omitempty,max=24,dive"`     // Fixed power factor controls, when absorbing reactive power.
+	FixedPFInject     []FixedPFGet           `json:"fixedPFInject,omitempty" validate:"omitempty,max=24,dive"`     // Fixed power factor controls, when injecting reactive power.
+	FixedVar          []FixedVarGet          `json:"fixedVar,omitempty" validate:"omitempty,max=24,dive"`          // Fixed reactive power controls.
+	LimitMaxDischarge []LimitMaxDischargeGet `json:"limitMaxDischarge,omitempty" validate:"omitempty,max=24,dive"` // Discharge limit controls.
+}
+
+// This field definition of the ReportDERControl response payload, sent by the CSMS to the Charging Station in response to a ReportDERControlRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ReportDERControlResponse struct {
+}
+
+// After accepting a GetDERControlRequest, the Charging Station reports the matching DER controls to the CSMS,
+// by sending one or more ReportDERControlRequest messages. The CSMS responds to each with a ReportDERControlResponse.
+type ReportDERControlFeature struct{}
+
+func (f ReportDERControlFeature) GetFeatureName() string {
+	return ReportDERControlFeatureName
+}
+
+func (f ReportDERControlFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ReportDERControlRequest{})
+}
+
+func (f ReportDERControlFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ReportDERControlResponse{})
+}
+
+func (r ReportDERControlRequest) GetFeatureName() string {
+	return ReportDERControlFeatureName
+}
+
+func (c ReportDERControlResponse) GetFeatureName() string {
+	return ReportDERControlFeatureName
+}
+
+// Creates a new ReportDERControlRequest, containing all required fields. Optional fields may be set afterwards.
+func NewReportDERControlRequest(requestID int) *ReportDERControlRequest {
+	return &ReportDERControlRequest{RequestID: requestID}
+}
+
+// Creates a new ReportDERControlResponse, which doesn't contain any required or optional fields.
+func NewReportDERControlResponse() *ReportDERControlResponse {
+	return &ReportDERControlResponse{}
+}
@@ -0,0 +1,203 @@
+package der
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Set DER Control (CSMS -> CS) --------------------
+
+const SetDERControlFeatureName = "SetDERControl"
+
+// Type of a DER control, as defined by IEEE 1547 and IEC 61850.
+type DERControlType string
+
+const (
+	DERControlTypeEnterService            DERControlType = "EnterService"
+	DERControlTypeFreqDroop               DERControlType = "FreqDroop"
+	DERControlTypeFreqWatt                DERControlType = "FreqWatt"
+	DERControlTypeFixedPFAbsorb           DERControlType = "FixedPFAbsorb"
+	DERControlTypeFixedPFInject           DERControlType = "FixedPFInject"
+	DERControlTypeFixedVar                DERControlType = "FixedVar"
+	DERControlTypeGradients               DERControlType = "Gradients"
+	DERControlTypeHFMustTrip              DERControlType = "HFMustTrip"
+	DERControlTypeHFMayTrip               DERControlType = "HFMayTrip"
+	DERControlTypeHVMustTrip              DERControlType = "HVMustTrip"
+	DERControlTypeHVMomCess               DERControlType = "HVMomCess"
+	DERControlTypeHVMayTrip               DERControlType = "HVMayTrip"
+	DERControlTypeLimitMaxDischarge       DERControlType = "LimitMaxDischarge"
+	DERControlTypeLFMustTrip              DERControlType = "LFMustTrip"
+	DERControlTypeLVMustTrip              DERControlType = "LVMustTrip"
+	DERControlTypeLVMomCess               DERControlType = "LVMomCess"
+	DERControlTypeLVMayTrip               DERControlType = "LVMayTrip"
+	DERControlTypePowerMonitoringMustTrip DERControlType = "PowerMonitoringMustTrip"
+	DERControlTypeVoltVar                 DERControlType = "VoltVar"
+	DERControlTypeVoltWatt                DERControlType = "VoltWatt"
+	DERControlTypeWattPF                  DERControlType = "WattPF"
+	DERControlTypeWattVar                 DERControlType = "WattVar"
+)
+
+func isValidDERControlType(fl validator.FieldLevel) bool {
+	controlType := DERControlType(fl.Field().String())
+	switch controlType {
+	case DERControlTypeEnterService, DERControlTypeFreqDroop, DERControlTypeFreqWatt, DERControlTypeFixedPFAbsorb,
+		DERControlTypeFixedPFInject, DERControlTypeFixedVar, DERControlTypeGradients, DERControlTypeHFMustTrip,
+		DERControlTypeHFMayTrip, DERControlTypeHVMustTrip, DERControlTypeHVMomCess, DERControlTypeHVMayTrip,
+		DERControlTypeLimitMaxDischarge, DERControlTypeLFMustTrip, DERControlTypeLVMustTrip, DERControlTypeLVMomCess,
+		DERControlTypeLVMayTrip, DERControlTypePowerMonitoringMustTrip, DERControlTypeVoltVar, DERControlTypeVoltWatt,
+		DERControlTypeWattPF, DERControlTypeWattVar:
+		return true
+	default:
+		return false
+	}
+}
+
+// Unit of the Y-axis of a DER curve, or of a setpoint.
+type DERUnit string
+
+const (
+	DERUnitNotApplicable DERUnit = "Not_Applicable"
+	DERUnitPctMaxW       DERUnit = "PctMaxW"
+	DERUnitPctMaxVar     DERUnit = "PctMaxVar"
+	DERUnitPctWAvail     DERUnit = "PctWAvail"
+	DERUnitPctVarAvail   DERUnit = "PctVarAvail"
+	DERUnitPctEffectiveV DERUnit = "PctEffectiveV"
+)
+
+func isValidDERUnit(fl validator.FieldLevel) bool {
+	unit := DERUnit(fl.Field().String())
+	switch unit {
+	case DERUnitNotApplicable, DERUnitPctMaxW, DERUnitPctMaxVar, DERUnitPctWAvail, DERUnitPctVarAvail, DERUnitPctEffectiveV:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status returned in response to SetDERControlRequest, GetDERControlRequest and ClearDERControlRequest.
+type DERControlStatus string
+
+const (
+	DERControlStatusAccepted     DERControlStatus = "Accepted"
+	DERControlStatusRejected     DERControlStatus = "Rejected"
+	DERControlStatusNotSupported DERControlStatus = "NotSupported"
+	DERControlStatusNotFound     DERControlStatus = "NotFound"
+)
+
+func isValidDERControlStatus(fl validator.FieldLevel) bool {
+	status := DERControlStatus(fl.Field().String())
+	switch status {
+	case DERControlStatusAccepted, DERControlStatusRejected, DERControlStatusNotSupported, DERControlStatusNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+// A single point of a DER curve.
+type DERCurvePoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// DERCurve defines the behavior of a curve-based DER control, e.g. VoltVar or FreqWatt.
+type DERCurve struct {
+	CurveData    []DERCurvePoint `json:"curveData" validate:"required,min=1,max=10"`        // The points of the curve.
+	Priority     int             `json:"priority" validate:"gte=0"`                         // Priority of the setting, 0 being the highest priority.
+	YUnit        DERUnit         `json:"yUnit" validate:"required,derUnit21"`               // Unit of the Y-axis of the curve.
+	ResponseTime *float64        `json:"responseTime,omitempty" validate:"omitempty,gte=0"` // Open loop response time in seconds.
+	StartTime    *types.DateTime `json:"startTime,omitempty" validate:"omitempty"`          // Point in time when the setting becomes active.
+	Duration     *float64        `json:"duration,omitempty" validate:"omitempty,gte=0"`     // Duration in seconds that the setting is active.
+}
+
+// FixedPF sets a fixed power factor for absorbing or injecting reactive power.
+type FixedPF struct {
+	Priority     int             `json:"priority" validate:"gte=0"`                     // Priority of the setting, 0 being the highest priority.
+	Displacement float64         `json:"displacement"`                                  // Power factor, cos(phi), as value between 0 and 1.
+	Excitation   bool            `json:"excitation"`                                    // True when absorbing reactive power (under-excited).
+	StartTime    *types.DateTime `json:"startTime,omitempty" validate:"omitempty"`      // Point in time when the setting becomes active.
+	Duration     *float64        `json:"duration,omitempty" validate:"omitempty,gte=0"` // Duration in seconds that the setting is active.
+}
+
+// FixedVar sets a fixed reactive power setpoint.
+type FixedVar struct {
+	Priority  int             `json:"priority" validate:"gte=0"`                     // Priority of the setting, 0 being the highest priority.
+	Setpoint  float64         `json:"setpoint"`                                      // The value specifies a target var output, interpreted according to the unit.
+	Unit      DERUnit         `json:"unit" validate:"required,derUnit21"`            // Unit of the setpoint.
+	StartTime *types.DateTime `json:"startTime,omitempty" validate:"omitempty"`      // Point in time when the setting becomes active.
+	Duration  *float64        `json:"duration,omitempty" validate:"omitempty,gte=0"` // Duration in seconds that the setting is active.
+}
+
+// LimitMaxDischarge limits the discharging power of the charging station.
+type LimitMaxDischarge struct {
+	Priority                int             `json:"priority" validate:"gte=0"`                                 // Priority of the setting, 0 being the highest priority.
+	PctMaxDischargePower    *float64        `json:"pctMaxDischargePower,omitempty" validate:"omitempty,gte=0"` // Only for PowerMonitoring. The value specifies a percentage of the maximum discharge power.
+	PowerMonitoringMustTrip *DERCurve       `json:"powerMonitoringMustTrip,omitempty" validate:"omitempty"`    // Only for PowerMonitoring. The curve to trip at.
+	StartTime               *types.DateTime `json:"startTime,omitempty" validate:"omitempty"`                  // Point in time when the setting becomes active.
+	Duration                *float64        `json:"duration,omitempty" validate:"omitempty,gte=0"`             // Duration in seconds that the setting is active.
+}
+
+// The field definition of the SetDERControl request payload sent by the CSMS to the Charging Station.
+// Only the setting matching the ControlType shall be set:
+// FixedPFAbsorb, FixedPFInject, FixedVar and LimitMaxDischarge have a dedicated field, all other control types use a Curve.
+type SetDERControlRequest struct {
+	IsDefault         bool               `json:"isDefault"`                                        // True if this is a default DER control.
+	ControlID         string             `json:"controlId" validate:"required,max=36"`             // Unique ID of this setting.
+	ControlType       DERControlType     `json:"controlType" validate:"required,derControlType21"` // Type of the control.
+	Curve             *DERCurve          `json:"curve,omitempty" validate:"omitempty"`             // Curve-based settings.
+	FixedPFAbsorb     *FixedPF           `json:"fixedPFAbsorb,omitempty" validate:"omitempty"`     // Fixed power factor, when absorbing reactive power.
+	FixedPFInject     *FixedPF           `json:"fixedPFInject,omitempty" validate:"omitempty"`     // Fixed power factor, when injecting reactive power.
+	FixedVar          *FixedVar          `json:"fixedVar,omitempty" validate:"omitempty"`          // Fixed reactive power setpoint.
+	LimitMaxDischarge *LimitMaxDischarge `json:"limitMaxDischarge,omitempty" validate:"omitempty"` // Limit of the discharging power.
+}
+
+// This field definition of the SetDERControl response payload, sent by the Charging Station to the CSMS in response to a SetDERControlRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetDERControlResponse struct {
+	Status        DERControlStatus  `json:"status" validate:"required,derControlStatus21"`
+	SupersededIDs []string          `json:"supersededIds,omitempty" validate:"omitempty,max=24,dive,max=36"` // IDs of the controls, which were superseded by this control.
+	StatusInfo    *types.StatusInfo `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The CSMS configures a DER control on a Charging Station by sending a SetDERControlRequest.
+// A default control is always active, unless a scheduled control of the same type is active.
+// The Charging Station responds with a SetDERControlResponse, containing the IDs of the controls superseded by the new one.
+type SetDERControlFeature struct{}
+
+func (f SetDERControlFeature) GetFeatureName() string {
+	return SetDERControlFeatureName
+}
+
+func (f SetDERControlFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetDERControlRequest{})
+}
+
+func (f SetDERControlFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetDERControlResponse{})
+}
+
+func (r SetDERControlRequest) GetFeatureName() string {
+	return SetDERControlFeatureName
+}
+
+func (c SetDERControlResponse) GetFeatureName() string {
+	return SetDERControlFeatureName
+}
+
+// Creates a new SetDERControlRequest, containing all required fields. The setting for the control type must be set afterwards.
+func NewSetDERControlRequest(isDefault bool, controlID string, controlType DERControlType) *SetDERControlRequest {
+	return &SetDERControlRequest{IsDefault: isDefault, ControlID: controlID, ControlType: controlType}
+}
+
+// Creates a new SetDERControlResponse, containing all required fields. Optional fields may be set afterwards.
+func NewSetDERControlResponse(status DERControlStatus) *SetDERControlResponse {
+	return &SetDERControlResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("derControlType21", isValidDERControlType)
+	_ = types.Validate.RegisterValidation("derUnit21", isValidDERUnit)
+	_ = types.Validate.RegisterValidation("derControlStatus21", isValidDERControlStatus)
+}
@@ -0,0 +1,91 @@
+package payment
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Notify Settlement (CS -> CSMS) --------------------
+
+const NotifySettlementFeatureName = "NotifySettlement"
+
+// Status of a payment settlement.
+type PaymentStatus string
+
+const (
+	PaymentStatusSettled  PaymentStatus = "Settled"
+	PaymentStatusCanceled PaymentStatus = "Canceled"
+	PaymentStatusRejected PaymentStatus = "Rejected"
+	PaymentStatusFailed   PaymentStatus = "Failed"
+)
+
+func isValidPaymentStatus(fl validator.FieldLevel) bool {
+	status := PaymentStatus(fl.Field().String())
+	switch status {
+	case PaymentStatusSettled, PaymentStatusCanceled, PaymentStatusRejected, PaymentStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the NotifySettlement request payload sent by the Charging Station to the CSMS.
+type NotifySettlementRequest struct {
+	TransactionID    string          `json:"transactionId,omitempty" validate:"omitempty,max=36"` // The transaction the payment belongs to. Omitted if the payment failed before a transaction was started.
+	PspRef           string          `json:"pspRef" validate:"required,max=255"`                  // The payment reference received from the payment service provider.
+	Status           PaymentStatus   `json:"status" validate:"required,paymentStatus21"`          // The status of the settlement.
+	StatusInfo       string          `json:"statusInfo,omitempty" validate:"omitempty,max=500"`   // Additional information from the payment terminal.
+	SettlementAmount float64         `json:"settlementAmount"`                                    // The amount that was settled, or attempted to be settled.
+	SettlementTime   *types.DateTime `json:"settlementTime" validate:"required"`                  // The time when the settlement was done.
+	ReceiptID        string          `json:"receiptId,omitempty" validate:"omitempty,max=50"`     // The ID of the receipt, generated by the payment terminal.
+	ReceiptURL       string          `json:"receiptUrl,omitempty" validate:"omitempty,max=2000"`  // The URL of the receipt, generated by the payment terminal.
+	VatNumber        string          `json:"vatNumber,omitempty" validate:"omitempty,max=20"`     // The VAT number for a company receipt.
+}
+
+// This field definition of the NotifySettlement response payload, sent by the CSMS to the Charging Station in response to a NotifySettlementRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifySettlementResponse struct {
+	ReceiptURL string `json:"receiptUrl,omitempty" validate:"omitempty,max=2000"` // The URL of the receipt, generated by the CSMS.
+	ReceiptID  string `json:"receiptId,omitempty" validate:"omitempty,max=50"`    // The ID of the receipt, generated by the CSMS.
+}
+
+// After the payment for a transaction was settled via a local payment terminal, the Charging Station
+// informs the CSMS about the result by sending a NotifySettlementRequest.
+// The CSMS responds with a NotifySettlementResponse, optionally containing a receipt, which may be shown to the driver.
+type NotifySettlementFeature struct{}
+
+func (f NotifySettlementFeature) GetFeatureName() string {
+	return NotifySettlementFeatureName
+}
+
+func (f NotifySettlementFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifySettlementRequest{})
+}
+
+func (f NotifySettlementFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifySettlementResponse{})
+}
+
+func (r NotifySettlementRequest) GetFeatureName() string {
+	return NotifySettlementFeatureName
+}
+
+func (c NotifySettlementResponse) GetFeatureName() string {
+	return NotifySettlementFeatureName
+}
+
+// Creates a new NotifySettlementRequest, containing all required fields. Optional fields may be set afterwards.
+func NewNotifySettlementRequest(pspRef string, status PaymentStatus, settlementAmount float64, settlementTime *types.DateTime) *NotifySettlementRequest {
+	return &NotifySettlementRequest{PspRef: pspRef, Status: status, SettlementAmount: settlementAmount, SettlementTime: settlementTime}
+}
+
+// Creates a new NotifySettlementResponse, which doesn't contain any required fields. Optional fields may be set afterwards.
+func NewNotifySettlementResponse() *NotifySettlementResponse {
+	return &NotifySettlementResponse{}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("paymentStatus21", isValidPaymentStatus)
+}
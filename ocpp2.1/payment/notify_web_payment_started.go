@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"reflect"
+)
+
+// -------------------- Notify Web Payment Started (CSMS -> CS) --------------------
+
+const NotifyWebPaymentStartedFeatureName = "NotifyWebPaymentStarted"
+
+// The field definition of the NotifyWebPaymentStarted request payload sent by the CSMS to the Charging Station.
+type NotifyWebPaymentStartedRequest struct {
+	EvseID  int `json:"evseId" validate:"gte=0"`  // The EVSE, for which the web payment was started.
+	Timeout int `json:"timeout" validate:"gte=0"` // Time in seconds, after which the web payment is considered to have failed.
+}
+
+// This field definition of the NotifyWebPaymentStarted response payload, sent by the Charging Station to the CSMS in response to a NotifyWebPaymentStartedRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyWebPaymentStartedResponse struct {
+}
+
+// After a driver opened the payment web page, e.g. by scanning a QR code on the Charging Station,
+// the CSMS informs the Charging Station by sending a NotifyWebPaymentStartedRequest.
+// The Charging Station responds with a NotifyWebPaymentStartedResponse and may show the progress to the driver.
+type NotifyWebPaymentStartedFeature struct{}
+
+func (f NotifyWebPaymentStartedFeature) GetFeatureName() string {
+	return NotifyWebPaymentStartedFeatureName
+}
+
+func (f NotifyWebPaymentStartedFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyWebPaymentStartedRequest{})
+}
+
+func (f NotifyWebPaymentStartedFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyWebPaymentStartedResponse{})
+}
+
+func (r NotifyWebPaymentStartedRequest) GetFeatureName() string {
+	return NotifyWebPaymentStartedFeatureName
+}
+
+func (c NotifyWebPaymentStartedResponse) GetFeatureName() string {
+	return NotifyWebPaymentStartedFeatureName
+}
+
+// Creates a new NotifyWebPaymentStartedRequest, containing all required fields. There are no optional fields for this message.
+func NewNotifyWebPaymentStartedRequest(evseID int, timeout int) *NotifyWebPaymentStartedRequest {
+	return &NotifyWebPaymentStartedRequest{EvseID: evseID, Timeout: timeout}
+}
+
+// Creates a new NotifyWebPaymentStartedResponse, which doesn't contain any required or optional fields.
+func NewNotifyWebPaymentStartedResponse() *NotifyWebPaymentStartedResponse {
+	return &NotifyWebPaymentStartedResponse{}
+}
@@ -0,0 +1,31 @@
+// The payment functional block contains OCPP 2.1 features for ad-hoc payments at a charging station,
+// e.g. via a payment terminal or a web payment initiated by scanning a QR code.
+package payment
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.1 Payment profile.
+type CSMSHandler interface {
+	// OnNotifySettlement is called on the CSMS whenever a NotifySettlementRequest is received from a charging station.
+	OnNotifySettlement(chargingStationID string, request *NotifySettlementRequest) (response *NotifySettlementResponse, err error)
+	// OnVatNumberValidation is called on the CSMS whenever a VatNumberValidationRequest is received from a charging station.
+	OnVatNumberValidation(chargingStationID string, request *VatNumberValidationRequest) (response *VatNumberValidationResponse, err error)
+	// OnNotifyQRCodeScanned is called on the CSMS whenever a NotifyQRCodeScannedRequest is received from a charging station.
+	OnNotifyQRCodeScanned(chargingStationID string, request *NotifyQRCodeScannedRequest) (response *NotifyQRCodeScannedResponse, err error)
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.1 Payment profile.
+type ChargingStationHandler interface {
+	// OnNotifyWebPaymentStarted is called on a charging station whenever a NotifyWebPaymentStartedRequest is received from the CSMS.
+	OnNotifyWebPaymentStarted(request *NotifyWebPaymentStartedRequest) (response *NotifyWebPaymentStartedResponse, err error)
+}
+
+const ProfileName = "payment"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	NotifySettlementFeature{},
+	NotifyWebPaymentStartedFeature{},
+	VatNumberValidationFeature{},
+	NotifyQRCodeScannedFeature{},
+)
@@ -0,0 +1,72 @@
+package payment
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+)
+
+// -------------------- Vat Number Validation (CS -> CSMS) --------------------
+
+const VatNumberValidationFeatureName = "VatNumberValidation"
+
+// Address of a company, as registered for a VAT number.
+type Address struct {
+	Name       string `json:"name" validate:"required,max=50"`
+	Address1   string `json:"address1" validate:"required,max=100"`
+	Address2   string `json:"address2,omitempty" validate:"omitempty,max=100"`
+	City       string `json:"city" validate:"required,max=100"`
+	PostalCode string `json:"postalCode,omitempty" validate:"omitempty,max=20"`
+	Country    string `json:"country" validate:"required,max=50"`
+}
+
+// The field definition of the VatNumberValidation request payload sent by the Charging Station to the CSMS.
+type VatNumberValidationRequest struct {
+	VatNumber string `json:"vatNumber" validate:"required,max=20"`        // The VAT number to validate.
+	EvseID    *int   `json:"evseId,omitempty" validate:"omitempty,gte=0"` // The EVSE, on which the VAT number was entered.
+}
+
+// This field definition of the VatNumberValidation response payload, sent by the CSMS to the Charging Station in response to a VatNumberValidationRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type VatNumberValidationResponse struct {
+	Company    *Address            `json:"company,omitempty" validate:"omitempty"` // The company registered for the VAT number.
+	StatusInfo *types.StatusInfo   `json:"statusInfo,omitempty" validate:"omitempty"`
+	VatNumber  string              `json:"vatNumber" validate:"required,max=20"`        // The validated VAT number.
+	EvseID     *int                `json:"evseId,omitempty" validate:"omitempty,gte=0"` // The EVSE, on which the VAT number was entered.
+	Status     types.GenericStatus `json:"status" validate:"required,genericStatus21"`  // Accepted if the VAT number is valid.
+}
+
+// A driver may request a company receipt by entering a VAT number on the Charging Station.
+// The Charging Station validates the VAT number by sending a VatNumberValidationRequest to the CSMS.
+// The CSMS responds with a VatNumberValidationResponse, containing the registered company address if the VAT number is valid.
+type VatNumberValidationFeature struct{}
+
+func (f VatNumberValidationFeature) GetFeatureName() string {
+	return VatNumberValidationFeatureName
+}
+
+func (f VatNumberValidationFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(VatNumberValidationRequest{})
+}
+
+func (f VatNumberValidationFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(VatNumberValidationResponse{})
+}
+
+func (r VatNumberValidationRequest) GetFeatureName() string {
+	return VatNumberValidationFeatureName
+}
+
+func (c VatNumberValidationResponse) GetFeatureName() string {
+	return VatNumberValidationFeatureName
+}
+
+// Creates a new VatNumberValidationRequest, containing all required fields. Optional fields may be set afterwards.
+func NewVatNumberValidationRequest(vatNumber string) *VatNumberValidationRequest {
+	return &VatNumberValidationRequest{VatNumber: vatNumber}
+}
+
+// Creates a new VatNumberValidationResponse, containing all required fields. Optional fields may be set afterwards.
+func NewVatNumberValidationResponse(vatNumber string, status types.GenericStatus) *VatNumberValidationResponse {
+	return &VatNumberValidationResponse{VatNumber: vatNumber, Status: status}
+}
@@ -0,0 +1,87 @@
+package tariff
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Change Transaction Tariff (CSMS -> CS) --------------------
+
+const ChangeTransactionTariffFeatureName = "ChangeTransactionTariff"
+
+// Status returned in response to ChangeTransactionTariffRequest.
+type TariffChangeStatus string
+
+const (
+	TariffChangeStatusAccepted              TariffChangeStatus = "Accepted"
+	TariffChangeStatusRejected              TariffChangeStatus = "Rejected"
+	TariffChangeStatusTooManyElements       TariffChangeStatus = "TooManyElements"
+	TariffChangeStatusConditionNotSupported TariffChangeStatus = "ConditionNotSupported"
+	TariffChangeStatusTxNotFound            TariffChangeStatus = "TxNotFound"
+	TariffChangeStatusNoCurrencyChange      TariffChangeStatus = "NoCurrencyChange"
+)
+
+func isValidTariffChangeStatus(fl validator.FieldLevel) bool {
+	status := TariffChangeStatus(fl.Field().String())
+	switch status {
+	case TariffChangeStatusAccepted, TariffChangeStatusRejected, TariffChangeStatusTooManyElements,
+		TariffChangeStatusConditionNotSupported, TariffChangeStatusTxNotFound, TariffChangeStatusNoCurrencyChange:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the ChangeTransactionTariff request payload sent by the CSMS to the Charging Station.
+type ChangeTransactionTariffRequest struct {
+	TransactionID string `json:"transactionId" validate:"required,max=36"` // The transaction to change the tariff of.
+	Tariff        Tariff `json:"tariff" validate:"required"`               // The new tariff.
+}
+
+// This field definition of the ChangeTransactionTariff response payload, sent by the Charging Station to the CSMS in response to a ChangeTransactionTariffRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ChangeTransactionTariffResponse struct {
+	Status     TariffChangeStatus `json:"status" validate:"required,tariffChangeStatus21"`
+	StatusInfo *types.StatusInfo  `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The CSMS replaces the tariff of an ongoing transaction by sending a ChangeTransactionTariffRequest.
+// The new tariff applies from that moment on, the cost accrued so far remains unchanged.
+// The Charging Station responds with a ChangeTransactionTariffResponse.
+type ChangeTransactionTariffFeature struct{}
+
+func (f ChangeTransactionTariffFeature) GetFeatureName() string {
+	return ChangeTransactionTariffFeatureName
+}
+
+func (f ChangeTransactionTariffFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ChangeTransactionTariffRequest{})
+}
+
+func (f ChangeTransactionTariffFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ChangeTransactionTariffResponse{})
+}
+
+func (r ChangeTransactionTariffRequest) GetFeatureName() string {
+	return ChangeTransactionTariffFeatureName
+}
+
+func (c ChangeTransactionTariffResponse) GetFeatureName() string {
+	return ChangeTransactionTariffFeatureName
+}
+
+// Creates a new ChangeTransactionTariffRequest, containing all required fields. There are no optional fields for this message.
+func NewChangeTransactionTariffRequest(transactionID string, tariff Tariff) *ChangeTransactionTariffRequest {
+	return &ChangeTransactionTariffRequest{TransactionID: transactionID, Tariff: tariff}
+}
+
+// Creates a new ChangeTransactionTariffResponse, containing all required fields. Optional fields may be set afterwards.
+func NewChangeTransactionTariffResponse(status TariffChangeStatus) *ChangeTransactionTariffResponse {
+	return &ChangeTransactionTariffResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("tariffChangeStatus21", isValidTariffChangeStatus)
+}
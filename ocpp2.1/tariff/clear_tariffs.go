@@ -0,0 +1,88 @@
+package tariff
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Clear Tariffs (CSMS -> CS) --------------------
+
+const ClearTariffsFeatureName = "ClearTariffs"
+
+// Status of a single tariff, returned in response to ClearTariffsRequest.
+type TariffClearStatus string
+
+const (
+	TariffClearStatusAccepted TariffClearStatus = "Accepted"
+	TariffClearStatusRejected TariffClearStatus = "Rejected"
+	TariffClearStatusNoTariff TariffClearStatus = "NoTariff"
+)
+
+func isValidTariffClearStatus(fl validator.FieldLevel) bool {
+	status := TariffClearStatus(fl.Field().String())
+	switch status {
+	case TariffClearStatusAccepted, TariffClearStatusRejected, TariffClearStatusNoTariff:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result of clearing a single tariff.
+type ClearTariffsResult struct {
+	TariffID   string            `json:"tariffId,omitempty" validate:"omitempty,max=60"` // ID of the tariff. Omitted if no tariff was found.
+	Status     TariffClearStatus `json:"status" validate:"required,tariffClearStatus21"`
+	StatusInfo *types.StatusInfo `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The field definition of the ClearTariffs request payload sent by the CSMS to the Charging Station.
+type ClearTariffsRequest struct {
+	TariffIDs []string `json:"tariffIds,omitempty" validate:"omitempty,dive,max=60"` // Tariffs to clear. All tariffs are cleared when omitted.
+	EvseID    *int     `json:"evseId,omitempty" validate:"omitempty,gte=0"`          // Only clear tariffs of this EVSE.
+}
+
+// This field definition of the ClearTariffs response payload, sent by the Charging Station to the CSMS in response to a ClearTariffsRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type ClearTariffsResponse struct {
+	ClearTariffsResult []ClearTariffsResult `json:"clearTariffsResult" validate:"required,min=1,dive"`
+}
+
+// The CSMS removes tariffs from a Charging Station by sending a ClearTariffsRequest.
+// The Charging Station responds with a ClearTariffsResponse, containing a result for every tariff.
+type ClearTariffsFeature struct{}
+
+func (f ClearTariffsFeature) GetFeatureName() string {
+	return ClearTariffsFeatureName
+}
+
+func (f ClearTariffsFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(ClearTariffsRequest{})
+}
+
+func (f ClearTariffsFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(ClearTariffsResponse{})
+}
+
+func (r ClearTariffsRequest) GetFeatureName() string {
+	return ClearTariffsFeatureName
+}
+
+func (c ClearTariffsResponse) GetFeatureName() string {
+	return ClearTariffsFeatureName
+}
+
+// Creates a new ClearTariffsRequest, which doesn't contain any required fields. Optional fields may be set afterwards.
+func NewClearTariffsRequest() *ClearTariffsRequest {
+	return &ClearTariffsRequest{}
+}
+
+// Creates a new ClearTariffsResponse, containing all required fields. There are no optional fields for this message.
+func NewClearTariffsResponse(results ...ClearTariffsResult) *ClearTariffsResponse {
+	return &ClearTariffsResponse{ClearTariffsResult: results}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("tariffClearStatus21", isValidTariffClearStatus)
+}
@@ -0,0 +1,109 @@
+package tariff
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Get Tariffs (CSMS -> CS) --------------------
+
+const GetTariffsFeatureName = "GetTariffs"
+
+// Status returned in response to GetTariffsRequest.
+type TariffGetStatus string
+
+const (
+	TariffGetStatusAccepted TariffGetStatus = "Accepted"
+	TariffGetStatusRejected TariffGetStatus = "Rejected"
+	TariffGetStatusNoTariff TariffGetStatus = "NoTariff"
+)
+
+func isValidTariffGetStatus(fl validator.FieldLevel) bool {
+	status := TariffGetStatus(fl.Field().String())
+	switch status {
+	case TariffGetStatusAccepted, TariffGetStatusRejected, TariffGetStatusNoTariff:
+		return true
+	default:
+		return false
+	}
+}
+
+// Kind of an installed tariff.
+type TariffKind string
+
+const (
+	TariffKindDefaultTariff TariffKind = "DefaultTariff"
+	TariffKindDriverTariff  TariffKind = "DriverTariff"
+)
+
+func isValidTariffKind(fl validator.FieldLevel) bool {
+	kind := TariffKind(fl.Field().String())
+	switch kind {
+	case TariffKindDefaultTariff, TariffKindDriverTariff:
+		return true
+	default:
+		return false
+	}
+}
+
+// Describes a tariff installed on a Charging Station, and where it is used.
+type TariffAssignment struct {
+	TariffID   string     `json:"tariffId" validate:"required,max=60"`                  // Unique ID of the tariff.
+	TariffKind TariffKind `json:"tariffKind" validate:"required,tariffKind21"`          // Kind of the tariff.
+	EvseIDs    []int      `json:"evseIds,omitempty" validate:"omitempty,dive,gte=0"`    // EVSEs the tariff is assigned to.
+	IdTokens   []string   `json:"idTokens,omitempty" validate:"omitempty,dive,max=255"` // IdTokens the tariff is assigned to. Only for driver tariffs.
+}
+
+// The field definition of the GetTariffs request payload sent by the CSMS to the Charging Station.
+type GetTariffsRequest struct {
+	EvseID int `json:"evseId" validate:"gte=0"` // Only report tariffs of this EVSE. 0 reports the tariffs of all EVSEs.
+}
+
+// This field definition of the GetTariffs response payload, sent by the Charging Station to the CSMS in response to a GetTariffsRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type GetTariffsResponse struct {
+	Status            TariffGetStatus    `json:"status" validate:"required,tariffGetStatus21"`
+	StatusInfo        *types.StatusInfo  `json:"statusInfo,omitempty" validate:"omitempty"`
+	TariffAssignments []TariffAssignment `json:"tariffAssignments,omitempty" validate:"omitempty,dive"`
+}
+
+// The CSMS requests the tariffs installed on a Charging Station by sending a GetTariffsRequest.
+// The Charging Station responds with a GetTariffsResponse, which contains the IDs and assignments of all matching tariffs.
+type GetTariffsFeature struct{}
+
+func (f GetTariffsFeature) GetFeatureName() string {
+	return GetTariffsFeatureName
+}
+
+func (f GetTariffsFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(GetTariffsRequest{})
+}
+
+func (f GetTariffsFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(GetTariffsResponse{})
+}
+
+func (r GetTariffsRequest) GetFeatureName() string {
+	return GetTariffsFeatureName
+}
+
+func (c GetTariffsResponse) GetFeatureName() string {
+	return GetTariffsFeatureName
+}
+
+// Creates a new GetTariffsRequest, containing all required fields. There are no optional fields for this message.
+func NewGetTariffsRequest(evseID int) *GetTariffsRequest {
+	return &GetTariffsRequest{EvseID: evseID}
+}
+
+// Creates a new GetTariffsResponse, containing all required fields. Optional fields may be set afterwards.
+func NewGetTariffsResponse(status TariffGetStatus) *GetTariffsResponse {
+	return &GetTariffsResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("tariffGetStatus21", isValidTariffGetStatus)
+	_ = types.Validate.RegisterValidation("tariffKind21", isValidTariffKind)
+}
@@ -0,0 +1,59 @@
+package tariff
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+)
+
+// -------------------- Set Default Tariff (CSMS -> CS) --------------------
+
+const SetDefaultTariffFeatureName = "SetDefaultTariff"
+
+// The field definition of the SetDefaultTariff request payload sent by the CSMS to the Charging Station.
+type SetDefaultTariffRequest struct {
+	EvseID int    `json:"evseId" validate:"gte=0"`    // EVSE the tariff applies to. 0 applies the tariff to all EVSEs.
+	Tariff Tariff `json:"tariff" validate:"required"` // The default tariff.
+}
+
+// This field definition of the SetDefaultTariff response payload, sent by the Charging Station to the CSMS in response to a SetDefaultTariffRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type SetDefaultTariffResponse struct {
+	Status     TariffSetStatus   `json:"status" validate:"required,tariffSetStatus21"`
+	StatusInfo *types.StatusInfo `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The CSMS installs a default tariff on a Charging Station by sending a SetDefaultTariffRequest.
+// The default tariff is used for all transactions, unless a driver-specific tariff was provided with the authorization.
+// The Charging Station responds with a SetDefaultTariffResponse.
+type SetDefaultTariffFeature struct{}
+
+func (f SetDefaultTariffFeature) GetFeatureName() string {
+	return SetDefaultTariffFeatureName
+}
+
+func (f SetDefaultTariffFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(SetDefaultTariffRequest{})
+}
+
+func (f SetDefaultTariffFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(SetDefaultTariffResponse{})
+}
+
+func (r SetDefaultTariffRequest) GetFeatureName() string {
+	return SetDefaultTariffFeatureName
+}
+
+func (c SetDefaultTariffResponse) GetFeatureName() string {
+	return SetDefaultTariffFeatureName
+}
+
+// Creates a new SetDefaultTariffRequest, containing all required fields. There are no optional fields for this message.
+func NewSetDefaultTariffRequest(evseID int, tariff Tariff) *SetDefaultTariffRequest {
+	return &SetDefaultTariffRequest{EvseID: evseID, Tariff: tariff}
+}
+
+// Creates a new SetDefaultTariffResponse, containing all required fields. Optional fields may be set afterwards.
+func NewSetDefaultTariffResponse(status TariffSetStatus) *SetDefaultTariffResponse {
+	return &SetDefaultTariffResponse{Status: status}
+}
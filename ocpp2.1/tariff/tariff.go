@@ -0,0 +1,31 @@
+// The tariff functional block contains OCPP 2.1 features for installing tariffs on a charging station,
+// which allow the charging station to calculate the cost of a transaction locally.
+package tariff
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.1 Tariff profile.
+type CSMSHandler interface {
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.1 Tariff profile.
+type ChargingStationHandler interface {
+	// OnSetDefaultTariff is called on a charging station whenever a SetDefaultTariffRequest is received from the CSMS.
+	OnSetDefaultTariff(request *SetDefaultTariffRequest) (response *SetDefaultTariffResponse, err error)
+	// OnGetTariffs is called on a charging station whenever a GetTariffsRequest is received from the CSMS.
+	OnGetTariffs(request *GetTariffsRequest) (response *GetTariffsResponse, err error)
+	// OnClearTariffs is called on a charging station whenever a ClearTariffsRequest is received from the CSMS.
+	OnClearTariffs(request *ClearTariffsRequest) (response *ClearTariffsResponse, err error)
+	// OnChangeTransactionTariff is called on a charging station whenever a ChangeTransactionTariffRequest is received from the CSMS.
+	OnChangeTransactionTariff(request *ChangeTransactionTariffRequest) (response *ChangeTransactionTariffResponse, err error)
+}
+
+const ProfileName = "tariff"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	SetDefaultTariffFeature{},
+	GetTariffsFeature{},
+	ClearTariffsFeature{},
+	ChangeTransactionTariffFeature{},
+)
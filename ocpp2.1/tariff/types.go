@@ -0,0 +1,97 @@
+package tariff
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// Status returned in response to SetDefaultTariffRequest.
+type TariffSetStatus string
+
+const (
+	TariffSetStatusAccepted              TariffSetStatus = "Accepted"
+	TariffSetStatusRejected              TariffSetStatus = "Rejected"
+	TariffSetStatusTooManyElements       TariffSetStatus = "TooManyElements"
+	TariffSetStatusConditionNotSupported TariffSetStatus = "ConditionNotSupported"
+	TariffSetStatusDuplicateTariffId     TariffSetStatus = "DuplicateTariffId"
+)
+
+func isValidTariffSetStatus(fl validator.FieldLevel) bool {
+	status := TariffSetStatus(fl.Field().String())
+	switch status {
+	case TariffSetStatusAccepted, TariffSetStatusRejected, TariffSetStatusTooManyElements, TariffSetStatusConditionNotSupported, TariffSetStatusDuplicateTariffId:
+		return true
+	default:
+		return false
+	}
+}
+
+// Tax rate, applied to a price.
+type TaxRate struct {
+	Type  string  `json:"type" validate:"required,max=20"`            // Type of this tax, e.g. "Federal", "State", for information on receipt.
+	Tax   float64 `json:"tax" validate:"gte=0"`                       // Tax percentage.
+	Stack *int    `json:"stack,omitempty" validate:"omitempty,gte=0"` // Stack level for this type of tax. Default value, when absent, is 0.
+}
+
+// Price with and without tax.
+type Price struct {
+	ExclTax  *float64  `json:"exclTax,omitempty" validate:"omitempty"`             // Price or cost excluding tax.
+	InclTax  *float64  `json:"inclTax,omitempty" validate:"omitempty"`             // Price or cost including tax.
+	TaxRates []TaxRate `json:"taxRates,omitempty" validate:"omitempty,max=5,dive"` // Tax rates applied to the price.
+}
+
+// Price per kWh of an energy tariff.
+type TariffEnergyPrice struct {
+	PriceKwh float64 `json:"priceKwh"` // Price per kWh (excl. tax) for this element.
+}
+
+// Energy component of a tariff.
+type TariffEnergy struct {
+	Prices   []TariffEnergyPrice `json:"prices" validate:"required,min=1,dive"`
+	TaxRates []TaxRate           `json:"taxRates,omitempty" validate:"omitempty,max=5,dive"`
+}
+
+// Price per minute of a time-based tariff.
+type TariffTimePrice struct {
+	PriceMinute float64 `json:"priceMinute"` // Price per minute (excl. tax) for this element.
+}
+
+// Time component of a tariff, used for charging time and idle time.
+type TariffTime struct {
+	Prices   []TariffTimePrice `json:"prices" validate:"required,min=1,dive"`
+	TaxRates []TaxRate         `json:"taxRates,omitempty" validate:"omitempty,max=5,dive"`
+}
+
+// Fixed price of a tariff.
+type TariffFixedPrice struct {
+	PriceFixed float64 `json:"priceFixed"` // Fixed price (excl. tax) for this element.
+}
+
+// Fixed fee component of a tariff.
+type TariffFixed struct {
+	Prices   []TariffFixedPrice `json:"prices" validate:"required,min=1,dive"`
+	TaxRates []TaxRate          `json:"taxRates,omitempty" validate:"omitempty,max=5,dive"`
+}
+
+// A tariff, which can be used by a Charging Station to calculate the cost of a transaction.
+type Tariff struct {
+	TariffID     string                 `json:"tariffId" validate:"required,max=60"`                    // Unique ID of the tariff.
+	Currency     string                 `json:"currency" validate:"required,len=3"`                     // Currency code according to ISO 4217.
+	Description  []types.MessageContent `json:"description,omitempty" validate:"omitempty,max=10,dive"` // Description of the tariff, in different languages.
+	Energy       *TariffEnergy          `json:"energy,omitempty" validate:"omitempty"`                  // Price of the delivered energy.
+	ValidFrom    *types.DateTime        `json:"validFrom,omitempty" validate:"omitempty"`               // Time when the tariff becomes active.
+	ChargingTime *TariffTime            `json:"chargingTime,omitempty" validate:"omitempty"`            // Price of the time spent charging.
+	IdleTime     *TariffTime            `json:"idleTime,omitempty" validate:"omitempty"`                // Price of the time spent not charging.
+	FixedFee     *TariffFixed           `json:"fixedFee,omitempty" validate:"omitempty"`                // Fixed fee per transaction.
+	MinCost      *Price                 `json:"minCost,omitempty" validate:"omitempty"`                 // Minimum cost of a transaction.
+	MaxCost      *Price                 `json:"maxCost,omitempty" validate:"omitempty"`                 // Maximum cost of a transaction.
+}
+
+// Creates a new Tariff, containing all required fields. Optional fields may be set afterwards.
+func NewTariff(tariffID string, currency string) Tariff {
+	return Tariff{TariffID: tariffID, Currency: currency}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("tariffSetStatus21", isValidTariffSetStatus)
+}
@@ -0,0 +1,72 @@
+// Contains common and shared data types between OCPP 2.1 messages.
+//
+// OCPP 2.1 is backwards compatible with OCPP 2.0.1, therefore data types that didn't change
+// are aliases of the respective ocpp2.0.1/types definitions.
+package types
+
+import (
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+const (
+	V21Subprotocol = "ocpp2.1"
+)
+
+type DateTime = types.DateTime
+
+func NewDateTime(time time.Time) *DateTime {
+	return types.NewDateTime(time)
+}
+
+type StatusInfo = types.StatusInfo
+
+func NewStatusInfo(reasonCode string, additionalInfo string) *StatusInfo {
+	return types.NewStatusInfo(reasonCode, additionalInfo)
+}
+
+type IdToken = types.IdToken
+type IdTokenType = types.IdTokenType
+
+const (
+	IdTokenTypeCentral         = types.IdTokenTypeCentral
+	IdTokenTypeEMAID           = types.IdTokenTypeEMAID
+	IdTokenTypeISO14443        = types.IdTokenTypeISO14443
+	IdTokenTypeKeyCode         = types.IdTokenTypeKeyCode
+	IdTokenTypeLocal           = types.IdTokenTypeLocal
+	IdTokenTypeNoAuthorization = types.IdTokenTypeNoAuthorization
+	IdTokenTypeISO15693        = types.IdTokenTypeISO15693
+	IdTokenTypeMacAddress      = types.IdTokenTypeMacAddress
+)
+
+type MessageContent = types.MessageContent
+
+// Generic status, used by several OCPP 2.1 responses.
+type GenericStatus string
+
+const (
+	GenericStatusAccepted GenericStatus = "Accepted"
+	GenericStatusRejected GenericStatus = "Rejected"
+)
+
+func isValidGenericStatus(fl validator.FieldLevel) bool {
+	status := GenericStatus(fl.Field().String())
+	switch status {
+	case GenericStatusAccepted, GenericStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validator used for validating all OCPP 2.1 messages.
+// Any additional custom validations must be added to this object for automatic validation.
+// Validations introduced by OCPP 2.1 use a "21" suffix, to avoid clashing with the OCPP 2.0 and 2.0.1 validations.
+var Validate = ocppj.Validate
+
+func init() {
+	_ = Validate.RegisterValidation("genericStatus21", isValidGenericStatus)
+}
@@ -0,0 +1,552 @@
+// The package contains an implementation of the OCPP 2.1 communication protocol between a Charging Station and an Charging Station Management System in an EV charging infrastructure.
+//
+// The API mirrors the one of the ocpp2.0.1 package: NewChargingStation and NewCSMS accept the same parameters,
+// but negotiate the "ocpp2.1" websocket subprotocol.
+// OCPP 2.1 is backwards compatible with OCPP 2.0.1, hence the functional blocks of the ocpp2.0.1 package are reused as they are,
+// while the functional blocks introduced by OCPP 2.1 (V2X, DER control, tariffs, payment and battery swap) are contained in this package.
+// Fields that OCPP 2.1 added to existing 2.0.1 messages are not modelled, and are ignored when received.
+//
+// In addition to CALL, CALLRESULT and CALLERROR, OCPP 2.1 endpoints exchange CALLRESULTERROR and SEND messages.
+// Unconfirmed requests may be sent via SendUnconfirmedRequest and are received via the handler passed to SetUnconfirmedRequestHandler.
+// If the remote endpoint rejects a response with a CALLRESULTERROR, the handler passed to SetCallResultErrorHandler is invoked.
+package ocpp21
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lorenzodonini/ocpp-go/internal/callbackqueue"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/display"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/iso15118"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/tariffcost"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/batteryswap"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/der"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	types21 "github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/v2x"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+type ChargingStationConnection interface {
+	ID() string
+	TLSConnectionState() *tls.ConnectionState
+}
+
+type ChargingStationConnectionHandler func(chargePoint ChargingStationConnection)
+
+// -------------------- v2.1 Charging Station --------------------
+
+// A Charging Station represents the physical system where an EV can be charged.
+// You can instantiate a default Charging Station struct by calling NewChargingStation.
+//
+// The logic for incoming messages needs to be implemented, and message handlers need to be registered with the charging station:
+//	handler := &ChargingStationHandler{} 				// Custom struct
+//  chargingStation.SetAuthorizationHandler(handler)
+//  chargingStation.SetProvisioningHandler(handler)
+//  // set more handlers...
+// Refer to the ChargingStationHandler interface of each profile for the implementation requirements.
+//
+// If a handler for a profile is not set, the OCPP library will reply to incoming messages for that profile with a NotImplemented error.
+//
+// A charging station can be started and stopped using the Start and Stop functions.
+// While running, messages can be sent to the CSMS by calling the Charging Station's functions, e.g.
+//	bootConf, err := chargingStation.BootNotification(BootReasonPowerUp, "model1", "vendor1")
+//
+// All messages are synchronous blocking, and return either the response from the CSMS or an error.
+// To send asynchronous messages and avoid blocking the calling thread, refer to SendRequestAsync.
+type ChargingStation interface {
+	// Sends a BootNotificationRequest to the CSMS, along with information about the charging station.
+	BootNotification(reason provisioning.BootReason, model string, chargePointVendor string, props ...func(request *provisioning.BootNotificationRequest)) (*provisioning.BootNotificationResponse, error)
+	// Requests explicit authorization to the CSMS, provided a valid IdToken (typically the customer's). The CSMS may either authorize or reject the token.
+	Authorize(idToken string, tokenType types.IdTokenType, props ...func(request *authorization.AuthorizeRequest)) (*authorization.AuthorizeResponse, error)
+	// Notifies the CSMS, that a previously set charging limit was cleared.
+	ClearedChargingLimit(chargingLimitSource types.ChargingLimitSourceType, props ...func(request *smartcharging.ClearedChargingLimitRequest)) (*smartcharging.ClearedChargingLimitResponse, error)
+	// Performs a custom data transfer to the CSMS. The message payload is not pre-defined and must be supported by the CSMS. Every vendor may implement their own proprietary logic for this message.
+	DataTransfer(vendorId string, props ...func(request *data.DataTransferRequest)) (*data.DataTransferResponse, error)
+	// Notifies the CSMS of a status change during a firmware update procedure (download, installation).
+	FirmwareStatusNotification(status firmware.FirmwareStatus, requestID int, props ...func(request *firmware.FirmwareStatusNotificationRequest)) (*firmware.FirmwareStatusNotificationResponse, error)
+	// Requests a new certificate, required for an ISO 15118 EV, from the CSMS.
+	Get15118EVCertificate(schemaVersion string, action iso15118.CertificateAction, exiRequest string, props ...func(request *iso15118.Get15118EVCertificateRequest)) (*iso15118.Get15118EVCertificateResponse, error)
+	// Requests the CSMS to provide OCSP certificate status for the charging station's 15118 certificates.
+	GetCertificateStatus(ocspRequestData types.OCSPRequestDataType, props ...func(request *iso15118.GetCertificateStatusRequest)) (*iso15118.GetCertificateStatusResponse, error)
+	// Notifies the CSMS that the charging station is still connected. The returned current time may be used by the charging station to synchronize its internal clock.
+	Heartbeat(props ...func(request *provisioning.HeartbeatRequest)) (*provisioning.HeartbeatResponse, error)
+	// Notifies the CSMS about the status of a log upload.
+	LogStatusNotification(status diagnostics.UploadLogStatus, props ...func(request *diagnostics.LogStatusNotificationRequest)) (*diagnostics.LogStatusNotificationResponse, error)
+	// Sends a set of meter values, sampled on the given EVSE, to the CSMS. Meter values related to a transaction should be sent via TransactionEvent instead.
+	MeterValues(evseID int, meterValues []types.MeterValue, props ...func(request *meter.MeterValuesRequest)) (*meter.MeterValuesResponse, error)
+	// Notifies the CSMS about a charging limit, imposed on the charging station by an external system.
+	NotifyChargingLimit(chargingLimit smartcharging.ChargingLimit, props ...func(request *smartcharging.NotifyChargingLimitRequest)) (*smartcharging.NotifyChargingLimitResponse, error)
+	// Sends (part of) the customer information, previously requested by the CSMS, to the CSMS.
+	NotifyCustomerInformation(data string, seqNo int, generatedAt *types.DateTime, requestID int, props ...func(request *diagnostics.NotifyCustomerInformationRequest)) (*diagnostics.NotifyCustomerInformationResponse, error)
+	// Sends to the CSMS the display messages currently configured on a charging station, as requested by a previous GetDisplayMessagesRequest.
+	NotifyDisplayMessages(requestID int, props ...func(request *display.NotifyDisplayMessagesRequest)) (*display.NotifyDisplayMessagesResponse, error)
+	// Forwards the charging needs of an EV, received over ISO 15118, to the CSMS.
+	NotifyEVChargingNeeds(evseID int, chargingNeeds smartcharging.ChargingNeeds, props ...func(request *smartcharging.NotifyEVChargingNeedsRequest)) (*smartcharging.NotifyEVChargingNeedsResponse, error)
+	// Forwards the charging schedule computed by an EV, received over ISO 15118, to the CSMS.
+	NotifyEVChargingSchedule(timeBase *types.DateTime, evseID int, schedule types.ChargingSchedule, props ...func(request *smartcharging.NotifyEVChargingScheduleRequest)) (*smartcharging.NotifyEVChargingScheduleResponse, error)
+	// Notifies the CSMS about monitoring events that occurred on the charging station.
+	NotifyEvent(generatedAt *types.DateTime, seqNo int, eventData []diagnostics.EventData, props ...func(request *diagnostics.NotifyEventRequest)) (*diagnostics.NotifyEventResponse, error)
+	// Sends (part of) a monitoring report, previously requested by the CSMS, to the CSMS.
+	NotifyMonitoringReport(requestID int, seqNo int, generatedAt *types.DateTime, monitorData []diagnostics.MonitoringData, props ...func(request *diagnostics.NotifyMonitoringReportRequest)) (*diagnostics.NotifyMonitoringReportResponse, error)
+	// Sends a part of a report, requested via GetReportRequest or GetBaseReportRequest, to the CSMS.
+	NotifyReport(generatedAt *types.DateTime, seqNo int, props ...func(request *provisioning.NotifyReportRequest)) (*provisioning.NotifyReportResponse, error)
+	// Notifies the CSMS about the progress of a firmware publishing procedure, performed by a local controller.
+	PublishFirmwareStatusNotification(status firmware.PublishFirmwareStatus, props ...func(request *firmware.PublishFirmwareStatusNotificationRequest)) (*firmware.PublishFirmwareStatusNotificationResponse, error)
+	// Reports the charging profiles installed on the charging station, as requested previously by the CSMS.
+	ReportChargingProfiles(requestID int, chargingLimitSource types.ChargingLimitSourceType, evseID int, chargingProfile []types.ChargingProfile, props ...func(request *smartcharging.ReportChargingProfilesRequest)) (*smartcharging.ReportChargingProfilesResponse, error)
+	// Informs the CSMS that a reservation was terminated, because it expired or was removed.
+	ReservationStatusUpdate(reservationID int, status reservation.ReservationUpdateStatus, props ...func(request *reservation.ReservationStatusUpdateRequest)) (*reservation.ReservationStatusUpdateResponse, error)
+	// Notifies the CSMS about a critical security event that occurred on the charging station.
+	SecurityEventNotification(typ string, timestamp *types.DateTime, props ...func(request *security.SecurityEventNotificationRequest)) (*security.SecurityEventNotificationResponse, error)
+	// Requests the CSMS to issue a new certificate, by sending a Certificate Signing Request.
+	SignCertificate(csr string, props ...func(request *security.SignCertificateRequest)) (*security.SignCertificateResponse, error)
+	// Notifies the CSMS about a connector status change.
+	StatusNotification(timestamp *types.DateTime, status availability.ConnectorStatus, evseID int, connectorID int, props ...func(request *availability.StatusNotificationRequest)) (*availability.StatusNotificationResponse, error)
+	// Sends information to the CSMS about a transaction, used for billing purposes.
+	TransactionEvent(t transactions.TransactionEvent, timestamp *types.DateTime, reason transactions.TriggerReason, seqNo int, info transactions.Transaction, props ...func(request *transactions.TransactionEventRequest)) (*transactions.TransactionEventResponse, error)
+	// Reports the DER controls configured on the charging station, as requested previously by the CSMS.
+	ReportDERControl(requestID int, props ...func(request *der.ReportDERControlRequest)) (*der.ReportDERControlResponse, error)
+	// Notifies the CSMS about the start or end of a DER alarm.
+	NotifyDERAlarm(controlType der.DERControlType, timestamp *types.DateTime, props ...func(request *der.NotifyDERAlarmRequest)) (*der.NotifyDERAlarmResponse, error)
+	// Notifies the CSMS that a scheduled DER control was started or stopped.
+	NotifyDERStartStop(controlID string, started bool, timestamp *types.DateTime, props ...func(request *der.NotifyDERStartStopRequest)) (*der.NotifyDERStartStopResponse, error)
+	// Notifies the CSMS about the result of a payment settlement, performed via a local payment terminal.
+	NotifySettlement(pspRef string, status payment.PaymentStatus, settlementAmount float64, settlementTime *types.DateTime, props ...func(request *payment.NotifySettlementRequest)) (*payment.NotifySettlementResponse, error)
+	// Requests the CSMS to validate a VAT number, entered by a driver for a company receipt.
+	VatNumberValidation(vatNumber string, props ...func(request *payment.VatNumberValidationRequest)) (*payment.VatNumberValidationResponse, error)
+	// Notifies the CSMS that the QR code of an EVSE was scanned by a driver.
+	NotifyQRCodeScanned(evseID int, timeout int, props ...func(request *payment.NotifyQRCodeScannedRequest)) (*payment.NotifyQRCodeScannedResponse, error)
+	// Reports the batteries inserted into or taken out of a battery swap station to the CSMS.
+	BatterySwap(eventType batteryswap.BatterySwapEvent, idToken types.IdToken, requestID int, batteryData []batteryswap.BatteryData, props ...func(request *batteryswap.BatterySwapRequest)) (*batteryswap.BatterySwapResponse, error)
+
+	// Registers a handler for incoming security profile messages
+	SetSecurityHandler(handler security.ChargingStationHandler)
+	// Registers a handler for incoming provisioning profile messages
+	SetProvisioningHandler(handler provisioning.ChargingStationHandler)
+	// Registers a handler for incoming authorization profile messages
+	SetAuthorizationHandler(handler authorization.ChargingStationHandler)
+	// Registers a handler for incoming local authorization list profile messages
+	SetLocalAuthListHandler(handler localauth.ChargingStationHandler)
+	// Registers a handler for incoming transactions profile messages
+	SetTransactionsHandler(handler transactions.ChargingStationHandler)
+	// Registers a handler for incoming remote control profile messages
+	SetRemoteControlHandler(handler remotecontrol.ChargingStationHandler)
+	// Registers a handler for incoming availability profile messages
+	SetAvailabilityHandler(handler availability.ChargingStationHandler)
+	// Registers a handler for incoming reservation profile messages
+	SetReservationHandler(handler reservation.ChargingStationHandler)
+	// Registers a handler for incoming tariff and cost profile messages
+	SetTariffCostHandler(handler tariffcost.ChargingStationHandler)
+	// Registers a handler for incoming meter profile messages
+	SetMeterHandler(handler meter.ChargingStationHandler)
+	// Registers a handler for incoming smart charging messages
+	SetSmartChargingHandler(handler smartcharging.ChargingStationHandler)
+	// Registers a handler for incoming firmware management messages
+	SetFirmwareHandler(handler firmware.ChargingStationHandler)
+	// Registers a handler for incoming ISO15118 management messages
+	SetISO15118Handler(handler iso15118.ChargingStationHandler)
+	// Registers a handler for incoming diagnostics messages
+	SetDiagnosticsHandler(handler diagnostics.ChargingStationHandler)
+	// Registers a handler for incoming display messages
+	SetDisplayHandler(handler display.ChargingStationHandler)
+	// Registers a handler for incoming data transfer messages
+	SetDataHandler(handler data.ChargingStationHandler)
+	// Registers a handler for incoming V2X messages
+	SetV2XHandler(handler v2x.ChargingStationHandler)
+	// Registers a handler for incoming DER control messages
+	SetDERControlHandler(handler der.ChargingStationHandler)
+	// Registers a handler for incoming tariff messages
+	SetTariffHandler(handler tariff.ChargingStationHandler)
+	// Registers a handler for incoming payment messages
+	SetPaymentHandler(handler payment.ChargingStationHandler)
+	// Registers a handler for incoming battery swap messages
+	SetBatterySwapHandler(handler batteryswap.ChargingStationHandler)
+	// Registers a handler for incoming unconfirmed requests (SEND messages), which don't expect a response.
+	// If no handler is set, the requests are reported via the Errors channel.
+	SetUnconfirmedRequestHandler(handler func(request ocpp.Request))
+	// Registers a handler, which is invoked whenever the CSMS rejects a response of the charging station with a CALLRESULTERROR message.
+	// If no handler is set, the errors are reported via the Errors channel.
+	SetCallResultErrorHandler(handler func(err *ocpp.Error, details interface{}))
+	// Sends a request to the CSMS.
+	// The CSMS will respond with a confirmation, or with an error if the request was invalid or could not be processed.
+	// In case of network issues (i.e. the remote host couldn't be reached), the function also returns an error.
+	//
+	// The request is synchronous blocking.
+	SendRequest(request ocpp.Request) (ocpp.Response, error)
+	// Sends a request to the CSMS, like SendRequest.
+	// If the context is done before a response is received, ctx.Err() is returned right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	//
+	// Typed helpers such as BootNotification may be replaced by passing the respective request to this function, e.g.:
+	//	response, err := chargingStation.SendRequestWithContext(ctx, provisioning.NewBootNotificationRequest(provisioning.BootReasonPowerUp, "model1", "vendor1"))
+	SendRequestWithContext(ctx context.Context, request ocpp.Request) (ocpp.Response, error)
+	// Sends an asynchronous request to the CSMS.
+	// The CSMS will respond with a confirmation message, or with an error if the request was invalid or could not be processed.
+	// This result is propagated via a callback, called asynchronously.
+	//
+	// In case of network issues (i.e. the remote host couldn't be reached), the function returns an error directly. In this case, the callback is never invoked.
+	SendRequestAsync(request ocpp.Request, callback func(confirmation ocpp.Response, protoError error)) error
+	// Sends an unconfirmed request (SEND message) to the CSMS. The CSMS doesn't respond to the request.
+	//
+	// The function returns an error, if the request is invalid or couldn't be sent.
+	SendUnconfirmedRequest(request ocpp.Request) error
+	// Connects to the CSMS and starts the charging station routine.
+	// The function doesn't block and returns right away, after having attempted to open a connection to the CSMS.
+	// If the connection couldn't be opened, an error is returned.
+	//
+	// Optional client options must be set before calling this function. Refer to NewChargingStation.
+	//
+	// No auto-reconnect logic is implemented as of now, but is planned for the future.
+	Start(csmsUrl string) error
+	// Stops the charging station routine, disconnecting it from the CSMS.
+	// Any pending requests are discarded.
+	Stop()
+	// Errors returns a channel for error messages. If it doesn't exist it es created.
+	// The channel is closed by the charging station when stopped.
+	Errors() <-chan error
+}
+
+// Creates a new OCPP 2.1 charging station client.
+// The id parameter is required to uniquely identify the charge point.
+//
+// The endpoint and client parameters may be omitted, in order to use a default configuration:
+//   chargingStation := NewChargingStation("someUniqueId", nil, nil)
+//
+// Additional networking parameters (e.g. TLS or proxy configuration) may be passed, by creating a custom client.
+// Here is an example for a client using TLS configuration with a self-signed certificate:
+//	certPool := x509.NewCertPool()
+//	data, err := ioutil.ReadFile("serverSelfSignedCertFilename")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	ok = certPool.AppendCertsFromPEM(data)
+//	if !ok {
+//		log.Fatal("couldn't parse PEM certificate")
+//	}
+//	cs := NewChargingStation("someUniqueId", nil, ws.NewTLSClient(&tls.Config{
+//		RootCAs: certPool,
+//	})
+//
+// For more advanced options, or if a custom networking/occpj layer is required,
+// please refer to ocppj.Client and ws.WsClient.
+//
+// Requests that can't be written to the network are canceled right away. To retransmit them instead,
+// pass an endpoint whose ocppj.DefaultClientDispatcher has a retry policy (see SetRetryPolicy).
+func NewChargingStation(id string, endpoint *ocppj.Client, client ws.WsClient) ChargingStation {
+	if client == nil {
+		client = ws.NewClient()
+	}
+	client.AddOption(func(dialer *websocket.Dialer) {
+		// Look for v2.1 subprotocol and add it, if not found
+		alreadyExists := false
+		for _, proto := range dialer.Subprotocols {
+			if proto == types21.V21Subprotocol {
+				alreadyExists = true
+				break
+			}
+		}
+		if !alreadyExists {
+			dialer.Subprotocols = append(dialer.Subprotocols, types21.V21Subprotocol)
+		}
+	})
+	cs := chargingStation{responseHandler: make(chan receivedResponse, 1), errorHandler: make(chan *ocpp.Error, 1), callbacks: callbackqueue.New()}
+
+	if endpoint == nil {
+		dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
+		endpoint = ocppj.NewClient(id, client, dispatcher, nil, authorization.Profile, availability.Profile, data.Profile, diagnostics.Profile, display.Profile, firmware.Profile, iso15118.Profile, localauth.Profile, meter.Profile, provisioning.Profile, remotecontrol.Profile, reservation.Profile, security.Profile, smartcharging.Profile, tariffcost.Profile, transactions.Profile, v2x.Profile, der.Profile, tariff.Profile, payment.Profile, batteryswap.Profile)
+	}
+
+	// Callback invoked by dispatcher, whenever a queued request is canceled, due to timeout.
+	endpoint.SetOnRequestCanceled(cs.onRequestTimeout)
+	cs.client = endpoint
+
+	cs.client.SetResponseHandler(func(confirmation ocpp.Response, requestId string) {
+		cs.responseHandler <- receivedResponse{requestId: requestId, response: confirmation}
+	})
+	cs.client.SetErrorHandler(func(err *ocpp.Error, details interface{}) {
+		cs.errorHandler <- err
+	})
+	cs.client.SetRequestHandler(cs.handleIncomingRequest)
+	cs.client.SetSendHandler(cs.handleIncomingUnconfirmedRequest)
+	cs.client.SetCallResultErrorHandler(cs.handleIncomingCallResultError)
+	// CALLRESULTERROR and SEND messages are part of OCPP 2.1
+	cs.client.SetExtendedMessageTypesEnabled(true)
+	return &cs
+}
+
+// -------------------- v2.1 CSMS --------------------
+
+// A Charging Station Management System (CSMS) manages Charging Stations and has the information for authorizing Management Users for using its Charging Stations.
+// You can instantiate a default CSMS struct by calling the NewCSMS function.
+//
+// The logic for handling incoming messages needs to be implemented, and message handlers need to be registered with the CSMS:
+//	handler := &CSMSHandler{} 				// Custom struct
+//  csms.SetAuthorizationHandler(handler)
+//  csms.SetProvisioningHandler(handler)
+//  // set more handlers...
+// Refer to the CSMSHandler interface of each profile for the implementation requirements.
+//
+// If a handler for a profile is not set, the OCPP library will reply to incoming messages for that profile with a NotImplemented error.
+//
+// A CSMS can be started by using the Start function.
+// To be notified of incoming (dis)connections from charging stations refer to the SetNewChargingStationHandler and SetChargingStationDisconnectedHandler functions.
+//
+// While running, messages can be sent to a Charging Station by calling the CSMS's functions, e.g.:
+//	callback := func(conf *ClearDisplayResponse, err error) {
+//		// handle the response...
+//	}
+//	clearDisplayConf, err := csms.ClearDisplay("cs0001", callback, 10)
+// All messages are sent asynchronously and do not block the caller.
+type CSMS interface {
+	// Cancel a pending reservation, provided the reservationId, on a charging station.
+	CancelReservation(clientId string, callback func(*reservation.CancelReservationResponse, error), reservationId int, props ...func(*reservation.CancelReservationRequest)) error
+	// The CSMS installs a new certificate (chain), signed by the CA, on the charging station. This typically follows a SignCertificate message, initiated by the charging station.
+	CertificateSigned(clientId string, callback func(*security.CertificateSignedResponse, error), certificateChain string, props ...func(*security.CertificateSignedRequest)) error
+	// Instructs a charging station to change its availability to the desired operational status.
+	ChangeAvailability(clientId string, callback func(*availability.ChangeAvailabilityResponse, error), evseID int, operationalStatus availability.OperationalStatus, props ...func(*availability.ChangeAvailabilityRequest)) error
+	// Instructs a charging station to clear its current authorization cache. All authorization saved locally will be invalidated.
+	ClearCache(clientId string, callback func(*authorization.ClearCacheResponse, error), props ...func(*authorization.ClearCacheRequest)) error
+	// Instructs a charging station to clear some or all charging profiles, previously sent to the charging station.
+	ClearChargingProfile(clientId string, callback func(*smartcharging.ClearChargingProfileResponse, error), props ...func(request *smartcharging.ClearChargingProfileRequest)) error
+	// Removes a specific display message, currently configured in a charging station.
+	ClearDisplay(clientId string, callback func(*display.ClearDisplayResponse, error), id int, props ...func(*display.ClearDisplayRequest)) error
+	// Removes one or more monitoring settings from a charging station for the given variable IDs.
+	ClearVariableMonitoring(clientId string, callback func(*diagnostics.ClearVariableMonitoringResponse, error), id []int, props ...func(*diagnostics.ClearVariableMonitoringRequest)) error
+	// Instructs a charging station to display the updated current total cost of an ongoing transaction.
+	CostUpdated(clientId string, callback func(*tariffcost.CostUpdatedResponse, error), totalCost float64, transactionId string, props ...func(*tariffcost.CostUpdatedRequest)) error
+	// Instructs a charging station to send one or more reports, containing raw customer information.
+	CustomerInformation(clientId string, callback func(*diagnostics.CustomerInformationResponse, error), requestId int, report bool, clear bool, props ...func(*diagnostics.CustomerInformationRequest)) error
+	// Performs a custom data transfer to a charging station. The message payload is not pre-defined and must be supported by the charging station. Every vendor may implement their own proprietary logic for this message.
+	DataTransfer(clientId string, callback func(*data.DataTransferResponse, error), vendorId string, props ...func(*data.DataTransferRequest)) error
+	// Deletes a previously installed certificate on a charging station.
+	DeleteCertificate(clientId string, callback func(*iso15118.DeleteCertificateResponse, error), data types.CertificateHashData, props ...func(*iso15118.DeleteCertificateRequest)) error
+	// Requests a report from a charging station. The charging station will asynchronously send the report in chunks using NotifyReportRequest messages.
+	GetBaseReport(clientId string, callback func(*provisioning.GetBaseReportResponse, error), requestId int, reportBase provisioning.ReportBaseType, props ...func(*provisioning.GetBaseReportRequest)) error
+	// Request a charging station to report some or all installed charging profiles. The charging station will report these asynchronously using ReportChargingProfiles messages.
+	GetChargingProfiles(clientId string, callback func(*smartcharging.GetChargingProfilesResponse, error), chargingProfile smartcharging.ChargingProfileCriterion, props ...func(*smartcharging.GetChargingProfilesRequest)) error
+	// Requests a charging station to report the composite charging schedule for the indicated duration and evseID.
+	GetCompositeSchedule(clientId string, callback func(*smartcharging.GetCompositeScheduleResponse, error), duration int, evseId int, props ...func(*smartcharging.GetCompositeScheduleRequest)) error
+	// Retrieves all messages currently configured on a charging station.
+	GetDisplayMessages(clientId string, callback func(*display.GetDisplayMessagesResponse, error), requestId int, props ...func(*display.GetDisplayMessagesRequest)) error
+	// Retrieves all installed certificates on a charging station.
+	GetInstalledCertificateIds(clientId string, callback func(*iso15118.GetInstalledCertificateIdsResponse, error), typeOfCertificate types.CertificateUse, props ...func(*iso15118.GetInstalledCertificateIdsRequest)) error
+	// Queries a charging station for version number of the Local Authorization List.
+	GetLocalListVersion(clientId string, callback func(*localauth.GetLocalListVersionResponse, error), props ...func(*localauth.GetLocalListVersionRequest)) error
+	// Instructs a charging station to upload a diagnostics or security logfile to the CSMS.
+	GetLog(clientId string, callback func(*diagnostics.GetLogResponse, error), logType diagnostics.LogType, requestID int, logParameters diagnostics.LogParameters, props ...func(*diagnostics.GetLogRequest)) error
+	// Requests a report about configured monitoring settings per component and variable from a charging station. The reports will be uploaded asynchronously using NotifyMonitoringReport messages.
+	GetMonitoringReport(clientId string, callback func(*diagnostics.GetMonitoringReportResponse, error), props ...func(*diagnostics.GetMonitoringReportRequest)) error
+	// Requests a report from a charging station. The charging station will asynchronously send the report in chunks using NotifyReportRequest messages.
+	GetReport(clientId string, callback func(*provisioning.GetReportResponse, error), props ...func(*provisioning.GetReportRequest)) error
+	// Requests the status of a transaction from a charging station. The charging station will report whether it still has transaction-related messages queued, to be delivered to the CSMS.
+	GetTransactionStatus(clientId string, callback func(*transactions.GetTransactionStatusResponse, error), props ...func(*transactions.GetTransactionStatusRequest)) error
+	// Requests the values of one or more variables from a charging station.
+	GetVariables(clientId string, callback func(*provisioning.GetVariablesResponse, error), variableData []provisioning.GetVariableData, props ...func(*provisioning.GetVariablesRequest)) error
+	// Installs a new certificate (chain), signed by the CA, on the charging station.
+	InstallCertificate(clientId string, callback func(*iso15118.InstallCertificateResponse, error), certificateType types.CertificateUse, certificate string, props ...func(*iso15118.InstallCertificateRequest)) error
+	// Instructs a local controller to download and publish a firmware update, so that connected charging stations may retrieve it locally.
+	PublishFirmware(clientId string, callback func(*firmware.PublishFirmwareResponse, error), location string, checksum string, requestID int, props ...func(*firmware.PublishFirmwareRequest)) error
+	// Asks a charging station to start a transaction, on behalf of a user (e.g. via app or CSO help-desk). The transaction may be associated to a remote start ID, which allows the CSMS to correlate it with the following TransactionEvent.
+	RequestStartTransaction(clientId string, callback func(*remotecontrol.RequestStartTransactionResponse, error), remoteStartID int, idToken types.IdToken, props ...func(*remotecontrol.RequestStartTransactionRequest)) error
+	// Asks a charging station to stop an ongoing transaction, identified by the given transactionID.
+	RequestStopTransaction(clientId string, callback func(*remotecontrol.RequestStopTransactionResponse, error), transactionID string, props ...func(*remotecontrol.RequestStopTransactionRequest)) error
+	// Instructs the charging station to reserve an EVSE (or a connector type) for use by a specific user, until the given expiry time.
+	ReserveNow(clientId string, callback func(*reservation.ReserveNowResponse, error), id int, expiryDateTime *types.DateTime, idToken types.IdToken, props ...func(*reservation.ReserveNowRequest)) error
+	// Asks a charging station to reset itself, or a specific EVSE.
+	Reset(clientId string, callback func(*provisioning.ResetResponse, error), t provisioning.ResetType, props ...func(*provisioning.ResetRequest)) error
+	// Sends a local authorization list to a charging station, which can be used for the authorization of idTokens.
+	SendLocalList(clientId string, callback func(*localauth.SendLocalListResponse, error), versionNumber int, updateType localauth.UpdateType, props ...func(*localauth.SendLocalListRequest)) error
+	// Installs a charging profile on a charging station, to influence the power or current drawn by an EVSE or the charging station as a whole.
+	SetChargingProfile(clientId string, callback func(*smartcharging.SetChargingProfileResponse, error), evseID int, chargingProfile *types.ChargingProfile, props ...func(*smartcharging.SetChargingProfileRequest)) error
+	// Sends a message to a charging station, to be shown on one of its displays.
+	SetDisplayMessage(clientId string, callback func(*display.SetDisplayMessageResponse, error), message display.MessageInfo, props ...func(*display.SetDisplayMessageRequest)) error
+	// Activates a set of preconfigured monitoring settings on a charging station.
+	SetMonitoringBase(clientId string, callback func(*diagnostics.SetMonitoringBaseResponse, error), monitoringBase diagnostics.MonitoringBase, props ...func(*diagnostics.SetMonitoringBaseRequest)) error
+	// Restricts the reporting of monitoring events on a charging station to those with a severity lower than or equal to the given one.
+	SetMonitoringLevel(clientId string, callback func(*diagnostics.SetMonitoringLevelResponse, error), severity int, props ...func(*diagnostics.SetMonitoringLevelRequest)) error
+	// Updates the connection details on a charging station, for the given configuration slot.
+	SetNetworkProfile(clientId string, callback func(*provisioning.SetNetworkProfileResponse, error), configurationSlot int, connectionData provisioning.NetworkConnectionProfile, props ...func(*provisioning.SetNetworkProfileRequest)) error
+	// Sets monitoring triggers on one or more variables of a charging station.
+	SetVariableMonitoring(clientId string, callback func(*diagnostics.SetVariableMonitoringResponse, error), data []diagnostics.SetMonitoringData, props ...func(*diagnostics.SetVariableMonitoringRequest)) error
+	// Sets the values of one or more variables on a charging station.
+	SetVariables(clientId string, callback func(*provisioning.SetVariablesResponse, error), variableData []provisioning.SetVariableData, props ...func(*provisioning.SetVariablesRequest)) error
+	// Requests a charging station to send a charging station-initiated message.
+	TriggerMessage(clientId string, callback func(*remotecontrol.TriggerMessageResponse, error), requestedMessage remotecontrol.MessageTrigger, props ...func(*remotecontrol.TriggerMessageRequest)) error
+	// Instructs a charging station to unlock a connector, to help out a user, whose cable could not be unplugged.
+	UnlockConnector(clientId string, callback func(*remotecontrol.UnlockConnectorResponse, error), evseID int, connectorID int, props ...func(*remotecontrol.UnlockConnectorRequest)) error
+	// Instructs a local controller to stop publishing a previously published firmware update.
+	UnpublishFirmware(clientId string, callback func(*firmware.UnpublishFirmwareResponse, error), checksum string, props ...func(*firmware.UnpublishFirmwareRequest)) error
+	// Instructs a charging station to download and install a new firmware version.
+	UpdateFirmware(clientId string, callback func(*firmware.UpdateFirmwareResponse, error), requestID int, fw firmware.Firmware, props ...func(*firmware.UpdateFirmwareRequest)) error
+	// Restricts the energy transfer modes, which an EV may use during an ongoing transaction.
+	NotifyAllowedEnergyTransfer(clientId string, callback func(*v2x.NotifyAllowedEnergyTransferResponse, error), transactionID string, allowedEnergyTransfer []v2x.EnergyTransferMode, props ...func(*v2x.NotifyAllowedEnergyTransferRequest)) error
+	// Forwards an automatic frequency restoration reserve (aFRR) signal to a charging station.
+	AFRRSignal(clientId string, callback func(*v2x.AFRRSignalResponse, error), timestamp *types.DateTime, signal int, props ...func(*v2x.AFRRSignalRequest)) error
+	// Configures a DER control on a charging station. The settings for the control type must be passed via props.
+	SetDERControl(clientId string, callback func(*der.SetDERControlResponse, error), isDefault bool, controlID string, controlType der.DERControlType, props ...func(*der.SetDERControlRequest)) error
+	// Requests a charging station to report its configured DER controls. The charging station will report these asynchronously using ReportDERControl messages.
+	GetDERControl(clientId string, callback func(*der.GetDERControlResponse, error), requestID int, props ...func(*der.GetDERControlRequest)) error
+	// Removes default or scheduled DER controls from a charging station.
+	ClearDERControl(clientId string, callback func(*der.ClearDERControlResponse, error), isDefault bool, props ...func(*der.ClearDERControlRequest)) error
+	// Installs a default tariff on a charging station, for the given EVSE or for all EVSEs.
+	SetDefaultTariff(clientId string, callback func(*tariff.SetDefaultTariffResponse, error), evseID int, t tariff.Tariff, props ...func(*tariff.SetDefaultTariffRequest)) error
+	// Retrieves the tariffs installed on a charging station.
+	GetTariffs(clientId string, callback func(*tariff.GetTariffsResponse, error), evseID int, props ...func(*tariff.GetTariffsRequest)) error
+	// Removes some or all tariffs installed on a charging station.
+	ClearTariffs(clientId string, callback func(*tariff.ClearTariffsResponse, error), props ...func(*tariff.ClearTariffsRequest)) error
+	// Replaces the tariff of an ongoing transaction.
+	ChangeTransactionTariff(clientId string, callback func(*tariff.ChangeTransactionTariffResponse, error), transactionID string, t tariff.Tariff, props ...func(*tariff.ChangeTransactionTariffRequest)) error
+	// Notifies a charging station that a driver started a web payment for an EVSE.
+	NotifyWebPaymentStarted(clientId string, callback func(*payment.NotifyWebPaymentStartedResponse, error), evseID int, timeout int, props ...func(*payment.NotifyWebPaymentStartedRequest)) error
+	// Asks a battery swap station to start a battery swap on behalf of a driver.
+	RequestBatterySwap(clientId string, callback func(*batteryswap.RequestBatterySwapResponse, error), idToken types.IdToken, requestID int, props ...func(*batteryswap.RequestBatterySwapRequest)) error
+	//GetLocalListVersion(clientId string, callback func(*GetLocalListVersionResponse, error), props ...func(request *GetLocalListVersionRequest)) error
+	//GetDiagnostics(clientId string, callback func(*GetDiagnosticsConfirmation, error), location string, props ...func(request *GetDiagnosticsRequest)) error
+	//CancelReservation(clientId string, callback func(*CancelReservationResponse, error), reservationId int, props ...func(request *CancelReservationRequest)) error
+	//GetCompositeSchedule(clientId string, callback func(*GetCompositeScheduleResponse, error), connectorId int, duration int, props ...func(request *GetCompositeScheduleRequest)) error
+
+	// Registers a handler for incoming security profile messages.
+	SetSecurityHandler(handler security.CSMSHandler)
+	// Registers a handler for incoming provisioning profile messages.
+	SetProvisioningHandler(handler provisioning.CSMSHandler)
+	// Registers a handler for incoming authorization profile messages.
+	SetAuthorizationHandler(handler authorization.CSMSHandler)
+	// Registers a handler for incoming local authorization list profile messages.
+	SetLocalAuthListHandler(handler localauth.CSMSHandler)
+	// Registers a handler for incoming transactions profile messages
+	SetTransactionsHandler(handler transactions.CSMSHandler)
+	// Registers a handler for incoming remote control profile messages
+	SetRemoteControlHandler(handler remotecontrol.CSMSHandler)
+	// Registers a handler for incoming availability profile messages
+	SetAvailabilityHandler(handler availability.CSMSHandler)
+	// Registers a handler for incoming reservation profile messages
+	SetReservationHandler(handler reservation.CSMSHandler)
+	// Registers a handler for incoming tariff and cost profile messages
+	SetTariffCostHandler(handler tariffcost.CSMSHandler)
+	// Registers a handler for incoming meter profile messages
+	SetMeterHandler(handler meter.CSMSHandler)
+	// Registers a handler for incoming smart charging messages
+	SetSmartChargingHandler(handler smartcharging.CSMSHandler)
+	// Registers a handler for incoming firmware management messages
+	SetFirmwareHandler(handler firmware.CSMSHandler)
+	// Registers a handler for incoming ISO15118 management messages
+	SetISO15118Handler(handler iso15118.CSMSHandler)
+	// Registers a handler for incoming diagnostics messages
+	SetDiagnosticsHandler(handler diagnostics.CSMSHandler)
+	// Registers a handler for incoming display messages
+	SetDisplayHandler(handler display.CSMSHandler)
+	// Registers a handler for incoming data transfer messages
+	SetDataHandler(handler data.CSMSHandler)
+	// Registers a handler for incoming V2X messages
+	SetV2XHandler(handler v2x.CSMSHandler)
+	// Registers a handler for incoming DER control messages
+	SetDERControlHandler(handler der.CSMSHandler)
+	// Registers a handler for incoming tariff messages
+	SetTariffHandler(handler tariff.CSMSHandler)
+	// Registers a handler for incoming payment messages
+	SetPaymentHandler(handler payment.CSMSHandler)
+	// Registers a handler for incoming battery swap messages
+	SetBatterySwapHandler(handler batteryswap.CSMSHandler)
+	// Registers a handler for incoming unconfirmed requests (SEND messages), which don't expect a response.
+	// If no handler is set, the requests are reported via the Errors channel.
+	SetUnconfirmedRequestHandler(handler func(chargingStationID string, request ocpp.Request))
+	// Registers a handler, which is invoked whenever a charging station rejects a response of the CSMS with a CALLRESULTERROR message.
+	// If no handler is set, the errors are reported via the Errors channel.
+	SetCallResultErrorHandler(handler func(chargingStationID string, err *ocpp.Error, details interface{}))
+	// Registers a handler for new incoming Charging station connections.
+	SetNewChargingStationHandler(handler ChargingStationConnectionHandler)
+	// Registers a handler for Charging station disconnections.
+	SetChargingStationDisconnectedHandler(handler ChargingStationConnectionHandler)
+	// Sets the default timeout for responses to requests sent to charging stations. A timeout of zero disables the timeout, which is the default.
+	// If a charging station doesn't respond in time, the request is discarded and its callback is invoked with a GenericError.
+	//
+	// This function must be called before starting the CSMS. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetRequestTimeout(timeout time.Duration) error
+	// Sets the timeout for responses to requests of a specific feature (e.g. a slow firmware update), overriding the default request timeout for that feature only.
+	//
+	// This function must be called before starting the CSMS. An error is returned, if the dispatcher of the endpoint doesn't support timeouts.
+	SetFeatureRequestTimeout(featureName string, timeout time.Duration) error
+	// Sends an asynchronous request to a Charging Station, identified by the clientId.
+	// The charging station will respond with a confirmation message, or with an error if the request was invalid or could not be processed.
+	// This result is propagated via a callback, called asynchronously.
+	// In case of network issues (i.e. the remote host couldn't be reached), the function returns an error directly. In this case, the callback is never invoked.
+	SendRequestAsync(clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
+	// Sends an asynchronous request to a Charging Station, like SendRequestAsync.
+	// If the context is done before a response is received, the callback is invoked with ctx.Err() right away.
+	// The request is then removed from the outgoing queue, or no response is awaited anymore if it was sent already.
+	SendRequestAsyncWithContext(ctx context.Context, clientId string, request ocpp.Request, callback func(ocpp.Response, error)) error
+	// Sends an unconfirmed request (SEND message) to a Charging Station, identified by the clientId. The charging station doesn't respond to the request.
+	//
+	// The function returns an error, if the request is invalid or couldn't be sent.
+	SendUnconfirmedRequest(clientId string, request ocpp.Request) error
+	// Starts running the CSMS on the specified port and URL.
+	// The central system runs as a daemon and handles incoming charge point connections and messages.
+
+	// The function blocks forever, so it is suggested to wrap it in a goroutine, in case other functionality needs to be executed on the main program thread.
+	Start(listenPort int, listenPath string)
+	// Errors returns a channel for error messages. If it doesn't exist it es created.
+	Errors() <-chan error
+}
+
+// Creates a new OCPP 2.1 CSMS.
+//
+// The endpoint and client parameters may be omitted, in order to use a default configuration:
+//   csms := NewCSMS(nil, nil)
+//
+// It is recommended to use the default configuration, unless a custom networking / ocppj layer is required.
+// The default dispatcher supports all implemented OCPP 2.1 features out-of-the-box.
+//
+// If you need a TLS server, you may use the following:
+//	csms := NewCSMS(nil, ws.NewTLSServer("certificatePath", "privateKeyPath"))
+func NewCSMS(endpoint *ocppj.Server, server ws.WsServer) CSMS {
+	if server == nil {
+		server = ws.NewServer()
+	}
+	server.AddSupportedSubprotocol(types21.V21Subprotocol)
+	if endpoint == nil {
+		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		endpoint = ocppj.NewServer(server, dispatcher, nil, authorization.Profile, availability.Profile, data.Profile, diagnostics.Profile, display.Profile, firmware.Profile, iso15118.Profile, localauth.Profile, meter.Profile, provisioning.Profile, remotecontrol.Profile, reservation.Profile, security.Profile, smartcharging.Profile, tariffcost.Profile, transactions.Profile, v2x.Profile, der.Profile, tariff.Profile, payment.Profile, batteryswap.Profile)
+	}
+	cs := newCSMS(endpoint)
+	cs.server.SetRequestHandler(func(client ws.Channel, request ocpp.Request, requestId string, action string) {
+		cs.handleIncomingRequest(client, request, requestId, action)
+	})
+	cs.server.SetResponseHandler(func(client ws.Channel, response ocpp.Response, requestId string) {
+		cs.handleIncomingResponse(client, response, requestId)
+	})
+	cs.server.SetErrorHandler(func(client ws.Channel, err *ocpp.Error, details interface{}) {
+		cs.handleIncomingError(client, err, details)
+	})
+	cs.server.SetSendHandler(func(client ws.Channel, request ocpp.Request, requestId string, action string) {
+		cs.handleIncomingUnconfirmedRequest(client, request, requestId, action)
+	})
+	cs.server.SetCallResultErrorHandler(func(client ws.Channel, err *ocpp.Error, details interface{}) {
+		cs.handleIncomingCallResultError(client, err, details)
+	})
+	// CALLRESULTERROR and SEND messages are part of OCPP 2.1
+	cs.server.SetExtendedMessageTypesEnabled(true)
+	// Callback invoked by dispatcher, whenever a queued request is canceled, due to timeout.
+	cs.server.SetOnRequestCanceled(ocppj.NewRequestTimeoutHandler(cs.callbackQueue.DequeueRequest, cs.error))
+	return &cs
+}
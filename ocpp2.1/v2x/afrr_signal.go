@@ -0,0 +1,60 @@
+package v2x
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+)
+
+// -------------------- AFRR Signal (CSMS -> CS) --------------------
+
+const AFRRSignalFeatureName = "AFRRSignal"
+
+// The field definition of the AFRRSignal request payload sent by the CSMS to the Charging Station.
+type AFRRSignalRequest struct {
+	Timestamp *types.DateTime `json:"timestamp" validate:"required"` // Time when the signal becomes active.
+	Signal    int             `json:"signal"`                        // Value of the signal, which selects the respective entry of the active charging schedule.
+}
+
+// This field definition of the AFRRSignal response payload, sent by the Charging Station to the CSMS in response to an AFRRSignalRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type AFRRSignalResponse struct {
+	Status     types.GenericStatus `json:"status" validate:"required,genericStatus21"`
+	StatusInfo *types.StatusInfo   `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// When participating in automatic frequency restoration reserve (aFRR), the CSMS forwards the activation signal
+// of the grid operator to the Charging Station via an AFRRSignalRequest.
+// The Charging Station responds with an AFRRSignalResponse and applies the charging or discharging setpoint,
+// which is associated to the signal value in its active charging schedule.
+type AFRRSignalFeature struct{}
+
+func (f AFRRSignalFeature) GetFeatureName() string {
+	return AFRRSignalFeatureName
+}
+
+func (f AFRRSignalFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(AFRRSignalRequest{})
+}
+
+func (f AFRRSignalFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(AFRRSignalResponse{})
+}
+
+func (r AFRRSignalRequest) GetFeatureName() string {
+	return AFRRSignalFeatureName
+}
+
+func (c AFRRSignalResponse) GetFeatureName() string {
+	return AFRRSignalFeatureName
+}
+
+// Creates a new AFRRSignalRequest, containing all required fields. There are no optional fields for this message.
+func NewAFRRSignalRequest(timestamp *types.DateTime, signal int) *AFRRSignalRequest {
+	return &AFRRSignalRequest{Timestamp: timestamp, Signal: signal}
+}
+
+// Creates a new AFRRSignalResponse, containing all required fields. Optional fields may be set afterwards.
+func NewAFRRSignalResponse(status types.GenericStatus) *AFRRSignalResponse {
+	return &AFRRSignalResponse{Status: status}
+}
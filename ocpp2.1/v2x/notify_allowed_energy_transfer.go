@@ -0,0 +1,113 @@
+package v2x
+
+import (
+	"reflect"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// -------------------- Notify Allowed Energy Transfer (CSMS -> CS) --------------------
+
+const NotifyAllowedEnergyTransferFeatureName = "NotifyAllowedEnergyTransfer"
+
+// EnergyTransferMode contains the energy transfer modes supported by OCPP 2.1, including bidirectional modes.
+type EnergyTransferMode string
+
+const (
+	EnergyTransferModeACSinglePhase EnergyTransferMode = "AC_single_phase" // AC single phase charging according to IEC 62196.
+	EnergyTransferModeACTwoPhase    EnergyTransferMode = "AC_two_phase"    // AC two phase charging according to IEC 62196.
+	EnergyTransferModeACThreePhase  EnergyTransferMode = "AC_three_phase"  // AC three phase charging according to IEC 62196.
+	EnergyTransferModeDC            EnergyTransferMode = "DC"              // DC charging.
+	EnergyTransferModeACBPT         EnergyTransferMode = "AC_BPT"          // AC bidirectional power transfer.
+	EnergyTransferModeACBPTDER      EnergyTransferMode = "AC_BPT_DER"      // AC bidirectional power transfer with DER control.
+	EnergyTransferModeACDER         EnergyTransferMode = "AC_DER"          // AC charging with DER control.
+	EnergyTransferModeDCBPT         EnergyTransferMode = "DC_BPT"          // DC bidirectional power transfer.
+	EnergyTransferModeDCACDP        EnergyTransferMode = "DC_ACDP"         // DC charging via an automated connection device (pantograph).
+	EnergyTransferModeDCACDPBPT     EnergyTransferMode = "DC_ACDP_BPT"     // DC bidirectional power transfer via an automated connection device.
+	EnergyTransferModeWPT           EnergyTransferMode = "WPT"             // Wireless power transfer.
+)
+
+func isValidEnergyTransferMode(fl validator.FieldLevel) bool {
+	mode := EnergyTransferMode(fl.Field().String())
+	switch mode {
+	case EnergyTransferModeACSinglePhase, EnergyTransferModeACTwoPhase, EnergyTransferModeACThreePhase, EnergyTransferModeDC,
+		EnergyTransferModeACBPT, EnergyTransferModeACBPTDER, EnergyTransferModeACDER, EnergyTransferModeDCBPT,
+		EnergyTransferModeDCACDP, EnergyTransferModeDCACDPBPT, EnergyTransferModeWPT:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status returned in response to NotifyAllowedEnergyTransferRequest.
+type NotifyAllowedEnergyTransferStatus string
+
+const (
+	NotifyAllowedEnergyTransferStatusAccepted NotifyAllowedEnergyTransferStatus = "Accepted"
+	NotifyAllowedEnergyTransferStatusRejected NotifyAllowedEnergyTransferStatus = "Rejected"
+)
+
+func isValidNotifyAllowedEnergyTransferStatus(fl validator.FieldLevel) bool {
+	status := NotifyAllowedEnergyTransferStatus(fl.Field().String())
+	switch status {
+	case NotifyAllowedEnergyTransferStatusAccepted, NotifyAllowedEnergyTransferStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// The field definition of the NotifyAllowedEnergyTransfer request payload sent by the CSMS to the Charging Station.
+type NotifyAllowedEnergyTransferRequest struct {
+	TransactionID         string               `json:"transactionId" validate:"required,max=36"`
+	AllowedEnergyTransfer []EnergyTransferMode `json:"allowedEnergyTransfer" validate:"required,min=1,dive,energyTransferMode21"`
+}
+
+// This field definition of the NotifyAllowedEnergyTransfer response payload, sent by the Charging Station to the CSMS in response to a NotifyAllowedEnergyTransferRequest.
+// In case the request was invalid, or couldn't be processed, an error will be sent instead.
+type NotifyAllowedEnergyTransferResponse struct {
+	Status     NotifyAllowedEnergyTransferStatus `json:"status" validate:"required,notifyAllowedEnergyTransferStatus21"`
+	StatusInfo *types.StatusInfo                 `json:"statusInfo,omitempty" validate:"omitempty"`
+}
+
+// The CSMS may restrict the energy transfer modes, which an EV is allowed to use during a transaction,
+// e.g. after the EV requested bidirectional power transfer which isn't allowed by the contract of the driver.
+// The CSMS sends a NotifyAllowedEnergyTransferRequest to the Charging Station, which responds with a NotifyAllowedEnergyTransferResponse.
+// The Charging Station then offers only the allowed modes in the following renegotiation with the EV.
+type NotifyAllowedEnergyTransferFeature struct{}
+
+func (f NotifyAllowedEnergyTransferFeature) GetFeatureName() string {
+	return NotifyAllowedEnergyTransferFeatureName
+}
+
+func (f NotifyAllowedEnergyTransferFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(NotifyAllowedEnergyTransferRequest{})
+}
+
+func (f NotifyAllowedEnergyTransferFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(NotifyAllowedEnergyTransferResponse{})
+}
+
+func (r NotifyAllowedEnergyTransferRequest) GetFeatureName() string {
+	return NotifyAllowedEnergyTransferFeatureName
+}
+
+func (c NotifyAllowedEnergyTransferResponse) GetFeatureName() string {
+	return NotifyAllowedEnergyTransferFeatureName
+}
+
+// Creates a new NotifyAllowedEnergyTransferRequest, containing all required fields. There are no optional fields for this message.
+func NewNotifyAllowedEnergyTransferRequest(transactionID string, allowedEnergyTransfer ...EnergyTransferMode) *NotifyAllowedEnergyTransferRequest {
+	return &NotifyAllowedEnergyTransferRequest{TransactionID: transactionID, AllowedEnergyTransfer: allowedEnergyTransfer}
+}
+
+// Creates a new NotifyAllowedEnergyTransferResponse, containing all required fields. Optional fields may be set afterwards.
+func NewNotifyAllowedEnergyTransferResponse(status NotifyAllowedEnergyTransferStatus) *NotifyAllowedEnergyTransferResponse {
+	return &NotifyAllowedEnergyTransferResponse{Status: status}
+}
+
+func init() {
+	_ = types.Validate.RegisterValidation("energyTransferMode21", isValidEnergyTransferMode)
+	_ = types.Validate.RegisterValidation("notifyAllowedEnergyTransferStatus21", isValidNotifyAllowedEnergyTransferStatus)
+}
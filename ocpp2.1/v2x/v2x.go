@@ -0,0 +1,24 @@
+// The V2X functional block contains OCPP 2.1 features for bidirectional power transfer between an EV and the grid.
+package v2x
+
+import "github.com/lorenzodonini/ocpp-go/ocpp"
+
+// Needs to be implemented by a CSMS for handling messages part of the OCPP 2.1 V2X profile.
+type CSMSHandler interface {
+}
+
+// Needs to be implemented by Charging stations for handling messages part of the OCPP 2.1 V2X profile.
+type ChargingStationHandler interface {
+	// OnNotifyAllowedEnergyTransfer is called on a charging station whenever a NotifyAllowedEnergyTransferRequest is received from the CSMS.
+	OnNotifyAllowedEnergyTransfer(request *NotifyAllowedEnergyTransferRequest) (response *NotifyAllowedEnergyTransferResponse, err error)
+	// OnAFRRSignal is called on a charging station whenever an AFRRSignalRequest is received from the CSMS.
+	OnAFRRSignal(request *AFRRSignalRequest) (response *AFRRSignalResponse, err error)
+}
+
+const ProfileName = "v2x"
+
+var Profile = ocpp.NewProfile(
+	ProfileName,
+	NotifyAllowedEnergyTransferFeature{},
+	AFRRSignalFeature{},
+)
@@ -0,0 +1,85 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/v2x"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestAFRRSignalRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{v2x.AFRRSignalRequest{Timestamp: types.NewDateTime(time.Now()), Signal: 1}, true},
+		{v2x.AFRRSignalRequest{Timestamp: types.NewDateTime(time.Now()), Signal: -1}, true},
+		{v2x.AFRRSignalRequest{Timestamp: types.NewDateTime(time.Now())}, true},
+		{v2x.AFRRSignalRequest{Signal: 1}, false},
+		{v2x.AFRRSignalRequest{}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestAFRRSignalResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{v2x.AFRRSignalResponse{Status: types.GenericStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok")}, true},
+		{v2x.AFRRSignalResponse{Status: types.GenericStatusRejected}, true},
+		{v2x.AFRRSignalResponse{}, false},
+		{v2x.AFRRSignalResponse{Status: "invalidStatus"}, false},
+		{v2x.AFRRSignalResponse{Status: types.GenericStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestAFRRSignalE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	timestamp := types.NewDateTime(time.Now())
+	signal := -1
+	status := types.GenericStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"timestamp":"%v","signal":%v}]`, messageId, v2x.AFRRSignalFeatureName, timestamp.FormatTimestamp(), signal)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	afrrSignalResponse := v2x.NewAFRRSignalResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationV2XHandler{}
+	handler.On("OnAFRRSignal", mock.Anything).Return(afrrSignalResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*v2x.AFRRSignalRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assertDateTimeEquality(t, timestamp, request.Timestamp)
+		assert.Equal(t, signal, request.Signal)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.AFRRSignal(wsId, func(response *v2x.AFRRSignalResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, timestamp, signal)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestAFRRSignalInvalidEndpoint() {
+	messageId := defaultMessageId
+	timestamp := types.NewDateTime(time.Now())
+	signal := 1
+	request := v2x.NewAFRRSignalRequest(timestamp, signal)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"timestamp":"%v","signal":%v}]`, messageId, v2x.AFRRSignalFeatureName, timestamp.FormatTimestamp(), signal)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
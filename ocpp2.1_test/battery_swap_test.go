@@ -0,0 +1,93 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/batteryswap"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestBatterySwapRequestValidation() {
+	t := suite.T()
+	idToken := types.IdToken{IdToken: "1234", Type: types.IdTokenTypeISO14443}
+	batteryData := batteryswap.BatteryData{EvseID: 1, SerialNumber: "battery1", SoC: 80.5, SoH: 95.0, ProductionDate: types.NewDateTime(time.Now()), VendorInfo: "info"}
+	var requestTable = []GenericTestEntry{
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{batteryData}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken, RequestID: 1}, true},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: "battery1"}}, EventType: batteryswap.BatterySwapEventBatteryOutTimeout, IdToken: idToken}, true},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{batteryData}, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{batteryData}, EventType: "invalidEvent", IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{batteryData}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: types.IdToken{IdToken: "1234"}}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{EvseID: -1, SerialNumber: "battery1"}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{EvseID: 1}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: newLongString(51)}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: "battery1", SoC: 101}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: "battery1", SoC: -1}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: "battery1", SoH: 101}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+		{batteryswap.BatterySwapRequest{BatteryData: []batteryswap.BatteryData{{SerialNumber: "battery1", VendorInfo: newLongString(501)}}, EventType: batteryswap.BatterySwapEventBatteryIn, IdToken: idToken}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestBatterySwapResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{batteryswap.BatterySwapResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestBatterySwapE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	eventType := batteryswap.BatterySwapEventBatteryOut
+	idToken := types.IdToken{IdToken: "1234", Type: types.IdTokenTypeISO14443}
+	requestID := 42
+	batteryData := batteryswap.BatteryData{EvseID: 1, SerialNumber: "battery1", SoC: 20.5, SoH: 95.5}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"batteryData":[{"evseId":%v,"serialNumber":"%v","soC":%v,"soH":%v}],"eventType":"%v","idToken":{"idToken":"%v","type":"%v"},"requestId":%v}]`,
+		messageId, batteryswap.BatterySwapFeatureName, batteryData.EvseID, batteryData.SerialNumber, batteryData.SoC, batteryData.SoH, eventType, idToken.IdToken, idToken.Type, requestID)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	batterySwapResponse := batteryswap.NewBatterySwapResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSBatterySwapHandler{}
+	handler.On("OnBatterySwap", mock.AnythingOfType("string"), mock.Anything).Return(batterySwapResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*batteryswap.BatterySwapRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, eventType, request.EventType)
+		assert.Equal(t, idToken, request.IdToken)
+		assert.Equal(t, requestID, request.RequestID)
+		require.Len(t, request.BatteryData, 1)
+		assert.Equal(t, batteryData, request.BatteryData[0])
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.BatterySwap(eventType, idToken, requestID, []batteryswap.BatteryData{batteryData})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV21TestSuite) TestBatterySwapInvalidEndpoint() {
+	messageId := defaultMessageId
+	eventType := batteryswap.BatterySwapEventBatteryIn
+	idToken := types.IdToken{IdToken: "1234", Type: types.IdTokenTypeISO14443}
+	requestID := 42
+	batteryData := batteryswap.BatteryData{EvseID: 1, SerialNumber: "battery1", SoC: 80, SoH: 95}
+	request := batteryswap.NewBatterySwapRequest(eventType, idToken, requestID, batteryData)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"batteryData":[{"evseId":%v,"serialNumber":"%v","soC":%v,"soH":%v}],"eventType":"%v","idToken":{"idToken":"%v","type":"%v"},"requestId":%v}]`,
+		messageId, batteryswap.BatterySwapFeatureName, batteryData.EvseID, batteryData.SerialNumber, batteryData.SoC, batteryData.SoH, eventType, idToken.IdToken, idToken.Type, requestID)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
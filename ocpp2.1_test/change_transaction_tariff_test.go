@@ -0,0 +1,86 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestChangeTransactionTariffRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{tariff.ChangeTransactionTariffRequest{TransactionID: "1234", Tariff: tariff.NewTariff("tariff1", "EUR")}, true},
+		{tariff.ChangeTransactionTariffRequest{TransactionID: "1234"}, false},
+		{tariff.ChangeTransactionTariffRequest{Tariff: tariff.NewTariff("tariff1", "EUR")}, false},
+		{tariff.ChangeTransactionTariffRequest{TransactionID: ">36..................................", Tariff: tariff.NewTariff("tariff1", "EUR")}, false},
+		{tariff.ChangeTransactionTariffRequest{TransactionID: "1234", Tariff: tariff.NewTariff("tariff1", "EURO")}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestChangeTransactionTariffResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{tariff.ChangeTransactionTariffResponse{Status: tariff.TariffChangeStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok")}, true},
+		{tariff.ChangeTransactionTariffResponse{Status: tariff.TariffChangeStatusNoCurrencyChange}, true},
+		{tariff.ChangeTransactionTariffResponse{}, false},
+		{tariff.ChangeTransactionTariffResponse{Status: "invalidStatus"}, false},
+		{tariff.ChangeTransactionTariffResponse{Status: tariff.TariffChangeStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestChangeTransactionTariffE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	transactionID := "1234"
+	newTariff := tariff.NewTariff("tariff2", "EUR")
+	newTariff.FixedFee = &tariff.TariffFixed{Prices: []tariff.TariffFixedPrice{{PriceFixed: 1.5}}}
+	status := tariff.TariffChangeStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"transactionId":"%v","tariff":{"tariffId":"%v","currency":"%v","fixedFee":{"prices":[{"priceFixed":%v}]}}}]`,
+		messageId, tariff.ChangeTransactionTariffFeatureName, transactionID, newTariff.TariffID, newTariff.Currency, newTariff.FixedFee.Prices[0].PriceFixed)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	changeTransactionTariffResponse := tariff.NewChangeTransactionTariffResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationTariffHandler{}
+	handler.On("OnChangeTransactionTariff", mock.Anything).Return(changeTransactionTariffResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*tariff.ChangeTransactionTariffRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, transactionID, request.TransactionID)
+		assert.Equal(t, newTariff, request.Tariff)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.ChangeTransactionTariff(wsId, func(response *tariff.ChangeTransactionTariffResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, transactionID, newTariff)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestChangeTransactionTariffInvalidEndpoint() {
+	messageId := defaultMessageId
+	transactionID := "1234"
+	newTariff := tariff.NewTariff("tariff2", "EUR")
+	request := tariff.NewChangeTransactionTariffRequest(transactionID, newTariff)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"transactionId":"%v","tariff":{"tariffId":"%v","currency":"%v"}}]`, messageId, tariff.ChangeTransactionTariffFeatureName, transactionID, newTariff.TariffID, newTariff.Currency)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
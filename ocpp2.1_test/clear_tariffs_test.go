@@ -0,0 +1,90 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestClearTariffsRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{tariff.ClearTariffsRequest{TariffIDs: []string{"tariff1", "tariff2"}, EvseID: newInt(1)}, true},
+		{tariff.ClearTariffsRequest{EvseID: newInt(0)}, true},
+		{tariff.ClearTariffsRequest{}, true},
+		{tariff.ClearTariffsRequest{TariffIDs: []string{newLongString(61)}}, false},
+		{tariff.ClearTariffsRequest{EvseID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestClearTariffsResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{TariffID: "tariff1", Status: tariff.TariffClearStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok")}}}, true},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{Status: tariff.TariffClearStatusNoTariff}}}, true},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{}}, false},
+		{tariff.ClearTariffsResponse{}, false},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{TariffID: "tariff1"}}}, false},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{TariffID: "tariff1", Status: "invalidStatus"}}}, false},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{TariffID: newLongString(61), Status: tariff.TariffClearStatusAccepted}}}, false},
+		{tariff.ClearTariffsResponse{ClearTariffsResult: []tariff.ClearTariffsResult{{TariffID: "tariff1", Status: tariff.TariffClearStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestClearTariffsE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	tariffID := "tariff1"
+	evseID := 1
+	result := tariff.ClearTariffsResult{TariffID: tariffID, Status: tariff.TariffClearStatusAccepted}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"tariffIds":["%v"],"evseId":%v}]`, messageId, tariff.ClearTariffsFeatureName, tariffID, evseID)
+	responseJson := fmt.Sprintf(`[3,"%v",{"clearTariffsResult":[{"tariffId":"%v","status":"%v"}]}]`, messageId, result.TariffID, result.Status)
+	clearTariffsResponse := tariff.NewClearTariffsResponse(result)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationTariffHandler{}
+	handler.On("OnClearTariffs", mock.Anything).Return(clearTariffsResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*tariff.ClearTariffsRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, []string{tariffID}, request.TariffIDs)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, evseID, *request.EvseID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.ClearTariffs(wsId, func(response *tariff.ClearTariffsResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		require.Len(t, response.ClearTariffsResult, 1)
+		assert.Equal(t, result, response.ClearTariffsResult[0])
+		resultChannel <- true
+	}, func(request *tariff.ClearTariffsRequest) {
+		request.TariffIDs = []string{tariffID}
+		request.EvseID = &evseID
+	})
+	require.Nil(t, err)
+	success := <-resultChannel
+	assert.True(t, success)
+}
+
+func (suite *OcppV21TestSuite) TestClearTariffsInvalidEndpoint() {
+	messageId := defaultMessageId
+	request := tariff.NewClearTariffsRequest()
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, tariff.ClearTariffsFeatureName)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
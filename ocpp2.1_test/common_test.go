@@ -0,0 +1,203 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Utility functions
+func newInt(i int) *int {
+	return &i
+}
+
+func newFloat(f float64) *float64 {
+	return &f
+}
+
+func newBool(b bool) *bool {
+	return &b
+}
+
+func newLongString(length int) string {
+	return strings.Repeat(".", length)
+}
+
+// Test
+func (suite *OcppV21TestSuite) TestGenericStatusValidation() {
+	var testTable = []GenericTestEntry{
+		{struct {
+			Status types.GenericStatus `validate:"required,genericStatus21"`
+		}{types.GenericStatusAccepted}, true},
+		{struct {
+			Status types.GenericStatus `validate:"required,genericStatus21"`
+		}{types.GenericStatusRejected}, true},
+		{struct {
+			Status types.GenericStatus `validate:"required,genericStatus21"`
+		}{"invalidStatus"}, false},
+	}
+	ExecuteGenericTestTable(suite.T(), testTable)
+}
+
+// Messages of the 2.0.1 functional blocks are exchanged over the 2.1 protocol as they are.
+func (suite *OcppV21TestSuite) TestOcpp201MessageE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	currentTime := types.NewDateTime(time.Now())
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	responseJson := fmt.Sprintf(`[3,"%v",{"currentTime":"%v"}]`, messageId, currentTime.FormatTimestamp())
+	heartbeatResponse := provisioning.NewHeartbeatResponse(currentTime)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSProvisioningHandler{}
+	handler.On("OnHeartbeat", mock.AnythingOfType("string"), mock.Anything).Return(heartbeatResponse, nil)
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.Heartbeat()
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assertDateTimeEquality(t, currentTime, response.CurrentTime)
+}
+
+func (suite *OcppV21TestSuite) TestUnconfirmedRequestFromChargingStation() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestJson := fmt.Sprintf(`[6,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	resultC := make(chan ocpp.Request, 1)
+	suite.csms.SetUnconfirmedRequestHandler(func(chargingStationID string, request ocpp.Request) {
+		assert.Equal(t, wsId, chargingStationID)
+		resultC <- request
+	})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	err = suite.chargingStation.SendUnconfirmedRequest(provisioning.NewHeartbeatRequest())
+	require.Nil(t, err)
+	request := <-resultC
+	assert.IsType(t, &provisioning.HeartbeatRequest{}, request)
+	// No response is sent for unconfirmed requests
+	suite.mockWsServer.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
+func (suite *OcppV21TestSuite) TestUnconfirmedRequestFromCSMS() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestJson := fmt.Sprintf(`[6,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, forwardWrittenMessage: false})
+	resultC := make(chan ocpp.Request, 1)
+	suite.chargingStation.SetUnconfirmedRequestHandler(func(request ocpp.Request) {
+		resultC <- request
+	})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	err = suite.csms.SendUnconfirmedRequest(wsId, provisioning.NewHeartbeatRequest())
+	require.Nil(t, err)
+	request := <-resultC
+	assert.IsType(t, &provisioning.HeartbeatRequest{}, request)
+	suite.mockWsClient.AssertNotCalled(t, "Write", mock.Anything)
+}
+
+func (suite *OcppV21TestSuite) TestUnconfirmedRequestWithoutHandler() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestJson := fmt.Sprintf(`[6,"%v","%v",{}]`, messageId, provisioning.HeartbeatFeatureName)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	errC := suite.csms.Errors()
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	err = suite.chargingStation.SendUnconfirmedRequest(provisioning.NewHeartbeatRequest())
+	require.Nil(t, err)
+	err = <-errC
+	assert.Contains(t, err.Error(), "no handler available for unconfirmed request")
+}
+
+func (suite *OcppV21TestSuite) TestCallResultErrorFromCSMS() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	errorDescription := "invalid response"
+	resultErrorJson := fmt.Sprintf(`[5,"%v","%v","%v",null]`, messageId, ocppj.FormationViolation, errorDescription)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, forwardWrittenMessage: false})
+	resultC := make(chan *ocpp.Error, 1)
+	suite.chargingStation.SetCallResultErrorHandler(func(err *ocpp.Error, details interface{}) {
+		assert.Nil(t, details)
+		resultC <- err
+	})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	err = suite.mockWsClient.MessageHandler([]byte(resultErrorJson))
+	require.Nil(t, err)
+	resultErr := <-resultC
+	assert.Equal(t, messageId, resultErr.MessageId)
+	assert.Equal(t, ocppj.FormationViolation, resultErr.Code)
+	assert.Equal(t, errorDescription, resultErr.Description)
+}
+
+func (suite *OcppV21TestSuite) TestCallResultErrorFromChargingStation() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	errorDescription := "invalid response"
+	resultErrorJson := fmt.Sprintf(`[5,"%v","%v","%v",null]`, messageId, ocppj.InternalError, errorDescription)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, forwardWrittenMessage: false})
+	resultC := make(chan *ocpp.Error, 1)
+	suite.csms.SetCallResultErrorHandler(func(chargingStationID string, err *ocpp.Error, details interface{}) {
+		assert.Equal(t, wsId, chargingStationID)
+		resultC <- err
+	})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	err = suite.mockWsServer.MessageHandler(channel, []byte(resultErrorJson))
+	require.Nil(t, err)
+	resultErr := <-resultC
+	assert.Equal(t, messageId, resultErr.MessageId)
+	assert.Equal(t, ocppj.InternalError, resultErr.Code)
+	assert.Equal(t, errorDescription, resultErr.Description)
+}
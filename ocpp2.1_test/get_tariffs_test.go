@@ -0,0 +1,91 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestGetTariffsRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{tariff.GetTariffsRequest{EvseID: 1}, true},
+		{tariff.GetTariffsRequest{}, true},
+		{tariff.GetTariffsRequest{EvseID: -1}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestGetTariffsResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok"), TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1", TariffKind: tariff.TariffKindDriverTariff, EvseIDs: []int{1, 2}, IdTokens: []string{"token1"}}}}, true},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1", TariffKind: tariff.TariffKindDefaultTariff}}}, true},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusNoTariff}, true},
+		{tariff.GetTariffsResponse{}, false},
+		{tariff.GetTariffsResponse{Status: "invalidStatus"}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffKind: tariff.TariffKindDefaultTariff}}}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: newLongString(61), TariffKind: tariff.TariffKindDefaultTariff}}}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1"}}}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1", TariffKind: "invalidKind"}}}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1", TariffKind: tariff.TariffKindDefaultTariff, EvseIDs: []int{-1}}}}, false},
+		{tariff.GetTariffsResponse{Status: tariff.TariffGetStatusAccepted, TariffAssignments: []tariff.TariffAssignment{{TariffID: "tariff1", TariffKind: tariff.TariffKindDriverTariff, IdTokens: []string{newLongString(256)}}}}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestGetTariffsE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	status := tariff.TariffGetStatusAccepted
+	assignment := tariff.TariffAssignment{TariffID: "tariff1", TariffKind: tariff.TariffKindDefaultTariff, EvseIDs: []int{evseID}}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v}]`, messageId, tariff.GetTariffsFeatureName, evseID)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v","tariffAssignments":[{"tariffId":"%v","tariffKind":"%v","evseIds":[%v]}]}]`,
+		messageId, status, assignment.TariffID, assignment.TariffKind, evseID)
+	getTariffsResponse := tariff.NewGetTariffsResponse(status)
+	getTariffsResponse.TariffAssignments = []tariff.TariffAssignment{assignment}
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationTariffHandler{}
+	handler.On("OnGetTariffs", mock.Anything).Return(getTariffsResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*tariff.GetTariffsRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, evseID, request.EvseID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.GetTariffs(wsId, func(response *tariff.GetTariffsResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		require.Len(t, response.TariffAssignments, 1)
+		assert.Equal(t, assignment, response.TariffAssignments[0])
+		resultChannel <- true
+	}, evseID)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestGetTariffsInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	request := tariff.NewGetTariffsRequest(evseID)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v}]`, messageId, tariff.GetTariffsFeatureName, evseID)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
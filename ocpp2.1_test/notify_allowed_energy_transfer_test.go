@@ -0,0 +1,86 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/v2x"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestNotifyAllowedEnergyTransferRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: "1234", AllowedEnergyTransfer: []v2x.EnergyTransferMode{v2x.EnergyTransferModeDC, v2x.EnergyTransferModeDCBPT}}, true},
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: "1234", AllowedEnergyTransfer: []v2x.EnergyTransferMode{v2x.EnergyTransferModeWPT}}, true},
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: "1234", AllowedEnergyTransfer: []v2x.EnergyTransferMode{}}, false},
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: "1234"}, false},
+		{v2x.NotifyAllowedEnergyTransferRequest{AllowedEnergyTransfer: []v2x.EnergyTransferMode{v2x.EnergyTransferModeDC}}, false},
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: ">36..................................", AllowedEnergyTransfer: []v2x.EnergyTransferMode{v2x.EnergyTransferModeDC}}, false},
+		{v2x.NotifyAllowedEnergyTransferRequest{TransactionID: "1234", AllowedEnergyTransfer: []v2x.EnergyTransferMode{"invalidMode"}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyAllowedEnergyTransferResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{v2x.NotifyAllowedEnergyTransferResponse{Status: v2x.NotifyAllowedEnergyTransferStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok")}, true},
+		{v2x.NotifyAllowedEnergyTransferResponse{Status: v2x.NotifyAllowedEnergyTransferStatusRejected}, true},
+		{v2x.NotifyAllowedEnergyTransferResponse{}, false},
+		{v2x.NotifyAllowedEnergyTransferResponse{Status: "invalidStatus"}, false},
+		{v2x.NotifyAllowedEnergyTransferResponse{Status: v2x.NotifyAllowedEnergyTransferStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyAllowedEnergyTransferE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	transactionID := "1234"
+	modes := []v2x.EnergyTransferMode{v2x.EnergyTransferModeACThreePhase, v2x.EnergyTransferModeACBPT}
+	status := v2x.NotifyAllowedEnergyTransferStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"transactionId":"%v","allowedEnergyTransfer":["%v","%v"]}]`, messageId, v2x.NotifyAllowedEnergyTransferFeatureName, transactionID, modes[0], modes[1])
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	notifyAllowedEnergyTransferResponse := v2x.NewNotifyAllowedEnergyTransferResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationV2XHandler{}
+	handler.On("OnNotifyAllowedEnergyTransfer", mock.Anything).Return(notifyAllowedEnergyTransferResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*v2x.NotifyAllowedEnergyTransferRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, transactionID, request.TransactionID)
+		assert.Equal(t, modes, request.AllowedEnergyTransfer)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.NotifyAllowedEnergyTransfer(wsId, func(response *v2x.NotifyAllowedEnergyTransferResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, transactionID, modes)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyAllowedEnergyTransferInvalidEndpoint() {
+	messageId := defaultMessageId
+	transactionID := "1234"
+	mode := v2x.EnergyTransferModeDC
+	request := v2x.NewNotifyAllowedEnergyTransferRequest(transactionID, mode)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"transactionId":"%v","allowedEnergyTransfer":["%v"]}]`, messageId, v2x.NotifyAllowedEnergyTransferFeatureName, transactionID, mode)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,101 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestNotifySettlementRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{payment.NotifySettlementRequest{TransactionID: "1234", PspRef: "psp1", Status: payment.PaymentStatusSettled, StatusInfo: "info", SettlementAmount: 12.5, SettlementTime: types.NewDateTime(time.Now()), ReceiptID: "receipt1", ReceiptURL: "https://receipts.example.com/1", VatNumber: "DE123456789"}, true},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusFailed, SettlementTime: types.NewDateTime(time.Now())}, true},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusSettled}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: "invalidStatus", SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{TransactionID: ">36..................................", PspRef: "psp1", Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{PspRef: newLongString(256), Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusSettled, StatusInfo: newLongString(501), SettlementTime: types.NewDateTime(time.Now())}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now()), ReceiptID: newLongString(51)}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now()), ReceiptURL: newLongString(2001)}, false},
+		{payment.NotifySettlementRequest{PspRef: "psp1", Status: payment.PaymentStatusSettled, SettlementTime: types.NewDateTime(time.Now()), VatNumber: newLongString(21)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifySettlementResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{payment.NotifySettlementResponse{ReceiptURL: "https://receipts.example.com/1", ReceiptID: "receipt1"}, true},
+		{payment.NotifySettlementResponse{}, true},
+		{payment.NotifySettlementResponse{ReceiptURL: newLongString(2001)}, false},
+		{payment.NotifySettlementResponse{ReceiptID: newLongString(51)}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifySettlementE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	transactionID := "1234"
+	pspRef := "psp1"
+	status := payment.PaymentStatusSettled
+	settlementAmount := 12.5
+	settlementTime := types.NewDateTime(time.Now())
+	receiptURL := "https://receipts.example.com/1"
+	receiptID := "receipt1"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"transactionId":"%v","pspRef":"%v","status":"%v","settlementAmount":%v,"settlementTime":"%v"}]`,
+		messageId, payment.NotifySettlementFeatureName, transactionID, pspRef, status, settlementAmount, settlementTime.FormatTimestamp())
+	responseJson := fmt.Sprintf(`[3,"%v",{"receiptUrl":"%v","receiptId":"%v"}]`, messageId, receiptURL, receiptID)
+	notifySettlementResponse := payment.NewNotifySettlementResponse()
+	notifySettlementResponse.ReceiptURL = receiptURL
+	notifySettlementResponse.ReceiptID = receiptID
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSPaymentHandler{}
+	handler.On("OnNotifySettlement", mock.AnythingOfType("string"), mock.Anything).Return(notifySettlementResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*payment.NotifySettlementRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, transactionID, request.TransactionID)
+		assert.Equal(t, pspRef, request.PspRef)
+		assert.Equal(t, status, request.Status)
+		assert.Equal(t, settlementAmount, request.SettlementAmount)
+		assertDateTimeEquality(t, settlementTime, request.SettlementTime)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.NotifySettlement(pspRef, status, settlementAmount, settlementTime, func(request *payment.NotifySettlementRequest) {
+		request.TransactionID = transactionID
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, receiptURL, response.ReceiptURL)
+	assert.Equal(t, receiptID, response.ReceiptID)
+}
+
+func (suite *OcppV21TestSuite) TestNotifySettlementInvalidEndpoint() {
+	messageId := defaultMessageId
+	pspRef := "psp1"
+	status := payment.PaymentStatusSettled
+	settlementAmount := 12.5
+	settlementTime := types.NewDateTime(time.Now())
+	request := payment.NewNotifySettlementRequest(pspRef, status, settlementAmount, settlementTime)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"pspRef":"%v","status":"%v","settlementAmount":%v,"settlementTime":"%v"}]`,
+		messageId, payment.NotifySettlementFeatureName, pspRef, status, settlementAmount, settlementTime.FormatTimestamp())
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,76 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestNotifyWebPaymentStartedRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{payment.NotifyWebPaymentStartedRequest{EvseID: 1, Timeout: 60}, true},
+		{payment.NotifyWebPaymentStartedRequest{}, true},
+		{payment.NotifyWebPaymentStartedRequest{EvseID: -1, Timeout: 60}, false},
+		{payment.NotifyWebPaymentStartedRequest{EvseID: 1, Timeout: -1}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyWebPaymentStartedResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{payment.NotifyWebPaymentStartedResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyWebPaymentStartedE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	timeout := 120
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"timeout":%v}]`, messageId, payment.NotifyWebPaymentStartedFeatureName, evseID, timeout)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	notifyWebPaymentStartedResponse := payment.NewNotifyWebPaymentStartedResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationPaymentHandler{}
+	handler.On("OnNotifyWebPaymentStarted", mock.Anything).Return(notifyWebPaymentStartedResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*payment.NotifyWebPaymentStartedRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, evseID, request.EvseID)
+		assert.Equal(t, timeout, request.Timeout)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.NotifyWebPaymentStarted(wsId, func(response *payment.NotifyWebPaymentStartedResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		resultChannel <- true
+	}, evseID, timeout)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestNotifyWebPaymentStartedInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	timeout := 120
+	request := payment.NewNotifyWebPaymentStartedRequest(evseID, timeout)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"timeout":%v}]`, messageId, payment.NotifyWebPaymentStartedFeatureName, evseID, timeout)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
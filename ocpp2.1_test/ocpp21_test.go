@@ -0,0 +1,615 @@
+package ocpp21_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	ocpp21 "github.com/lorenzodonini/ocpp-go/ocpp2.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/batteryswap"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/der"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/v2x"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ---------------------- MOCK WEBSOCKET ----------------------
+
+type MockWebSocket struct {
+	id string
+}
+
+func (websocket MockWebSocket) ID() string {
+	return websocket.id
+}
+
+func (websocket MockWebSocket) TLSConnectionState() *tls.ConnectionState {
+	return nil
+}
+
+func (websocket MockWebSocket) Subprotocol() string {
+	return ""
+}
+
+func NewMockWebSocket(id string) MockWebSocket {
+	return MockWebSocket{id: id}
+}
+
+// ---------------------- MOCK WEBSOCKET SERVER ----------------------
+
+type MockWebsocketServer struct {
+	mock.Mock
+	ws.WsServer
+	MessageHandler            func(ws ws.Channel, data []byte) error
+	NewClientHandler          func(ws ws.Channel)
+	DisconnectedClientHandler func(ws ws.Channel)
+}
+
+func (websocketServer *MockWebsocketServer) Start(port int, listenPath string) {
+	websocketServer.MethodCalled("Start", port, listenPath)
+}
+
+func (websocketServer *MockWebsocketServer) Stop() {
+	websocketServer.MethodCalled("Stop")
+}
+
+func (websocketServer *MockWebsocketServer) Write(webSocketId string, data []byte) error {
+	args := websocketServer.MethodCalled("Write", webSocketId, data)
+	return args.Error(0)
+}
+
+func (websocketServer *MockWebsocketServer) SetMessageHandler(handler func(ws ws.Channel, data []byte) error) {
+	websocketServer.MessageHandler = handler
+}
+
+func (websocketServer *MockWebsocketServer) SetNewClientHandler(handler func(ws ws.Channel)) {
+	websocketServer.NewClientHandler = handler
+}
+
+func (websocketServer *MockWebsocketServer) SetDisconnectedClientHandler(handler func(ws ws.Channel)) {
+	websocketServer.DisconnectedClientHandler = handler
+}
+
+func (websocketServer *MockWebsocketServer) AddSupportedSubprotocol(subProto string) {
+}
+
+func (websocketServer *MockWebsocketServer) NewClient(websocketId string, client interface{}) {
+	websocketServer.MethodCalled("NewClient", websocketId, client)
+}
+
+// ---------------------- MOCK WEBSOCKET CLIENT ----------------------
+
+type MockWebsocketClient struct {
+	mock.Mock
+	ws.WsClient
+	MessageHandler      func(data []byte) error
+	ReconnectedHandler  func()
+	DisconnectedHandler func(err error)
+	errC                chan error
+}
+
+func (websocketClient *MockWebsocketClient) Start(url string) error {
+	args := websocketClient.MethodCalled("Start", url)
+	return args.Error(0)
+}
+
+func (websocketClient *MockWebsocketClient) Stop() {
+	websocketClient.MethodCalled("Stop")
+}
+
+func (websocketClient *MockWebsocketClient) SetMessageHandler(handler func(data []byte) error) {
+	websocketClient.MessageHandler = handler
+}
+
+func (websocketClient *MockWebsocketClient) SetReconnectedHandler(handler func()) {
+	websocketClient.ReconnectedHandler = handler
+}
+
+func (websocketClient *MockWebsocketClient) SetDisconnectedHandler(handler func(err error)) {
+	websocketClient.DisconnectedHandler = handler
+}
+
+func (websocketClient *MockWebsocketClient) Write(data []byte) error {
+	args := websocketClient.MethodCalled("Write", data)
+	return args.Error(0)
+}
+
+func (websocketClient *MockWebsocketClient) AddOption(option interface{}) {
+}
+
+func (websocketClient *MockWebsocketClient) SetBasicAuth(username string, password string) {
+}
+
+func (websocketClient *MockWebsocketClient) SetTimeoutConfig(config ws.ClientTimeoutConfig) {
+}
+
+func (websocketClient *MockWebsocketClient) Errors() <-chan error {
+	if websocketClient.errC == nil {
+		websocketClient.errC = make(chan error, 1)
+	}
+	return websocketClient.errC
+}
+
+// Default queue capacity
+const queueCapacity = 10
+
+// ---------------------- MOCK FEATURE ----------------------
+const (
+	MockFeatureName = "Mock"
+)
+
+type MockRequest struct {
+	mock.Mock
+	MockValue string `json:"mockValue" validate:"required,max=10"`
+}
+
+type MockConfirmation struct {
+	mock.Mock
+	MockValue string `json:"mockValue" validate:"required,min=5"`
+}
+
+type MockFeature struct {
+	mock.Mock
+}
+
+func (f *MockFeature) GetFeatureName() string {
+	return MockFeatureName
+}
+
+func (f *MockFeature) GetRequestType() reflect.Type {
+	return reflect.TypeOf(MockRequest{})
+}
+
+func (f *MockFeature) GetResponseType() reflect.Type {
+	return reflect.TypeOf(MockConfirmation{})
+}
+
+func (r *MockRequest) GetFeatureName() string {
+	return MockFeatureName
+}
+
+func (c *MockConfirmation) GetFeatureName() string {
+	return MockFeatureName
+}
+
+func newMockRequest(value string) *MockRequest {
+	return &MockRequest{MockValue: value}
+}
+
+func newMockConfirmation(value string) *MockConfirmation {
+	return &MockConfirmation{MockValue: value}
+}
+
+// ---------------------- MOCK CSMS PROVISIONING HANDLER ----------------------
+
+type MockCSMSProvisioningHandler struct {
+	mock.Mock
+}
+
+func (handler *MockCSMSProvisioningHandler) OnBootNotification(chargingStationId string, request *provisioning.BootNotificationRequest) (confirmation *provisioning.BootNotificationResponse, err error) {
+	args := handler.MethodCalled("OnBootNotification", chargingStationId, request)
+	conf := args.Get(0).(*provisioning.BootNotificationResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSProvisioningHandler) OnHeartbeat(chargingStationID string, request *provisioning.HeartbeatRequest) (confirmation *provisioning.HeartbeatResponse, err error) {
+	args := handler.MethodCalled("OnHeartbeat", chargingStationID, request)
+	conf := args.Get(0).(*provisioning.HeartbeatResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSProvisioningHandler) OnNotifyReport(chargingStationID string, request *provisioning.NotifyReportRequest) (confirmation *provisioning.NotifyReportResponse, err error) {
+	args := handler.MethodCalled("OnNotifyReport", chargingStationID, request)
+	conf := args.Get(0).(*provisioning.NotifyReportResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CS V2X HANDLER ----------------------
+
+type MockChargingStationV2XHandler struct {
+	mock.Mock
+}
+
+func (handler *MockChargingStationV2XHandler) OnNotifyAllowedEnergyTransfer(request *v2x.NotifyAllowedEnergyTransferRequest) (response *v2x.NotifyAllowedEnergyTransferResponse, err error) {
+	args := handler.MethodCalled("OnNotifyAllowedEnergyTransfer", request)
+	conf := args.Get(0).(*v2x.NotifyAllowedEnergyTransferResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationV2XHandler) OnAFRRSignal(request *v2x.AFRRSignalRequest) (response *v2x.AFRRSignalResponse, err error) {
+	args := handler.MethodCalled("OnAFRRSignal", request)
+	conf := args.Get(0).(*v2x.AFRRSignalResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CS DER CONTROL HANDLER ----------------------
+
+type MockChargingStationDERControlHandler struct {
+	mock.Mock
+}
+
+func (handler *MockChargingStationDERControlHandler) OnSetDERControl(request *der.SetDERControlRequest) (response *der.SetDERControlResponse, err error) {
+	args := handler.MethodCalled("OnSetDERControl", request)
+	conf := args.Get(0).(*der.SetDERControlResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationDERControlHandler) OnGetDERControl(request *der.GetDERControlRequest) (response *der.GetDERControlResponse, err error) {
+	args := handler.MethodCalled("OnGetDERControl", request)
+	conf := args.Get(0).(*der.GetDERControlResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationDERControlHandler) OnClearDERControl(request *der.ClearDERControlRequest) (response *der.ClearDERControlResponse, err error) {
+	args := handler.MethodCalled("OnClearDERControl", request)
+	conf := args.Get(0).(*der.ClearDERControlResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CSMS DER CONTROL HANDLER ----------------------
+
+type MockCSMSDERControlHandler struct {
+	mock.Mock
+}
+
+func (handler *MockCSMSDERControlHandler) OnReportDERControl(chargingStationID string, request *der.ReportDERControlRequest) (response *der.ReportDERControlResponse, err error) {
+	args := handler.MethodCalled("OnReportDERControl", chargingStationID, request)
+	conf := args.Get(0).(*der.ReportDERControlResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSDERControlHandler) OnNotifyDERAlarm(chargingStationID string, request *der.NotifyDERAlarmRequest) (response *der.NotifyDERAlarmResponse, err error) {
+	args := handler.MethodCalled("OnNotifyDERAlarm", chargingStationID, request)
+	conf := args.Get(0).(*der.NotifyDERAlarmResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSDERControlHandler) OnNotifyDERStartStop(chargingStationID string, request *der.NotifyDERStartStopRequest) (response *der.NotifyDERStartStopResponse, err error) {
+	args := handler.MethodCalled("OnNotifyDERStartStop", chargingStationID, request)
+	conf := args.Get(0).(*der.NotifyDERStartStopResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CS TARIFF HANDLER ----------------------
+
+type MockChargingStationTariffHandler struct {
+	mock.Mock
+}
+
+func (handler *MockChargingStationTariffHandler) OnSetDefaultTariff(request *tariff.SetDefaultTariffRequest) (response *tariff.SetDefaultTariffResponse, err error) {
+	args := handler.MethodCalled("OnSetDefaultTariff", request)
+	conf := args.Get(0).(*tariff.SetDefaultTariffResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationTariffHandler) OnGetTariffs(request *tariff.GetTariffsRequest) (response *tariff.GetTariffsResponse, err error) {
+	args := handler.MethodCalled("OnGetTariffs", request)
+	conf := args.Get(0).(*tariff.GetTariffsResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationTariffHandler) OnClearTariffs(request *tariff.ClearTariffsRequest) (response *tariff.ClearTariffsResponse, err error) {
+	args := handler.MethodCalled("OnClearTariffs", request)
+	conf := args.Get(0).(*tariff.ClearTariffsResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockChargingStationTariffHandler) OnChangeTransactionTariff(request *tariff.ChangeTransactionTariffRequest) (response *tariff.ChangeTransactionTariffResponse, err error) {
+	args := handler.MethodCalled("OnChangeTransactionTariff", request)
+	conf := args.Get(0).(*tariff.ChangeTransactionTariffResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CS PAYMENT HANDLER ----------------------
+
+type MockChargingStationPaymentHandler struct {
+	mock.Mock
+}
+
+func (handler *MockChargingStationPaymentHandler) OnNotifyWebPaymentStarted(request *payment.NotifyWebPaymentStartedRequest) (response *payment.NotifyWebPaymentStartedResponse, err error) {
+	args := handler.MethodCalled("OnNotifyWebPaymentStarted", request)
+	conf := args.Get(0).(*payment.NotifyWebPaymentStartedResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CSMS PAYMENT HANDLER ----------------------
+
+type MockCSMSPaymentHandler struct {
+	mock.Mock
+}
+
+func (handler *MockCSMSPaymentHandler) OnNotifySettlement(chargingStationID string, request *payment.NotifySettlementRequest) (response *payment.NotifySettlementResponse, err error) {
+	args := handler.MethodCalled("OnNotifySettlement", chargingStationID, request)
+	conf := args.Get(0).(*payment.NotifySettlementResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSPaymentHandler) OnVatNumberValidation(chargingStationID string, request *payment.VatNumberValidationRequest) (response *payment.VatNumberValidationResponse, err error) {
+	args := handler.MethodCalled("OnVatNumberValidation", chargingStationID, request)
+	conf := args.Get(0).(*payment.VatNumberValidationResponse)
+	return conf, args.Error(1)
+}
+
+func (handler *MockCSMSPaymentHandler) OnNotifyQRCodeScanned(chargingStationID string, request *payment.NotifyQRCodeScannedRequest) (response *payment.NotifyQRCodeScannedResponse, err error) {
+	args := handler.MethodCalled("OnNotifyQRCodeScanned", chargingStationID, request)
+	conf := args.Get(0).(*payment.NotifyQRCodeScannedResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CS BATTERY SWAP HANDLER ----------------------
+
+type MockChargingStationBatterySwapHandler struct {
+	mock.Mock
+}
+
+func (handler *MockChargingStationBatterySwapHandler) OnRequestBatterySwap(request *batteryswap.RequestBatterySwapRequest) (response *batteryswap.RequestBatterySwapResponse, err error) {
+	args := handler.MethodCalled("OnRequestBatterySwap", request)
+	conf := args.Get(0).(*batteryswap.RequestBatterySwapResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- MOCK CSMS BATTERY SWAP HANDLER ----------------------
+
+type MockCSMSBatterySwapHandler struct {
+	mock.Mock
+}
+
+func (handler *MockCSMSBatterySwapHandler) OnBatterySwap(chargingStationID string, request *batteryswap.BatterySwapRequest) (response *batteryswap.BatterySwapResponse, err error) {
+	args := handler.MethodCalled("OnBatterySwap", chargingStationID, request)
+	conf := args.Get(0).(*batteryswap.BatterySwapResponse)
+	return conf, args.Error(1)
+}
+
+// ---------------------- COMMON UTILITY METHODS ----------------------
+
+type expectedCSMSOptions struct {
+	clientId              string
+	rawWrittenMessage     []byte
+	startReturnArgument   interface{}
+	writeReturnArgument   interface{}
+	forwardWrittenMessage bool
+}
+
+type expectedChargingStationOptions struct {
+	serverUrl             string
+	clientId              string
+	createChannelOnStart  bool
+	channel               ws.Channel
+	rawWrittenMessage     []byte
+	startReturnArgument   interface{}
+	writeReturnArgument   interface{}
+	forwardWrittenMessage bool
+}
+
+func setupDefaultCSMSHandlers(suite *OcppV21TestSuite, options expectedCSMSOptions, handlers ...interface{}) {
+	t := suite.T()
+	for _, h := range handlers {
+		switch h.(type) {
+		case *MockCSMSProvisioningHandler:
+			suite.csms.SetProvisioningHandler(h.(*MockCSMSProvisioningHandler))
+		case *MockCSMSDERControlHandler:
+			suite.csms.SetDERControlHandler(h.(*MockCSMSDERControlHandler))
+		case *MockCSMSPaymentHandler:
+			suite.csms.SetPaymentHandler(h.(*MockCSMSPaymentHandler))
+		case *MockCSMSBatterySwapHandler:
+			suite.csms.SetBatterySwapHandler(h.(*MockCSMSBatterySwapHandler))
+		}
+	}
+	suite.csms.SetNewChargingStationHandler(func(chargingStation ocpp21.ChargingStationConnection) {
+		assert.Equal(t, options.clientId, chargingStation.ID())
+	})
+	suite.mockWsServer.On("Start", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(options.startReturnArgument)
+	suite.mockWsServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Return(options.writeReturnArgument).Run(func(args mock.Arguments) {
+		clientId := args.String(0)
+		data := args.Get(1)
+		bytes := data.([]byte)
+		assert.Equal(t, options.clientId, clientId)
+		if options.rawWrittenMessage != nil {
+			assert.NotNil(t, bytes)
+			assert.Equal(t, options.rawWrittenMessage, bytes)
+		}
+		if options.forwardWrittenMessage {
+			// Notify client of incoming response
+			err := suite.mockWsClient.MessageHandler(bytes)
+			assert.Nil(t, err)
+		}
+	})
+}
+
+func setupDefaultChargingStationHandlers(suite *OcppV21TestSuite, options expectedChargingStationOptions, handlers ...interface{}) {
+	t := suite.T()
+	for _, h := range handlers {
+		switch h.(type) {
+		case *MockChargingStationV2XHandler:
+			suite.chargingStation.SetV2XHandler(h.(*MockChargingStationV2XHandler))
+		case *MockChargingStationDERControlHandler:
+			suite.chargingStation.SetDERControlHandler(h.(*MockChargingStationDERControlHandler))
+		case *MockChargingStationTariffHandler:
+			suite.chargingStation.SetTariffHandler(h.(*MockChargingStationTariffHandler))
+		case *MockChargingStationPaymentHandler:
+			suite.chargingStation.SetPaymentHandler(h.(*MockChargingStationPaymentHandler))
+		case *MockChargingStationBatterySwapHandler:
+			suite.chargingStation.SetBatterySwapHandler(h.(*MockChargingStationBatterySwapHandler))
+		}
+	}
+	suite.mockWsClient.On("Start", mock.AnythingOfType("string")).Return(options.startReturnArgument).Run(func(args mock.Arguments) {
+		u := args.String(0)
+		assert.Equal(t, fmt.Sprintf("%s/%s", options.serverUrl, options.clientId), u)
+		// Notify server of incoming connection
+		if options.createChannelOnStart {
+			suite.mockWsServer.NewClientHandler(options.channel)
+		}
+	})
+	suite.mockWsClient.On("Write", mock.Anything).Return(options.writeReturnArgument).Run(func(args mock.Arguments) {
+		data := args.Get(0)
+		bytes := data.([]byte)
+		if options.rawWrittenMessage != nil {
+			assert.NotNil(t, bytes)
+			assert.Equal(t, options.rawWrittenMessage, bytes)
+		}
+		// Notify server of incoming request
+		if options.forwardWrittenMessage {
+			err := suite.mockWsServer.MessageHandler(options.channel, bytes)
+			assert.Nil(t, err)
+		}
+	})
+}
+
+func assertDateTimeEquality(t *testing.T, expected *types.DateTime, actual *types.DateTime) {
+	assert.Equal(t, expected.FormatTimestamp(), actual.FormatTimestamp())
+}
+
+func testUnsupportedRequestFromChargingStation(suite *OcppV21TestSuite, request ocpp.Request, requestJson string, messageId string, handlers ...interface{}) {
+	t := suite.T()
+	wsId := "test_id"
+	wsUrl := "someUrl"
+	expectedError := fmt.Sprintf("unsupported action %v on charging station, cannot send request", request.GetFeatureName())
+	errorDescription := fmt.Sprintf("unsupported action %v on CSMS", request.GetFeatureName())
+	errorJson := fmt.Sprintf(`[4,"%v","%v","%v",null]`, messageId, ocppj.NotSupported, errorDescription)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(errorJson), forwardWrittenMessage: false})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(errorJson), forwardWrittenMessage: true}, handlers...)
+	resultChannel := make(chan bool, 1)
+	suite.ocppjClient.SetErrorHandler(func(err *ocpp.Error, details interface{}) {
+		assert.Equal(t, messageId, err.MessageId)
+		assert.Equal(t, ocppj.NotSupported, err.Code)
+		assert.Equal(t, errorDescription, err.Description)
+		assert.Nil(t, details)
+		resultChannel <- true
+	})
+	// Start
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	// Run request test
+	err = suite.chargingStation.SendRequestAsync(request, func(confirmation ocpp.Response, err error) {
+		t.Fail()
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedError, err.Error())
+	// Run response test
+	suite.ocppjClient.RequestState.AddPendingRequest(messageId, request)
+	err = suite.mockWsServer.MessageHandler(channel, []byte(requestJson))
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func testUnsupportedRequestFromCentralSystem(suite *OcppV21TestSuite, request ocpp.Request, requestJson string, messageId string, handlers ...interface{}) {
+	t := suite.T()
+	wsId := "test_id"
+	wsUrl := "someUrl"
+	expectedError := fmt.Sprintf("unsupported action %v on CSMS, cannot send request", request.GetFeatureName())
+	errorDescription := fmt.Sprintf("unsupported action %v on charging station", request.GetFeatureName())
+	errorJson := fmt.Sprintf(`[4,"%v","%v","%v",null]`, messageId, ocppj.NotSupported, errorDescription)
+	channel := NewMockWebSocket(wsId)
+
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: false})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(errorJson), forwardWrittenMessage: true}, handlers...)
+	suite.ocppjServer.SetErrorHandler(func(channel ws.Channel, err *ocpp.Error, details interface{}) {
+		assert.Equal(t, messageId, err.MessageId)
+		assert.Equal(t, wsId, channel.ID())
+		assert.Equal(t, ocppj.NotSupported, err.Code)
+		assert.Equal(t, errorDescription, err.Description)
+		assert.Nil(t, details)
+	})
+	// Start
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	// Run request test, expecting an error
+	err = suite.csms.SendRequestAsync(wsId, request, func(response ocpp.Response, err error) {
+		t.Fail()
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedError, err.Error())
+	// Mark mocked request as pending, otherwise response will be ignored
+	suite.ocppjServer.RequestState.AddPendingRequest(wsId, messageId, request)
+	// Run response test
+	err = suite.mockWsClient.MessageHandler([]byte(requestJson))
+	assert.Nil(t, err)
+}
+
+type GenericTestEntry struct {
+	Element       interface{}
+	ExpectedValid bool
+}
+
+// TODO: pass expected error value for improved validation and error message
+func ExecuteGenericTestTable(t *testing.T, testTable []GenericTestEntry) {
+	for _, testCase := range testTable {
+		err := types.Validate.Struct(testCase.Element)
+		if err != nil {
+			assert.Equal(t, testCase.ExpectedValid, false, err.Error())
+		} else {
+			assert.Equal(t, testCase.ExpectedValid, true, "%v is valid", testCase.Element)
+		}
+	}
+}
+
+// ---------------------- TESTS ----------------------
+
+type OcppV21TestSuite struct {
+	suite.Suite
+	ocppjClient        *ocppj.Client
+	ocppjServer        *ocppj.Server
+	mockWsServer       *MockWebsocketServer
+	mockWsClient       *MockWebsocketClient
+	chargingStation    ocpp21.ChargingStation
+	csms               ocpp21.CSMS
+	messageIdGenerator TestRandomIdGenerator
+	clientDispatcher   ocppj.ClientDispatcher
+	serverDispatcher   ocppj.ServerDispatcher
+}
+
+type TestRandomIdGenerator struct {
+	generator func() string
+}
+
+func (testGenerator *TestRandomIdGenerator) generateId() string {
+	return testGenerator.generator()
+}
+
+var defaultMessageId = "1234"
+
+func (suite *OcppV21TestSuite) SetupTest() {
+	provisioningProfile := provisioning.Profile
+	v2xProfile := v2x.Profile
+	derProfile := der.Profile
+	tariffProfile := tariff.Profile
+	paymentProfile := payment.Profile
+	batterySwapProfile := batteryswap.Profile
+	mockClient := MockWebsocketClient{}
+	mockServer := MockWebsocketServer{}
+	suite.mockWsClient = &mockClient
+	suite.mockWsServer = &mockServer
+	suite.clientDispatcher = ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(queueCapacity))
+	suite.serverDispatcher = ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(queueCapacity))
+	suite.ocppjClient = ocppj.NewClient("test_id", suite.mockWsClient, suite.clientDispatcher, nil, provisioningProfile, v2xProfile, derProfile, tariffProfile, paymentProfile, batterySwapProfile)
+	suite.ocppjServer = ocppj.NewServer(suite.mockWsServer, suite.serverDispatcher, nil, provisioningProfile, v2xProfile, derProfile, tariffProfile, paymentProfile, batterySwapProfile)
+	suite.chargingStation = ocpp21.NewChargingStation("test_id", suite.ocppjClient, suite.mockWsClient)
+	suite.csms = ocpp21.NewCSMS(suite.ocppjServer, suite.mockWsServer)
+	suite.messageIdGenerator = TestRandomIdGenerator{generator: func() string {
+		return defaultMessageId
+	}}
+	ocppj.SetMessageIdGenerator(suite.messageIdGenerator.generateId)
+}
+
+//TODO: implement generic protocol tests
+
+func TestOcpp21Protocol(t *testing.T) {
+	logrus.SetLevel(logrus.PanicLevel)
+	suite.Run(t, new(OcppV21TestSuite))
+}
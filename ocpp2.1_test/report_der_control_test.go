@@ -0,0 +1,87 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/der"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestReportDERControlRequestValidation() {
+	t := suite.T()
+	curve := der.DERCurve{CurveData: []der.DERCurvePoint{{X: 90.0, Y: 100.0}}, YUnit: der.DERUnitPctMaxW}
+	var requestTable = []GenericTestEntry{
+		{der.ReportDERControlRequest{RequestID: 1, Tbc: true, Curve: []der.DERCurveGet{{ID: "control1", CurveType: der.DERControlTypeVoltWatt, IsDefault: true, Curve: curve}}}, true},
+		{der.ReportDERControlRequest{RequestID: 1, FixedPFAbsorb: []der.FixedPFGet{{ID: "control1", FixedPF: der.FixedPF{Displacement: 0.9}}}}, true},
+		{der.ReportDERControlRequest{RequestID: 1, FixedPFInject: []der.FixedPFGet{{ID: "control1", FixedPF: der.FixedPF{Displacement: 0.9}}}}, true},
+		{der.ReportDERControlRequest{RequestID: 1, FixedVar: []der.FixedVarGet{{ID: "control1", FixedVar: der.FixedVar{Setpoint: 20.0, Unit: der.DERUnitPctMaxVar}}}}, true},
+		{der.ReportDERControlRequest{RequestID: 1, LimitMaxDischarge: []der.LimitMaxDischargeGet{{ID: "control1", IsSuperseded: true, LimitMaxDischarge: der.LimitMaxDischarge{PctMaxDischargePower: newFloat(50.0)}}}}, true},
+		{der.ReportDERControlRequest{RequestID: 1}, true},
+		{der.ReportDERControlRequest{}, true},
+		{der.ReportDERControlRequest{RequestID: 1, Curve: []der.DERCurveGet{{CurveType: der.DERControlTypeVoltWatt, Curve: curve}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, Curve: []der.DERCurveGet{{ID: "control1", CurveType: "invalidControlType", Curve: curve}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, Curve: []der.DERCurveGet{{ID: "control1", CurveType: der.DERControlTypeVoltWatt, Curve: der.DERCurve{}}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, Curve: make([]der.DERCurveGet, 25)}, false},
+		{der.ReportDERControlRequest{RequestID: 1, FixedPFAbsorb: []der.FixedPFGet{{ID: ">36..................................", FixedPF: der.FixedPF{Displacement: 0.9}}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, FixedPFInject: []der.FixedPFGet{{ID: "control1", FixedPF: der.FixedPF{Priority: -1}}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, FixedVar: []der.FixedVarGet{{ID: "control1", FixedVar: der.FixedVar{Setpoint: 20.0, Unit: "invalidUnit"}}}}, false},
+		{der.ReportDERControlRequest{RequestID: 1, LimitMaxDischarge: []der.LimitMaxDischargeGet{{ID: "control1", LimitMaxDischarge: der.LimitMaxDischarge{PctMaxDischargePower: newFloat(-1)}}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestReportDERControlResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{der.ReportDERControlResponse{}, true},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestReportDERControlE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	requestID := 42
+	fixedVar := der.FixedVarGet{ID: "control1", IsDefault: true, IsSuperseded: false, FixedVar: der.FixedVar{Priority: 1, Setpoint: 20.5, Unit: der.DERUnitPctMaxVar}}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v,"fixedVar":[{"id":"%v","isDefault":%v,"isSuperseded":%v,"fixedVar":{"priority":%v,"setpoint":%v,"unit":"%v"}}]}]`,
+		messageId, der.ReportDERControlFeatureName, requestID, fixedVar.ID, fixedVar.IsDefault, fixedVar.IsSuperseded, fixedVar.FixedVar.Priority, fixedVar.FixedVar.Setpoint, fixedVar.FixedVar.Unit)
+	responseJson := fmt.Sprintf(`[3,"%v",{}]`, messageId)
+	reportDERControlResponse := der.NewReportDERControlResponse()
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSDERControlHandler{}
+	handler.On("OnReportDERControl", mock.AnythingOfType("string"), mock.Anything).Return(reportDERControlResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*der.ReportDERControlRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, requestID, request.RequestID)
+		assert.False(t, request.Tbc)
+		require.Len(t, request.FixedVar, 1)
+		assert.Equal(t, fixedVar, request.FixedVar[0])
+		assert.Empty(t, request.Curve)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.ReportDERControl(requestID, func(request *der.ReportDERControlRequest) {
+		request.FixedVar = []der.FixedVarGet{fixedVar}
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+}
+
+func (suite *OcppV21TestSuite) TestReportDERControlInvalidEndpoint() {
+	messageId := defaultMessageId
+	requestID := 42
+	request := der.NewReportDERControlRequest(requestID)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"requestId":%v}]`, messageId, der.ReportDERControlFeatureName, requestID)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,123 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/tariff"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestTariffValidation() {
+	t := suite.T()
+	taxRates := []tariff.TaxRate{{Type: "Federal", Tax: 19.0, Stack: newInt(0)}}
+	var testTable = []GenericTestEntry{
+		{tariff.Tariff{
+			TariffID:     "tariff1",
+			Currency:     "EUR",
+			Description:  []types.MessageContent{{Format: "UTF8", Language: "en", Content: "Standard tariff"}},
+			Energy:       &tariff.TariffEnergy{Prices: []tariff.TariffEnergyPrice{{PriceKwh: 0.35}}, TaxRates: taxRates},
+			ValidFrom:    types.NewDateTime(time.Now()),
+			ChargingTime: &tariff.TariffTime{Prices: []tariff.TariffTimePrice{{PriceMinute: 0.05}}},
+			IdleTime:     &tariff.TariffTime{Prices: []tariff.TariffTimePrice{{PriceMinute: 0.10}}, TaxRates: taxRates},
+			FixedFee:     &tariff.TariffFixed{Prices: []tariff.TariffFixedPrice{{PriceFixed: 1.0}}},
+			MinCost:      &tariff.Price{ExclTax: newFloat(1.0), InclTax: newFloat(1.19), TaxRates: taxRates},
+			MaxCost:      &tariff.Price{ExclTax: newFloat(100.0)},
+		}, true},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR"}, true},
+		{tariff.Tariff{TariffID: "tariff1"}, false},
+		{tariff.Tariff{Currency: "EUR"}, false},
+		{tariff.Tariff{TariffID: newLongString(61), Currency: "EUR"}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EURO"}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", Description: []types.MessageContent{{Format: "invalidFormat", Content: "Standard tariff"}}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", Description: make([]types.MessageContent, 11)}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", Energy: &tariff.TariffEnergy{}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", Energy: &tariff.TariffEnergy{Prices: []tariff.TariffEnergyPrice{{PriceKwh: 0.35}}, TaxRates: []tariff.TaxRate{{Tax: 19.0}}}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", Energy: &tariff.TariffEnergy{Prices: []tariff.TariffEnergyPrice{{PriceKwh: 0.35}}, TaxRates: make([]tariff.TaxRate, 6)}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", ChargingTime: &tariff.TariffTime{}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", IdleTime: &tariff.TariffTime{Prices: []tariff.TariffTimePrice{}}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", FixedFee: &tariff.TariffFixed{}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", MinCost: &tariff.Price{TaxRates: []tariff.TaxRate{{Type: newLongString(21), Tax: 19.0}}}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", MaxCost: &tariff.Price{TaxRates: []tariff.TaxRate{{Type: "Federal", Tax: -1}}}}, false},
+		{tariff.Tariff{TariffID: "tariff1", Currency: "EUR", MaxCost: &tariff.Price{TaxRates: []tariff.TaxRate{{Type: "Federal", Tax: 19.0, Stack: newInt(-1)}}}}, false},
+	}
+	ExecuteGenericTestTable(t, testTable)
+}
+
+func (suite *OcppV21TestSuite) TestSetDefaultTariffRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{tariff.SetDefaultTariffRequest{EvseID: 1, Tariff: tariff.NewTariff("tariff1", "EUR")}, true},
+		{tariff.SetDefaultTariffRequest{Tariff: tariff.NewTariff("tariff1", "EUR")}, true},
+		{tariff.SetDefaultTariffRequest{}, false},
+		{tariff.SetDefaultTariffRequest{EvseID: -1, Tariff: tariff.NewTariff("tariff1", "EUR")}, false},
+		{tariff.SetDefaultTariffRequest{EvseID: 1, Tariff: tariff.NewTariff("tariff1", "")}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestSetDefaultTariffResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{tariff.SetDefaultTariffResponse{Status: tariff.TariffSetStatusAccepted, StatusInfo: types.NewStatusInfo("200", "ok")}, true},
+		{tariff.SetDefaultTariffResponse{Status: tariff.TariffSetStatusDuplicateTariffId}, true},
+		{tariff.SetDefaultTariffResponse{}, false},
+		{tariff.SetDefaultTariffResponse{Status: "invalidStatus"}, false},
+		{tariff.SetDefaultTariffResponse{Status: tariff.TariffSetStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestSetDefaultTariffE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	evseID := 1
+	defaultTariff := tariff.NewTariff("tariff1", "EUR")
+	defaultTariff.Energy = &tariff.TariffEnergy{Prices: []tariff.TariffEnergyPrice{{PriceKwh: 0.35}}}
+	status := tariff.TariffSetStatusAccepted
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"tariff":{"tariffId":"%v","currency":"%v","energy":{"prices":[{"priceKwh":%v}]}}}]`,
+		messageId, tariff.SetDefaultTariffFeatureName, evseID, defaultTariff.TariffID, defaultTariff.Currency, defaultTariff.Energy.Prices[0].PriceKwh)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v"}]`, messageId, status)
+	setDefaultTariffResponse := tariff.NewSetDefaultTariffResponse(status)
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationTariffHandler{}
+	handler.On("OnSetDefaultTariff", mock.Anything).Return(setDefaultTariffResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*tariff.SetDefaultTariffRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, evseID, request.EvseID)
+		assert.Equal(t, defaultTariff, request.Tariff)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetDefaultTariff(wsId, func(response *tariff.SetDefaultTariffResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		resultChannel <- true
+	}, evseID, defaultTariff)
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestSetDefaultTariffInvalidEndpoint() {
+	messageId := defaultMessageId
+	evseID := 1
+	defaultTariff := tariff.NewTariff("tariff1", "EUR")
+	request := tariff.NewSetDefaultTariffRequest(evseID, defaultTariff)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"evseId":%v,"tariff":{"tariffId":"%v","currency":"%v"}}]`, messageId, tariff.SetDefaultTariffFeatureName, evseID, defaultTariff.TariffID, defaultTariff.Currency)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
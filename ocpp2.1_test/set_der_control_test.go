@@ -0,0 +1,116 @@
+package ocpp21_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/der"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestSetDERControlRequestValidation() {
+	t := suite.T()
+	curve := der.DERCurve{CurveData: []der.DERCurvePoint{{X: 90.0, Y: 100.0}, {X: 110.0, Y: 0.0}}, Priority: 0, YUnit: der.DERUnitPctMaxW, ResponseTime: newFloat(1.5), StartTime: types.NewDateTime(time.Now()), Duration: newFloat(3600)}
+	var requestTable = []GenericTestEntry{
+		{der.SetDERControlRequest{IsDefault: true, ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &curve}, true},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeFixedPFAbsorb, FixedPFAbsorb: &der.FixedPF{Priority: 1, Displacement: 0.9, Excitation: true}}, true},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeFixedPFInject, FixedPFInject: &der.FixedPF{Priority: 1, Displacement: 0.9}}, true},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeFixedVar, FixedVar: &der.FixedVar{Priority: 1, Setpoint: 20.0, Unit: der.DERUnitPctMaxVar}}, true},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeLimitMaxDischarge, LimitMaxDischarge: &der.LimitMaxDischarge{Priority: 1, PctMaxDischargePower: newFloat(50.0)}}, true},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeEnterService}, true},
+		{der.SetDERControlRequest{ControlID: "control1"}, false},
+		{der.SetDERControlRequest{ControlType: der.DERControlTypeEnterService}, false},
+		{der.SetDERControlRequest{ControlID: ">36..................................", ControlType: der.DERControlTypeEnterService}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: "invalidControlType"}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: []der.DERCurvePoint{}, YUnit: der.DERUnitPctMaxW}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: make([]der.DERCurvePoint, 11), YUnit: der.DERUnitPctMaxW}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: curve.CurveData, YUnit: "invalidUnit"}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: curve.CurveData, Priority: -1, YUnit: der.DERUnitPctMaxW}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: curve.CurveData, YUnit: der.DERUnitPctMaxW, ResponseTime: newFloat(-1)}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeVoltWatt, Curve: &der.DERCurve{CurveData: curve.CurveData, YUnit: der.DERUnitPctMaxW, Duration: newFloat(-1)}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeFixedPFAbsorb, FixedPFAbsorb: &der.FixedPF{Priority: -1}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeFixedVar, FixedVar: &der.FixedVar{Priority: 1, Setpoint: 20.0}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeLimitMaxDischarge, LimitMaxDischarge: &der.LimitMaxDischarge{Priority: 1, PctMaxDischargePower: newFloat(-1)}}, false},
+		{der.SetDERControlRequest{ControlID: "control1", ControlType: der.DERControlTypeLimitMaxDischarge, LimitMaxDischarge: &der.LimitMaxDischarge{Priority: 1, PowerMonitoringMustTrip: &der.DERCurve{}}}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestSetDERControlResponseValidation() {
+	t := suite.T()
+	var responseTable = []GenericTestEntry{
+		{der.SetDERControlResponse{Status: der.DERControlStatusAccepted, SupersededIDs: []string{"control0"}, StatusInfo: types.NewStatusInfo("200", "ok")}, true},
+		{der.SetDERControlResponse{Status: der.DERControlStatusNotSupported}, true},
+		{der.SetDERControlResponse{}, false},
+		{der.SetDERControlResponse{Status: "invalidStatus"}, false},
+		{der.SetDERControlResponse{Status: der.DERControlStatusAccepted, SupersededIDs: []string{">36.................................."}}, false},
+		{der.SetDERControlResponse{Status: der.DERControlStatusAccepted, SupersededIDs: make([]string, 25)}, false},
+		{der.SetDERControlResponse{Status: der.DERControlStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestSetDERControlE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	isDefault := false
+	controlID := "control1"
+	controlType := der.DERControlTypeFixedVar
+	fixedVar := der.FixedVar{Priority: 1, Setpoint: 20.5, Unit: der.DERUnitPctMaxVar}
+	status := der.DERControlStatusAccepted
+	supersededID := "control0"
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"isDefault":%v,"controlId":"%v","controlType":"%v","fixedVar":{"priority":%v,"setpoint":%v,"unit":"%v"}}]`,
+		messageId, der.SetDERControlFeatureName, isDefault, controlID, controlType, fixedVar.Priority, fixedVar.Setpoint, fixedVar.Unit)
+	responseJson := fmt.Sprintf(`[3,"%v",{"status":"%v","supersededIds":["%v"]}]`, messageId, status, supersededID)
+	setDERControlResponse := der.NewSetDERControlResponse(status)
+	setDERControlResponse.SupersededIDs = []string{supersededID}
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockChargingStationDERControlHandler{}
+	handler.On("OnSetDERControl", mock.Anything).Return(setDERControlResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(0).(*der.SetDERControlRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, isDefault, request.IsDefault)
+		assert.Equal(t, controlID, request.ControlID)
+		assert.Equal(t, controlType, request.ControlType)
+		require.NotNil(t, request.FixedVar)
+		assert.Equal(t, fixedVar, *request.FixedVar)
+		assert.Nil(t, request.Curve)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	resultChannel := make(chan bool, 1)
+	err = suite.csms.SetDERControl(wsId, func(response *der.SetDERControlResponse, err error) {
+		require.Nil(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, status, response.Status)
+		assert.Equal(t, []string{supersededID}, response.SupersededIDs)
+		resultChannel <- true
+	}, isDefault, controlID, controlType, func(request *der.SetDERControlRequest) {
+		request.FixedVar = &fixedVar
+	})
+	require.Nil(t, err)
+	result := <-resultChannel
+	assert.True(t, result)
+}
+
+func (suite *OcppV21TestSuite) TestSetDERControlInvalidEndpoint() {
+	messageId := defaultMessageId
+	isDefault := true
+	controlID := "control1"
+	controlType := der.DERControlTypeEnterService
+	request := der.NewSetDERControlRequest(isDefault, controlID, controlType)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"isDefault":%v,"controlId":"%v","controlType":"%v"}]`, messageId, der.SetDERControlFeatureName, isDefault, controlID, controlType)
+	testUnsupportedRequestFromChargingStation(suite, request, requestJson, messageId)
+}
@@ -0,0 +1,98 @@
+package ocpp21_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/payment"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test
+func (suite *OcppV21TestSuite) TestVatNumberValidationRequestValidation() {
+	t := suite.T()
+	var requestTable = []GenericTestEntry{
+		{payment.VatNumberValidationRequest{VatNumber: "DE123456789", EvseID: newInt(1)}, true},
+		{payment.VatNumberValidationRequest{VatNumber: "DE123456789"}, true},
+		{payment.VatNumberValidationRequest{}, false},
+		{payment.VatNumberValidationRequest{VatNumber: newLongString(21)}, false},
+		{payment.VatNumberValidationRequest{VatNumber: "DE123456789", EvseID: newInt(-1)}, false},
+	}
+	ExecuteGenericTestTable(t, requestTable)
+}
+
+func (suite *OcppV21TestSuite) TestVatNumberValidationResponseValidation() {
+	t := suite.T()
+	company := payment.Address{Name: "Company", Address1: "Street 1", Address2: "Floor 2", City: "City", PostalCode: "12345", Country: "Germany"}
+	var responseTable = []GenericTestEntry{
+		{payment.VatNumberValidationResponse{Company: &company, StatusInfo: types.NewStatusInfo("200", "ok"), VatNumber: "DE123456789", EvseID: newInt(1), Status: types.GenericStatusAccepted}, true},
+		{payment.VatNumberValidationResponse{VatNumber: "DE123456789", Status: types.GenericStatusRejected}, true},
+		{payment.VatNumberValidationResponse{VatNumber: "DE123456789"}, false},
+		{payment.VatNumberValidationResponse{Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{VatNumber: "DE123456789", Status: "invalidStatus"}, false},
+		{payment.VatNumberValidationResponse{VatNumber: newLongString(21), Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{VatNumber: "DE123456789", EvseID: newInt(-1), Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{VatNumber: "DE123456789", Status: types.GenericStatusAccepted, StatusInfo: types.NewStatusInfo("", "")}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Address1: "Street 1", City: "City", Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: "Company", City: "City", Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: "Company", Address1: "Street 1", Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: "Company", Address1: "Street 1", City: "City"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: newLongString(51), Address1: "Street 1", City: "City", Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: "Company", Address1: "Street 1", Address2: newLongString(101), City: "City", Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+		{payment.VatNumberValidationResponse{Company: &payment.Address{Name: "Company", Address1: "Street 1", City: "City", PostalCode: newLongString(21), Country: "Germany"}, VatNumber: "DE123456789", Status: types.GenericStatusAccepted}, false},
+	}
+	ExecuteGenericTestTable(t, responseTable)
+}
+
+func (suite *OcppV21TestSuite) TestVatNumberValidationE2EMocked() {
+	t := suite.T()
+	wsId := "test_id"
+	messageId := defaultMessageId
+	wsUrl := "someUrl"
+	vatNumber := "DE123456789"
+	evseID := 1
+	status := types.GenericStatusAccepted
+	company := payment.Address{Name: "Company", Address1: "Street 1", City: "City", Country: "Germany"}
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"vatNumber":"%v","evseId":%v}]`, messageId, payment.VatNumberValidationFeatureName, vatNumber, evseID)
+	responseJson := fmt.Sprintf(`[3,"%v",{"company":{"name":"%v","address1":"%v","city":"%v","country":"%v"},"vatNumber":"%v","evseId":%v,"status":"%v"}]`,
+		messageId, company.Name, company.Address1, company.City, company.Country, vatNumber, evseID, status)
+	vatNumberValidationResponse := payment.NewVatNumberValidationResponse(vatNumber, status)
+	vatNumberValidationResponse.Company = &company
+	vatNumberValidationResponse.EvseID = &evseID
+	channel := NewMockWebSocket(wsId)
+
+	handler := &MockCSMSPaymentHandler{}
+	handler.On("OnVatNumberValidation", mock.AnythingOfType("string"), mock.Anything).Return(vatNumberValidationResponse, nil).Run(func(args mock.Arguments) {
+		request, ok := args.Get(1).(*payment.VatNumberValidationRequest)
+		require.True(t, ok)
+		require.NotNil(t, request)
+		assert.Equal(t, vatNumber, request.VatNumber)
+		require.NotNil(t, request.EvseID)
+		assert.Equal(t, evseID, *request.EvseID)
+	})
+	setupDefaultCSMSHandlers(suite, expectedCSMSOptions{clientId: wsId, rawWrittenMessage: []byte(responseJson), forwardWrittenMessage: true}, handler)
+	setupDefaultChargingStationHandlers(suite, expectedChargingStationOptions{serverUrl: wsUrl, clientId: wsId, createChannelOnStart: true, channel: channel, rawWrittenMessage: []byte(requestJson), forwardWrittenMessage: true})
+	// Run Test
+	suite.csms.Start(8887, "somePath")
+	err := suite.chargingStation.Start(wsUrl)
+	require.Nil(t, err)
+	response, err := suite.chargingStation.VatNumberValidation(vatNumber, func(request *payment.VatNumberValidationRequest) {
+		request.EvseID = &evseID
+	})
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, status, response.Status)
+	assert.Equal(t, vatNumber, response.VatNumber)
+	require.NotNil(t, response.Company)
+	assert.Equal(t, company, *response.Company)
+}
+
+func (suite *OcppV21TestSuite) TestVatNumberValidationInvalidEndpoint() {
+	messageId := defaultMessageId
+	vatNumber := "DE123456789"
+	request := payment.NewVatNumberValidationRequest(vatNumber)
+	requestJson := fmt.Sprintf(`[2,"%v","%v",{"vatNumber":"%v"}]`, messageId, payment.VatNumberValidationFeatureName, vatNumber)
+	testUnsupportedRequestFromCentralSystem(suite, request, requestJson, messageId)
+}
@@ -341,6 +341,83 @@ func (suite *OcppJTestSuite) TestCentralSystemErrorHandler() {
 	assert.Nil(t, err)
 }
 
+func (suite *OcppJTestSuite) TestCentralSystemSendHandler() {
+	t := suite.T()
+	mockChargePointId := "1234"
+	mockUniqueId := "5678"
+	mockValue := "someValue"
+	mockSend := fmt.Sprintf(`[6,"%v","%v",{"mockValue":"%v"}]`, mockUniqueId, MockFeatureName, mockValue)
+	handled := false
+	suite.centralSystem.SetSendHandler(func(chargePoint ws.Channel, request ocpp.Request, requestId string, action string) {
+		assert.Equal(t, mockChargePointId, chargePoint.ID())
+		assert.Equal(t, mockUniqueId, requestId)
+		assert.Equal(t, MockFeatureName, action)
+		require.IsType(t, &MockRequest{}, request)
+		assert.Equal(t, mockValue, request.(*MockRequest).MockValue)
+		handled = true
+	})
+	suite.centralSystem.SetExtendedMessageTypesEnabled(true)
+	suite.mockServer.On("Start", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return()
+	suite.centralSystem.Start(8887, "somePath")
+	suite.serverDispatcher.CreateClient(mockChargePointId)
+	// Simulate charge point message. No reply is expected
+	channel := NewMockWebSocket(mockChargePointId)
+	err := suite.mockServer.MessageHandler(channel, []byte(mockSend))
+	require.NoError(t, err)
+	assert.True(t, handled)
+	suite.mockServer.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
+func (suite *OcppJTestSuite) TestCentralSystemCallResultErrorHandler() {
+	t := suite.T()
+	mockChargePointId := "1234"
+	mockUniqueId := "5678"
+	mockErrorCode := ocppj.GenericError
+	mockErrorDescription := "Mock Description"
+	mockError := fmt.Sprintf(`[5,"%v","%v","%v",{"details":"someValue"}]`, mockUniqueId, mockErrorCode, mockErrorDescription)
+	handled := false
+	suite.centralSystem.SetCallResultErrorHandler(func(chargePoint ws.Channel, err *ocpp.Error, details interface{}) {
+		assert.Equal(t, mockChargePointId, chargePoint.ID())
+		assert.Equal(t, mockUniqueId, err.MessageId)
+		assert.Equal(t, mockErrorCode, err.Code)
+		assert.Equal(t, mockErrorDescription, err.Description)
+		assert.Equal(t, map[string]interface{}{"details": "someValue"}, details)
+		handled = true
+	})
+	suite.centralSystem.SetExtendedMessageTypesEnabled(true)
+	suite.mockServer.On("Start", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return()
+	suite.centralSystem.Start(8887, "somePath")
+	suite.serverDispatcher.CreateClient(mockChargePointId)
+	// Simulate charge point message
+	channel := NewMockWebSocket(mockChargePointId)
+	err := suite.mockServer.MessageHandler(channel, []byte(mockError))
+	require.NoError(t, err)
+	assert.True(t, handled)
+}
+
+func (suite *OcppJTestSuite) TestCentralSystemSendUnconfirmedRequest() {
+	t := suite.T()
+	mockChargePointId := "1234"
+	writeC := make(chan []byte, 1)
+	suite.mockServer.On("Write", mockChargePointId, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		writeC <- args.Get(1).([]byte)
+	})
+	suite.mockServer.On("Start", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return()
+	suite.centralSystem.Start(8887, "somePath")
+	// Not supported by default
+	err := suite.centralSystem.SendUnconfirmedRequest(mockChargePointId, newMockRequest("mockValue"))
+	require.Error(t, err)
+	suite.centralSystem.SetExtendedMessageTypesEnabled(true)
+	err = suite.centralSystem.SendUnconfirmedRequest(mockChargePointId, newMockRequest("mockValue"))
+	require.NoError(t, err)
+	data := <-writeC
+	parsedData, err := ocppj.ParseRawJsonMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, float64(ocppj.SEND), parsedData[0])
+	assert.Equal(t, MockFeatureName, parsedData[2])
+	assert.False(t, suite.centralSystem.RequestState.HasPendingRequest(mockChargePointId))
+}
+
 func addMockPendingRequest(suite *OcppJTestSuite, mockRequest ocpp.Request, mockUniqueID string, mockChargePointID string) {
 	mockCall, _ := suite.centralSystem.CreateCall(mockRequest)
 	mockCall.UniqueId = mockUniqueID
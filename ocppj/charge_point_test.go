@@ -263,6 +263,128 @@ func (suite *OcppJTestSuite) TestChargePointCallErrorHandler() {
 	assert.Nil(t, err)
 }
 
+func (suite *OcppJTestSuite) TestChargePointSendHandler() {
+	t := suite.T()
+	mockUniqueId := "5678"
+	mockValue := "someValue"
+	mockSend := fmt.Sprintf(`[6,"%v","%v",{"mockValue":"%v"}]`, mockUniqueId, MockFeatureName, mockValue)
+	handled := false
+	suite.chargePoint.SetSendHandler(func(request ocpp.Request, requestId string, action string) {
+		assert.Equal(t, mockUniqueId, requestId)
+		assert.Equal(t, MockFeatureName, action)
+		require.IsType(t, &MockRequest{}, request)
+		assert.Equal(t, mockValue, request.(*MockRequest).MockValue)
+		handled = true
+	})
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	suite.mockClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	err := suite.chargePoint.Start("someUrl")
+	require.NoError(t, err)
+	// Simulate central system message. No reply is expected
+	err = suite.mockClient.MessageHandler([]byte(mockSend))
+	require.NoError(t, err)
+	assert.True(t, handled)
+	suite.mockClient.AssertNotCalled(t, "Write", mock.Anything)
+}
+
+func (suite *OcppJTestSuite) TestChargePointCallResultErrorHandler() {
+	t := suite.T()
+	mockUniqueId := "5678"
+	mockErrorCode := ocppj.GenericError
+	mockErrorDescription := "Mock Description"
+	mockError := fmt.Sprintf(`[5,"%v","%v","%v",null]`, mockUniqueId, mockErrorCode, mockErrorDescription)
+	handled := false
+	suite.chargePoint.SetCallResultErrorHandler(func(err *ocpp.Error, details interface{}) {
+		assert.Equal(t, mockUniqueId, err.MessageId)
+		assert.Equal(t, mockErrorCode, err.Code)
+		assert.Equal(t, mockErrorDescription, err.Description)
+		assert.Nil(t, details)
+		handled = true
+	})
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	suite.mockClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	err := suite.chargePoint.Start("someUrl")
+	require.NoError(t, err)
+	// Simulate central system message
+	err = suite.mockClient.MessageHandler([]byte(mockError))
+	require.NoError(t, err)
+	assert.True(t, handled)
+}
+
+func (suite *OcppJTestSuite) TestChargePointExtendedMessageTypesDisabled() {
+	t := suite.T()
+	mockUniqueId := "5678"
+	mockSend := fmt.Sprintf(`[6,"%v","%v",{"mockValue":"someValue"}]`, mockUniqueId, MockFeatureName)
+	suite.chargePoint.SetSendHandler(func(request ocpp.Request, requestId string, action string) {
+		assert.Fail(t, "unexpected send handler invocation")
+	})
+	writeC := make(chan []byte, 1)
+	suite.mockClient.On("Write", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	})
+	suite.mockClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	err := suite.chargePoint.Start("someUrl")
+	require.NoError(t, err)
+	// Message type is rejected via CallError
+	err = suite.mockClient.MessageHandler([]byte(mockSend))
+	require.Error(t, err)
+	data := <-writeC
+	assert.Equal(t, fmt.Sprintf(`[4,"%v","%v","Invalid message type ID 6",null]`, mockUniqueId, ocppj.MessageTypeNotSupported), string(data))
+}
+
+func (suite *OcppJTestSuite) TestChargePointInvalidCallResultReply() {
+	t := suite.T()
+	mockUniqueId := "5678"
+	mockRequest := newMockRequest("testValue")
+	mockConfirmation := fmt.Sprintf(`[3,"%v",{"mockValue":"%v"}]`, mockUniqueId, "abc")
+	writeC := make(chan []byte, 1)
+	suite.mockClient.On("Write", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	})
+	suite.mockClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	suite.chargePoint.RequestState.AddPendingRequest(mockUniqueId, mockRequest)
+	err := suite.chargePoint.Start("someUrl")
+	require.NoError(t, err)
+	// Invalid response is reported via CallResultError
+	err = suite.mockClient.MessageHandler([]byte(mockConfirmation))
+	require.Error(t, err)
+	data := <-writeC
+	parsedData, err := ocppj.ParseRawJsonMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, float64(ocppj.CALL_RESULT_ERROR), parsedData[0])
+	assert.Equal(t, mockUniqueId, parsedData[1])
+	assert.Equal(t, string(ocppj.PropertyConstraintViolation), parsedData[2])
+}
+
+func (suite *OcppJTestSuite) TestChargePointSendUnconfirmedRequest() {
+	t := suite.T()
+	writeC := make(chan []byte, 1)
+	suite.mockClient.On("Write", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	})
+	suite.mockClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	err := suite.chargePoint.Start("someUrl")
+	require.NoError(t, err)
+	// Not supported by default
+	err = suite.chargePoint.SendUnconfirmedRequest(newMockRequest("mockValue"))
+	require.Error(t, err)
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	err = suite.chargePoint.SendUnconfirmedRequest(newMockRequest("mockValue"))
+	require.NoError(t, err)
+	data := <-writeC
+	parsedData, err := ocppj.ParseRawJsonMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, float64(ocppj.SEND), parsedData[0])
+	assert.Equal(t, MockFeatureName, parsedData[2])
+	// Request is neither queued nor pending
+	assert.True(t, suite.clientRequestQueue.IsEmpty())
+	assert.False(t, suite.chargePoint.RequestState.HasPendingRequest())
+	// Invalid requests are rejected
+	err = suite.chargePoint.SendUnconfirmedRequest(newMockRequest(""))
+	require.Error(t, err)
+}
+
 // ----------------- Queue processing tests -----------------
 
 func (suite *OcppJTestSuite) TestClientEnqueueRequest() {
@@ -1,7 +1,9 @@
 package ocppj
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp"
 	"github.com/lorenzodonini/ocpp-go/ws"
@@ -16,12 +18,30 @@ type Client struct {
 	requestHandler        func(request ocpp.Request, requestId string, action string)
 	responseHandler       func(response ocpp.Response, requestId string)
 	errorHandler          func(err *ocpp.Error, details interface{})
+	sendHandler           func(request ocpp.Request, requestId string, action string)
+	resultErrorHandler    func(err *ocpp.Error, details interface{})
 	onDisconnectedHandler func(err error)
 	onReconnectedHandler  func()
+	restoredHandler       RestoredRequestHandler
 	dispatcher            ClientDispatcher
 	RequestState          ClientState
+	restoredRequests      map[string]*Call
+	restoredMutex         sync.Mutex
+	contextRequests       map[string]chan struct{}
+	contextMutex          sync.Mutex
 }
 
+// Implemented by dispatchers and queues, which may contain requests before being started,
+// e.g. because they were restored from persistent storage.
+type queuedRequestLister interface {
+	queuedRequests() []*Call
+}
+
+// RestoredRequestHandler is invoked when a request, which was restored by a persistent queue, is completed.
+// The response is set if the server replied with a CallResult, the error is set if it replied with a CallError.
+// Both are nil if the request was canceled.
+type RestoredRequestHandler func(requestId string, action string, request ocpp.Request, response ocpp.Response, err *ocpp.Error)
+
 // Creates a new Client endpoint.
 // Requires a unique client ID, a websocket client, a struct for queueing/dispatching requests,
 // a state handler and a list of supported profiles (optional).
@@ -62,9 +82,44 @@ func (c *Client) SetErrorHandler(handler func(err *ocpp.Error, details interface
 	c.errorHandler = handler
 }
 
+// Registers a handler for incoming unconfirmed requests (Send messages).
+// No response may be sent for these requests.
+//
+// Send messages are only accepted if extended message types are enabled on the endpoint.
+func (c *Client) SetSendHandler(handler func(request ocpp.Request, requestId string, action string)) {
+	c.sendHandler = handler
+}
+
+// Registers a handler for incoming CallResultError messages,
+// which report that the server couldn't process a response previously sent by this client.
+// The message ID of the error identifies the response, i.e. the request it referred to.
+//
+// CallResultError messages are only accepted if extended message types are enabled on the endpoint.
+func (c *Client) SetCallResultErrorHandler(handler func(err *ocpp.Error, details interface{})) {
+	c.resultErrorHandler = handler
+}
+
+// Registers a handler for completed requests, which were restored by a persistent queue.
+// Such requests were sent by a previous run, hence their results aren't forwarded to the response, error and
+// cancel handlers. If no handler is registered, the results are only logged.
+func (c *Client) SetRestoredRequestHandler(handler RestoredRequestHandler) {
+	c.restoredHandler = handler
+}
+
 // Registers the handler to be called on timeout.
+// The handler isn't invoked for requests restored by a persistent queue, see SetRestoredRequestHandler.
 func (c *Client) SetOnRequestCanceled(handler CanceledRequestHandler) {
-	c.dispatcher.SetOnRequestCanceled(handler)
+	if handler == nil {
+		c.dispatcher.SetOnRequestCanceled(nil)
+		return
+	}
+	c.dispatcher.SetOnRequestCanceled(func(id string, action string, request ocpp.Request) {
+		c.completeContextRequest(id)
+		if c.completeRestoredRequest(id, nil, nil) {
+			return
+		}
+		handler(id, action, request)
+	})
 }
 
 func (c *Client) SetOnDisconnectedHandler(handler func(err error)) {
@@ -93,6 +148,7 @@ func (c *Client) Start(serverURL string) error {
 	fullUrl := fmt.Sprintf("%v/%v", serverURL, c.Id)
 	err := c.client.Start(fullUrl)
 	if err == nil {
+		c.restoreRequests()
 		c.dispatcher.Start()
 	}
 	return err
@@ -103,6 +159,12 @@ func (c *Client) Start(serverURL string) error {
 func (c *Client) Stop() {
 	c.client.Stop()
 	c.dispatcher.Stop()
+	c.contextMutex.Lock()
+	for id, done := range c.contextRequests {
+		close(done)
+		delete(c.contextRequests, id)
+	}
+	c.contextMutex.Unlock()
 }
 
 // Sends an OCPP Request to the server.
@@ -119,28 +181,82 @@ func (c *Client) Stop() {
 //
 // - the output queue is full
 func (c *Client) SendRequest(request ocpp.Request) error {
+	_, err := c.sendRequest(request, nil)
+	return err
+}
+
+// Sends an OCPP Request to the server, like SendRequest.
+//
+// The context is monitored until a response to the request is received.
+// If the context is done before that and the dispatcher implements ClientCancelDispatcher, the request is canceled:
+// it is removed from the queue without being sent, or no response is awaited anymore if it was sent already.
+// The OnRequestCanceled handler is invoked for the canceled request.
+//
+// Returns the unique ID of the request, which is passed to the response, error and OnRequestCanceled handlers.
+// Returns ctx.Err() if the context is already done, or any of the errors returned by SendRequest.
+func (c *Client) SendRequestWithContext(ctx context.Context, request ocpp.Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	var done chan struct{}
+	if ctx.Done() != nil {
+		done = make(chan struct{})
+	}
+	call, err := c.sendRequest(request, done)
+	if err != nil {
+		return "", err
+	}
+	if done == nil {
+		// Context can never be canceled
+		return call.UniqueId, nil
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Debugf("context done for request %v - %v: %v", call.UniqueId, call.Action, ctx.Err())
+			if d, ok := c.dispatcher.(ClientCancelDispatcher); ok {
+				d.CancelRequest(call.UniqueId)
+			}
+			c.completeContextRequest(call.UniqueId)
+		case <-done:
+		}
+	}()
+	return call.UniqueId, nil
+}
+
+// Validates and enqueues a request. If a done channel is passed, it gets closed once the request completes.
+func (c *Client) sendRequest(request ocpp.Request, done chan struct{}) (*Call, error) {
 	if !c.dispatcher.IsRunning() {
-		return fmt.Errorf("ocppj client is not started, couldn't send request")
+		return nil, fmt.Errorf("ocppj client is not started, couldn't send request")
 	}
 	err := Validate.Struct(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	call, err := c.CreateCall(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	jsonMessage, err := call.MarshalJSON()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if done != nil {
+		c.contextMutex.Lock()
+		if c.contextRequests == nil {
+			c.contextRequests = map[string]chan struct{}{}
+		}
+		c.contextRequests[call.UniqueId] = done
+		c.contextMutex.Unlock()
 	}
 	// Message will be processed by dispatcher. A dedicated mechanism allows to delegate the message queue handling.
 	if err := c.dispatcher.SendRequest(RequestBundle{Call: call, Data: jsonMessage}); err != nil {
 		log.Errorf("request %v - %v: %v", call.UniqueId, call.Action, err)
-		return err
+		c.completeContextRequest(call.UniqueId)
+		return nil, err
 	}
 	log.Debugf("enqueued request %v - %v", call.UniqueId, call.Action)
-	return nil
+	return call, nil
 }
 
 // Sends an OCPP Response to the server.
@@ -190,6 +306,65 @@ func (c *Client) SendError(requestId string, errorCode ocpp.ErrorCode, descripti
 	return c.client.Write(jsonMessage)
 }
 
+// Sends an unconfirmed OCPP Request to the server, using a Send message.
+// The server will not reply to the request, hence the message is written directly, bypassing the request queue.
+//
+// Returns an error in the following cases:
+//
+// - the client wasn't started
+//
+// - extended message types are not enabled on the endpoint
+//
+// - message validation fails (request is malformed)
+//
+// - the endpoint doesn't support the feature
+//
+// - a network error occurred
+func (c *Client) SendUnconfirmedRequest(request ocpp.Request) error {
+	if !c.dispatcher.IsRunning() {
+		return fmt.Errorf("ocppj client is not started, couldn't send request")
+	}
+	err := Validate.Struct(request)
+	if err != nil {
+		return err
+	}
+	send, err := c.CreateSend(request)
+	if err != nil {
+		return err
+	}
+	jsonMessage, err := send.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return c.client.Write(jsonMessage)
+}
+
+// Sends a CallResultError to the server, reporting that a response received from the server couldn't be processed.
+// The requestID parameter is required and identifies the request, to which the response referred.
+//
+// Returns an error in the following cases:
+//
+// - extended message types are not enabled on the endpoint
+//
+// - message validation fails (error is malformed)
+//
+// - a network error occurred
+func (c *Client) SendCallResultError(requestId string, errorCode ocpp.ErrorCode, description string, details interface{}) error {
+	callResultError, err := c.CreateCallResultError(requestId, errorCode, description, details)
+	if err != nil {
+		return err
+	}
+	err = Validate.Struct(callResultError)
+	if err != nil {
+		return err
+	}
+	jsonMessage, err := callResultError.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return c.client.Write(jsonMessage)
+}
+
 func (c *Client) ocppMessageHandler(data []byte) error {
 	parsedJson, err := ParseRawJsonMessage(data)
 	if err != nil {
@@ -200,7 +375,13 @@ func (c *Client) ocppMessageHandler(data []byte) error {
 	if err != nil {
 		ocppErr := err.(*ocpp.Error)
 		if ocppErr.MessageId != "" {
-			err2 := c.SendError(ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			var err2 error
+			switch c.replyTypeForInvalidMessage(parsedJson) {
+			case CALL_ERROR:
+				err2 = c.SendError(ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			case CALL_RESULT_ERROR:
+				err2 = c.SendCallResultError(ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			}
 			if err2 != nil {
 				return err2
 			}
@@ -216,20 +397,86 @@ func (c *Client) ocppMessageHandler(data []byte) error {
 		case CALL_RESULT:
 			callResult := message.(*CallResult)
 			c.dispatcher.CompleteRequest(callResult.GetUniqueId()) // Remove current request from queue and send next one
-			if c.responseHandler != nil {
+			c.completeContextRequest(callResult.UniqueId)
+			if !c.completeRestoredRequest(callResult.UniqueId, callResult.Payload, nil) && c.responseHandler != nil {
 				c.responseHandler(callResult.Payload, callResult.UniqueId)
 			}
 		case CALL_ERROR:
 			callError := message.(*CallError)
 			c.dispatcher.CompleteRequest(callError.GetUniqueId()) // Remove current request from queue and send next one
-			if c.errorHandler != nil {
-				c.errorHandler(ocpp.NewError(callError.ErrorCode, callError.ErrorDescription, callError.UniqueId), callError.ErrorDetails)
+			c.completeContextRequest(callError.UniqueId)
+			ocppErr := ocpp.NewError(callError.ErrorCode, callError.ErrorDescription, callError.UniqueId)
+			if !c.completeRestoredRequest(callError.UniqueId, nil, ocppErr) && c.errorHandler != nil {
+				c.errorHandler(ocppErr, callError.ErrorDetails)
+			}
+		case CALL_RESULT_ERROR:
+			callResultError := message.(*CallResultError)
+			if c.resultErrorHandler != nil {
+				c.resultErrorHandler(ocpp.NewError(callResultError.ErrorCode, callResultError.ErrorDescription, callResultError.UniqueId), callResultError.ErrorDetails)
+			} else {
+				log.Errorf("server couldn't process response to request %v: %v %v", callResultError.UniqueId, callResultError.ErrorCode, callResultError.ErrorDescription)
+			}
+		case SEND:
+			send := message.(*Send)
+			if c.sendHandler != nil {
+				c.sendHandler(send.Payload, send.UniqueId, send.Action)
+			} else {
+				log.Infof("no handler for unconfirmed request %v - %v, discarding it", send.UniqueId, send.Action)
 			}
 		}
 	}
 	return nil
 }
 
+// Keeps track of requests, which are queued before starting the dispatcher for the first time (e.g. restored from a file).
+// Their responses were requested by a previous run, therefore they are only forwarded to the restored request handler.
+// Requests still queued when the client is restarted were sent by the current run and keep their callbacks.
+func (c *Client) restoreRequests() {
+	c.restoredMutex.Lock()
+	defer c.restoredMutex.Unlock()
+	if c.restoredRequests != nil {
+		return
+	}
+	c.restoredRequests = map[string]*Call{}
+	if lister, ok := c.dispatcher.(queuedRequestLister); ok {
+		for _, call := range lister.queuedRequests() {
+			c.restoredRequests[call.UniqueId] = call
+		}
+	}
+}
+
+// Forwards the result of a restored request to the restored request handler, removing it from the restored requests.
+// Returns false if the request wasn't restored.
+func (c *Client) completeRestoredRequest(requestId string, response ocpp.Response, err *ocpp.Error) bool {
+	c.restoredMutex.Lock()
+	call, ok := c.restoredRequests[requestId]
+	delete(c.restoredRequests, requestId)
+	c.restoredMutex.Unlock()
+	if !ok {
+		return false
+	}
+	if c.restoredHandler != nil {
+		c.restoredHandler(requestId, call.Action, call.Payload, response, err)
+	} else if err != nil {
+		log.Errorf("received error for restored request %v: %v %v", requestId, err.Code, err.Description)
+	} else if response == nil {
+		log.Errorf("restored request %v for %v was canceled", requestId, call.Action)
+	} else {
+		log.Debugf("received response for restored request %v", requestId)
+	}
+	return true
+}
+
+// Stops monitoring the context of a request, if the request was sent with a context.
+func (c *Client) completeContextRequest(requestId string) {
+	c.contextMutex.Lock()
+	defer c.contextMutex.Unlock()
+	if done, ok := c.contextRequests[requestId]; ok {
+		close(done)
+		delete(c.contextRequests, requestId)
+	}
+}
+
 func (c *Client) onDisconnected(err error) {
 	log.Error("disconnected from server", err)
 	c.dispatcher.Pause()
@@ -83,6 +83,17 @@ type pendingRequest struct {
 
 type CanceledRequestHandler func(id string, action string, request ocpp.Request)
 
+// ClientCancelDispatcher is an optional interface, implemented by client dispatchers supporting the cancellation of requests.
+type ClientCancelDispatcher interface {
+	// Cancels a queued or pending request, typically because the caller isn't awaiting a response anymore.
+	// A request that wasn't sent yet is discarded without being sent, a pending request stops awaiting its response.
+	//
+	// If the request queue implements RemovableRequestQueue, a queued request is removed right away.
+	// Otherwise it is discarded once it reaches the front of the queue.
+	// The OnRequestCanceled callback is invoked for every discarded request.
+	CancelRequest(requestID string)
+}
+
 // DefaultClientDispatcher is a default implementation of the ClientDispatcher interface.
 //
 // The dispatcher implements the ClientState as well for simplicity.
@@ -91,13 +102,20 @@ type DefaultClientDispatcher struct {
 	requestQueue        RequestQueue
 	requestChannel      chan bool
 	readyForDispatch    chan bool
+	retryChannel        chan string
+	cancelChannel       chan string
+	stoppedChannel      chan struct{}
 	pendingRequestState ClientState
 	network             ws.WsClient
 	mutex               sync.RWMutex
+	queueMutex          sync.Mutex
 	onRequestCancel     CanceledRequestHandler
 	timer               *time.Timer
 	paused              bool
 	timeout             time.Duration
+	retryPolicy         *RetryPolicy
+	retryRequestID      string
+	retryAttempts       int
 }
 
 const defaultTimeoutTick = 24 * time.Hour
@@ -122,9 +140,24 @@ func (d *DefaultClientDispatcher) SetTimeout(timeout time.Duration) {
 	d.timeout = timeout
 }
 
+// Sets the policy for retransmitting requests, which couldn't be written to the network.
+// If no policy is set, such requests are canceled right away.
+//
+// This function must be called before starting the dispatcher, otherwise it may lead to unexpected behavior.
+func (d *DefaultClientDispatcher) SetRetryPolicy(policy *RetryPolicy) {
+	d.retryPolicy = policy
+}
+
 func (d *DefaultClientDispatcher) Start() {
 	d.requestChannel = make(chan bool, 1)
+	d.retryChannel = make(chan string, 1)
+	d.cancelChannel = make(chan string, 1)
+	d.stoppedChannel = make(chan struct{})
 	d.timer = time.NewTimer(defaultTimeoutTick) // Default to 24 hours tick
+	if !d.requestQueue.IsEmpty() {
+		// Requests restored by a persistent queue are dispatched right away
+		d.requestChannel <- true
+	}
 	go d.messagePump()
 }
 
@@ -140,13 +173,24 @@ func (d *DefaultClientDispatcher) IsPaused() bool {
 	return d.paused
 }
 
+// Stop stops the message pump and waits for it to exit, so that the dispatcher may be started again right away.
 func (d *DefaultClientDispatcher) Stop() {
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
 	close(d.requestChannel)
+	stoppedChannel := d.stoppedChannel
+	d.mutex.Unlock()
+	<-stoppedChannel
 	// TODO: clear pending requests?
 }
 
+// Returns the requests contained in the queue, if the queue supports listing them.
+func (d *DefaultClientDispatcher) queuedRequests() []*Call {
+	if lister, ok := d.requestQueue.(queuedRequestLister); ok {
+		return lister.queuedRequests()
+	}
+	return nil
+}
+
 func (d *DefaultClientDispatcher) SetNetworkClient(client ws.WsClient) {
 	d.network = client
 }
@@ -167,14 +211,18 @@ func (d *DefaultClientDispatcher) SendRequest(req interface{}) error {
 }
 
 func (d *DefaultClientDispatcher) messagePump() {
-	rdy := true // Ready to transmit at the beginning
+	rdy := true                           // Ready to transmit at the beginning
+	canceledRequests := map[string]bool{} // Requests to be discarded once they reach the front of the queue
 	for {
 		select {
 		case _, ok := <-d.requestChannel:
 			// New request was posted
 			if !ok {
 				d.requestQueue.Init()
+				d.mutex.Lock()
 				d.requestChannel = nil
+				d.mutex.Unlock()
+				close(d.stoppedChannel)
 				return
 			}
 		case _, ok := <-d.timer.C:
@@ -183,18 +231,33 @@ func (d *DefaultClientDispatcher) messagePump() {
 				continue
 			}
 			if d.pendingRequestState.HasPendingRequest() {
-				// Current request timed out. Removing request and triggering cancel callback
-				el := d.requestQueue.Peek()
-				bundle, _ := el.(RequestBundle)
-				d.CompleteRequest(bundle.Call.UniqueId)
-				if d.onRequestCancel != nil {
-					d.onRequestCancel(bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+				// Current request timed out. Removing request and triggering cancel callback, unless a response was received in the meantime
+				front, _ := d.requestQueue.Peek().(RequestBundle)
+				if front.Call != nil {
+					if bundle, ok := d.completeRequest(front.Call.UniqueId); ok && d.onRequestCancel != nil {
+						d.onRequestCancel(bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+					}
 				}
 			}
 			// No request is currently pending -> set timer to high number
 			d.timer.Reset(defaultTimeoutTick)
 		case rdy = <-d.readyForDispatch:
 			// Ready flag set, keep going
+		case requestID := <-d.retryChannel:
+			// Retransmission delay elapsed. Ignored if the request was already sent again in the meantime
+			bundle, ok := d.requestQueue.Peek().(RequestBundle)
+			if ok && bundle.Call.UniqueId == requestID && !d.pendingRequestState.HasPendingRequest() {
+				rdy = true
+			}
+		case requestID := <-d.cancelChannel:
+			if !d.removeCanceledRequest(requestID) {
+				// Discarded once it reaches the front of the queue
+				canceledRequests[requestID] = true
+			}
+		}
+		if len(canceledRequests) > 0 && d.discardCanceledRequests(canceledRequests) {
+			// The front request was discarded, the next one may be sent
+			rdy = true
 		}
 		// Check if dispatcher is paused
 		d.mutex.Lock()
@@ -226,7 +289,19 @@ func (d *DefaultClientDispatcher) dispatchNextRequest() {
 	// Attempt to send over network
 	err := d.network.Write(jsonMessage)
 	if err != nil {
-		//TODO: handle retransmission instead of skipping request altogether
+		if d.retryRequestID != bundle.Call.UniqueId {
+			d.retryRequestID = bundle.Call.UniqueId
+			d.retryAttempts = 0
+		}
+		d.retryAttempts++
+		if d.retryPolicy.ShouldRetry(bundle.Call.Action, d.retryAttempts) {
+			// Keep request at the front of the queue and retransmit it after a delay
+			delay := d.retryPolicy.Delay(d.retryAttempts)
+			log.Debugf("retransmitting request %v in %v, after error: %v", bundle.Call.UniqueId, delay, err)
+			d.pendingRequestState.DeletePendingRequest(bundle.Call.UniqueId)
+			d.scheduleRetry(bundle.Call.UniqueId, delay)
+			return
+		}
 		d.CompleteRequest(bundle.Call.GetUniqueId())
 		if d.onRequestCancel != nil {
 			d.onRequestCancel(bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
@@ -234,6 +309,74 @@ func (d *DefaultClientDispatcher) dispatchNextRequest() {
 	}
 }
 
+// discardCanceledRequests removes canceled requests from the front of the queue.
+// Returns true if at least one request was removed and no request is pending anymore.
+func (d *DefaultClientDispatcher) discardCanceledRequests(canceledRequests map[string]bool) bool {
+	discarded := false
+	for {
+		bundle, ok := d.requestQueue.Peek().(RequestBundle)
+		if !ok || !canceledRequests[bundle.Call.UniqueId] {
+			break
+		}
+		delete(canceledRequests, bundle.Call.UniqueId)
+		if _, ok = popRequest(d.requestQueue, &d.queueMutex, bundle.Call.UniqueId); !ok {
+			// Completed in the meantime
+			continue
+		}
+		d.pendingRequestState.DeletePendingRequest(bundle.Call.UniqueId)
+		discarded = true
+		log.Debugf("discarded canceled request %v", bundle.Call.UniqueId)
+		if d.onRequestCancel != nil {
+			d.onRequestCancel(bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+		}
+	}
+	if d.requestQueue.IsEmpty() {
+		// Remaining requests were completed before being canceled
+		for requestID := range canceledRequests {
+			delete(canceledRequests, requestID)
+		}
+	}
+	return discarded && !d.pendingRequestState.HasPendingRequest()
+}
+
+// removeCanceledRequest removes a canceled request from within the queue, if supported by the queue.
+// Returns false if the request needs to be discarded once it reaches the front of the queue instead.
+func (d *DefaultClientDispatcher) removeCanceledRequest(requestID string) bool {
+	removed, ok := removeQueuedRequest(d.requestQueue, &d.queueMutex, requestID)
+	if !ok {
+		return false
+	}
+	// The request may have been completed already, in which case there is nothing to remove
+	if bundle, ok := removed.(RequestBundle); ok {
+		log.Debugf("discarded canceled request %v", bundle.Call.UniqueId)
+		if d.onRequestCancel != nil {
+			d.onRequestCancel(bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+		}
+	}
+	return true
+}
+
+func (d *DefaultClientDispatcher) CancelRequest(requestID string) {
+	if !d.IsRunning() {
+		return
+	}
+	select {
+	case d.cancelChannel <- requestID:
+	case <-d.stoppedChannel:
+	}
+}
+
+// scheduleRetry notifies the message pump after the given delay, that a failed request may be sent again.
+func (d *DefaultClientDispatcher) scheduleRetry(requestID string, delay time.Duration) {
+	stoppedChannel := d.stoppedChannel
+	time.AfterFunc(delay, func() {
+		select {
+		case d.retryChannel <- requestID:
+		case <-stoppedChannel:
+		}
+	})
+}
+
 func (d *DefaultClientDispatcher) Pause() {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
@@ -258,21 +401,23 @@ func (d *DefaultClientDispatcher) Resume() {
 }
 
 func (d *DefaultClientDispatcher) CompleteRequest(requestId string) {
-	el := d.requestQueue.Peek()
-	if el == nil {
-		log.Errorf("attempting to pop front of queue, but queue is empty")
-		return
+	if _, ok := d.completeRequest(requestId); !ok {
+		log.Errorf("internal state mismatch: received response for %v, which is not at the front of the queue", requestId)
 	}
-	bundle, _ := el.(RequestBundle)
-	if bundle.Call.UniqueId != requestId {
-		log.Errorf("internal state mismatch: received response for %v but expected response for %v", requestId, bundle.Call.UniqueId)
-		return
+}
+
+// completeRequest removes a request from the front of the queue and signals that the next one may be sent.
+// Returns false if the request isn't at the front of the queue, e.g. because it was completed or canceled in the meantime.
+func (d *DefaultClientDispatcher) completeRequest(requestId string) (RequestBundle, bool) {
+	bundle, ok := popRequest(d.requestQueue, &d.queueMutex, requestId)
+	if !ok {
+		return bundle, false
 	}
-	d.requestQueue.Pop()
 	d.pendingRequestState.DeletePendingRequest(requestId)
-	log.Debugf("removed request %v from front of queue", bundle.Call.UniqueId)
+	log.Debugf("removed request %v from front of queue", requestId)
 	// Signal that next message in queue may be sent
 	d.readyForDispatch <- true
+	return bundle, true
 }
 
 // ServerDispatcher contains the state and logic for handling outgoing messages on a server endpoint.
@@ -299,7 +444,7 @@ type ServerDispatcher interface {
 	// The dispatcher takes care of removing the request marked by the requestID from
 	// that client's pending requests. It will then attempt to process the next queued request.
 	CompleteRequest(clientID string, requestID string)
-	// Sets a callback to be invoked when a request gets canceled, due to network timeouts.
+	// Sets a callback to be invoked when a request gets canceled, due to network timeouts or a missing response.
 	// The callback passes the original client ID, message ID, feature name and request struct of the failed request.
 	//
 	// Calling Stop on the dispatcher will not trigger this callback.
@@ -331,7 +476,39 @@ type ServerDispatcher interface {
 	DeleteClient(clientID string)
 }
 
+// ServerTimeoutDispatcher is an optional interface, implemented by server dispatchers supporting response timeouts.
+//
+// Timeouts are disabled by default. Once enabled, a request that doesn't receive a response within the timeout
+// is discarded and the OnRequestCanceled callback is invoked.
+type ServerTimeoutDispatcher interface {
+	// Sets the default timeout to be considered after sending a request to a client.
+	// If a response to the request is not received within the specified period, the request
+	// is discarded and the OnRequestCanceled callback is invoked.
+	//
+	// Timers are kept separately for each client. A timeout of zero disables the timeout.
+	//
+	// This function must be called before starting the dispatcher, otherwise it may lead to unexpected behavior.
+	SetTimeout(timeout time.Duration)
+	// Sets the timeout for requests of a specific feature (e.g. a slow firmware update),
+	// overriding the default timeout for that feature only.
+	//
+	// This function must be called before starting the dispatcher, otherwise it may lead to unexpected behavior.
+	SetFeatureTimeout(featureName string, timeout time.Duration)
+}
+
+// ServerCancelDispatcher is an optional interface, implemented by server dispatchers supporting the cancellation of requests.
+type ServerCancelDispatcher interface {
+	// Cancels a queued or pending request for a specific client, typically because the caller isn't awaiting a response anymore.
+	// A request that wasn't sent yet is discarded without being sent, a pending request stops awaiting its response.
+	//
+	// If the client's request queue implements RemovableRequestQueue, a queued request is removed right away.
+	// Otherwise it is discarded once it reaches the front of the client's queue.
+	// The OnRequestCanceled callback is invoked for every discarded request.
+	CancelRequest(clientID string, requestID string)
+}
+
 // DefaultServerDispatcher is a default implementation of the ServerDispatcher interface.
+// It also implements the ServerTimeoutDispatcher interface, with timeouts being disabled by default.
 //
 // The dispatcher implements the ClientState as well for simplicity.
 // Access to pending requests is thread-safe.
@@ -339,10 +516,31 @@ type DefaultServerDispatcher struct {
 	queueMap            ServerQueueMap
 	requestChannel      chan string
 	readyForDispatch    chan string
+	timeoutChannel      chan clientRequest
+	retryChannel        chan clientRequest
+	cancelChannel       chan clientRequest
+	stoppedChannel      chan struct{}
 	pendingRequestState ServerState
 	onRequestCancel     func(string, string, string, ocpp.Request)
 	network             ws.WsServer
 	mutex               sync.RWMutex
+	queueMutex          sync.Mutex
+	timeout             time.Duration
+	featureTimeouts     map[string]time.Duration
+	retryPolicy         *RetryPolicy
+	retryAttempts       map[string]retryState
+}
+
+// clientRequest is used internally for notifying the message pump about events regarding a request for a client.
+type clientRequest struct {
+	clientID  string
+	requestID string
+}
+
+// retryState is used internally for counting the failed transmission attempts of a client's current request.
+type retryState struct {
+	requestID string
+	attempts  int
 }
 
 // NewDefaultServerDispatcher creates a new DefaultServerDispatcher struct.
@@ -351,13 +549,35 @@ func NewDefaultServerDispatcher(queueMap ServerQueueMap) *DefaultServerDispatche
 		queueMap:         queueMap,
 		requestChannel:   nil,
 		readyForDispatch: make(chan string, 1),
+		featureTimeouts:  map[string]time.Duration{},
+		retryAttempts:    map[string]retryState{},
 	}
 	d.pendingRequestState = NewServerState(&d.mutex)
 	return d
 }
 
+func (d *DefaultServerDispatcher) SetTimeout(timeout time.Duration) {
+	d.timeout = timeout
+}
+
+func (d *DefaultServerDispatcher) SetFeatureTimeout(featureName string, timeout time.Duration) {
+	d.featureTimeouts[featureName] = timeout
+}
+
+// Sets the policy for retransmitting requests, which couldn't be written to the network.
+// Retransmissions are scheduled separately for each client. If no policy is set, such requests are canceled right away.
+//
+// This function must be called before starting the dispatcher, otherwise it may lead to unexpected behavior.
+func (d *DefaultServerDispatcher) SetRetryPolicy(policy *RetryPolicy) {
+	d.retryPolicy = policy
+}
+
 func (d *DefaultServerDispatcher) Start() {
 	d.requestChannel = make(chan string, 1)
+	d.timeoutChannel = make(chan clientRequest, 1)
+	d.retryChannel = make(chan clientRequest, 1)
+	d.cancelChannel = make(chan clientRequest, 1)
+	d.stoppedChannel = make(chan struct{})
 	go d.messagePump()
 }
 
@@ -417,12 +637,24 @@ func (d *DefaultServerDispatcher) messagePump() {
 	var ok bool
 	var rdy bool
 	var clientQueue RequestQueue
-	clientReadyMap := map[string]bool{} // Empty at the beginning
+	clientReadyMap := map[string]bool{}              // Empty at the beginning
+	clientTimers := map[string]*time.Timer{}         // Response timers for each client with a pending request
+	canceledRequests := map[string]map[string]bool{} // Requests to be discarded once they reach the front of a client's queue
+	stopTimer := func(clientID string) {
+		if timer, ok := clientTimers[clientID]; ok {
+			timer.Stop()
+			delete(clientTimers, clientID)
+		}
+	}
 	for {
 		select {
 		case clientID, ok = <-d.requestChannel:
 			// Check if channel was closed
 			if !ok {
+				for id := range clientTimers {
+					stopTimer(id)
+				}
+				close(d.stoppedChannel)
 				d.queueMap.Init()
 				d.requestChannel = nil
 				log.Info("stopped processing requests")
@@ -431,8 +663,11 @@ func (d *DefaultServerDispatcher) messagePump() {
 			clientQueue, ok = d.queueMap.Get(clientID)
 			// Check whether there is a request queue for the specified client
 			if !ok {
-				// No client queue found, deleting the ready flag
+				// No client queue found, deleting the ready flag, the response timer, the retry state and canceled requests
 				delete(clientReadyMap, clientID)
+				delete(d.retryAttempts, clientID)
+				delete(canceledRequests, clientID)
+				stopTimer(clientID)
 				rdy = false
 				break
 			}
@@ -443,30 +678,116 @@ func (d *DefaultServerDispatcher) messagePump() {
 				rdy = true
 				clientReadyMap[clientID] = rdy
 			}
-			//TODO: check for response timeout
 		case clientID = <-d.readyForDispatch:
 			// Client can now transmit again
+			stopTimer(clientID)
 			clientQueue, rdy = d.queueMap.Get(clientID)
 			if rdy {
 				clientReadyMap[clientID] = rdy
 			}
+		case t := <-d.timeoutChannel:
+			// Response timeout elapsed for a client
+			clientID = t.clientID
+			clientQueue, rdy = d.queueMap.Get(clientID)
+			if !rdy {
+				break
+			}
+			// Current request timed out. Removing request and triggering cancel callback
+			bundle, ok := popRequest(clientQueue, &d.queueMutex, t.requestID)
+			if !ok {
+				// A response was received in the meantime
+				rdy = false
+				break
+			}
+			delete(clientTimers, clientID)
+			d.pendingRequestState.DeletePendingRequest(clientID, t.requestID)
+			log.Debugf("request %v for client %v timed out", t.requestID, clientID)
+			if d.onRequestCancel != nil {
+				d.onRequestCancel(clientID, t.requestID, bundle.Call.Action, bundle.Call.Payload)
+			}
+			clientReadyMap[clientID] = rdy
+		case r := <-d.retryChannel:
+			// Retransmission delay elapsed. Ignored if the request was already sent again in the meantime
+			clientID = r.clientID
+			clientQueue, rdy = d.queueMap.Get(clientID)
+			if !rdy {
+				break
+			}
+			bundle, ok := clientQueue.Peek().(RequestBundle)
+			rdy = ok && bundle.Call.UniqueId == r.requestID && !d.pendingRequestState.HasPendingRequest(clientID)
+			if rdy {
+				clientReadyMap[clientID] = rdy
+			}
+		case r := <-d.cancelChannel:
+			clientID = r.clientID
+			clientQueue, ok = d.queueMap.Get(clientID)
+			if !ok {
+				rdy = false
+				break
+			}
+			if !d.removeCanceledRequest(clientID, clientQueue, r.requestID) {
+				// Discarded once it reaches the front of the client's queue
+				if canceledRequests[clientID] == nil {
+					canceledRequests[clientID] = map[string]bool{}
+				}
+				canceledRequests[clientID][r.requestID] = true
+			}
+			rdy = clientReadyMap[clientID]
+		}
+		if canceled, ok := canceledRequests[clientID]; ok && clientQueue != nil {
+			if d.discardCanceledRequests(clientID, clientQueue, canceled) {
+				// The front request was discarded, the next one may be sent
+				stopTimer(clientID)
+				rdy = true
+				clientReadyMap[clientID] = rdy
+			}
+			if len(canceled) == 0 {
+				delete(canceledRequests, clientID)
+			}
 		}
 		// Only dispatch request if able to send and request queue isn't empty
 		if rdy && !clientQueue.IsEmpty() {
-			d.dispatchNextRequest(clientID)
+			call, dispatched := d.dispatchNextRequest(clientID)
 			// Update ready state
 			rdy = false
 			clientReadyMap[clientID] = rdy
+			if dispatched {
+				if timer := d.startTimer(clientID, call); timer != nil {
+					clientTimers[clientID] = timer
+				}
+			}
 		}
 	}
 }
 
-func (d *DefaultServerDispatcher) dispatchNextRequest(clientID string) {
+// startTimer starts the response timer for a request that was just sent to a client.
+// Returns nil if no timeout is configured for the request.
+func (d *DefaultServerDispatcher) startTimer(clientID string, call *Call) *time.Timer {
+	requestID := call.UniqueId
+	timeout, ok := d.featureTimeouts[call.Action]
+	if !ok {
+		timeout = d.timeout
+	}
+	if timeout <= 0 {
+		return nil
+	}
+	stoppedChannel := d.stoppedChannel
+	return time.AfterFunc(timeout, func() {
+		select {
+		case d.timeoutChannel <- clientRequest{clientID: clientID, requestID: requestID}:
+		case <-stoppedChannel:
+		}
+	})
+}
+
+// dispatchNextRequest sends the first queued request for a client.
+// Returns the sent call and whether it is now pending, awaiting a response.
+func (d *DefaultServerDispatcher) dispatchNextRequest(clientID string) (*Call, bool) {
 	// Get first element in queue
 	q, ok := d.queueMap.Get(clientID)
 	if !ok {
 		log.Errorf("failed to dispatch next request for client %s, no request queue available", clientID)
-		return
+		return nil, false
 	}
 	el := q.Peek()
 	bundle, _ := el.(RequestBundle)
@@ -476,12 +797,95 @@ func (d *DefaultServerDispatcher) dispatchNextRequest(clientID string) {
 	err := d.network.Write(clientID, jsonMessage)
 	if err != nil {
 		log.Errorf("error while sending message: %v", err)
-		//TODO: handle retransmission instead of removing pending request
+		state := d.retryAttempts[clientID]
+		if state.requestID != callID {
+			state = retryState{requestID: callID}
+		}
+		state.attempts++
+		d.retryAttempts[clientID] = state
+		if d.retryPolicy.ShouldRetry(bundle.Call.Action, state.attempts) {
+			// Keep request at the front of the queue and retransmit it after a delay
+			delay := d.retryPolicy.Delay(state.attempts)
+			log.Debugf("retransmitting request %v for client %v in %v", callID, clientID, delay)
+			d.pendingRequestState.DeletePendingRequest(clientID, callID)
+			d.scheduleRetry(clientID, callID, delay)
+			return bundle.Call, false
+		}
 		d.CompleteRequest(clientID, callID)
 		if d.onRequestCancel != nil {
 			d.onRequestCancel(clientID, callID, bundle.Call.Action, bundle.Call.Payload)
 		}
+		return bundle.Call, false
 	}
+	return bundle.Call, true
+}
+
+// discardCanceledRequests removes canceled requests from the front of a client's queue.
+// Returns true if at least one request was removed and no request is pending for the client anymore.
+func (d *DefaultServerDispatcher) discardCanceledRequests(clientID string, q RequestQueue, canceledRequests map[string]bool) bool {
+	discarded := false
+	for {
+		bundle, ok := q.Peek().(RequestBundle)
+		if !ok || !canceledRequests[bundle.Call.UniqueId] {
+			break
+		}
+		delete(canceledRequests, bundle.Call.UniqueId)
+		if _, ok = popRequest(q, &d.queueMutex, bundle.Call.UniqueId); !ok {
+			// Completed in the meantime
+			continue
+		}
+		d.pendingRequestState.DeletePendingRequest(clientID, bundle.Call.UniqueId)
+		discarded = true
+		log.Debugf("discarded canceled request %v for client %v", bundle.Call.UniqueId, clientID)
+		if d.onRequestCancel != nil {
+			d.onRequestCancel(clientID, bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+		}
+	}
+	if q.IsEmpty() {
+		// Remaining requests were completed before being canceled
+		for requestID := range canceledRequests {
+			delete(canceledRequests, requestID)
+		}
+	}
+	return discarded && !d.pendingRequestState.HasPendingRequest(clientID)
+}
+
+// removeCanceledRequest removes a canceled request from within a client's queue, if supported by the queue.
+// Returns false if the request needs to be discarded once it reaches the front of the queue instead.
+func (d *DefaultServerDispatcher) removeCanceledRequest(clientID string, clientQueue RequestQueue, requestID string) bool {
+	removed, ok := removeQueuedRequest(clientQueue, &d.queueMutex, requestID)
+	if !ok {
+		return false
+	}
+	// The request may have been completed already, in which case there is nothing to remove
+	if bundle, ok := removed.(RequestBundle); ok {
+		log.Debugf("discarded canceled request %v for client %v", bundle.Call.UniqueId, clientID)
+		if d.onRequestCancel != nil {
+			d.onRequestCancel(clientID, bundle.Call.UniqueId, bundle.Call.Action, bundle.Call.Payload)
+		}
+	}
+	return true
+}
+
+func (d *DefaultServerDispatcher) CancelRequest(clientID string, requestID string) {
+	if !d.IsRunning() {
+		return
+	}
+	select {
+	case d.cancelChannel <- clientRequest{clientID: clientID, requestID: requestID}:
+	case <-d.stoppedChannel:
+	}
+}
+
+// scheduleRetry notifies the message pump after the given delay, that a failed request for a client may be sent again.
+func (d *DefaultServerDispatcher) scheduleRetry(clientID string, requestID string, delay time.Duration) {
+	stoppedChannel := d.stoppedChannel
+	time.AfterFunc(delay, func() {
+		select {
+		case d.retryChannel <- clientRequest{clientID: clientID, requestID: requestID}:
+		case <-stoppedChannel:
+		}
+	})
 }
 
 func (d *DefaultServerDispatcher) CompleteRequest(clientID string, requestID string) {
@@ -490,20 +894,43 @@ func (d *DefaultServerDispatcher) CompleteRequest(clientID string, requestID str
 		log.Errorf("attempting to complete request for client %v, but no matching queue found", clientID)
 		return
 	}
-	el := q.Peek()
-	if el == nil {
-		log.Errorf("attempting to pop front of queue, but queue is empty")
-		return
-	}
-	bundle, _ := el.(RequestBundle)
-	callID := bundle.Call.GetUniqueId()
-	if callID != requestID {
-		log.Errorf("internal state mismatch: received response for %v but expected response for %v", requestID, callID)
+	if _, ok = popRequest(q, &d.queueMutex, requestID); !ok {
+		log.Errorf("internal state mismatch: received response for %v, which is not at the front of the queue", requestID)
 		return
 	}
-	q.Pop()
 	d.pendingRequestState.DeletePendingRequest(clientID, requestID)
-	log.Debugf("removed request %v from front of queue", callID)
+	log.Debugf("removed request %v from front of queue", requestID)
 	// Signal that next message in queue may be sent
 	d.readyForDispatch <- clientID
 }
+
+// popRequest removes the request with the given ID from the front of a queue.
+// Checking the front and removing it happens atomically with respect to other dispatcher operations guarded by the mutex,
+// so a request is never removed twice, e.g. when its response is received while it times out or is canceled.
+// Returns false if the request isn't at the front of the queue.
+func popRequest(q RequestQueue, mutex *sync.Mutex, requestID string) (RequestBundle, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	bundle, ok := q.Peek().(RequestBundle)
+	if !ok || bundle.Call.UniqueId != requestID {
+		return RequestBundle{}, false
+	}
+	q.Pop()
+	return bundle, true
+}
+
+// removeQueuedRequest removes the request with the given ID from within a queue, if the queue implements RemovableRequestQueue.
+// The front request may be pending, hence it is never removed.
+// Returns the removed element, which is nil if the request wasn't queued, and false if the request can't be removed.
+func removeQueuedRequest(q RequestQueue, mutex *sync.Mutex, requestID string) (interface{}, bool) {
+	removable, ok := q.(RemovableRequestQueue)
+	if !ok {
+		return nil, false
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if bundle, ok := q.Peek().(RequestBundle); ok && bundle.Call.UniqueId == requestID {
+		return nil, false
+	}
+	return removable.Remove(requestID), true
+}
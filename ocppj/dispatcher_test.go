@@ -14,6 +14,17 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 )
 
+// Request queue, which delays Peek, widening the window for concurrent modifications by the dispatcher.
+type slowPeekQueue struct {
+	*ocppj.FIFOClientQueue
+}
+
+func (q slowPeekQueue) Peek() interface{} {
+	el := q.FIFOClientQueue.Peek()
+	time.Sleep(time.Millisecond)
+	return el
+}
+
 type ServerDispatcherTestSuite struct {
 	suite.Suite
 	mutex           sync.RWMutex
@@ -124,6 +135,224 @@ func (s *ServerDispatcherTestSuite) TestRequestCanceled() {
 	assert.True(t, q.IsEmpty())
 }
 
+func (s *ServerDispatcherTestSuite) TestRequestTimeout() {
+	t := s.T()
+	// Setup
+	clientID := "client1"
+	timeout := make(chan string, 1)
+	writeC := make(chan bool, 1)
+	s.websocketServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(nil)
+	// Create mock request
+	req := newMockRequest("somevalue")
+	call, err := s.endpoint.CreateCall(req)
+	require.NoError(t, err)
+	requestID := call.UniqueId
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	bundle := ocppj.RequestBundle{Call: call, Data: data}
+	// Set low feature timeout to trigger OnRequestCanceled callback
+	timeoutDispatcher, ok := s.dispatcher.(ocppj.ServerTimeoutDispatcher)
+	require.True(t, ok)
+	timeoutDispatcher.SetTimeout(time.Hour)
+	timeoutDispatcher.SetFeatureTimeout(MockFeatureName, 500*time.Millisecond)
+	s.dispatcher.SetOnRequestCanceled(func(cID string, rID string, action string, request ocpp.Request) {
+		assert.Equal(t, clientID, cID)
+		assert.Equal(t, MockFeatureName, action)
+		assert.Equal(t, req, request)
+		timeout <- rID
+	})
+	s.dispatcher.Start()
+	require.True(t, s.dispatcher.IsRunning())
+	// Simulate client connection
+	s.dispatcher.CreateClient(clientID)
+	// Send mocked request
+	err = s.dispatcher.SendRequest(clientID, bundle)
+	require.NoError(t, err)
+	// Check status after sending request
+	_, _ = <-writeC
+	assert.True(t, s.state.HasPendingRequest(clientID))
+	// Wait for timeout
+	select {
+	case rID := <-timeout:
+		assert.Equal(t, requestID, rID)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "request didn't time out")
+	}
+	assert.False(t, s.state.HasPendingRequest(clientID))
+	q, ok := s.queueMap.Get(clientID)
+	require.True(t, ok)
+	assert.True(t, q.IsEmpty())
+	// A request completed in time must not time out
+	req2 := newMockRequest("othervalue")
+	call2, err := s.endpoint.CreateCall(req2)
+	require.NoError(t, err)
+	data2, err := call2.MarshalJSON()
+	require.NoError(t, err)
+	err = s.dispatcher.SendRequest(clientID, ocppj.RequestBundle{Call: call2, Data: data2})
+	require.NoError(t, err)
+	_, _ = <-writeC
+	s.dispatcher.CompleteRequest(clientID, call2.UniqueId)
+	select {
+	case rID := <-timeout:
+		require.Fail(t, "unexpected timeout", rID)
+	case <-time.After(time.Second):
+	}
+	assert.True(t, q.IsEmpty())
+}
+
+func (s *ServerDispatcherTestSuite) TestRequestRetransmitted() {
+	t := s.T()
+	// Setup
+	clientID := "client1"
+	writeC := make(chan bool, 2)
+	s.websocketServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(fmt.Errorf("mockError")).Once()
+	s.websocketServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(nil)
+	// Create mock request
+	req := newMockRequest("somevalue")
+	call, err := s.endpoint.CreateCall(req)
+	require.NoError(t, err)
+	requestID := call.UniqueId
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	bundle := ocppj.RequestBundle{Call: call, Data: data}
+	policy := ocppj.NewRetryPolicy(2, MockFeatureName)
+	policy.InitialInterval = 50 * time.Millisecond
+	s.dispatcher.(*ocppj.DefaultServerDispatcher).SetRetryPolicy(policy)
+	s.dispatcher.SetOnRequestCanceled(func(cID string, rID string, action string, request ocpp.Request) {
+		require.Fail(t, "unexpected OnRequestCanceled")
+	})
+	s.dispatcher.Start()
+	require.True(t, s.dispatcher.IsRunning())
+	// Simulate client connection
+	s.dispatcher.CreateClient(clientID)
+	err = s.dispatcher.SendRequest(clientID, bundle)
+	require.NoError(t, err)
+	// Wait for the failed attempt and the successful retransmission
+	for i := 0; i < 2; i++ {
+		select {
+		case <-writeC:
+		case <-time.After(time.Second):
+			require.Fail(t, "request wasn't retransmitted")
+		}
+	}
+	assert.True(t, s.state.HasPendingRequest(clientID))
+	q, ok := s.queueMap.Get(clientID)
+	require.True(t, ok)
+	assert.Equal(t, 1, q.Size())
+	s.dispatcher.CompleteRequest(clientID, requestID)
+	assert.False(t, s.state.HasPendingRequest(clientID))
+	assert.True(t, q.IsEmpty())
+}
+
+func (s *ServerDispatcherTestSuite) TestCancelRequest() {
+	t := s.T()
+	// Setup
+	clientID := "client1"
+	writeC := make(chan bool, 2)
+	s.websocketServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(nil)
+	canceledC := make(chan string, 2)
+	s.dispatcher.SetOnRequestCanceled(func(cID string, rID string, action string, request ocpp.Request) {
+		assert.Equal(t, clientID, cID)
+		canceledC <- rID
+	})
+	s.dispatcher.Start()
+	s.dispatcher.CreateClient(clientID)
+	var bundles []ocppj.RequestBundle
+	for i := 0; i < 2; i++ {
+		call, err := s.endpoint.CreateCall(newMockRequest("somevalue"))
+		require.NoError(t, err)
+		data, err := call.MarshalJSON()
+		require.NoError(t, err)
+		bundles = append(bundles, ocppj.RequestBundle{Call: call, Data: data})
+	}
+	// First request is pending, second one is queued
+	require.NoError(t, s.dispatcher.SendRequest(clientID, bundles[0]))
+	<-writeC
+	require.NoError(t, s.dispatcher.SendRequest(clientID, bundles[1]))
+	cancelDispatcher, ok := s.dispatcher.(ocppj.ServerCancelDispatcher)
+	require.True(t, ok)
+	q, ok := s.queueMap.Get(clientID)
+	require.True(t, ok)
+	// Canceling the queued request removes it right away, while the first request is still pending
+	cancelDispatcher.CancelRequest(clientID, bundles[1].Call.UniqueId)
+	select {
+	case rID := <-canceledC:
+		assert.Equal(t, bundles[1].Call.UniqueId, rID)
+	case <-time.After(time.Second):
+		require.Fail(t, "queued request wasn't canceled")
+	}
+	assert.Equal(t, 1, q.Size())
+	assert.True(t, s.state.HasPendingRequest(clientID))
+	// Canceling the pending request stops awaiting its response
+	cancelDispatcher.CancelRequest(clientID, bundles[0].Call.UniqueId)
+	select {
+	case rID := <-canceledC:
+		assert.Equal(t, bundles[0].Call.UniqueId, rID)
+	case <-time.After(time.Second):
+		require.Fail(t, "pending request wasn't canceled")
+	}
+	assert.True(t, q.IsEmpty())
+	assert.False(t, s.state.HasPendingRequest(clientID))
+	// The canceled queued request was never sent
+	assert.Len(t, writeC, 0)
+}
+
+func (s *ServerDispatcherTestSuite) TestCancelRequestWhileCompleted() {
+	t := s.T()
+	// Setup
+	clientID := "client1"
+	writeC := make(chan []byte, 2)
+	s.websocketServer.On("Write", mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- args.Get(1).([]byte)
+	}).Return(nil)
+	s.dispatcher.SetOnRequestCanceled(func(cID string, rID string, action string, request ocpp.Request) {})
+	s.queueMap.Add(clientID, slowPeekQueue{ocppj.NewFIFOClientQueue(10)})
+	s.dispatcher.Start()
+	s.dispatcher.CreateClient(clientID)
+	cancelDispatcher, ok := s.dispatcher.(ocppj.ServerCancelDispatcher)
+	require.True(t, ok)
+	q, ok := s.queueMap.Get(clientID)
+	require.True(t, ok)
+	newBundle := func() ocppj.RequestBundle {
+		call, err := s.endpoint.CreateCall(newMockRequest("somevalue"))
+		require.NoError(t, err)
+		data, err := call.MarshalJSON()
+		require.NoError(t, err)
+		return ocppj.RequestBundle{Call: call, Data: data}
+	}
+	pending := newBundle()
+	require.NoError(t, s.dispatcher.SendRequest(clientID, pending))
+	<-writeC
+	for i := 0; i < 50; i++ {
+		next := newBundle()
+		require.NoError(t, s.dispatcher.SendRequest(clientID, next))
+		// The response to the pending request is received while it is canceled. It must only be removed once
+		done := make(chan struct{})
+		go func() {
+			s.dispatcher.CompleteRequest(clientID, pending.Call.UniqueId)
+			close(done)
+		}()
+		cancelDispatcher.CancelRequest(clientID, pending.Call.UniqueId)
+		<-done
+		select {
+		case data := <-writeC:
+			assert.Equal(t, next.Data, data)
+		case <-time.After(time.Second):
+			require.FailNow(t, "next request wasn't sent")
+		}
+		require.Equal(t, 1, q.Size())
+		pending = next
+	}
+}
+
 func (s *ServerDispatcherTestSuite) TestCreateClient() {
 	t := s.T()
 	// Setup
@@ -268,6 +497,185 @@ func (c *ClientDispatcherTestSuite) TestRequestCanceled() {
 	assert.True(t, c.queue.IsEmpty())
 }
 
+func (c *ClientDispatcherTestSuite) TestRequestRetransmitted() {
+	t := c.T()
+	// Setup
+	writeC := make(chan bool, 3)
+	c.websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(fmt.Errorf("mockError")).Times(2)
+	c.websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(nil)
+	// Create mock request
+	req := newMockRequest("somevalue")
+	call, err := c.endpoint.CreateCall(req)
+	require.NoError(t, err)
+	requestID := call.UniqueId
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	bundle := ocppj.RequestBundle{Call: call, Data: data}
+	// Retransmit mock requests up to 3 times
+	policy := ocppj.NewRetryPolicy(3, MockFeatureName)
+	policy.InitialInterval = 50 * time.Millisecond
+	c.dispatcher.(*ocppj.DefaultClientDispatcher).SetRetryPolicy(policy)
+	c.dispatcher.SetOnRequestCanceled(func(rID string, action string, request ocpp.Request) {
+		require.Fail(t, "unexpected OnRequestCanceled")
+	})
+	c.dispatcher.Start()
+	require.True(t, c.dispatcher.IsRunning())
+	err = c.dispatcher.SendRequest(bundle)
+	require.NoError(t, err)
+	// Wait for the two failed attempts and the successful retransmission
+	for i := 0; i < 3; i++ {
+		select {
+		case <-writeC:
+		case <-time.After(time.Second):
+			require.Fail(t, "request wasn't retransmitted")
+		}
+	}
+	assert.True(t, c.state.HasPendingRequest())
+	assert.Equal(t, 1, c.queue.Size())
+	c.dispatcher.CompleteRequest(requestID)
+	assert.False(t, c.state.HasPendingRequest())
+	assert.True(t, c.queue.IsEmpty())
+}
+
+func (c *ClientDispatcherTestSuite) TestRetransmissionExhausted() {
+	t := c.T()
+	// Setup
+	canceled := make(chan bool, 1)
+	writeC := make(chan bool, 2)
+	c.websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(fmt.Errorf("mockError"))
+	// Create mock request
+	req := newMockRequest("somevalue")
+	call, err := c.endpoint.CreateCall(req)
+	require.NoError(t, err)
+	requestID := call.UniqueId
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	bundle := ocppj.RequestBundle{Call: call, Data: data}
+	policy := ocppj.NewRetryPolicy(2)
+	policy.InitialInterval = 50 * time.Millisecond
+	c.dispatcher.(*ocppj.DefaultClientDispatcher).SetRetryPolicy(policy)
+	c.dispatcher.SetOnRequestCanceled(func(rID string, action string, request ocpp.Request) {
+		assert.Equal(t, requestID, rID)
+		canceled <- true
+	})
+	c.dispatcher.Start()
+	require.True(t, c.dispatcher.IsRunning())
+	err = c.dispatcher.SendRequest(bundle)
+	require.NoError(t, err)
+	// Request is canceled after the second failed attempt
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		require.Fail(t, "request wasn't canceled")
+	}
+	assert.Len(t, writeC, 2)
+	assert.False(t, c.state.HasPendingRequest())
+	assert.True(t, c.queue.IsEmpty())
+}
+
+func (c *ClientDispatcherTestSuite) TestCancelRequest() {
+	t := c.T()
+	// Setup
+	writeC := make(chan bool, 2)
+	c.websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- true
+	}).Return(nil)
+	canceledC := make(chan string, 2)
+	c.dispatcher.SetOnRequestCanceled(func(rID string, action string, request ocpp.Request) {
+		canceledC <- rID
+	})
+	c.dispatcher.Start()
+	var bundles []ocppj.RequestBundle
+	for i := 0; i < 2; i++ {
+		call, err := c.endpoint.CreateCall(newMockRequest("somevalue"))
+		require.NoError(t, err)
+		data, err := call.MarshalJSON()
+		require.NoError(t, err)
+		bundles = append(bundles, ocppj.RequestBundle{Call: call, Data: data})
+	}
+	// First request is pending, second one is queued
+	require.NoError(t, c.dispatcher.SendRequest(bundles[0]))
+	<-writeC
+	require.NoError(t, c.dispatcher.SendRequest(bundles[1]))
+	cancelDispatcher, ok := c.dispatcher.(ocppj.ClientCancelDispatcher)
+	require.True(t, ok)
+	// Canceling the queued request removes it right away, while the first request is still pending
+	cancelDispatcher.CancelRequest(bundles[1].Call.UniqueId)
+	select {
+	case rID := <-canceledC:
+		assert.Equal(t, bundles[1].Call.UniqueId, rID)
+	case <-time.After(time.Second):
+		require.Fail(t, "queued request wasn't canceled")
+	}
+	assert.Equal(t, 1, c.queue.Size())
+	assert.True(t, c.state.HasPendingRequest())
+	// Canceling the pending request stops awaiting its response
+	cancelDispatcher.CancelRequest(bundles[0].Call.UniqueId)
+	select {
+	case rID := <-canceledC:
+		assert.Equal(t, bundles[0].Call.UniqueId, rID)
+	case <-time.After(time.Second):
+		require.Fail(t, "pending request wasn't canceled")
+	}
+	assert.True(t, c.queue.IsEmpty())
+	assert.False(t, c.state.HasPendingRequest())
+	// The canceled queued request was never sent
+	assert.Len(t, writeC, 0)
+}
+
+func (c *ClientDispatcherTestSuite) TestCancelRequestWhileCompleted() {
+	t := c.T()
+	// Setup
+	writeC := make(chan []byte, 2)
+	c.websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	}).Return(nil)
+	c.queue = slowPeekQueue{ocppj.NewFIFOClientQueue(10)}
+	c.dispatcher = ocppj.NewDefaultClientDispatcher(c.queue)
+	c.dispatcher.SetPendingRequestState(c.state)
+	c.dispatcher.SetNetworkClient(&c.websocketClient)
+	c.dispatcher.SetOnRequestCanceled(func(rID string, action string, request ocpp.Request) {})
+	c.dispatcher.Start()
+	cancelDispatcher, ok := c.dispatcher.(ocppj.ClientCancelDispatcher)
+	require.True(t, ok)
+	newBundle := func() ocppj.RequestBundle {
+		call, err := c.endpoint.CreateCall(newMockRequest("somevalue"))
+		require.NoError(t, err)
+		data, err := call.MarshalJSON()
+		require.NoError(t, err)
+		return ocppj.RequestBundle{Call: call, Data: data}
+	}
+	pending := newBundle()
+	require.NoError(t, c.dispatcher.SendRequest(pending))
+	<-writeC
+	for i := 0; i < 50; i++ {
+		next := newBundle()
+		require.NoError(t, c.dispatcher.SendRequest(next))
+		// The response to the pending request is received while it is canceled. It must only be removed once
+		done := make(chan struct{})
+		go func() {
+			c.dispatcher.CompleteRequest(pending.Call.UniqueId)
+			close(done)
+		}()
+		cancelDispatcher.CancelRequest(pending.Call.UniqueId)
+		<-done
+		select {
+		case data := <-writeC:
+			assert.Equal(t, next.Data, data)
+		case <-time.After(time.Second):
+			require.FailNow(t, "next request wasn't sent")
+		}
+		require.Equal(t, 1, c.queue.Size())
+		pending = next
+	}
+}
+
 func (c *ClientDispatcherTestSuite) TestDispatcherTimeout() {
 	t := c.T()
 	// Setup
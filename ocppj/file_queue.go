@@ -0,0 +1,338 @@
+package ocppj
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+)
+
+// Number of removed elements after which the queue file is rewritten, dropping all removal records.
+const fileQueueCompactThreshold = 100
+
+// Record markers of the append-only queue file. Every record is terminated by a newline.
+const (
+	fileQueuePushRecord byte = '+'
+	fileQueuePopRecord  byte = '-'
+)
+
+// FileClientQueue is a persistent RequestQueue implementation, backed by an append-only file.
+// The queue is thread-safe, and ordering and capacity semantics match those of the FIFOClientQueue.
+//
+// Every Push appends the raw OCPP-J message of a RequestBundle to the file, every Pop appends a removal record.
+// Writes are synced to disk before returning, so a queue survives crashes of the process:
+// a record that was only partially written when crashing is discarded while loading the file.
+// The file is periodically rewritten, to drop records of removed elements.
+//
+// Requests that can't be restored from the file (e.g. because their profile wasn't passed to the queue)
+// are never dispatched, nor counted by Size. They are kept in the file though, until the queue is cleared.
+//
+// Requests are restored from the file when creating the queue, and are dispatched once the dispatcher is started.
+// Unlike the FIFOClientQueue, Init doesn't discard persisted requests, but reloads the queue from its file.
+// This way requests, which were still queued when stopping a client, are sent after the next start.
+// To discard all requests, use Clear.
+//
+// Only elements of type RequestBundle may be pushed to the queue.
+type FileClientQueue struct {
+	elements []interface{}
+	unparsed int
+	capacity int
+	path     string
+	file     *os.File
+	popped   int
+	endpoint Endpoint
+	mutex    sync.RWMutex
+}
+
+// NewFileClientQueue creates a new FileClientQueue with the given capacity, persisted in the file at path.
+// If the file exists already, the queue is restored from it. Otherwise it is created.
+//
+// The profiles supported by the client need to be passed, in order to restore the requests contained in the file.
+// Passing capacity = 0 will create a queue without a maximum capacity.
+// Restored requests are never discarded, even if they exceed the capacity.
+//
+// An error is returned, if the file couldn't be read or written.
+func NewFileClientQueue(path string, capacity int, profiles ...*ocpp.Profile) (*FileClientQueue, error) {
+	q := &FileClientQueue{
+		elements: make([]interface{}, 0, capacity),
+		capacity: capacity,
+		path:     path,
+	}
+	for _, profile := range profiles {
+		q.endpoint.AddProfile(profile)
+	}
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Reads all elements from the queue file and rewrites it, so that further records may be appended safely.
+func (q *FileClientQueue) load() error {
+	data, err := ioutil.ReadFile(q.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("couldn't read request queue file %v: %w", q.path, err)
+	}
+	elements := make([]interface{}, 0, q.capacity)
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			// Partially written record, discarded
+			log.Errorf("discarding incomplete record in request queue file %v", q.path)
+			break
+		}
+		record := data[:i]
+		data = data[i+1:]
+		if len(record) == 0 {
+			continue
+		}
+		switch record[0] {
+		case fileQueuePushRecord:
+			bundle, err := q.parseBundle(record[1:])
+			if err != nil {
+				log.Errorf("couldn't restore request from queue file %v, it won't be dispatched: %v", q.path, err)
+				elements = append(elements, unparsedRequest{data: record[1:]})
+				continue
+			}
+			elements = append(elements, bundle)
+		case fileQueuePopRecord:
+			if len(elements) > 0 {
+				elements = elements[1:]
+			}
+		default:
+			log.Errorf("discarding unknown record in request queue file %v", q.path)
+		}
+	}
+	q.elements = elements
+	q.unparsed = 0
+	for _, el := range elements {
+		if _, ok := el.(unparsedRequest); ok {
+			q.unparsed++
+		}
+	}
+	return q.rewrite()
+}
+
+// Placeholder for a queued request, which couldn't be restored from the queue file.
+// It keeps removal records aligned with the queue elements, and is written back when rewriting the file.
+type unparsedRequest struct {
+	data []byte
+}
+
+// Restores a RequestBundle from the raw OCPP-J message of a request.
+func (q *FileClientQueue) parseBundle(data []byte) (RequestBundle, error) {
+	arr, err := ParseRawJsonMessage(data)
+	if err != nil {
+		return RequestBundle{}, err
+	}
+	message, err := q.endpoint.ParseMessage(arr, nil)
+	if err != nil {
+		return RequestBundle{}, err
+	}
+	call, ok := message.(*Call)
+	if !ok {
+		return RequestBundle{}, fmt.Errorf("message %v is not a call", message.GetUniqueId())
+	}
+	return RequestBundle{Call: call, Data: data}, nil
+}
+
+// Atomically replaces the queue file with a file containing only the current elements.
+func (q *FileClientQueue) rewrite() error {
+	if q.file != nil {
+		_ = q.file.Close()
+		q.file = nil
+	}
+	tmpPath := q.path + ".tmp"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't create request queue file %v: %w", tmpPath, err)
+	}
+	w := bufio.NewWriter(tmpFile)
+	for _, el := range q.elements {
+		_ = w.WriteByte(fileQueuePushRecord)
+		switch el := el.(type) {
+		case RequestBundle:
+			_, _ = w.Write(el.Data)
+		case unparsedRequest:
+			_, _ = w.Write(el.data)
+		}
+		_ = w.WriteByte('\n')
+	}
+	err = w.Flush()
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	_ = tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't write request queue file %v: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, q.path); err != nil {
+		return fmt.Errorf("couldn't replace request queue file %v: %w", q.path, err)
+	}
+	q.file, err = os.OpenFile(q.path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't open request queue file %v: %w", q.path, err)
+	}
+	q.popped = 0
+	return nil
+}
+
+// Returns the index of the first restored request in the queue, or -1 if there is none.
+func (q *FileClientQueue) front() int {
+	for i, el := range q.elements {
+		if _, ok := el.(RequestBundle); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the number of requests in the queue, which may be dispatched.
+func (q *FileClientQueue) size() int {
+	return len(q.elements) - q.unparsed
+}
+
+// Appends a record to the queue file and syncs it to disk.
+func (q *FileClientQueue) appendRecord(record []byte) error {
+	if q.file == nil {
+		return fmt.Errorf("request queue file %v is not open", q.path)
+	}
+	if _, err := q.file.Write(record); err != nil {
+		return err
+	}
+	return q.file.Sync()
+}
+
+func (q *FileClientQueue) Init() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if err := q.load(); err != nil {
+		log.Errorf("couldn't reload request queue: %v", err)
+	}
+}
+
+func (q *FileClientQueue) Push(element interface{}) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.size() >= q.capacity && q.capacity > 0 {
+		return fmt.Errorf("request queue is full, cannot push new element")
+	}
+	bundle, ok := element.(RequestBundle)
+	if !ok {
+		return fmt.Errorf("cannot push element of type %T to persistent request queue", element)
+	}
+	record := make([]byte, 0, len(bundle.Data)+2)
+	record = append(record, fileQueuePushRecord)
+	record = append(record, bundle.Data...)
+	record = append(record, '\n')
+	if err := q.appendRecord(record); err != nil {
+		return fmt.Errorf("couldn't persist request %v: %w", bundle.Call.UniqueId, err)
+	}
+	q.elements = append(q.elements, element)
+	return nil
+}
+
+func (q *FileClientQueue) Peek() interface{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	i := q.front()
+	if i < 0 {
+		return nil
+	}
+	return q.elements[i]
+}
+
+func (q *FileClientQueue) Pop() interface{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	i := q.front()
+	if i < 0 {
+		return nil
+	}
+	result := q.elements[i]
+	q.elements = append(q.elements[:i:i], q.elements[i+1:]...)
+	q.popped++
+	var err error
+	// Removal records only refer to the front of the queue, hence the file is rewritten if unparsed requests precede it
+	if i > 0 || len(q.elements) == 0 || q.popped >= fileQueueCompactThreshold {
+		err = q.rewrite()
+	} else {
+		err = q.appendRecord([]byte{fileQueuePopRecord, '\n'})
+	}
+	if err != nil {
+		log.Errorf("couldn't persist removal of request from queue: %v", err)
+	}
+	return result
+}
+
+func (q *FileClientQueue) Remove(requestID string) interface{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for i, el := range q.elements {
+		if bundle, ok := el.(RequestBundle); ok && bundle.Call.UniqueId == requestID {
+			q.elements = append(q.elements[:i:i], q.elements[i+1:]...)
+			// Removal records only refer to the front of the queue, hence the file is rewritten
+			if err := q.rewrite(); err != nil {
+				log.Errorf("couldn't persist removal of request %v from queue: %v", requestID, err)
+			}
+			return el
+		}
+	}
+	return nil
+}
+
+func (q *FileClientQueue) Size() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.size()
+}
+
+func (q *FileClientQueue) IsFull() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.size() >= q.capacity && q.capacity > 0
+}
+
+func (q *FileClientQueue) IsEmpty() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.size() == 0
+}
+
+// Returns all queued requests, in queue order.
+func (q *FileClientQueue) queuedRequests() []*Call {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	calls := make([]*Call, 0, q.size())
+	for _, el := range q.elements {
+		if bundle, ok := el.(RequestBundle); ok {
+			calls = append(calls, bundle.Call)
+		}
+	}
+	return calls
+}
+
+// Clear discards all elements, both from memory and from the queue file.
+func (q *FileClientQueue) Clear() error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.elements = make([]interface{}, 0, q.capacity)
+	q.unparsed = 0
+	return q.rewrite()
+}
+
+// Close closes the queue file. The queue must not be used after closing it.
+func (q *FileClientQueue) Close() error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.file == nil {
+		return nil
+	}
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
@@ -0,0 +1,330 @@
+package ocppj_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+type FileClientQueueTestSuite struct {
+	suite.Suite
+	dir      string
+	path     string
+	profile  *ocpp.Profile
+	endpoint ocppj.Endpoint
+	queue    *ocppj.FileClientQueue
+}
+
+func (suite *FileClientQueueTestSuite) SetupTest() {
+	var err error
+	suite.dir, err = ioutil.TempDir("", "ocppj-queue")
+	require.NoError(suite.T(), err)
+	suite.path = filepath.Join(suite.dir, "queue")
+	suite.profile = ocpp.NewProfile("mock", MockFeature{})
+	suite.endpoint = ocppj.Endpoint{}
+	suite.endpoint.AddProfile(suite.profile)
+	suite.queue, err = ocppj.NewFileClientQueue(suite.path, queueCapacity, suite.profile)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *FileClientQueueTestSuite) TearDownTest() {
+	_ = suite.queue.Close()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *FileClientQueueTestSuite) newBundle(value string) ocppj.RequestBundle {
+	t := suite.T()
+	call, err := suite.endpoint.CreateCall(newMockRequest(value))
+	require.NoError(t, err)
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	return ocppj.RequestBundle{Call: call, Data: data}
+}
+
+func (suite *FileClientQueueTestSuite) reopen() {
+	var err error
+	require.NoError(suite.T(), suite.queue.Close())
+	suite.queue, err = ocppj.NewFileClientQueue(suite.path, queueCapacity, suite.profile)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *FileClientQueueTestSuite) TestQueueOrder() {
+	t := suite.T()
+	bundles := []ocppj.RequestBundle{suite.newBundle("value1"), suite.newBundle("value2"), suite.newBundle("value3")}
+	for _, bundle := range bundles {
+		require.NoError(t, suite.queue.Push(bundle))
+	}
+	assert.Equal(t, 3, suite.queue.Size())
+	for _, bundle := range bundles {
+		assert.Equal(t, bundle, suite.queue.Peek())
+		assert.Equal(t, bundle, suite.queue.Pop())
+	}
+	assert.True(t, suite.queue.IsEmpty())
+	assert.Nil(t, suite.queue.Pop())
+}
+
+func (suite *FileClientQueueTestSuite) TestRemove() {
+	t := suite.T()
+	bundles := []ocppj.RequestBundle{suite.newBundle("value1"), suite.newBundle("value2"), suite.newBundle("value3")}
+	for _, bundle := range bundles {
+		require.NoError(t, suite.queue.Push(bundle))
+	}
+	assert.Nil(t, suite.queue.Remove("unknown"))
+	assert.Equal(t, bundles[1], suite.queue.Remove(bundles[1].Call.UniqueId))
+	assert.Equal(t, 2, suite.queue.Size())
+	// Removal is persisted
+	suite.reopen()
+	require.Equal(t, 2, suite.queue.Size())
+	assert.Equal(t, bundles[0].Call.UniqueId, suite.queue.Pop().(ocppj.RequestBundle).Call.UniqueId)
+	assert.Equal(t, bundles[2].Call.UniqueId, suite.queue.Pop().(ocppj.RequestBundle).Call.UniqueId)
+	assert.True(t, suite.queue.IsEmpty())
+}
+
+func (suite *FileClientQueueTestSuite) TestQueueFull() {
+	t := suite.T()
+	for i := 0; i < queueCapacity; i++ {
+		require.NoError(t, suite.queue.Push(suite.newBundle("somevalue")))
+	}
+	assert.True(t, suite.queue.IsFull())
+	err := suite.queue.Push(suite.newBundle("somevalue"))
+	assert.Error(t, err)
+	assert.Equal(t, queueCapacity, suite.queue.Size())
+}
+
+func (suite *FileClientQueueTestSuite) TestInvalidElement() {
+	t := suite.T()
+	err := suite.queue.Push(newMockRequest("somevalue"))
+	assert.Error(t, err)
+	assert.True(t, suite.queue.IsEmpty())
+}
+
+func (suite *FileClientQueueTestSuite) TestRestore() {
+	t := suite.T()
+	bundles := []ocppj.RequestBundle{suite.newBundle("value1"), suite.newBundle("value2"), suite.newBundle("value3")}
+	for _, bundle := range bundles {
+		require.NoError(t, suite.queue.Push(bundle))
+	}
+	suite.queue.Pop()
+	suite.reopen()
+	require.Equal(t, 2, suite.queue.Size())
+	for _, bundle := range bundles[1:] {
+		restored, ok := suite.queue.Pop().(ocppj.RequestBundle)
+		require.True(t, ok)
+		assert.Equal(t, bundle.Call.UniqueId, restored.Call.UniqueId)
+		assert.Equal(t, bundle.Call.Action, restored.Call.Action)
+		assert.Equal(t, bundle.Data, restored.Data)
+		request, ok := restored.Call.Payload.(*MockRequest)
+		require.True(t, ok)
+		assert.Equal(t, bundle.Call.Payload.(*MockRequest).MockValue, request.MockValue)
+	}
+	// Popped elements are not restored
+	suite.reopen()
+	assert.True(t, suite.queue.IsEmpty())
+}
+
+func (suite *FileClientQueueTestSuite) TestRestoreIncompleteRecord() {
+	t := suite.T()
+	bundle := suite.newBundle("value1")
+	require.NoError(t, suite.queue.Push(bundle))
+	require.NoError(t, suite.queue.Close())
+	// Simulate a crash while appending a record
+	file, err := os.OpenFile(suite.path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.Write(suite.newBundle("value2").Data[:10])
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	suite.queue, err = ocppj.NewFileClientQueue(suite.path, queueCapacity, suite.profile)
+	require.NoError(t, err)
+	require.Equal(t, 1, suite.queue.Size())
+	assert.Equal(t, bundle.Data, suite.queue.Peek().(ocppj.RequestBundle).Data)
+	// Records appended after restoring are valid
+	require.NoError(t, suite.queue.Push(suite.newBundle("value3")))
+	suite.reopen()
+	assert.Equal(t, 2, suite.queue.Size())
+}
+
+func (suite *FileClientQueueTestSuite) TestRestoreUnparsableRecord() {
+	t := suite.T()
+	bundle := suite.newBundle("value1")
+	unknown1 := []byte(`[2,"unknown1","UnknownAction",{}]`)
+	unknown2 := []byte(`[2,"unknown2","UnknownAction",{}]`)
+	require.NoError(t, suite.queue.Close())
+	// The removal record refers to the first, unparsable request
+	content := "+" + string(unknown1) + "\n+" + string(unknown2) + "\n+" + string(bundle.Data) + "\n-\n"
+	require.NoError(t, ioutil.WriteFile(suite.path, []byte(content), 0644))
+	var err error
+	suite.queue, err = ocppj.NewFileClientQueue(suite.path, queueCapacity, suite.profile)
+	require.NoError(t, err)
+	// Unparsable requests are neither counted nor dispatched
+	require.Equal(t, 1, suite.queue.Size())
+	assert.Equal(t, bundle.Data, suite.queue.Peek().(ocppj.RequestBundle).Data)
+	assert.Equal(t, bundle.Data, suite.queue.Pop().(ocppj.RequestBundle).Data)
+	assert.True(t, suite.queue.IsEmpty())
+	assert.Nil(t, suite.queue.Pop())
+	// Remaining unparsable requests are kept in the file
+	suite.reopen()
+	assert.True(t, suite.queue.IsEmpty())
+	data, err := ioutil.ReadFile(suite.path)
+	require.NoError(t, err)
+	assert.Equal(t, "+"+string(unknown2)+"\n", string(data))
+	require.NoError(t, suite.queue.Push(bundle))
+	assert.Equal(t, 1, suite.queue.Size())
+	require.NoError(t, suite.queue.Clear())
+	data, err = ioutil.ReadFile(suite.path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func (suite *FileClientQueueTestSuite) TestInitAndClear() {
+	t := suite.T()
+	require.NoError(t, suite.queue.Push(suite.newBundle("value1")))
+	// Init doesn't discard persisted requests
+	suite.queue.Init()
+	assert.Equal(t, 1, suite.queue.Size())
+	require.NoError(t, suite.queue.Clear())
+	assert.True(t, suite.queue.IsEmpty())
+	suite.reopen()
+	assert.True(t, suite.queue.IsEmpty())
+}
+
+func (suite *FileClientQueueTestSuite) TestCompaction() {
+	t := suite.T()
+	require.NoError(t, suite.queue.Push(suite.newBundle("value1")))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, suite.queue.Push(suite.newBundle("somevalue")))
+		suite.queue.Pop()
+	}
+	// After 100 removals the file only contains the remaining request
+	require.Equal(t, 1, suite.queue.Size())
+	remaining := suite.queue.Peek().(ocppj.RequestBundle)
+	info, err := os.Stat(suite.path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(remaining.Data)+2), info.Size())
+	suite.reopen()
+	assert.Equal(t, 1, suite.queue.Size())
+}
+
+func (suite *FileClientQueueTestSuite) TestDispatchRestoredRequests() {
+	t := suite.T()
+	bundle := suite.newBundle("value1")
+	require.NoError(t, suite.queue.Push(bundle))
+	suite.reopen()
+	// Restored request is sent as soon as the dispatcher starts
+	writeC := make(chan []byte, 1)
+	websocketClient := MockWebsocketClient{}
+	websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	}).Return(nil)
+	dispatcher := ocppj.NewDefaultClientDispatcher(suite.queue)
+	dispatcher.SetNetworkClient(&websocketClient)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+	select {
+	case data := <-writeC:
+		assert.Equal(t, bundle.Data, data)
+	case <-time.After(time.Second):
+		require.Fail(t, "restored request wasn't dispatched")
+	}
+	dispatcher.CompleteRequest(bundle.Call.UniqueId)
+	assert.True(t, suite.queue.IsEmpty())
+}
+
+func (suite *FileClientQueueTestSuite) TestRestoredResponseNotForwarded() {
+	t := suite.T()
+	bundle := suite.newBundle("value1")
+	require.NoError(t, suite.queue.Push(bundle))
+	suite.reopen()
+	writeC := make(chan []byte, 1)
+	websocketClient := MockWebsocketClient{}
+	websocketClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	websocketClient.On("Stop").Return()
+	websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	}).Return(nil)
+	client := ocppj.NewClient("mock_id", &websocketClient, ocppj.NewDefaultClientDispatcher(suite.queue), nil, suite.profile)
+	client.SetResponseHandler(func(response ocpp.Response, requestId string) {
+		assert.Fail(t, "unexpected response for restored request %v", requestId)
+	})
+	restoredC := make(chan ocpp.Response, 1)
+	client.SetRestoredRequestHandler(func(requestId string, action string, request ocpp.Request, response ocpp.Response, err *ocpp.Error) {
+		assert.Equal(t, bundle.Call.UniqueId, requestId)
+		assert.Equal(t, MockFeatureName, action)
+		assert.Equal(t, bundle.Call.Payload, request)
+		assert.Nil(t, err)
+		restoredC <- response
+	})
+	require.NoError(t, client.Start("someUrl"))
+	defer client.Stop()
+	select {
+	case <-writeC:
+	case <-time.After(time.Second):
+		require.Fail(t, "restored request wasn't dispatched")
+	}
+	// Response completes the restored request, invoking the restored request handler instead of the response handler
+	err := websocketClient.MessageHandler([]byte(`[3,"` + bundle.Call.UniqueId + `",{"mockValue":"someValue"}]`))
+	require.NoError(t, err)
+	assert.True(t, suite.queue.IsEmpty())
+	select {
+	case response := <-restoredC:
+		require.IsType(t, &MockConfirmation{}, response)
+		assert.Equal(t, "someValue", response.(*MockConfirmation).MockValue)
+	default:
+		assert.Fail(t, "restored request handler wasn't invoked")
+	}
+}
+
+func (suite *FileClientQueueTestSuite) TestRestartedRequestNotRestored() {
+	t := suite.T()
+	writeC := make(chan []byte, 2)
+	websocketClient := MockWebsocketClient{}
+	websocketClient.On("Start", mock.AnythingOfType("string")).Return(nil)
+	websocketClient.On("Stop").Return()
+	websocketClient.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+		writeC <- args.Get(0).([]byte)
+	}).Return(nil)
+	client := ocppj.NewClient("mock_id", &websocketClient, ocppj.NewDefaultClientDispatcher(suite.queue), nil, suite.profile)
+	responseC := make(chan string, 1)
+	client.SetResponseHandler(func(response ocpp.Response, requestId string) {
+		responseC <- requestId
+	})
+	client.SetRestoredRequestHandler(func(requestId string, action string, request ocpp.Request, response ocpp.Response, err *ocpp.Error) {
+		assert.Fail(t, "unexpected restored result for request %v", requestId)
+	})
+	require.NoError(t, client.Start("someUrl"))
+	require.NoError(t, client.SendRequest(newMockRequest("somevalue")))
+	select {
+	case <-writeC:
+	case <-time.After(time.Second):
+		require.Fail(t, "request wasn't dispatched")
+	}
+	// Request sent by the current run is still queued after restarting the client
+	client.Stop()
+	require.NoError(t, client.Start("someUrl"))
+	defer client.Stop()
+	require.Equal(t, 1, suite.queue.Size())
+	requestId := suite.queue.Peek().(ocppj.RequestBundle).Call.UniqueId
+	select {
+	case <-writeC:
+	case <-time.After(time.Second):
+		require.Fail(t, "request wasn't dispatched after restart")
+	}
+	// Response is forwarded to the regular response handler
+	err := websocketClient.MessageHandler([]byte(`[3,"` + requestId + `",{"mockValue":"someValue"}]`))
+	require.NoError(t, err)
+	select {
+	case id := <-responseC:
+		assert.Equal(t, requestId, id)
+	case <-time.After(time.Second):
+		assert.Fail(t, "response handler wasn't invoked")
+	}
+	assert.True(t, suite.queue.IsEmpty())
+}
@@ -37,9 +37,11 @@ func SetLogger(logger ocpp.Logger) {
 type MessageType int
 
 const (
-	CALL        MessageType = 2
-	CALL_RESULT MessageType = 3
-	CALL_ERROR  MessageType = 4
+	CALL              MessageType = 2
+	CALL_RESULT       MessageType = 3
+	CALL_ERROR        MessageType = 4
+	CALL_RESULT_ERROR MessageType = 5 // Introduced with OCPP 2.1.
+	SEND              MessageType = 6 // Introduced with OCPP 2.1.
 )
 
 // An OCPP-J message.
@@ -150,6 +152,68 @@ func (callError *CallError) MarshalJSON() ([]byte, error) {
 	return ocppMessageToJson(fields)
 }
 
+// -------------------- Call Result Error --------------------
+
+// An OCPP-J CallResultError message, sent in response to a CallResult which the receiver couldn't process.
+//
+// This message type was introduced with OCPP 2.1. No reply shall be sent to a CallResultError.
+type CallResultError struct {
+	Message
+	MessageTypeId    MessageType    `json:"messageTypeId" validate:"required,eq=5"`
+	UniqueId         string         `json:"uniqueId" validate:"required,max=36"`
+	ErrorCode        ocpp.ErrorCode `json:"errorCode" validate:"errorCode"`
+	ErrorDescription string         `json:"errorDescription" validate:"required"`
+	ErrorDetails     interface{}    `json:"errorDetails" validate:"omitempty"`
+}
+
+func (callResultError *CallResultError) GetMessageTypeId() MessageType {
+	return callResultError.MessageTypeId
+}
+
+func (callResultError *CallResultError) GetUniqueId() string {
+	return callResultError.UniqueId
+}
+
+func (callResultError *CallResultError) MarshalJSON() ([]byte, error) {
+	fields := make([]interface{}, 5)
+	fields[0] = int(callResultError.MessageTypeId)
+	fields[1] = callResultError.UniqueId
+	fields[2] = callResultError.ErrorCode
+	fields[3] = callResultError.ErrorDescription
+	fields[4] = callResultError.ErrorDetails
+	return ocppMessageToJson(fields)
+}
+
+// -------------------- Send --------------------
+
+// An OCPP-J Send message, containing an unconfirmed OCPP Request.
+//
+// This message type was introduced with OCPP 2.1. The receiver shall neither reply with a CallResult nor with a CallError.
+type Send struct {
+	Message       `validate:"-"`
+	MessageTypeId MessageType  `json:"messageTypeId" validate:"required,eq=6"`
+	UniqueId      string       `json:"uniqueId" validate:"required,max=36"`
+	Action        string       `json:"action" validate:"required,max=36"`
+	Payload       ocpp.Request `json:"payload" validate:"required"`
+}
+
+func (send *Send) GetMessageTypeId() MessageType {
+	return send.MessageTypeId
+}
+
+func (send *Send) GetUniqueId() string {
+	return send.UniqueId
+}
+
+func (send *Send) MarshalJSON() ([]byte, error) {
+	fields := make([]interface{}, 4)
+	fields[0] = int(send.MessageTypeId)
+	fields[1] = send.UniqueId
+	fields[2] = send.Action
+	fields[3] = send.Payload
+	return json.Marshal(fields)
+}
+
 const (
 	NotImplemented                ocpp.ErrorCode = "NotImplemented"                // Requested Action is not known by receiver.
 	NotSupported                  ocpp.ErrorCode = "NotSupported"                  // Requested Action is recognized but not supported by the receiver.
@@ -261,7 +325,16 @@ func errorFromValidation(validationErrors validator.ValidationErrors, messageId
 // An OCPP-J endpoint is one of the two entities taking part in the communication.
 // The endpoint keeps state for supported OCPP profiles and current pending requests.
 type Endpoint struct {
-	Profiles []*ocpp.Profile
+	Profiles             []*ocpp.Profile
+	extendedMessageTypes bool
+}
+
+// Enables support for the CallResultError and Send message types on the endpoint.
+//
+// Both message types were introduced with OCPP 2.1 and are disabled by default,
+// since earlier versions of the protocol require them to be rejected with a MessageTypeNotSupported error.
+func (endpoint *Endpoint) SetExtendedMessageTypesEnabled(enabled bool) {
+	endpoint.extendedMessageTypes = enabled
 }
 
 // Adds support for a new profile on the endpoint.
@@ -409,11 +482,77 @@ func (endpoint *Endpoint) ParseMessage(arr []interface{}, pendingRequestState Cl
 			return nil, errorFromValidation(err.(validator.ValidationErrors), uniqueId, "")
 		}
 		return &callError, nil
+	} else if typeId == CALL_RESULT_ERROR && endpoint.extendedMessageTypes {
+		if len(arr) < 4 {
+			return nil, ocpp.NewError(FormationViolation, "Invalid Call Result Error message. Expected array length >= 4", uniqueId)
+		}
+		var details interface{}
+		if len(arr) > 4 {
+			details = arr[4]
+		}
+		rawErrorCode, _ := arr[2].(string)
+		description, _ := arr[3].(string)
+		callResultError := CallResultError{
+			MessageTypeId:    CALL_RESULT_ERROR,
+			UniqueId:         uniqueId,
+			ErrorCode:        ocpp.ErrorCode(rawErrorCode),
+			ErrorDescription: description,
+			ErrorDetails:     details,
+		}
+		err := Validate.Struct(callResultError)
+		if err != nil {
+			return nil, errorFromValidation(err.(validator.ValidationErrors), uniqueId, "")
+		}
+		return &callResultError, nil
+	} else if typeId == SEND && endpoint.extendedMessageTypes {
+		if len(arr) != 4 {
+			return nil, ocpp.NewError(FormationViolation, "Invalid Send message. Expected array length 4", uniqueId)
+		}
+		action, _ := arr[2].(string)
+		profile, ok := endpoint.GetProfileForFeature(action)
+		if !ok {
+			return nil, ocpp.NewError(NotSupported, fmt.Sprintf("Unsupported feature %v", action), uniqueId)
+		}
+		request, err := profile.ParseRequest(action, arr[3], parseRawJsonRequest)
+		if err != nil {
+			return nil, ocpp.NewError(FormationViolation, err.Error(), uniqueId)
+		}
+		send := Send{
+			MessageTypeId: SEND,
+			UniqueId:      uniqueId,
+			Action:        action,
+			Payload:       request,
+		}
+		err = Validate.Struct(send)
+		if err != nil {
+			return nil, errorFromValidation(err.(validator.ValidationErrors), uniqueId, action)
+		}
+		return &send, nil
 	} else {
 		return nil, ocpp.NewError(MessageTypeNotSupported, fmt.Sprintf("Invalid message type ID %v", typeId), uniqueId)
 	}
 }
 
+// Returns the type of message, which shall be sent in reply to a received message that couldn't be parsed.
+//
+// If extended message types are enabled, invalid CallResult messages are reported via a CallResultError,
+// while no reply at all is sent for invalid CallResultError and Send messages (0 is returned).
+// Otherwise every invalid message is reported via a CallError.
+func (endpoint *Endpoint) replyTypeForInvalidMessage(arr []interface{}) MessageType {
+	if !endpoint.extendedMessageTypes || len(arr) == 0 {
+		return CALL_ERROR
+	}
+	rawTypeId, _ := arr[0].(float64)
+	switch MessageType(rawTypeId) {
+	case CALL_RESULT:
+		return CALL_RESULT_ERROR
+	case CALL_RESULT_ERROR, SEND:
+		return 0
+	default:
+		return CALL_ERROR
+	}
+}
+
 // Creates a Call message, given an OCPP request. A unique ID for the message is automatically generated.
 // Returns an error in case the request's feature is not supported on this endpoint.
 //
@@ -471,3 +610,47 @@ func (endpoint *Endpoint) CreateCallError(uniqueId string, code ocpp.ErrorCode,
 	}
 	return &callError
 }
+
+// Creates a CallResultError message, given the unique ID of the CallResult that couldn't be processed and the error.
+//
+// Returns an error in case extended message types are not enabled on this endpoint.
+func (endpoint *Endpoint) CreateCallResultError(uniqueId string, code ocpp.ErrorCode, description string, details interface{}) (*CallResultError, error) {
+	if !endpoint.extendedMessageTypes {
+		return nil, fmt.Errorf("Couldn't create Call Result Error, message type not supported by endpoint")
+	}
+	callResultError := CallResultError{
+		MessageTypeId:    CALL_RESULT_ERROR,
+		UniqueId:         uniqueId,
+		ErrorCode:        code,
+		ErrorDescription: description,
+		ErrorDetails:     details,
+	}
+	return &callResultError, nil
+}
+
+// Creates a Send message, given an OCPP request. A unique ID for the message is automatically generated.
+// Returns an error in case the request's feature is not supported on this endpoint,
+// or if extended message types are not enabled on this endpoint.
+//
+// Since the message is unconfirmed, it must not be added to the list of pending requests.
+func (endpoint *Endpoint) CreateSend(request ocpp.Request) (*Send, error) {
+	if !endpoint.extendedMessageTypes {
+		return nil, fmt.Errorf("Couldn't create Send, message type not supported by endpoint")
+	}
+	action := request.GetFeatureName()
+	profile, _ := endpoint.GetProfileForFeature(action)
+	if profile == nil {
+		return nil, fmt.Errorf("Couldn't create Send for unsupported action %v", action)
+	}
+	send := Send{
+		MessageTypeId: SEND,
+		UniqueId:      messageIdGenerator(),
+		Action:        action,
+		Payload:       request,
+	}
+	err := Validate.Struct(send)
+	if err != nil {
+		return nil, err
+	}
+	return &send, nil
+}
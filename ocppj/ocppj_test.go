@@ -29,6 +29,10 @@ func (websocket MockWebSocket) TLSConnectionState() *tls.ConnectionState {
 	return nil
 }
 
+func (websocket MockWebSocket) Subprotocol() string {
+	return ""
+}
+
 func NewMockWebSocket(id string) MockWebSocket {
 	return MockWebSocket{id: id}
 }
@@ -486,6 +490,59 @@ func (suite *OcppJTestSuite) TestCreateCallError() {
 	CheckCallError(t, callError, mockUniqueId, ocppj.GenericError, mockDescription, mockDetails)
 }
 
+func (suite *OcppJTestSuite) TestCreateSend() {
+	t := suite.T()
+	mockValue := "somevalue"
+	request := newMockRequest(mockValue)
+	// Send is not supported by default
+	send, err := suite.chargePoint.CreateSend(request)
+	require.Error(t, err)
+	assert.Nil(t, send)
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	send, err = suite.chargePoint.CreateSend(request)
+	require.NoError(t, err)
+	require.NotNil(t, send)
+	assert.Equal(t, ocppj.SEND, send.GetMessageTypeId())
+	assert.Equal(t, MockFeatureName, send.Action)
+	assert.NotEmpty(t, send.GetUniqueId())
+	message, ok := send.Payload.(*MockRequest)
+	assert.True(t, ok)
+	assert.Equal(t, mockValue, message.MockValue)
+	jsonMessage, err := send.MarshalJSON()
+	require.NoError(t, err)
+	parsedData, err := ocppj.ParseRawJsonMessage(jsonMessage)
+	require.NoError(t, err)
+	require.Len(t, parsedData, 4)
+	assert.Equal(t, float64(ocppj.SEND), parsedData[0])
+	assert.Equal(t, send.GetUniqueId(), parsedData[1])
+	assert.Equal(t, MockFeatureName, parsedData[2])
+	// Send is never stored as pending request
+	pendingRequest, exists := suite.chargePoint.RequestState.GetPendingRequest(send.UniqueId)
+	assert.False(t, exists)
+	assert.Nil(t, pendingRequest)
+}
+
+func (suite *OcppJTestSuite) TestCreateCallResultError() {
+	t := suite.T()
+	mockUniqueId := "123456"
+	mockDescription := "somedescription"
+	// CallResultError is not supported by default
+	callResultError, err := suite.chargePoint.CreateCallResultError(mockUniqueId, ocppj.GenericError, mockDescription, nil)
+	require.Error(t, err)
+	assert.Nil(t, callResultError)
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	callResultError, err = suite.chargePoint.CreateCallResultError(mockUniqueId, ocppj.GenericError, mockDescription, nil)
+	require.NoError(t, err)
+	require.NotNil(t, callResultError)
+	assert.Equal(t, ocppj.CALL_RESULT_ERROR, callResultError.GetMessageTypeId())
+	assert.Equal(t, mockUniqueId, callResultError.GetUniqueId())
+	assert.Equal(t, ocppj.GenericError, callResultError.ErrorCode)
+	assert.Equal(t, mockDescription, callResultError.ErrorDescription)
+	jsonMessage, err := callResultError.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(`[%v,"%v","%v","%v",null]`, ocppj.CALL_RESULT_ERROR, mockUniqueId, ocppj.GenericError, mockDescription), string(jsonMessage))
+}
+
 func (suite *OcppJTestSuite) TestParseMessageInvalidLength() {
 	t := suite.T()
 	mockMessage := make([]interface{}, 2)
@@ -628,6 +685,103 @@ func (suite *OcppJTestSuite) TestParseMessageInvalidCallError() {
 	assert.Equal(t, "Invalid Call Error message. Expected array length >= 4", protoErr.Description)
 }
 
+func (suite *OcppJTestSuite) TestParseMessageExtendedTypesDisabled() {
+	t := suite.T()
+	messageId := "12345"
+	for _, typeId := range []ocppj.MessageType{ocppj.CALL_RESULT_ERROR, ocppj.SEND} {
+		mockMessage := make([]interface{}, 4)
+		mockMessage[0] = float64(typeId) // Message Type ID
+		mockMessage[1] = messageId       // Unique ID
+		mockMessage[2] = MockFeatureName
+		mockMessage[3] = newMockRequest("somevalue")
+		message, err := suite.chargePoint.ParseMessage(mockMessage, suite.chargePoint.RequestState)
+		require.Nil(t, message)
+		require.Error(t, err)
+		protoErr := err.(*ocpp.Error)
+		require.NotNil(t, protoErr)
+		assert.Equal(t, messageId, protoErr.MessageId)
+		assert.Equal(t, ocppj.MessageTypeNotSupported, protoErr.Code)
+		assert.Equal(t, fmt.Sprintf("Invalid message type ID %v", typeId), protoErr.Description)
+	}
+}
+
+func (suite *OcppJTestSuite) TestParseMessageCallResultError() {
+	t := suite.T()
+	messageId := "12345"
+	mockDescription := "somedescription"
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	json := fmt.Sprintf(`[%v,"%v","%v","%v",{"detail":"somedetail"}]`, ocppj.CALL_RESULT_ERROR, messageId, ocppj.GenericError, mockDescription)
+	parsedData, err := ocppj.ParseJsonMessage(json)
+	require.NoError(t, err)
+	message, err := suite.chargePoint.ParseMessage(parsedData, suite.chargePoint.RequestState)
+	require.NoError(t, err)
+	require.NotNil(t, message)
+	callResultError, ok := message.(*ocppj.CallResultError)
+	require.True(t, ok)
+	assert.Equal(t, ocppj.CALL_RESULT_ERROR, callResultError.GetMessageTypeId())
+	assert.Equal(t, messageId, callResultError.GetUniqueId())
+	assert.Equal(t, ocppj.GenericError, callResultError.ErrorCode)
+	assert.Equal(t, mockDescription, callResultError.ErrorDescription)
+	assert.Equal(t, map[string]interface{}{"detail": "somedetail"}, callResultError.ErrorDetails)
+}
+
+func (suite *OcppJTestSuite) TestParseMessageInvalidCallResultError() {
+	t := suite.T()
+	mockMessage := make([]interface{}, 3)
+	messageId := "12345"
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	mockMessage[0] = float64(ocppj.CALL_RESULT_ERROR) // Message Type ID
+	mockMessage[1] = messageId                        // Unique ID
+	mockMessage[2] = ocppj.GenericError
+	message, err := suite.chargePoint.ParseMessage(mockMessage, suite.chargePoint.RequestState)
+	require.Nil(t, message)
+	require.Error(t, err)
+	protoErr := err.(*ocpp.Error)
+	require.NotNil(t, protoErr)
+	assert.Equal(t, messageId, protoErr.MessageId)
+	assert.Equal(t, ocppj.FormationViolation, protoErr.Code)
+	assert.Equal(t, "Invalid Call Result Error message. Expected array length >= 4", protoErr.Description)
+}
+
+func (suite *OcppJTestSuite) TestParseMessageSend() {
+	t := suite.T()
+	messageId := "12345"
+	mockValue := "somevalue"
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	json := fmt.Sprintf(`[%v,"%v","%v",{"mockValue":"%v"}]`, ocppj.SEND, messageId, MockFeatureName, mockValue)
+	parsedData, err := ocppj.ParseJsonMessage(json)
+	require.NoError(t, err)
+	message, err := suite.chargePoint.ParseMessage(parsedData, suite.chargePoint.RequestState)
+	require.NoError(t, err)
+	require.NotNil(t, message)
+	send, ok := message.(*ocppj.Send)
+	require.True(t, ok)
+	assert.Equal(t, ocppj.SEND, send.GetMessageTypeId())
+	assert.Equal(t, messageId, send.GetUniqueId())
+	assert.Equal(t, MockFeatureName, send.Action)
+	request, ok := send.Payload.(*MockRequest)
+	require.True(t, ok)
+	assert.Equal(t, mockValue, request.MockValue)
+}
+
+func (suite *OcppJTestSuite) TestParseMessageInvalidSend() {
+	t := suite.T()
+	mockMessage := make([]interface{}, 3)
+	messageId := "12345"
+	suite.chargePoint.SetExtendedMessageTypesEnabled(true)
+	mockMessage[0] = float64(ocppj.SEND) // Message Type ID
+	mockMessage[1] = messageId           // Unique ID
+	mockMessage[2] = MockFeatureName
+	message, err := suite.chargePoint.ParseMessage(mockMessage, suite.chargePoint.RequestState)
+	require.Nil(t, message)
+	require.Error(t, err)
+	protoErr := err.(*ocpp.Error)
+	require.NotNil(t, protoErr)
+	assert.Equal(t, messageId, protoErr.MessageId)
+	assert.Equal(t, ocppj.FormationViolation, protoErr.Code)
+	assert.Equal(t, "Invalid Send message. Expected array length 4", protoErr.Description)
+}
+
 func (suite *OcppJTestSuite) TestParseMessageInvalidRequest() {
 	t := suite.T()
 	mockMessage := make([]interface{}, 4)
@@ -757,6 +911,7 @@ func (suite *OcppJTestSuite) TestLogger() {
 
 func TestMockOcppJ(t *testing.T) {
 	suite.Run(t, new(ClientQueueTestSuite))
+	suite.Run(t, new(FileClientQueueTestSuite))
 	suite.Run(t, new(ServerQueueMapTestSuite))
 	suite.Run(t, new(ClientStateTestSuite))
 	suite.Run(t, new(ServerStateTestSuite))
@@ -34,6 +34,14 @@ type RequestQueue interface {
 	IsEmpty() bool
 }
 
+// RemovableRequestQueue is an optional interface for request queues, allowing dispatchers to remove
+// a canceled request right away, instead of waiting for it to reach the front of the queue.
+type RemovableRequestQueue interface {
+	// Remove removes the RequestBundle with the given request ID from the queue, preserving the order of all other elements.
+	// Returns the removed element, or nil if no such request is queued.
+	Remove(requestID string) interface{}
+}
+
 // FIFOClientQueue is a default queue implementation. The queue is thread-safe.
 type FIFOClientQueue struct {
 	elements []interface{}
@@ -77,6 +85,18 @@ func (q *FIFOClientQueue) Pop() interface{} {
 	return result
 }
 
+func (q *FIFOClientQueue) Remove(requestID string) interface{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for i, el := range q.elements {
+		if bundle, ok := el.(RequestBundle); ok && bundle.Call.UniqueId == requestID {
+			q.elements = append(q.elements[:i:i], q.elements[i+1:]...)
+			return el
+		}
+	}
+	return nil
+}
+
 func (q *FIFOClientQueue) Size() int {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1,6 +1,8 @@
 package ocppj_test
 
 import (
+	"fmt"
+
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -119,6 +121,23 @@ func (suite *ClientQueueTestSuite) TestQueueClear() {
 	assert.Equal(t, 0, suite.queue.Size())
 }
 
+func (suite *ClientQueueTestSuite) TestRemoveElement() {
+	t := suite.T()
+	removable, ok := suite.queue.(ocppj.RemovableRequestQueue)
+	require.True(t, ok)
+	bundles := make([]ocppj.RequestBundle, 3)
+	for i := range bundles {
+		bundles[i] = ocppj.RequestBundle{Call: &ocppj.Call{UniqueId: fmt.Sprintf("id%v", i)}}
+		require.Nil(t, suite.queue.Push(bundles[i]))
+	}
+	assert.Nil(t, removable.Remove("unknown"))
+	assert.Equal(t, bundles[1], removable.Remove("id1"))
+	assert.Equal(t, 2, suite.queue.Size())
+	assert.Equal(t, bundles[0], suite.queue.Pop())
+	assert.Equal(t, bundles[2], suite.queue.Pop())
+	assert.True(t, suite.queue.IsEmpty())
+}
+
 type ServerQueueMapTestSuite struct {
 	suite.Suite
 	queueMap ocppj.ServerQueueMap
@@ -0,0 +1,81 @@
+package ocppj
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultRetryInitialInterval = 1 * time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMultiplier      = 2.0
+	defaultRetryJitter          = 0.2
+)
+
+// RetryPolicy defines how a dispatcher retransmits requests, which couldn't be written to the network.
+//
+// A failed request stays at the front of its queue while waiting to be retransmitted,
+// so the order of outgoing requests is preserved.
+// Once all attempts failed, the request is canceled as if no retry policy was set.
+type RetryPolicy struct {
+	MaxAttempts     int           // Maximum number of transmission attempts, including the first one.
+	InitialInterval time.Duration // Delay before the first retransmission.
+	MaxInterval     time.Duration // Upper bound for the delay between two attempts. Zero means no upper bound.
+	Multiplier      float64       // Factor by which the delay grows after every attempt.
+	Linear          bool          // If set, the delay is the initial interval multiplied by the number of failed attempts, and Multiplier is ignored.
+	Jitter          float64       // Randomization factor between 0 and 1, which is applied to every delay.
+	Features        []string      // Features whose requests may be retransmitted. If empty, all requests may be retransmitted.
+}
+
+// NewRetryPolicy creates a RetryPolicy with exponential backoff, which retransmits requests for the given features,
+// until maxAttempts transmission attempts have failed. If no features are passed, all requests are retransmitted.
+//
+// The delay starts at 1 second and doubles with every attempt, up to 30 seconds, and is randomized by 20%.
+// Fields may be changed after creation, e.g.:
+//	policy := ocppj.NewRetryPolicy(5, "StartTransaction", "StopTransaction")
+//	policy.InitialInterval = 500 * time.Millisecond
+func NewRetryPolicy(maxAttempts int, features ...string) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     maxAttempts,
+		InitialInterval: defaultRetryInitialInterval,
+		MaxInterval:     defaultRetryMaxInterval,
+		Multiplier:      defaultRetryMultiplier,
+		Jitter:          defaultRetryJitter,
+		Features:        features,
+	}
+}
+
+// ShouldRetry returns true, if a request for the given feature may be retransmitted,
+// after the given number of failed attempts.
+func (p *RetryPolicy) ShouldRetry(featureName string, attempts int) bool {
+	if p == nil || attempts >= p.MaxAttempts {
+		return false
+	}
+	if len(p.Features) == 0 {
+		return true
+	}
+	for _, feature := range p.Features {
+		if feature == featureName {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay returns the time to wait before retransmitting a request, after the given number of failed attempts.
+func (p *RetryPolicy) Delay(attempts int) time.Duration {
+	delay := float64(p.InitialInterval)
+	if p.Linear {
+		delay *= float64(attempts)
+	} else if attempts > 1 {
+		delay *= math.Pow(p.Multiplier, float64(attempts-1))
+	}
+	if p.MaxInterval > 0 && delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
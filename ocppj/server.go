@@ -1,8 +1,10 @@
 package ocppj
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp"
 	"github.com/lorenzodonini/ocpp-go/ws"
@@ -18,10 +20,14 @@ type Server struct {
 	requestHandler            RequestHandler
 	responseHandler           ResponseHandler
 	errorHandler              ErrorHandler
+	sendHandler               RequestHandler
+	resultErrorHandler        ErrorHandler
 	dispatcher                ServerDispatcher
 	RequestState              ServerState
 	waitGroup                 sync.WaitGroup
 	stopped                   chan struct{}
+	contextRequests           map[clientRequest]chan struct{}
+	contextMutex              sync.Mutex
 }
 
 type ClientHandler func(client ws.Channel)
@@ -78,6 +84,77 @@ func (s *Server) SetErrorHandler(handler ErrorHandler) {
 	s.errorHandler = handler
 }
 
+// Registers a handler for incoming unconfirmed requests (Send messages).
+// No response may be sent for these requests.
+//
+// Send messages are only accepted if extended message types are enabled on the endpoint.
+func (s *Server) SetSendHandler(handler RequestHandler) {
+	s.sendHandler = handler
+}
+
+// Registers a handler for incoming CallResultError messages,
+// which report that a client couldn't process a response previously sent by the server.
+// The message ID of the error identifies the response, i.e. the request it referred to.
+//
+// CallResultError messages are only accepted if extended message types are enabled on the endpoint.
+func (s *Server) SetCallResultErrorHandler(handler ErrorHandler) {
+	s.resultErrorHandler = handler
+}
+
+// Registers the handler to be called when a request to a client is canceled, e.g. on timeout.
+func (s *Server) SetOnRequestCanceled(handler func(clientID string, requestID string, action string, request ocpp.Request)) {
+	if handler == nil {
+		s.dispatcher.SetOnRequestCanceled(nil)
+		return
+	}
+	s.dispatcher.SetOnRequestCanceled(func(clientID string, requestID string, action string, request ocpp.Request) {
+		s.completeContextRequest(clientID, requestID)
+		handler(clientID, requestID, action, request)
+	})
+}
+
+// Sets the default timeout for responses to requests sent to clients, if supported by the dispatcher
+// (see ServerTimeoutDispatcher). A timeout of zero disables the timeout, which is the default.
+//
+// This function must be called before starting the server. An error is returned, if the dispatcher doesn't support timeouts.
+func (s *Server) SetRequestTimeout(timeout time.Duration) error {
+	d, ok := s.dispatcher.(ServerTimeoutDispatcher)
+	if !ok {
+		return fmt.Errorf("dispatcher of type %T doesn't support request timeouts", s.dispatcher)
+	}
+	d.SetTimeout(timeout)
+	return nil
+}
+
+// Sets the timeout for responses to requests of a specific feature, overriding the default request timeout for that feature only.
+//
+// This function must be called before starting the server. An error is returned, if the dispatcher doesn't support timeouts.
+func (s *Server) SetFeatureRequestTimeout(featureName string, timeout time.Duration) error {
+	d, ok := s.dispatcher.(ServerTimeoutDispatcher)
+	if !ok {
+		return fmt.Errorf("dispatcher of type %T doesn't support request timeouts", s.dispatcher)
+	}
+	d.SetFeatureTimeout(featureName, timeout)
+	return nil
+}
+
+// NewRequestTimeoutHandler creates a handler for requests canceled by the server dispatcher, to be passed to SetOnRequestCanceled.
+//
+// The callback awaiting the response of the canceled request is retrieved via dequeue and invoked with a GenericError.
+// This happens asynchronously, as the callback may send further requests to the dispatcher.
+// If no callback is available for the request, an error is passed to onError instead.
+func NewRequestTimeoutHandler(dequeue func(clientID string, requestID string) (func(response ocpp.Response, err error), bool), onError func(err error)) func(clientID string, requestID string, action string, request ocpp.Request) {
+	return func(clientID string, requestID string, action string, request ocpp.Request) {
+		go func() {
+			if callback, ok := dequeue(clientID, requestID); ok {
+				callback(nil, ocpp.NewError(GenericError, "request timed out, no response received from client", requestID))
+			} else {
+				onError(fmt.Errorf("no handler available for canceled request %s of type %v for client %s", requestID, action, clientID))
+			}
+		}()
+	}
+}
+
 // Registers a handler for incoming client connections.
 func (s *Server) SetNewClientHandler(handler ClientHandler) {
 	s.newClientHandler = handler
@@ -113,6 +190,12 @@ func (s *Server) Stop() {
 	s.waitGroup.Wait()
 	s.server.Stop()
 	s.dispatcher.Stop()
+	s.contextMutex.Lock()
+	for r, done := range s.contextRequests {
+		close(done)
+		delete(s.contextRequests, r)
+	}
+	s.contextMutex.Unlock()
 }
 
 // Sends an OCPP Request to a client, identified by the clientID parameter.
@@ -127,28 +210,82 @@ func (s *Server) Stop() {
 //
 // - the output queue is full
 func (s *Server) SendRequest(clientID string, request ocpp.Request) error {
+	_, err := s.sendRequest(clientID, request, nil)
+	return err
+}
+
+// Sends an OCPP Request to a client, like SendRequest.
+//
+// The context is monitored until a response to the request is received.
+// If the context is done before that and the dispatcher implements ServerCancelDispatcher, the request is canceled:
+// it is removed from the client's queue without being sent, or no response is awaited anymore if it was sent already.
+// The OnRequestCanceled handler is invoked for the canceled request.
+//
+// Returns the unique ID of the request, which is passed to the response, error and OnRequestCanceled handlers.
+// Returns ctx.Err() if the context is already done, or any of the errors returned by SendRequest.
+func (s *Server) SendRequestWithContext(ctx context.Context, clientID string, request ocpp.Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	var done chan struct{}
+	if ctx.Done() != nil {
+		done = make(chan struct{})
+	}
+	call, err := s.sendRequest(clientID, request, done)
+	if err != nil {
+		return "", err
+	}
+	if done == nil {
+		// Context can never be canceled
+		return call.UniqueId, nil
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Debugf("context done for request %v - %v for client %v: %v", call.UniqueId, call.Action, clientID, ctx.Err())
+			if d, ok := s.dispatcher.(ServerCancelDispatcher); ok {
+				d.CancelRequest(clientID, call.UniqueId)
+			}
+			s.completeContextRequest(clientID, call.UniqueId)
+		case <-done:
+		}
+	}()
+	return call.UniqueId, nil
+}
+
+// Validates and enqueues a request for a client. If a done channel is passed, it gets closed once the request completes.
+func (s *Server) sendRequest(clientID string, request ocpp.Request, done chan struct{}) (*Call, error) {
 	if !s.dispatcher.IsRunning() {
-		return fmt.Errorf("ocppj server is not started, couldn't send request")
+		return nil, fmt.Errorf("ocppj server is not started, couldn't send request")
 	}
 	err := Validate.Struct(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	call, err := s.CreateCall(request.(ocpp.Request))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	jsonMessage, err := call.MarshalJSON()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if done != nil {
+		s.contextMutex.Lock()
+		if s.contextRequests == nil {
+			s.contextRequests = map[clientRequest]chan struct{}{}
+		}
+		s.contextRequests[clientRequest{clientID: clientID, requestID: call.UniqueId}] = done
+		s.contextMutex.Unlock()
 	}
 	// Will not send right away. Queuing message and let it be processed by dedicated requestPump routine
 	if err := s.dispatcher.SendRequest(clientID, RequestBundle{call, jsonMessage}); err != nil {
 		log.Errorf("request %v - %v for client %v: %v", call.UniqueId, call.Action, clientID, err)
-		return err
+		s.completeContextRequest(clientID, call.UniqueId)
+		return nil, err
 	}
 	log.Debugf("enqueued request %v - %v for client %v", call.UniqueId, call.Action, clientID)
-	return nil
+	return call, nil
 }
 
 // Sends an OCPP Response to a client, identified by the clientID parameter.
@@ -198,6 +335,66 @@ func (s *Server) SendError(clientID string, requestId string, errorCode ocpp.Err
 	return s.server.Write(clientID, []byte(jsonMessage))
 }
 
+// Sends an unconfirmed OCPP Request to a client, identified by the clientID parameter, using a Send message.
+// The client will not reply to the request, hence the message is written directly, bypassing the request queue.
+//
+// Returns an error in the following cases:
+//
+// - the server wasn't started
+//
+// - extended message types are not enabled on the endpoint
+//
+// - message validation fails (request is malformed)
+//
+// - the endpoint doesn't support the feature
+//
+// - a network error occurred
+func (s *Server) SendUnconfirmedRequest(clientID string, request ocpp.Request) error {
+	if !s.dispatcher.IsRunning() {
+		return fmt.Errorf("ocppj server is not started, couldn't send request")
+	}
+	err := Validate.Struct(request)
+	if err != nil {
+		return err
+	}
+	send, err := s.CreateSend(request)
+	if err != nil {
+		return err
+	}
+	jsonMessage, err := send.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return s.server.Write(clientID, jsonMessage)
+}
+
+// Sends a CallResultError to a client, identified by the clientID parameter,
+// reporting that a response received from the client couldn't be processed.
+// The requestID parameter is required and identifies the request, to which the response referred.
+//
+// Returns an error in the following cases:
+//
+// - extended message types are not enabled on the endpoint
+//
+// - message validation fails (error is malformed)
+//
+// - a network error occurred
+func (s *Server) SendCallResultError(clientID string, requestId string, errorCode ocpp.ErrorCode, description string, details interface{}) error {
+	callResultError, err := s.CreateCallResultError(requestId, errorCode, description, details)
+	if err != nil {
+		return err
+	}
+	err = Validate.Struct(callResultError)
+	if err != nil {
+		return err
+	}
+	jsonMessage, err := callResultError.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return s.server.Write(clientID, jsonMessage)
+}
+
 func (s *Server) ocppMessageHandler(wsChannel ws.Channel, data []byte) error {
 	parsedJson, err := ParseRawJsonMessage(data)
 	if err != nil {
@@ -210,7 +407,13 @@ func (s *Server) ocppMessageHandler(wsChannel ws.Channel, data []byte) error {
 	if err != nil {
 		ocppErr := err.(*ocpp.Error)
 		if ocppErr.MessageId != "" {
-			err2 := s.SendError(wsChannel.ID(), ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			var err2 error
+			switch s.replyTypeForInvalidMessage(parsedJson) {
+			case CALL_ERROR:
+				err2 = s.SendError(wsChannel.ID(), ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			case CALL_RESULT_ERROR:
+				err2 = s.SendCallResultError(wsChannel.ID(), ocppErr.MessageId, ocppErr.Code, ocppErr.Description, nil)
+			}
 			if err2 != nil {
 				return err2
 			}
@@ -226,20 +429,47 @@ func (s *Server) ocppMessageHandler(wsChannel ws.Channel, data []byte) error {
 		case CALL_RESULT:
 			callResult := message.(*CallResult)
 			s.dispatcher.CompleteRequest(wsChannel.ID(), callResult.GetUniqueId())
+			s.completeContextRequest(wsChannel.ID(), callResult.UniqueId)
 			if s.responseHandler != nil {
 				s.responseHandler(wsChannel, callResult.Payload, callResult.UniqueId)
 			}
 		case CALL_ERROR:
 			callError := message.(*CallError)
 			s.dispatcher.CompleteRequest(wsChannel.ID(), callError.GetUniqueId())
+			s.completeContextRequest(wsChannel.ID(), callError.UniqueId)
 			if s.errorHandler != nil {
 				s.errorHandler(wsChannel, ocpp.NewError(callError.ErrorCode, callError.ErrorDescription, callError.UniqueId), callError.ErrorDetails)
 			}
+		case CALL_RESULT_ERROR:
+			callResultError := message.(*CallResultError)
+			if s.resultErrorHandler != nil {
+				s.resultErrorHandler(wsChannel, ocpp.NewError(callResultError.ErrorCode, callResultError.ErrorDescription, callResultError.UniqueId), callResultError.ErrorDetails)
+			} else {
+				log.Errorf("client %v couldn't process response to request %v: %v %v", wsChannel.ID(), callResultError.UniqueId, callResultError.ErrorCode, callResultError.ErrorDescription)
+			}
+		case SEND:
+			send := message.(*Send)
+			if s.sendHandler != nil {
+				s.sendHandler(wsChannel, send.Payload, send.UniqueId, send.Action)
+			} else {
+				log.Infof("no handler for unconfirmed request %v - %v from client %v, discarding it", send.UniqueId, send.Action, wsChannel.ID())
+			}
 		}
 	}
 	return nil
 }
 
+// Stops monitoring the context of a request, if the request was sent with a context.
+func (s *Server) completeContextRequest(clientID string, requestID string) {
+	s.contextMutex.Lock()
+	defer s.contextMutex.Unlock()
+	r := clientRequest{clientID: clientID, requestID: requestID}
+	if done, ok := s.contextRequests[r]; ok {
+		close(done)
+		delete(s.contextRequests, r)
+	}
+}
+
 func (s *Server) onClientConnected(ws ws.Channel) {
 	s.waitGroup.Add(1)
 	defer s.waitGroup.Done()
@@ -0,0 +1,116 @@
+package ocpps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+// The timeout of the HTTP client used by default for sending requests, including reading the response.
+const DefaultClientTimeout = 30 * time.Second
+
+// The endpoint sending requests to an OCPP-S service via HTTP.
+//
+// Requests are sent synchronously, i.e. the response is read from the same HTTP exchange.
+type Client struct {
+	Endpoint
+	namespace   string
+	httpClient  *http.Client
+	fromAddress string
+}
+
+// Creates a new Client endpoint, sending requests to the remote SOAP service identified by namespace.
+// If no HTTP client is passed, a client with a DefaultClientTimeout timeout is used.
+//
+// To send requests from a 1.6 central system to charge points, you may use:
+//	c := ocpps.NewClient(ocpps.ChargePointNamespace, nil, core.Profile)
+func NewClient(namespace string, httpClient *http.Client, profiles ...*ocpp.Profile) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultClientTimeout}
+	}
+	c := Client{namespace: namespace, httpClient: httpClient}
+	for _, profile := range profiles {
+		c.AddProfile(profile)
+	}
+	return &c
+}
+
+// Sets the address sent within the WS-Addressing From header of every request.
+// This should be the URL, at which the local SOAP service is reachable.
+func (c *Client) SetFromAddress(address string) {
+	c.fromAddress = address
+}
+
+// Sends a request to the SOAP service at the given URL and waits for the response.
+//
+// If the remote endpoint replies with a SOAP fault, an *ocpp.Error is returned, containing the fault subcode and reason.
+func (c *Client) SendRequest(url string, chargeBoxIdentity string, request ocpp.Request) (ocpp.Response, error) {
+	return c.SendRequestWithContext(context.Background(), url, chargeBoxIdentity, request)
+}
+
+// Sends a request to the SOAP service at the given URL and waits for the response, like SendRequest.
+//
+// The HTTP exchange is aborted as soon as the context is done, in which case the context error is returned.
+func (c *Client) SendRequestWithContext(ctx context.Context, url string, chargeBoxIdentity string, request ocpp.Request) (ocpp.Response, error) {
+	featureName := request.GetFeatureName()
+	if _, found := c.GetProfileForFeature(featureName); !found {
+		return nil, fmt.Errorf("couldn't create request for unsupported action %v", featureName)
+	}
+	if err := ocppj.Validate.Struct(request); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, faultFromValidation(validationErrors, featureName)
+		}
+		return nil, err
+	}
+	header := Header{
+		ChargeBoxIdentity: chargeBoxIdentity,
+		Action:            "/" + featureName,
+		MessageID:         messageIdGenerator(),
+		From:              c.fromAddress,
+		ReplyTo:           AnonymousAddress,
+		To:                url,
+	}
+	data, err := MarshalRequest(c.namespace, header, request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", ContentType)
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	data, err = ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	responseHeader, response, err := c.ParseResponse(data, featureName)
+	if err != nil {
+		if fault, ok := err.(*Fault); ok {
+			return nil, ocpp.NewError(fault.Subcode, fault.Reason, header.MessageID)
+		}
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status %v for request %v", httpResponse.StatusCode, header.MessageID)
+	}
+	if responseHeader.RelatesTo != "" && responseHeader.RelatesTo != header.MessageID {
+		return nil, fmt.Errorf("response relates to %v, expected %v", responseHeader.RelatesTo, header.MessageID)
+	}
+	return response, nil
+}
@@ -0,0 +1,628 @@
+// Contains an implementation of OCPP message dispatcher via SOAP over HTTP (OCPP-S).
+//
+// Messages are exchanged as SOAP 1.2 envelopes. The sender of a message is identified via the
+// chargeBoxIdentity header, while routing information is carried via WS-Addressing headers (Action, MessageID, From, ReplyTo, RelatesTo, To).
+//
+// The payload of a message is derived from the same structs used by OCPP-J: each field is encoded as a child element,
+// named after the field's JSON name. All payloads are validated via the ocppj validator.
+package ocpps
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+const (
+	SoapEnvelopeNamespace  = "http://www.w3.org/2003/05/soap-envelope"
+	AddressingNamespace    = "http://www.w3.org/2005/08/addressing"
+	AnonymousAddress       = "http://www.w3.org/2005/08/addressing/anonymous"
+	FaultAction            = "http://www.w3.org/2005/08/addressing/soap/fault"
+	CentralSystemNamespace = "urn://Ocpp/Cs/2015/10/" // Namespace of the OCPP 1.6 central system service.
+	ChargePointNamespace   = "urn://Ocpp/Cp/2015/10/" // Namespace of the OCPP 1.6 charge point service.
+	ContentType            = "application/soap+xml; charset=utf-8"
+)
+
+var messageIdGenerator = func() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// SetMessageIdGenerator sets a lambda function for generating the WS-Addressing MessageID of new messages.
+//
+// Settings this overrides the default behavior, which generates a random URN UUID.
+func SetMessageIdGenerator(generator func() string) {
+	if generator != nil {
+		messageIdGenerator = generator
+	}
+}
+
+// -------------------- Header --------------------
+
+// Header contains the SOAP header fields of an OCPP-S message.
+type Header struct {
+	ChargeBoxIdentity string
+	Action            string
+	MessageID         string
+	RelatesTo         string
+	From              string
+	ReplyTo           string
+	To                string
+}
+
+// Returns the name of the feature, which the message refers to.
+// The name is derived from the Action header, e.g. "/BootNotification" or "/BootNotificationResponse".
+func (h *Header) FeatureName() string {
+	return strings.TrimSuffix(strings.TrimPrefix(h.Action, "/"), "Response")
+}
+
+// -------------------- Fault --------------------
+
+// FaultCode is the top-level code of a SOAP 1.2 fault.
+type FaultCode string
+
+const (
+	Sender   FaultCode = "Sender"   // The message was incorrectly formed or contained incorrect information.
+	Receiver FaultCode = "Receiver" // The message could not be processed for reasons not directly attributable to its contents.
+)
+
+// Fault is a SOAP fault, returned in place of a response, whenever a request couldn't be processed.
+// The Subcode carries the OCPP error code.
+type Fault struct {
+	Code    FaultCode
+	Subcode ocpp.ErrorCode
+	Reason  string
+}
+
+// Creates a new SOAP fault.
+func NewFault(code FaultCode, subcode ocpp.ErrorCode, reason string) *Fault {
+	return &Fault{Code: code, Subcode: subcode, Reason: reason}
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("soap fault (%v): %v - %v", f.Code, f.Subcode, f.Reason)
+}
+
+func faultFromValidation(validationErrors validator.ValidationErrors, feature string) *Fault {
+	for _, el := range validationErrors {
+		if el.ActualTag() == "required" {
+			return NewFault(Sender, ocppj.OccurrenceConstraintViolation, fmt.Sprintf("Field %s required but not found for feature %s", el.Namespace(), feature))
+		}
+		return NewFault(Sender, ocppj.PropertyConstraintViolation, fmt.Sprintf("Field %s violates '%s' constraint for feature %s", el.Namespace(), el.ActualTag(), feature))
+	}
+	return NewFault(Sender, ocppj.GenericError, validationErrors.Error())
+}
+
+// -------------------- Endpoint --------------------
+
+// An OCPP-S endpoint, holding the profiles supported by the SOAP service.
+type Endpoint struct {
+	Profiles []*ocpp.Profile
+}
+
+// Adds support for a new profile on the endpoint.
+func (endpoint *Endpoint) AddProfile(profile *ocpp.Profile) {
+	endpoint.Profiles = append(endpoint.Profiles, profile)
+}
+
+// Retrieves a profile for the given profile name.
+// Returns a false flag in case no profile matching the specified name was found.
+func (endpoint *Endpoint) GetProfile(name string) (*ocpp.Profile, bool) {
+	for _, p := range endpoint.Profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Retrieves a profile for a given feature.
+// Returns a false flag in case no profile supporting the specified feature was found.
+func (endpoint *Endpoint) GetProfileForFeature(featureName string) (*ocpp.Profile, bool) {
+	for _, p := range endpoint.Profiles {
+		if p.SupportsFeature(featureName) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Parses a SOAP envelope containing an OCPP request.
+// The request type is inferred from the Action header and the supported profiles.
+//
+// If the request cannot be parsed or is invalid, a *Fault is returned, which may be sent back to the sender.
+func (endpoint *Endpoint) ParseRequest(data []byte) (*Header, ocpp.Request, error) {
+	header, body, err := parseEnvelope(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body.isFault() {
+		return header, nil, NewFault(Sender, ocppj.FormationViolation, "expected request, received fault")
+	}
+	action := header.FeatureName()
+	profile, ok := endpoint.GetProfileForFeature(action)
+	if !ok {
+		return header, nil, NewFault(Sender, ocppj.NotImplemented, fmt.Sprintf("unsupported action %v", action))
+	}
+	if body.name.Local != requestElementName(action) {
+		return header, nil, NewFault(Sender, ocppj.FormationViolation, fmt.Sprintf("unexpected element %v for action %v", body.name.Local, action))
+	}
+	requestType := profile.GetFeature(action).GetRequestType()
+	payload, err := decodePayload(body, requestType, action)
+	if err != nil {
+		return header, nil, err
+	}
+	return header, payload.(ocpp.Request), nil
+}
+
+// Parses a SOAP envelope containing a response to a request of the given feature.
+//
+// If the envelope contains a SOAP fault, the fault is returned as a *Fault error.
+// Any other error indicates an invalid response.
+func (endpoint *Endpoint) ParseResponse(data []byte, featureName string) (*Header, ocpp.Response, error) {
+	header, body, err := parseEnvelope(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if body.isFault() {
+		return header, nil, parseFault(body)
+	}
+	profile, ok := endpoint.GetProfileForFeature(featureName)
+	if !ok {
+		return header, nil, fmt.Errorf("unsupported action %v", featureName)
+	}
+	if body.name.Local != responseElementName(featureName) {
+		return header, nil, fmt.Errorf("unexpected element %v for action %v", body.name.Local, featureName)
+	}
+	responseType := profile.GetFeature(featureName).GetResponseType()
+	payload, err := decodePayload(body, responseType, featureName)
+	if err != nil {
+		return header, nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return header, payload.(ocpp.Response), nil
+}
+
+// -------------------- Marshaling --------------------
+
+// Creates a SOAP envelope for an OCPP request. The payload is qualified with the passed service namespace.
+func MarshalRequest(namespace string, header Header, request ocpp.Request) ([]byte, error) {
+	return marshalEnvelope(namespace, header, func(buf *bytes.Buffer) error {
+		return writeElement(buf, requestElementName(request.GetFeatureName()), namespace, reflect.ValueOf(request))
+	})
+}
+
+// Creates a SOAP envelope for an OCPP response. The payload is qualified with the passed service namespace.
+func MarshalResponse(namespace string, header Header, response ocpp.Response) ([]byte, error) {
+	return marshalEnvelope(namespace, header, func(buf *bytes.Buffer) error {
+		return writeElement(buf, responseElementName(response.GetFeatureName()), namespace, reflect.ValueOf(response))
+	})
+}
+
+// Creates a SOAP envelope for a fault. The fault subcode is qualified with the passed service namespace.
+func MarshalFault(namespace string, header Header, fault *Fault) ([]byte, error) {
+	return marshalEnvelope(namespace, header, func(buf *bytes.Buffer) error {
+		buf.WriteString("<s:Fault><s:Code><s:Value>s:")
+		buf.WriteString(string(fault.Code))
+		buf.WriteString("</s:Value><s:Subcode><s:Value>ocpp:")
+		buf.WriteString(string(fault.Subcode))
+		buf.WriteString("</s:Value></s:Subcode></s:Code><s:Reason><s:Text xml:lang=\"en\">")
+		_ = xml.EscapeText(buf, []byte(fault.Reason))
+		buf.WriteString("</s:Text></s:Reason></s:Fault>")
+		return nil
+	})
+}
+
+func marshalEnvelope(namespace string, header Header, writeBody func(buf *bytes.Buffer) error) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(buf, `<s:Envelope xmlns:s="%s" xmlns:a="%s" xmlns:ocpp="%s">`, SoapEnvelopeNamespace, AddressingNamespace, namespace)
+	buf.WriteString("<s:Header>")
+	writeHeaderField(buf, "ocpp:chargeBoxIdentity", header.ChargeBoxIdentity)
+	writeHeaderField(buf, "a:Action", header.Action)
+	writeHeaderField(buf, "a:MessageID", header.MessageID)
+	writeHeaderField(buf, "a:RelatesTo", header.RelatesTo)
+	writeAddressField(buf, "a:From", header.From)
+	writeAddressField(buf, "a:ReplyTo", header.ReplyTo)
+	writeHeaderField(buf, "a:To", header.To)
+	buf.WriteString("</s:Header><s:Body>")
+	if err := writeBody(buf); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</s:Body></s:Envelope>")
+	return buf.Bytes(), nil
+}
+
+func writeHeaderField(buf *bytes.Buffer, name string, value string) {
+	if value == "" {
+		return
+	}
+	buf.WriteString("<" + name + ">")
+	_ = xml.EscapeText(buf, []byte(value))
+	buf.WriteString("</" + name + ">")
+}
+
+func writeAddressField(buf *bytes.Buffer, name string, address string) {
+	if address == "" {
+		return
+	}
+	buf.WriteString("<" + name + ">")
+	writeHeaderField(buf, "a:Address", address)
+	buf.WriteString("</" + name + ">")
+}
+
+func requestElementName(featureName string) string {
+	return lowerFirst(featureName) + "Request"
+}
+
+func responseElementName(featureName string) string {
+	return lowerFirst(featureName) + "Response"
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// Returns the name of the element for a struct field, which matches the JSON name of the field.
+func fieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	if field.PkgPath != "" {
+		return "", false, true
+	}
+	tag := strings.Split(field.Tag.Get("json"), ",")
+	if tag[0] == "-" {
+		return "", false, true
+	}
+	name = tag[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range tag[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Encodes a value as an XML element. Slices are encoded as a sequence of elements with the same name.
+// Types implementing json.Marshaler are encoded as text, using their JSON representation.
+func writeElement(buf *bytes.Buffer, name string, namespace string, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			if err := writeElement(buf, name, namespace, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var text string
+	var marshaler json.Marshaler
+	if v.Type().Implements(jsonMarshalerType) {
+		marshaler = v.Interface().(json.Marshaler)
+	} else if reflect.PtrTo(v.Type()).Implements(jsonMarshalerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		marshaler = p.Interface().(json.Marshaler)
+	}
+	switch {
+	case marshaler != nil:
+		data, err := marshaler.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if string(data) == "null" {
+			return nil
+		}
+		if err = json.Unmarshal(data, &text); err != nil {
+			text = string(data)
+		}
+	case v.Kind() == reflect.Struct:
+		writeStartTag(buf, name, namespace)
+		for i := 0; i < v.NumField(); i++ {
+			elementName, omitEmpty, skip := fieldName(v.Type().Field(i))
+			if skip || (omitEmpty && isEmptyValue(v.Field(i))) {
+				continue
+			}
+			if err := writeElement(buf, elementName, "", v.Field(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</" + name + ">")
+		return nil
+	case v.Kind() == reflect.String:
+		text = v.String()
+	case v.Kind() == reflect.Bool:
+		text = strconv.FormatBool(v.Bool())
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		text = strconv.FormatInt(v.Int(), 10)
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64:
+		text = strconv.FormatUint(v.Uint(), 10)
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		text = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Errorf("unsupported type %v for element %v", v.Type(), name)
+	}
+	writeStartTag(buf, name, namespace)
+	_ = xml.EscapeText(buf, []byte(text))
+	buf.WriteString("</" + name + ">")
+	return nil
+}
+
+func writeStartTag(buf *bytes.Buffer, name string, namespace string) {
+	if namespace != "" {
+		fmt.Fprintf(buf, `<%s xmlns="%s">`, name, namespace)
+	} else {
+		buf.WriteString("<" + name + ">")
+	}
+}
+
+// -------------------- Unmarshaling --------------------
+
+// A generic XML element, used as intermediate representation while decoding a message.
+type element struct {
+	name     xml.Name
+	text     string
+	children []*element
+}
+
+func (e *element) child(name string) *element {
+	for _, c := range e.children {
+		if c.name.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (e *element) childrenNamed(name string) []*element {
+	var result []*element
+	for _, c := range e.children {
+		if c.name.Local == name {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (e *element) childText(name string) string {
+	if c := e.child(name); c != nil {
+		return strings.TrimSpace(c.text)
+	}
+	return ""
+}
+
+func (e *element) isFault() bool {
+	return e.name.Space == SoapEnvelopeNamespace && e.name.Local == "Fault"
+}
+
+func parseDocument(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root *element
+	var stack []*element
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{name: t.Name}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root == nil {
+				root = e
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty document")
+	}
+	return root, nil
+}
+
+// Parses a SOAP envelope, returning its header and the first element contained in the body.
+func parseEnvelope(data []byte) (*Header, *element, error) {
+	root, err := parseDocument(data)
+	if err != nil {
+		return nil, nil, NewFault(Sender, ocppj.FormationViolation, err.Error())
+	}
+	if root.name.Space != SoapEnvelopeNamespace || root.name.Local != "Envelope" {
+		return nil, nil, NewFault(Sender, ocppj.FormationViolation, fmt.Sprintf("invalid root element %v", root.name.Local))
+	}
+	header := &Header{}
+	if h := root.child("Header"); h != nil {
+		header.ChargeBoxIdentity = h.childText("chargeBoxIdentity")
+		header.Action = h.childText("Action")
+		header.MessageID = h.childText("MessageID")
+		header.RelatesTo = h.childText("RelatesTo")
+		header.To = h.childText("To")
+		if from := h.child("From"); from != nil {
+			header.From = from.childText("Address")
+		}
+		if replyTo := h.child("ReplyTo"); replyTo != nil {
+			header.ReplyTo = replyTo.childText("Address")
+		}
+	}
+	body := root.child("Body")
+	if body == nil || len(body.children) == 0 {
+		return header, nil, NewFault(Sender, ocppj.FormationViolation, "missing body")
+	}
+	return header, body.children[0], nil
+}
+
+func parseFault(e *element) *Fault {
+	fault := &Fault{}
+	if code := e.child("Code"); code != nil {
+		fault.Code = FaultCode(stripPrefix(code.childText("Value")))
+		if subcode := code.child("Subcode"); subcode != nil {
+			fault.Subcode = ocpp.ErrorCode(stripPrefix(subcode.childText("Value")))
+		}
+	}
+	if reason := e.child("Reason"); reason != nil {
+		fault.Reason = reason.childText("Text")
+	}
+	return fault
+}
+
+func stripPrefix(qualifiedName string) string {
+	if i := strings.LastIndex(qualifiedName, ":"); i >= 0 {
+		return qualifiedName[i+1:]
+	}
+	return qualifiedName
+}
+
+// Decodes a payload element into a new instance of the passed type, then validates it.
+// The element is first converted to a generic JSON representation, so that custom JSON unmarshalers are honored.
+func decodePayload(e *element, payloadType reflect.Type, feature string) (interface{}, error) {
+	raw, err := elementToValue(e, payloadType)
+	if err != nil {
+		return nil, NewFault(Sender, ocppj.TypeConstraintViolation, err.Error())
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, NewFault(Sender, ocppj.FormationViolation, err.Error())
+	}
+	payload := reflect.New(payloadType).Interface()
+	if err = json.Unmarshal(data, payload); err != nil {
+		return nil, NewFault(Sender, ocppj.FormationViolation, err.Error())
+	}
+	if err = ocppj.Validate.Struct(payload); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, faultFromValidation(validationErrors, feature)
+		}
+		return nil, NewFault(Sender, ocppj.GenericError, err.Error())
+	}
+	return payload, nil
+}
+
+// Converts an element to a value, which can be marshaled to JSON and then unmarshaled into the passed type.
+func elementToValue(e *element, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return strings.TrimSpace(e.text), nil
+	}
+	text := strings.TrimSpace(e.text)
+	switch t.Kind() {
+	case reflect.Struct:
+		result := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, skip := fieldName(field)
+			if skip {
+				continue
+			}
+			children := e.childrenNamed(name)
+			if len(children) == 0 {
+				continue
+			}
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+				values := make([]interface{}, len(children))
+				for j, c := range children {
+					value, err := elementToValue(c, fieldType.Elem())
+					if err != nil {
+						return nil, err
+					}
+					values[j] = value
+				}
+				result[name] = values
+			} else {
+				value, err := elementToValue(children[0], fieldType)
+				if err != nil {
+					return nil, err
+				}
+				result[name] = value
+			}
+		}
+		return result, nil
+	case reflect.String:
+		return e.text, nil
+	case reflect.Interface:
+		return e.text, nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value %v for element %v", text, e.name.Local)
+		}
+		return value, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value %v for element %v", text, e.name.Local)
+		}
+		return value, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned integer value %v for element %v", text, e.name.Local)
+		}
+		return value, nil
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal value %v for element %v", text, e.name.Local)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v for element %v", t, e.name.Local)
+}
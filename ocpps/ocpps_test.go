@@ -0,0 +1,234 @@
+package ocpps_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ocpps"
+)
+
+type OcppSTestSuite struct {
+	suite.Suite
+	server     *ocpps.Server
+	client     *ocpps.Client
+	httpServer *httptest.Server
+}
+
+func (suite *OcppSTestSuite) SetupTest() {
+	suite.server = ocpps.NewServer(ocpps.CentralSystemNamespace, core.Profile)
+	suite.client = ocpps.NewClient(ocpps.CentralSystemNamespace, nil, core.Profile)
+	suite.httpServer = httptest.NewServer(suite.server)
+}
+
+func (suite *OcppSTestSuite) TearDownTest() {
+	suite.httpServer.Close()
+}
+
+func (suite *OcppSTestSuite) TestMarshalRequest() {
+	t := suite.T()
+	header := ocpps.Header{ChargeBoxIdentity: "cp1", Action: "/BootNotification", MessageID: "urn:uuid:1234", From: "http://cp1:8080/", ReplyTo: ocpps.AnonymousAddress}
+	request := core.NewBootNotificationRequest("model1", "vendor & co")
+	data, err := ocpps.MarshalRequest(ocpps.CentralSystemNamespace, header, request)
+	require.NoError(t, err)
+	xml := string(data)
+	assert.Contains(t, xml, "<ocpp:chargeBoxIdentity>cp1</ocpp:chargeBoxIdentity>")
+	assert.Contains(t, xml, "<a:Action>/BootNotification</a:Action>")
+	assert.Contains(t, xml, "<a:From><a:Address>http://cp1:8080/</a:Address></a:From>")
+	assert.Contains(t, xml, `<bootNotificationRequest xmlns="urn://Ocpp/Cs/2015/10/"><chargePointModel>model1</chargePointModel><chargePointVendor>vendor &amp; co</chargePointVendor></bootNotificationRequest>`)
+	// Parse envelope back
+	parsedHeader, parsedRequest, err := suite.server.ParseRequest(data)
+	require.NoError(t, err)
+	assert.Equal(t, header, *parsedHeader)
+	assert.Equal(t, request, parsedRequest)
+}
+
+func (suite *OcppSTestSuite) TestParseRequestWithNestedElements() {
+	t := suite.T()
+	timestamp := types.NewDateTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	transactionId := 42
+	request := core.NewMeterValuesRequest(1, []types.MeterValue{
+		{Timestamp: timestamp, SampledValue: []types.SampledValue{{Value: "100", Unit: types.UnitOfMeasureWh}, {Value: "200"}}},
+		{Timestamp: timestamp, SampledValue: []types.SampledValue{{Value: "300", Measurand: types.MeasurandEnergyActiveImportRegister}}},
+	})
+	request.TransactionId = &transactionId
+	data, err := ocpps.MarshalRequest(ocpps.CentralSystemNamespace, ocpps.Header{ChargeBoxIdentity: "cp1", Action: "/MeterValues"}, request)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<timestamp>2020-01-02T03:04:05Z</timestamp>")
+	_, parsedRequest, err := suite.server.ParseRequest(data)
+	require.NoError(t, err)
+	require.IsType(t, &core.MeterValuesRequest{}, parsedRequest)
+	meterValues := parsedRequest.(*core.MeterValuesRequest)
+	assert.Equal(t, 1, meterValues.ConnectorId)
+	require.NotNil(t, meterValues.TransactionId)
+	assert.Equal(t, transactionId, *meterValues.TransactionId)
+	require.Len(t, meterValues.MeterValue, 2)
+	assert.Equal(t, timestamp.FormatTimestamp(), meterValues.MeterValue[0].Timestamp.FormatTimestamp())
+	assert.Equal(t, request.MeterValue[0].SampledValue, meterValues.MeterValue[0].SampledValue)
+	assert.Equal(t, request.MeterValue[1].SampledValue, meterValues.MeterValue[1].SampledValue)
+}
+
+func (suite *OcppSTestSuite) TestParseInvalidRequest() {
+	t := suite.T()
+	envelope := func(action string, body string) []byte {
+		return []byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:a="http://www.w3.org/2005/08/addressing" xmlns:cs="urn://Ocpp/Cs/2015/10/">` +
+			`<s:Header><cs:chargeBoxIdentity>cp1</cs:chargeBoxIdentity><a:Action>` + action + `</a:Action></s:Header>` +
+			`<s:Body>` + body + `</s:Body></s:Envelope>`)
+	}
+	var testTable = []struct {
+		data         []byte
+		expectedCode ocpp.ErrorCode
+	}{
+		{envelope("/BootNotification", `<cs:bootNotificationRequest><cs:chargePointModel>model1</cs:chargePointModel></cs:bootNotificationRequest>`), ocppj.OccurrenceConstraintViolation},
+		{envelope("/Heartbeat", `<cs:bootNotificationRequest></cs:bootNotificationRequest>`), ocppj.FormationViolation},
+		{envelope("/UnknownAction", `<cs:unknownActionRequest></cs:unknownActionRequest>`), ocppj.NotImplemented},
+		{envelope("/StatusNotification", `<cs:statusNotificationRequest><cs:connectorId>abc</cs:connectorId></cs:statusNotificationRequest>`), ocppj.TypeConstraintViolation},
+		{envelope("/StatusNotification", `<cs:statusNotificationRequest><cs:connectorId>1</cs:connectorId><cs:errorCode>NoError</cs:errorCode><cs:status>Invalid</cs:status></cs:statusNotificationRequest>`), ocppj.PropertyConstraintViolation},
+		{[]byte(`<notAnEnvelope/>`), ocppj.FormationViolation},
+		{[]byte(`<s:Envelope`), ocppj.FormationViolation},
+	}
+	for _, testCase := range testTable {
+		_, _, err := suite.server.ParseRequest(testCase.data)
+		require.Error(t, err)
+		require.IsType(t, &ocpps.Fault{}, err)
+		fault := err.(*ocpps.Fault)
+		assert.Equal(t, ocpps.Sender, fault.Code)
+		assert.Equal(t, testCase.expectedCode, fault.Subcode, fault.Reason)
+	}
+}
+
+func (suite *OcppSTestSuite) TestSendRequest() {
+	t := suite.T()
+	currentTime := types.NewDateTime(time.Now().UTC().Truncate(time.Second))
+	suite.client.SetFromAddress("http://cp1:8080/")
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		assert.Equal(t, "cp1", header.ChargeBoxIdentity)
+		assert.Equal(t, core.BootNotificationFeatureName, action)
+		require.IsType(t, &core.BootNotificationRequest{}, request)
+		assert.Equal(t, "model1", request.(*core.BootNotificationRequest).ChargePointModel)
+		return core.NewBootNotificationConfirmation(currentTime, 60, core.RegistrationStatusAccepted), nil
+	})
+	_, ok := suite.server.GetClientAddress("cp1")
+	assert.False(t, ok)
+	response, err := suite.client.SendRequest(suite.httpServer.URL, "cp1", core.NewBootNotificationRequest("model1", "vendor1"))
+	require.NoError(t, err)
+	require.IsType(t, &core.BootNotificationConfirmation{}, response)
+	confirmation := response.(*core.BootNotificationConfirmation)
+	assert.Equal(t, core.RegistrationStatusAccepted, confirmation.Status)
+	assert.Equal(t, 60, confirmation.Interval)
+	assert.Equal(t, currentTime.FormatTimestamp(), confirmation.CurrentTime.FormatTimestamp())
+	address, ok := suite.server.GetClientAddress("cp1")
+	assert.True(t, ok)
+	assert.Equal(t, "http://cp1:8080/", address)
+	suite.server.RemoveClientAddress("cp1")
+	_, ok = suite.server.GetClientAddress("cp1")
+	assert.False(t, ok)
+}
+
+func (suite *OcppSTestSuite) TestSendRequestContextCanceled() {
+	t := suite.T()
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		cancel()
+		time.Sleep(200 * time.Millisecond)
+		return core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil
+	})
+	response, err := suite.client.SendRequestWithContext(ctx, suite.httpServer.URL, "cp1", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func (suite *OcppSTestSuite) TestSendRequestFault() {
+	t := suite.T()
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		return nil, ocpp.NewError(ocppj.NotSupported, "not supported", header.MessageID)
+	})
+	response, err := suite.client.SendRequest(suite.httpServer.URL, "cp1", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	protoErr := err.(*ocpp.Error)
+	assert.Equal(t, ocppj.NotSupported, protoErr.Code)
+	assert.Equal(t, "not supported", protoErr.Description)
+}
+
+func (suite *OcppSTestSuite) TestSendInvalidRequest() {
+	t := suite.T()
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		t.Fail()
+		return nil, nil
+	})
+	response, err := suite.client.SendRequest(suite.httpServer.URL, "cp1", core.NewBootNotificationRequest("", "vendor1"))
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpps.Fault{}, err)
+	assert.Equal(t, ocppj.OccurrenceConstraintViolation, err.(*ocpps.Fault).Subcode)
+}
+
+func (suite *OcppSTestSuite) TestMissingChargeBoxIdentity() {
+	t := suite.T()
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		t.Fail()
+		return nil, nil
+	})
+	response, err := suite.client.SendRequest(suite.httpServer.URL, "", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.SecurityError, err.(*ocpp.Error).Code)
+}
+
+func (suite *OcppSTestSuite) TestCheckClient() {
+	t := suite.T()
+	suite.client.SetFromAddress("http://cp1:8080/")
+	suite.server.SetCheckClientHandler(func(chargeBoxIdentity string, r *http.Request) bool {
+		return chargeBoxIdentity == "cp2"
+	})
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		return core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil
+	})
+	// Rejected client
+	response, err := suite.client.SendRequest(suite.httpServer.URL, "cp1", core.NewHeartbeatRequest())
+	assert.Nil(t, response)
+	require.Error(t, err)
+	require.IsType(t, &ocpp.Error{}, err)
+	assert.Equal(t, ocppj.SecurityError, err.(*ocpp.Error).Code)
+	_, ok := suite.server.GetClientAddress("cp1")
+	assert.False(t, ok)
+	// Accepted client
+	response, err = suite.client.SendRequest(suite.httpServer.URL, "cp2", core.NewHeartbeatRequest())
+	require.NoError(t, err)
+	require.IsType(t, &core.HeartbeatConfirmation{}, response)
+	_, ok = suite.server.GetClientAddress("cp2")
+	assert.True(t, ok)
+}
+
+func (suite *OcppSTestSuite) TestRequestTooLarge() {
+	t := suite.T()
+	suite.server.SetMaxRequestSize(16)
+	suite.server.SetRequestHandler(func(header *ocpps.Header, request ocpp.Request, action string) (ocpp.Response, error) {
+		t.Fail()
+		return nil, nil
+	})
+	httpResponse, err := http.Post(suite.httpServer.URL, ocpps.ContentType, bytes.NewReader(make([]byte, 1024)))
+	require.NoError(t, err)
+	defer httpResponse.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, httpResponse.StatusCode)
+}
+
+func TestOcppS(t *testing.T) {
+	suite.Run(t, new(OcppSTestSuite))
+}
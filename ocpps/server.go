@@ -0,0 +1,178 @@
+package ocpps
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+// The default maximum size in bytes of a SOAP request body accepted by a Server.
+const DefaultMaxRequestSize = 64 * 1024
+
+// The endpoint receiving requests from OCPP-S clients, implemented as an http.Handler.
+//
+// Every request is processed synchronously: the registered RequestHandler is invoked and its result is
+// sent back to the client within the same HTTP exchange, either as a response or as a SOAP fault.
+type Server struct {
+	Endpoint
+	namespace          string
+	requestHandler     RequestHandler
+	checkClientHandler CheckClientHandler
+	maxRequestSize     int64
+	clientAddresses    map[string]string
+	mutex              sync.RWMutex
+}
+
+// RequestHandler processes an incoming request and returns the response to be sent to the client.
+// Returning an *ocpp.Error or a *Fault allows to control the fault sent back to the client.
+type RequestHandler func(header *Header, request ocpp.Request, action string) (ocpp.Response, error)
+
+// CheckClientHandler authenticates an incoming request, before it is processed.
+// The chargeBoxIdentity is the identity claimed by the client, while r is the raw HTTP request,
+// e.g. for checking basic auth credentials or the client TLS certificate.
+//
+// Returning false rejects the request with a SecurityError fault.
+type CheckClientHandler func(chargeBoxIdentity string, r *http.Request) bool
+
+// Creates a new Server endpoint, serving the SOAP service identified by namespace.
+//
+// To serve a 1.6 central system, you may use:
+//	s := ocpps.NewServer(ocpps.CentralSystemNamespace, core.Profile)
+func NewServer(namespace string, profiles ...*ocpp.Profile) *Server {
+	s := Server{namespace: namespace, maxRequestSize: DefaultMaxRequestSize, clientAddresses: map[string]string{}}
+	for _, profile := range profiles {
+		s.AddProfile(profile)
+	}
+	return &s
+}
+
+// Registers a handler for incoming requests.
+func (s *Server) SetRequestHandler(handler RequestHandler) {
+	s.requestHandler = handler
+}
+
+// Registers a handler for authenticating incoming requests.
+// The handler is invoked for every request, before the advertised client address is stored and before the request handler is invoked.
+//
+// If no handler is set, all requests are accepted.
+func (s *Server) SetCheckClientHandler(handler CheckClientHandler) {
+	s.checkClientHandler = handler
+}
+
+// Sets the maximum size in bytes of an incoming request body. Larger requests are rejected with a fault.
+// The default value is DefaultMaxRequestSize.
+func (s *Server) SetMaxRequestSize(size int64) {
+	s.maxRequestSize = size
+}
+
+// Returns the address a client advertised via the WS-Addressing From header of its last request.
+// Requests to the client should be sent to this address.
+//
+// Returns a false flag, if no request containing a valid From header was received from the client yet.
+func (s *Server) GetClientAddress(chargeBoxIdentity string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	address, ok := s.clientAddresses[chargeBoxIdentity]
+	return address, ok
+}
+
+// Removes a previously stored client address.
+func (s *Server) RemoveClientAddress(chargeBoxIdentity string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.clientAddresses, chargeBoxIdentity)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, s.maxRequestSize))
+	if err != nil {
+		s.writeFault(w, nil, NewFault(Sender, ocppj.FormationViolation, err.Error()))
+		return
+	}
+	header, request, err := s.ParseRequest(data)
+	if err != nil {
+		s.writeFault(w, header, err)
+		return
+	}
+	if header.ChargeBoxIdentity == "" {
+		s.writeFault(w, header, NewFault(Sender, ocppj.SecurityError, "missing chargeBoxIdentity header"))
+		return
+	}
+	if s.checkClientHandler != nil && !s.checkClientHandler(header.ChargeBoxIdentity, r) {
+		s.writeFault(w, header, NewFault(Sender, ocppj.SecurityError, fmt.Sprintf("client %v not authorized", header.ChargeBoxIdentity)))
+		return
+	}
+	if header.From != "" && header.From != AnonymousAddress {
+		s.mutex.Lock()
+		s.clientAddresses[header.ChargeBoxIdentity] = header.From
+		s.mutex.Unlock()
+	}
+	if s.requestHandler == nil {
+		s.writeFault(w, header, NewFault(Receiver, ocppj.NotSupported, fmt.Sprintf("no handler for action %v", header.FeatureName())))
+		return
+	}
+	response, err := s.requestHandler(header, request, header.FeatureName())
+	if err != nil {
+		s.writeFault(w, header, err)
+		return
+	}
+	if response == nil {
+		s.writeFault(w, header, NewFault(Receiver, ocppj.InternalError, "empty response"))
+		return
+	}
+	responseHeader := Header{
+		Action:    "/" + response.GetFeatureName() + "Response",
+		MessageID: messageIdGenerator(),
+		RelatesTo: header.MessageID,
+		To:        replyAddress(header),
+	}
+	data, err = MarshalResponse(s.namespace, responseHeader, response)
+	if err != nil {
+		s.writeFault(w, header, NewFault(Receiver, ocppj.InternalError, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// Sends a SOAP fault to the client. Faults caused by the sender are returned with HTTP status 400, all others with status 500.
+func (s *Server) writeFault(w http.ResponseWriter, requestHeader *Header, err error) {
+	var fault *Fault
+	switch e := err.(type) {
+	case *Fault:
+		fault = e
+	case *ocpp.Error:
+		fault = NewFault(Receiver, e.Code, e.Description)
+	default:
+		fault = NewFault(Receiver, ocppj.InternalError, err.Error())
+	}
+	header := Header{Action: FaultAction, MessageID: messageIdGenerator()}
+	if requestHeader != nil {
+		header.RelatesTo = requestHeader.MessageID
+		header.To = replyAddress(requestHeader)
+	}
+	data, _ := MarshalFault(s.namespace, header, fault)
+	w.Header().Set("Content-Type", ContentType)
+	if fault.Code == Sender {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_, _ = w.Write(data)
+}
+
+func replyAddress(header *Header) string {
+	if header.ReplyTo != "" {
+		return header.ReplyTo
+	}
+	return AnonymousAddress
+}
@@ -0,0 +1,262 @@
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+// Translates requests sent by the 2.0 CSMS into requests to a 1.6 charge point.
+// Requests are forwarded asynchronously to the charge point, while the handler waits for the response.
+type downstreamHandler struct {
+	proxy         *proxy
+	chargePointID string
+}
+
+func notSupportedError(action string) error {
+	return ocpp.NewError(ocppj.NotSupported, fmt.Sprintf("%v cannot be translated to OCPP 1.6", action), "")
+}
+
+func (h *downstreamHandler) OnGetBaseReport(request *provisioning.GetBaseReportRequest) (response *provisioning.GetBaseReportResponse, err error) {
+	return nil, notSupportedError(request.GetFeatureName())
+}
+
+func (h *downstreamHandler) OnGetReport(request *provisioning.GetReportRequest) (response *provisioning.GetReportResponse, err error) {
+	return nil, notSupportedError(request.GetFeatureName())
+}
+
+func (h *downstreamHandler) OnSetNetworkProfile(request *provisioning.SetNetworkProfileRequest) (response *provisioning.SetNetworkProfileResponse, err error) {
+	return nil, notSupportedError(request.GetFeatureName())
+}
+
+// Variables are mapped onto configuration keys with the same name. The component is ignored.
+func (h *downstreamHandler) OnGetVariables(request *provisioning.GetVariablesRequest) (response *provisioning.GetVariablesResponse, err error) {
+	keys := make([]string, 0, len(request.GetVariableData))
+	for _, variableData := range request.GetVariableData {
+		keys = append(keys, variableData.Variable.Name)
+	}
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.GetConfiguration(h.chargePointID, func(confirmation *core.GetConfigurationConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, keys)
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	confirmation := res.(*core.GetConfigurationConfirmation)
+	values := map[string]*string{}
+	for _, key := range confirmation.ConfigurationKey {
+		values[key.Key] = key.Value
+	}
+	results := make([]provisioning.GetVariableResult, 0, len(request.GetVariableData))
+	for _, variableData := range request.GetVariableData {
+		variableResult := provisioning.GetVariableResult{AttributeType: variableData.AttributeType, Component: variableData.Component, Variable: variableData.Variable}
+		value, ok := values[variableData.Variable.Name]
+		switch {
+		case variableData.AttributeType != "" && variableData.AttributeType != provisioning.AttributeActual:
+			variableResult.AttributeStatus = provisioning.GetVariableStatusNotSupportedAttributeType
+		case !ok:
+			variableResult.AttributeStatus = provisioning.GetVariableStatusUnknownVariable
+		default:
+			variableResult.AttributeStatus = provisioning.GetVariableStatusAccepted
+			if value != nil {
+				variableResult.AttributeValue = *value
+			}
+		}
+		results = append(results, variableResult)
+	}
+	return provisioning.NewGetVariablesResponse(results), nil
+}
+
+// Every variable is set separately via ChangeConfiguration, using the variable name as configuration key.
+func (h *downstreamHandler) OnSetVariables(request *provisioning.SetVariablesRequest) (response *provisioning.SetVariablesResponse, err error) {
+	results := make([]provisioning.SetVariableResult, 0, len(request.SetVariableData))
+	for _, variableData := range request.SetVariableData {
+		variableResult := provisioning.SetVariableResult{AttributeType: variableData.AttributeType, Component: variableData.Component, Variable: variableData.Variable}
+		if variableData.AttributeType != "" && variableData.AttributeType != provisioning.AttributeActual {
+			variableResult.AttributeStatus = provisioning.SetVariableStatusNotSupportedAttributeType
+			results = append(results, variableResult)
+			continue
+		}
+		resultC := make(chan result, 1)
+		err = h.proxy.centralSystem.ChangeConfiguration(h.chargePointID, func(confirmation *core.ChangeConfigurationConfirmation, err error) {
+			resultC <- result{confirmation, err}
+		}, variableData.Variable.Name, variableData.AttributeValue)
+		res, err := h.proxy.await(resultC, err)
+		if err != nil {
+			return nil, err
+		}
+		switch status := res.(*core.ChangeConfigurationConfirmation).Status; status {
+		case core.ConfigurationStatusNotSupported:
+			variableResult.AttributeStatus = provisioning.SetVariableStatusUnknownVariable
+		default:
+			variableResult.AttributeStatus = provisioning.SetVariableStatus(status)
+		}
+		results = append(results, variableResult)
+	}
+	return provisioning.NewSetVariablesResponse(results), nil
+}
+
+// Resets of a single EVSE are not supported by 1.6 charge points.
+// Once a reset was accepted, the next boot of the charge point is reported with the RemoteReset reason.
+func (h *downstreamHandler) OnReset(request *provisioning.ResetRequest) (response *provisioning.ResetResponse, err error) {
+	if request.EvseID != nil && *request.EvseID > 0 {
+		return provisioning.NewResetResponse(provisioning.ResetStatusRejected), nil
+	}
+	session, err := h.proxy.getSession(h.chargePointID)
+	if err != nil {
+		return nil, err
+	}
+	resetType := core.ResetTypeSoft
+	if request.Type == provisioning.ResetTypeImmediate {
+		resetType = core.ResetTypeHard
+	}
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.Reset(h.chargePointID, func(confirmation *core.ResetConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, resetType)
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	status := res.(*core.ResetConfirmation).Status
+	if status == core.ResetStatusAccepted {
+		session.setResetPending()
+	}
+	return provisioning.NewResetResponse(provisioning.ResetStatus(status)), nil
+}
+
+// An EVSE is mapped onto the connector with the same ID.
+func (h *downstreamHandler) OnChangeAvailability(request *availability.ChangeAvailabilityRequest) (response *availability.ChangeAvailabilityResponse, err error) {
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.ChangeAvailability(h.chargePointID, func(confirmation *core.ChangeAvailabilityConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, request.EvseID, core.AvailabilityType(request.OperationalStatus))
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	return availability.NewChangeAvailabilityResponse(availability.ChangeAvailabilityStatus(res.(*core.ChangeAvailabilityConfirmation).Status)), nil
+}
+
+func (h *downstreamHandler) OnClearCache(request *authorization.ClearCacheRequest) (response *authorization.ClearCacheResponse, err error) {
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.ClearCache(h.chargePointID, func(confirmation *core.ClearCacheConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	})
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	return authorization.NewClearCacheResponse(authorization.ClearCacheStatus(res.(*core.ClearCacheConfirmation).Status)), nil
+}
+
+func (h *downstreamHandler) OnDataTransfer(request *data.DataTransferRequest) (response *data.DataTransferResponse, err error) {
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.DataTransfer(h.chargePointID, func(confirmation *core.DataTransferConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, request.VendorId, func(dataRequest *core.DataTransferRequest) {
+		dataRequest.MessageId = request.MessageId
+		dataRequest.Data = request.Data
+	})
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	confirmation := res.(*core.DataTransferConfirmation)
+	response = data.NewDataTransferResponse(data.DataTransferStatus(confirmation.Status))
+	response.Data = confirmation.Data
+	return response, nil
+}
+
+// The remote start ID is attached to the next transaction started by the charge point.
+func (h *downstreamHandler) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (response *remotecontrol.RequestStartTransactionResponse, err error) {
+	session, err := h.proxy.getSession(h.chargePointID)
+	if err != nil {
+		return nil, err
+	}
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.RemoteStartTransaction(h.chargePointID, func(confirmation *core.RemoteStartTransactionConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, request.IDToken.IdToken, func(remoteStartRequest *core.RemoteStartTransactionRequest) {
+		remoteStartRequest.ConnectorId = request.EvseID
+	})
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	status := res.(*core.RemoteStartTransactionConfirmation).Status
+	if status == types16.RemoteStartStopStatusAccepted {
+		session.addRemoteStartID(request.RemoteStartID)
+	}
+	return remotecontrol.NewRequestStartTransactionResponse(remotecontrol.RequestStartStopStatus(status)), nil
+}
+
+func (h *downstreamHandler) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (response *remotecontrol.RequestStopTransactionResponse, err error) {
+	session, err := h.proxy.getSession(h.chargePointID)
+	if err != nil {
+		return nil, err
+	}
+	transactionID, ok := session.findTransaction(request.TransactionID)
+	if !ok {
+		return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatusRejected), nil
+	}
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.RemoteStopTransaction(h.chargePointID, func(confirmation *core.RemoteStopTransactionConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, transactionID)
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatus(res.(*core.RemoteStopTransactionConfirmation).Status)), nil
+}
+
+// Only triggers which exist in 1.6 are forwarded, while all others are answered with NotImplemented.
+func (h *downstreamHandler) OnTriggerMessage(request *remotecontrol.TriggerMessageRequest) (response *remotecontrol.TriggerMessageResponse, err error) {
+	switch request.RequestedMessage {
+	case remotecontrol.MessageTriggerBootNotification, remotecontrol.MessageTriggerHeartbeat, remotecontrol.MessageTriggerMeterValues, remotecontrol.MessageTriggerStatusNotification, remotecontrol.MessageTriggerFirmwareStatusNotification:
+	default:
+		return remotecontrol.NewTriggerMessageResponse(remotecontrol.TriggerMessageStatusNotImplemented), nil
+	}
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.TriggerMessage(h.chargePointID, func(confirmation *remotetrigger.TriggerMessageConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, remotetrigger.MessageTrigger(request.RequestedMessage), func(triggerRequest *remotetrigger.TriggerMessageRequest) {
+		if request.Evse != nil && request.Evse.ID > 0 {
+			connectorID := request.Evse.ID
+			triggerRequest.ConnectorId = &connectorID
+		}
+	})
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	return remotecontrol.NewTriggerMessageResponse(remotecontrol.TriggerMessageStatus(res.(*remotetrigger.TriggerMessageConfirmation).Status)), nil
+}
+
+func (h *downstreamHandler) OnUnlockConnector(request *remotecontrol.UnlockConnectorRequest) (response *remotecontrol.UnlockConnectorResponse, err error) {
+	resultC := make(chan result, 1)
+	err = h.proxy.centralSystem.UnlockConnector(h.chargePointID, func(confirmation *core.UnlockConnectorConfirmation, err error) {
+		resultC <- result{confirmation, err}
+	}, request.EvseID)
+	res, err := h.proxy.await(resultC, err)
+	if err != nil {
+		return nil, err
+	}
+	switch status := res.(*core.UnlockConnectorConfirmation).Status; status {
+	case core.UnlockStatusNotSupported:
+		return remotecontrol.NewUnlockConnectorResponse(remotecontrol.UnlockStatusUnknownConnector), nil
+	default:
+		return remotecontrol.NewUnlockConnectorResponse(remotecontrol.UnlockStatus(status)), nil
+	}
+}
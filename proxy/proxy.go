@@ -0,0 +1,360 @@
+// The proxy package contains a translating proxy, which lets OCPP 1.6 charge points connect to an OCPP 2.0 CSMS.
+//
+// The proxy accepts 1.6 charge points through an ocpp16.CentralSystem and maintains one upstream 2.0 ChargingStation
+// connection per charge point, using the charge point ID as charging station ID.
+// Messages are translated in both directions, e.g. StartTransaction and StopTransaction into TransactionEvent,
+// or SetVariables into ChangeConfiguration. Messages that have no 1.6 counterpart are answered with a CallError.
+//
+// The upstream connection always uses OCPP 2.0 (the "ocpp2.0" subprotocol). CSMSs which only accept OCPP 2.0.1
+// are not supported.
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Proxy translates between OCPP 1.6 charge points and an OCPP 2.0 CSMS.
+type Proxy interface {
+	// Sets the factory used for creating the upstream charging station of a newly connected charge point.
+	// This allows to customize the upstream connection, e.g. for using TLS. By default, ocpp2.NewChargingStation is used.
+	SetChargingStationFactory(factory func(chargePointID string) ocpp2.ChargingStation)
+	// Sets the maximum time the proxy waits for a charge point to respond to a request forwarded from the CSMS.
+	// Default is 30 seconds.
+	SetTimeout(timeout time.Duration)
+	// Sets the function generating the 1.6 transaction ID of every transaction started by a charge point.
+	// The 2.0 transaction ID reported to the CSMS is derived from it, hence IDs must be unique per charge point,
+	// also across restarts of the proxy (e.g. a persisted sequence).
+	// By default, IDs are taken from a counter, which is initialized with the current Unix time when the proxy is created.
+	SetTransactionIDGenerator(generator func(chargePointID string) int)
+	// Errors returns a channel for error messages. If it doesn't exist it es created.
+	Errors() <-chan error
+	// Starts the 1.6 central system, accepting charge points on the given port and path.
+	// This call is blocking.
+	Start(listenPort int, listenPath string)
+}
+
+// A transaction of a 1.6 charge point, together with its 2.0 counterpart.
+// The 2.0 transaction ID is derived from the 1.6 transaction ID, so the mapping survives restarts of the proxy.
+type transaction struct {
+	id            string
+	evseID        int // 0 if unknown
+	seqNo         int
+	remoteStartID *int
+}
+
+// Contains the upstream connection and the translation state of a single charge point.
+// The translation state is kept while the charge point is disconnected, as long as transactions are ongoing
+// or a reset forwarded by the proxy is pending.
+type chargePointSession struct {
+	id             string
+	station        ocpp2.ChargingStation
+	started        bool
+	transactions   map[int]*transaction
+	remoteStartIDs []int
+	resetPending   bool // The charge point accepted a reset requested by the CSMS, but didn't boot yet
+	mutex          sync.Mutex
+}
+
+type proxy struct {
+	centralSystem     ocpp16.CentralSystem
+	csmsURL           string
+	factory           func(chargePointID string) ocpp2.ChargingStation
+	generator         func(chargePointID string) int
+	timeout           time.Duration
+	sessions          map[string]*chargePointSession
+	lastTransactionID int
+	errC              chan error
+	mutex             sync.Mutex
+}
+
+// Creates a new proxy, which accepts 1.6 charge points via the given central system and forwards them to the 2.0 CSMS at csmsURL.
+// If no central system is passed, a default one is created.
+//
+// The proxy registers its own core profile and connection handlers on the central system.
+// Requests of other 1.6 profiles are not forwarded, and are answered with a CallError.
+//
+//	p := proxy.NewProxy(nil, "ws://csms:8887/ocpp")
+//	p.Start(8888, "/{ws}")
+func NewProxy(centralSystem ocpp16.CentralSystem, csmsURL string) Proxy {
+	if centralSystem == nil {
+		centralSystem = ocpp16.NewCentralSystem(nil, nil)
+	}
+	p := &proxy{
+		centralSystem:     centralSystem,
+		csmsURL:           csmsURL,
+		timeout:           defaultTimeout,
+		sessions:          map[string]*chargePointSession{},
+		lastTransactionID: int(time.Now().Unix()),
+		factory: func(chargePointID string) ocpp2.ChargingStation {
+			return ocpp2.NewChargingStation(chargePointID, nil, nil)
+		},
+	}
+	p.generator = p.nextTransactionID
+	centralSystem.SetCoreHandler(&upstreamHandler{p})
+	centralSystem.SetNewChargePointHandler(func(chargePoint ocpp16.ChargePointConnection) {
+		p.onChargePointConnected(chargePoint.ID())
+	})
+	centralSystem.SetChargePointDisconnectedHandler(func(chargePoint ocpp16.ChargePointConnection) {
+		p.onChargePointDisconnected(chargePoint.ID())
+	})
+	return p
+}
+
+func (p *proxy) SetChargingStationFactory(factory func(chargePointID string) ocpp2.ChargingStation) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.factory = factory
+}
+
+func (p *proxy) SetTimeout(timeout time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.timeout = timeout
+}
+
+func (p *proxy) SetTransactionIDGenerator(generator func(chargePointID string) int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.generator = generator
+}
+
+func (p *proxy) Errors() <-chan error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.errC == nil {
+		p.errC = make(chan error, 1)
+	}
+	return p.errC
+}
+
+func (p *proxy) Start(listenPort int, listenPath string) {
+	p.centralSystem.Start(listenPort, listenPath)
+}
+
+// Reports an error on the errors channel. The error is dropped if nobody is reading from the channel.
+func (p *proxy) error(err error) {
+	p.mutex.Lock()
+	errC := p.errC
+	p.mutex.Unlock()
+	if errC == nil {
+		return
+	}
+	select {
+	case errC <- err:
+	default:
+	}
+}
+
+func (p *proxy) onChargePointConnected(chargePointID string) {
+	p.mutex.Lock()
+	session, ok := p.sessions[chargePointID]
+	if !ok {
+		session = &chargePointSession{id: chargePointID, transactions: map[int]*transaction{}}
+		p.sessions[chargePointID] = session
+	}
+	p.mutex.Unlock()
+	if err := p.connectUpstream(session); err != nil {
+		p.error(err)
+	}
+}
+
+// Stops the upstream connection of a disconnected charge point.
+// The translation state is only dropped if no transactions are ongoing, as the charge point may report them after reconnecting.
+func (p *proxy) onChargePointDisconnected(chargePointID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	session, ok := p.sessions[chargePointID]
+	if !ok {
+		return
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if session.started {
+		session.station.Stop()
+		session.started = false
+	}
+	if len(session.transactions) == 0 && len(session.remoteStartIDs) == 0 && !session.resetPending {
+		delete(p.sessions, chargePointID)
+	}
+}
+
+// Creates the upstream charging station of a charge point.
+func (p *proxy) newStation(chargePointID string) ocpp2.ChargingStation {
+	p.mutex.Lock()
+	factory := p.factory
+	p.mutex.Unlock()
+	station := factory(chargePointID)
+	handler := &downstreamHandler{proxy: p, chargePointID: chargePointID}
+	station.SetProvisioningHandler(handler)
+	station.SetAvailabilityHandler(handler)
+	station.SetAuthorizationHandler(handler)
+	station.SetRemoteControlHandler(handler)
+	station.SetDataHandler(handler)
+	return station
+}
+
+// Connects the upstream charging station of a charge point to the CSMS, unless already connected.
+// A new charging station is created for every connection.
+func (p *proxy) connectUpstream(session *chargePointSession) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if session.started {
+		return nil
+	}
+	station := p.newStation(session.id)
+	if err := station.Start(p.csmsURL); err != nil {
+		return fmt.Errorf("couldn't connect charge point %v to CSMS: %w", session.id, err)
+	}
+	session.station = station
+	session.started = true
+	return nil
+}
+
+// Returns the session of a charge point, connecting it upstream if needed.
+// Charge points that don't use a persistent connection (e.g. via SOAP) are registered on their first message.
+func (p *proxy) getSession(chargePointID string) (*chargePointSession, error) {
+	p.mutex.Lock()
+	session, ok := p.sessions[chargePointID]
+	p.mutex.Unlock()
+	if !ok {
+		p.onChargePointConnected(chargePointID)
+		p.mutex.Lock()
+		session, ok = p.sessions[chargePointID]
+		p.mutex.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("no session for charge point %v", chargePointID)
+		}
+	}
+	if err := p.connectUpstream(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (p *proxy) getTimeout() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.timeout
+}
+
+// The result of a request forwarded to a charge point.
+type result struct {
+	response ocpp.Response
+	err      error
+}
+
+// Waits for the result of a request forwarded to a charge point. If sending the request failed, the error is returned directly.
+func (p *proxy) await(resultC chan result, err error) (ocpp.Response, error) {
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case r := <-resultC:
+		return r.response, r.err
+	case <-time.After(p.getTimeout()):
+		return nil, ocpp.NewError(ocppj.GenericError, "charge point didn't respond in time", "")
+	}
+}
+
+// The default transaction ID generator, shared by all charge points.
+func (p *proxy) nextTransactionID(chargePointID string) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lastTransactionID++
+	return p.lastTransactionID
+}
+
+func (p *proxy) newTransactionID(chargePointID string) int {
+	p.mutex.Lock()
+	generator := p.generator
+	p.mutex.Unlock()
+	return generator(chargePointID)
+}
+
+// Returns the 2.0 transaction ID of the transaction with the given 1.6 ID.
+func transactionIDFrom16(transactionID int) string {
+	return strconv.Itoa(transactionID)
+}
+
+// Registers a new transaction with the given 1.6 ID for a charge point.
+func (s *chargePointSession) startTransaction(transactionID int, evseID int) *transaction {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tx := &transaction{id: transactionIDFrom16(transactionID), evseID: evseID}
+	if len(s.remoteStartIDs) > 0 {
+		tx.remoteStartID = &s.remoteStartIDs[0]
+		s.remoteStartIDs = s.remoteStartIDs[1:]
+	}
+	s.transactions[transactionID] = tx
+	return tx
+}
+
+// Returns the transaction with the given 1.6 ID, together with the next sequence number to use.
+// Unknown transactions, e.g. started before the proxy was running, are registered on the fly.
+// An evseID of 0 means that the EVSE is not known.
+func (s *chargePointSession) nextEvent(transactionID int, evseID int) (*transaction, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tx, ok := s.transactions[transactionID]
+	if !ok {
+		tx = &transaction{id: transactionIDFrom16(transactionID)}
+		s.transactions[transactionID] = tx
+	}
+	if tx.evseID == 0 {
+		tx.evseID = evseID
+	}
+	tx.seqNo++
+	return tx, tx.seqNo
+}
+
+func (s *chargePointSession) endTransaction(transactionID int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.transactions, transactionID)
+}
+
+func (s *chargePointSession) setResetPending() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.resetPending = true
+}
+
+// Returns the reason to report for a boot of the charge point. 1.6 charge points don't report why they booted,
+// hence the reason is only known after a reset requested by the CSMS.
+func (s *chargePointSession) bootReason() provisioning.BootReason {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.resetPending {
+		s.resetPending = false
+		return provisioning.BootReasonRemoteReset
+	}
+	return provisioning.BootReasonUnknown
+}
+
+// Returns the 1.6 ID of the transaction with the given 2.0 ID.
+// Transactions that aren't tracked by the proxy (e.g. started before the proxy was restarted) are resolved as well.
+func (s *chargePointSession) findTransaction(id string) (int, bool) {
+	transactionID, err := strconv.Atoi(id)
+	if err != nil || transactionIDFrom16(transactionID) != id {
+		return 0, false
+	}
+	return transactionID, true
+}
+
+// Stores a remote start ID, which is attached to the next transaction started by the charge point.
+func (s *chargePointSession) addRemoteStartID(remoteStartID int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.remoteStartIDs = append(s.remoteStartIDs, remoteStartID)
+}
@@ -0,0 +1,325 @@
+package proxy_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/proxy"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+const (
+	csmsPort  = 8896
+	proxyPort = 8897
+	testPath  = "/{ws}"
+)
+
+// ---------------------- MOCK HANDLERS ----------------------
+
+type MockCSMSHandler struct {
+	mock.Mock
+}
+
+func (m *MockCSMSHandler) OnBootNotification(chargingStationID string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	args := m.MethodCalled("OnBootNotification", chargingStationID, request)
+	return args.Get(0).(*provisioning.BootNotificationResponse), args.Error(1)
+}
+
+func (m *MockCSMSHandler) OnHeartbeat(chargingStationID string, request *provisioning.HeartbeatRequest) (*provisioning.HeartbeatResponse, error) {
+	args := m.MethodCalled("OnHeartbeat", chargingStationID, request)
+	return args.Get(0).(*provisioning.HeartbeatResponse), args.Error(1)
+}
+
+func (m *MockCSMSHandler) OnNotifyReport(chargingStationID string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	args := m.MethodCalled("OnNotifyReport", chargingStationID, request)
+	return args.Get(0).(*provisioning.NotifyReportResponse), args.Error(1)
+}
+
+func (m *MockCSMSHandler) OnTransactionEvent(chargingStationID string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	args := m.MethodCalled("OnTransactionEvent", chargingStationID, request)
+	return args.Get(0).(*transactions.TransactionEventResponse), args.Error(1)
+}
+
+// Only configuration, remote start and reset operations are implemented, other calls on the embedded interface will panic.
+type chargePointHandler struct {
+	core.ChargePointHandler
+	changeConfiguration chan *core.ChangeConfigurationRequest
+	remoteStart         chan *core.RemoteStartTransactionRequest
+	reset               chan *core.ResetRequest
+}
+
+func (h *chargePointHandler) OnChangeConfiguration(request *core.ChangeConfigurationRequest) (*core.ChangeConfigurationConfirmation, error) {
+	h.changeConfiguration <- request
+	if request.Key == "HeartbeatInterval" {
+		return core.NewChangeConfigurationConfirmation(core.ConfigurationStatusAccepted), nil
+	}
+	return core.NewChangeConfigurationConfirmation(core.ConfigurationStatusNotSupported), nil
+}
+
+func (h *chargePointHandler) OnRemoteStartTransaction(request *core.RemoteStartTransactionRequest) (*core.RemoteStartTransactionConfirmation, error) {
+	h.remoteStart <- request
+	return core.NewRemoteStartTransactionConfirmation(types16.RemoteStartStopStatusAccepted), nil
+}
+
+func (h *chargePointHandler) OnReset(request *core.ResetRequest) (*core.ResetConfirmation, error) {
+	h.reset <- request
+	return core.NewResetConfirmation(core.ResetStatusAccepted), nil
+}
+
+// ---------------------- TEST SUITE ----------------------
+
+type ProxyTestSuite struct {
+	suite.Suite
+	csmsServer  *ws.Server
+	proxyServer *ws.Server
+	proxy       proxy.Proxy
+	csms        ocpp2.CSMS
+	csmsHandler *MockCSMSHandler
+	chargePoint ocpp16.ChargePoint
+	cpHandler   *chargePointHandler
+	connectedC  chan string
+}
+
+func (suite *ProxyTestSuite) SetupTest() {
+	suite.csmsServer = ws.NewServer()
+	suite.csms = ocpp2.NewCSMS(nil, suite.csmsServer)
+	suite.csmsHandler = &MockCSMSHandler{}
+	suite.csms.SetProvisioningHandler(suite.csmsHandler)
+	suite.csms.SetTransactionsHandler(suite.csmsHandler)
+	suite.connectedC = make(chan string, 1)
+	suite.csms.SetNewChargingStationHandler(func(chargingStation ocpp2.ChargingStationConnection) {
+		suite.connectedC <- chargingStation.ID()
+	})
+	go suite.csms.Start(csmsPort, testPath)
+	suite.proxyServer = ws.NewServer()
+	suite.proxy = proxy.NewProxy(ocpp16.NewCentralSystem(nil, suite.proxyServer), fmt.Sprintf("ws://localhost:%v", csmsPort))
+	suite.proxy.SetTimeout(2 * time.Second)
+	go suite.proxy.Start(proxyPort, testPath)
+	time.Sleep(100 * time.Millisecond)
+	suite.cpHandler = &chargePointHandler{changeConfiguration: make(chan *core.ChangeConfigurationRequest, 2), remoteStart: make(chan *core.RemoteStartTransactionRequest, 1), reset: make(chan *core.ResetRequest, 1)}
+	suite.connectChargePoint()
+}
+
+// Connects a new 1.6 charge point to the proxy and waits until the proxy connected it to the CSMS.
+func (suite *ProxyTestSuite) connectChargePoint() {
+	t := suite.T()
+	suite.chargePoint = ocpp16.NewChargePoint("cp1", nil, nil)
+	suite.chargePoint.SetCoreHandler(suite.cpHandler)
+	require.NoError(t, suite.chargePoint.Start(fmt.Sprintf("ws://localhost:%v", proxyPort)))
+	select {
+	case id := <-suite.connectedC:
+		assert.Equal(t, "cp1", id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("charge point wasn't connected to the CSMS")
+	}
+}
+
+func (suite *ProxyTestSuite) TearDownTest() {
+	suite.chargePoint.Stop()
+	suite.proxyServer.Stop()
+	suite.csmsServer.Stop()
+	time.Sleep(100 * time.Millisecond)
+}
+
+func (suite *ProxyTestSuite) TestBootNotification() {
+	t := suite.T()
+	currentTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.csmsHandler.On("OnBootNotification", "cp1", mock.Anything).Return(provisioning.NewBootNotificationResponse(types.NewDateTime(currentTime), 60, provisioning.RegistrationStatusAccepted), nil).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*provisioning.BootNotificationRequest)
+		assert.Equal(t, "model1", request.ChargingStation.Model)
+		assert.Equal(t, "vendor1", request.ChargingStation.VendorName)
+		assert.Equal(t, provisioning.BootReasonUnknown, request.Reason)
+	})
+	confirmation, err := suite.chargePoint.BootNotification("model1", "vendor1")
+	require.NoError(t, err)
+	assert.Equal(t, core.RegistrationStatusAccepted, confirmation.Status)
+	assert.Equal(t, 60, confirmation.Interval)
+	assert.True(t, currentTime.Equal(confirmation.CurrentTime.Time))
+}
+
+func (suite *ProxyTestSuite) TestBootNotificationAfterReset() {
+	t := suite.T()
+	reasonC := make(chan provisioning.BootReason, 1)
+	suite.csmsHandler.On("OnBootNotification", "cp1", mock.Anything).Return(provisioning.NewBootNotificationResponse(types.NewDateTime(time.Now()), 60, provisioning.RegistrationStatusAccepted), nil).Run(func(args mock.Arguments) {
+		reasonC <- args.Get(1).(*provisioning.BootNotificationRequest).Reason
+	})
+	resultC := make(chan *provisioning.ResetResponse, 1)
+	err := suite.csms.Reset("cp1", func(response *provisioning.ResetResponse, err error) {
+		require.NoError(t, err)
+		resultC <- response
+	}, provisioning.ResetTypeImmediate)
+	require.NoError(t, err)
+	request := <-suite.cpHandler.reset
+	assert.Equal(t, core.ResetTypeHard, request.Type)
+	assert.Equal(t, provisioning.ResetStatusAccepted, (<-resultC).Status)
+	// The charge point reboots, the boot is reported as caused by the reset
+	suite.chargePoint.Stop()
+	time.Sleep(100 * time.Millisecond)
+	suite.connectChargePoint()
+	_, err = suite.chargePoint.BootNotification("model1", "vendor1")
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.BootReasonRemoteReset, <-reasonC)
+	// Subsequent boots have an unknown reason
+	_, err = suite.chargePoint.BootNotification("model1", "vendor1")
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.BootReasonUnknown, <-reasonC)
+}
+
+func (suite *ProxyTestSuite) TestTransaction() {
+	t := suite.T()
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+	// Remote start
+	resultC := make(chan *remotecontrol.RequestStartTransactionResponse, 1)
+	evseID := 1
+	err := suite.csms.RequestStartTransaction("cp1", func(response *remotecontrol.RequestStartTransactionResponse, err error) {
+		require.NoError(t, err)
+		resultC <- response
+	}, 42, types.IdToken{IdToken: "tag1", Type: types.IdTokenTypeCentral}, func(request *remotecontrol.RequestStartTransactionRequest) {
+		request.EvseID = &evseID
+	})
+	require.NoError(t, err)
+	remoteStartRequest := <-suite.cpHandler.remoteStart
+	assert.Equal(t, "tag1", remoteStartRequest.IdTag)
+	require.NotNil(t, remoteStartRequest.ConnectorId)
+	assert.Equal(t, 1, *remoteStartRequest.ConnectorId)
+	assert.Equal(t, remotecontrol.RequestStartStopStatusAccepted, (<-resultC).Status)
+	// Transaction events
+	var transactionID string
+	response := transactions.NewTransactionEventResponse()
+	response.IDTokenInfo = types.NewIdTokenInfo(types.AuthorizationStatusAccepted)
+	suite.csmsHandler.On("OnTransactionEvent", "cp1", mock.MatchedBy(func(request *transactions.TransactionEventRequest) bool {
+		return request.EventType == transactions.TransactionEventStarted
+	})).Return(response, nil).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*transactions.TransactionEventRequest)
+		transactionID = request.TransactionInfo.TransactionID
+		assert.NotEmpty(t, transactionID)
+		assert.Equal(t, transactions.TriggerReasonRemoteStart, request.TriggerReason)
+		assert.Equal(t, 0, request.SequenceNo)
+		require.NotNil(t, request.TransactionInfo.RemoteStartID)
+		assert.Equal(t, 42, *request.TransactionInfo.RemoteStartID)
+		require.NotNil(t, request.Evse)
+		assert.Equal(t, 1, request.Evse.ID)
+		require.NotNil(t, request.IDToken)
+		assert.Equal(t, "tag1", request.IDToken.IdToken)
+		require.Len(t, request.MeterValue, 1)
+		require.Len(t, request.MeterValue[0].SampledValue, 1)
+		assert.Equal(t, 100.0, request.MeterValue[0].SampledValue[0].Value)
+		assert.Equal(t, types.ReadingContextTransactionBegin, request.MeterValue[0].SampledValue[0].Context)
+	})
+	suite.csmsHandler.On("OnTransactionEvent", "cp1", mock.MatchedBy(func(request *transactions.TransactionEventRequest) bool {
+		return request.EventType == transactions.TransactionEventEnded
+	})).Return(transactions.NewTransactionEventResponse(), nil).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*transactions.TransactionEventRequest)
+		assert.Equal(t, transactionID, request.TransactionInfo.TransactionID)
+		assert.Equal(t, transactions.ReasonRemote, request.TransactionInfo.StoppedReason)
+		assert.Equal(t, 1, request.SequenceNo)
+		require.NotNil(t, request.Evse)
+		assert.Equal(t, 1, request.Evse.ID)
+		require.Len(t, request.MeterValue, 1)
+		assert.Equal(t, 5100.0, request.MeterValue[0].SampledValue[0].Value)
+	})
+	confirmation, err := suite.chargePoint.StartTransaction(1, "tag1", 100, types16.NewDateTime(start))
+	require.NoError(t, err)
+	assert.Equal(t, types16.AuthorizationStatusAccepted, confirmation.IdTagInfo.Status)
+	assert.Equal(t, strconv.Itoa(confirmation.TransactionId), transactionID)
+	_, err = suite.chargePoint.StopTransaction(5100, types16.NewDateTime(end), confirmation.TransactionId, func(request *core.StopTransactionRequest) {
+		request.Reason = core.ReasonRemote
+	})
+	require.NoError(t, err)
+	suite.csmsHandler.AssertNumberOfCalls(t, "OnTransactionEvent", 2)
+}
+
+func (suite *ProxyTestSuite) TestTransactionAfterReconnect() {
+	t := suite.T()
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.proxy.SetTransactionIDGenerator(func(chargePointID string) int {
+		assert.Equal(t, "cp1", chargePointID)
+		return 7
+	})
+	// The CSMS doesn't return an id token info, hence the id tag isn't accepted
+	suite.csmsHandler.On("OnTransactionEvent", "cp1", mock.MatchedBy(func(request *transactions.TransactionEventRequest) bool {
+		return request.EventType == transactions.TransactionEventStarted
+	})).Return(transactions.NewTransactionEventResponse(), nil)
+	suite.csmsHandler.On("OnTransactionEvent", "cp1", mock.MatchedBy(func(request *transactions.TransactionEventRequest) bool {
+		return request.EventType == transactions.TransactionEventEnded
+	})).Return(transactions.NewTransactionEventResponse(), nil).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*transactions.TransactionEventRequest)
+		assert.Equal(t, "7", request.TransactionInfo.TransactionID)
+		assert.Equal(t, 1, request.SequenceNo)
+		require.NotNil(t, request.Evse)
+		assert.Equal(t, 2, request.Evse.ID)
+	})
+	confirmation, err := suite.chargePoint.StartTransaction(2, "tag1", 100, types16.NewDateTime(start))
+	require.NoError(t, err)
+	assert.Equal(t, 7, confirmation.TransactionId)
+	assert.Equal(t, types16.AuthorizationStatusInvalid, confirmation.IdTagInfo.Status)
+	// The transaction state is kept while the charge point reconnects
+	suite.chargePoint.Stop()
+	time.Sleep(100 * time.Millisecond)
+	suite.connectChargePoint()
+	_, err = suite.chargePoint.StopTransaction(200, types16.NewDateTime(start.Add(time.Hour)), confirmation.TransactionId)
+	require.NoError(t, err)
+	suite.csmsHandler.AssertNumberOfCalls(t, "OnTransactionEvent", 2)
+}
+
+func (suite *ProxyTestSuite) TestSetVariables() {
+	t := suite.T()
+	resultC := make(chan *provisioning.SetVariablesResponse, 1)
+	variableData := []provisioning.SetVariableData{
+		{AttributeValue: "60", Component: types.Component{Name: "OCPPCommCtrlr"}, Variable: types.Variable{Name: "HeartbeatInterval"}},
+		{AttributeValue: "1", Component: types.Component{Name: "OCPPCommCtrlr"}, Variable: types.Variable{Name: "UnknownKey"}},
+		{AttributeType: provisioning.AttributeTarget, AttributeValue: "1", Component: types.Component{Name: "OCPPCommCtrlr"}, Variable: types.Variable{Name: "HeartbeatInterval"}},
+	}
+	err := suite.csms.SetVariables("cp1", func(response *provisioning.SetVariablesResponse, err error) {
+		require.NoError(t, err)
+		resultC <- response
+	}, variableData)
+	require.NoError(t, err)
+	request := <-suite.cpHandler.changeConfiguration
+	assert.Equal(t, "HeartbeatInterval", request.Key)
+	assert.Equal(t, "60", request.Value)
+	request = <-suite.cpHandler.changeConfiguration
+	assert.Equal(t, "UnknownKey", request.Key)
+	response := <-resultC
+	require.Len(t, response.SetVariableResult, 3)
+	assert.Equal(t, provisioning.SetVariableStatusAccepted, response.SetVariableResult[0].AttributeStatus)
+	assert.Equal(t, provisioning.SetVariableStatusUnknownVariable, response.SetVariableResult[1].AttributeStatus)
+	assert.Equal(t, provisioning.SetVariableStatusNotSupportedAttributeType, response.SetVariableResult[2].AttributeStatus)
+	assert.Equal(t, "UnknownKey", response.SetVariableResult[1].Variable.Name)
+}
+
+func (suite *ProxyTestSuite) TestUnsupportedRequest() {
+	t := suite.T()
+	resultC := make(chan error, 1)
+	err := suite.csms.GetBaseReport("cp1", func(response *provisioning.GetBaseReportResponse, err error) {
+		resultC <- err
+	}, 1, provisioning.ReportTypeFullInventory)
+	require.NoError(t, err)
+	err = <-resultC
+	require.Error(t, err)
+	ocppErr, ok := err.(*ocpp.Error)
+	require.True(t, ok)
+	assert.Equal(t, ocppj.NotSupported, ocppErr.Code)
+}
+
+func TestProxy(t *testing.T) {
+	suite.Run(t, new(ProxyTestSuite))
+}
@@ -0,0 +1,287 @@
+package proxy
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+)
+
+// Translates requests sent by 1.6 charge points into requests to the 2.0 CSMS.
+type upstreamHandler struct {
+	proxy *proxy
+}
+
+func dateTimeFrom16(dateTime *types16.DateTime) *types.DateTime {
+	if dateTime == nil {
+		return types.NewDateTime(time.Now())
+	}
+	return types.NewDateTime(dateTime.Time)
+}
+
+func evseFromConnector(connectorID int) *types.EVSE {
+	connector := 1
+	return &types.EVSE{ID: connectorID, ConnectorID: &connector}
+}
+
+func idTokenFrom16(idTag string) *types.IdToken {
+	return &types.IdToken{IdToken: idTag, Type: types.IdTokenTypeISO14443}
+}
+
+// Converts a 2.0 id token info into a 1.6 id tag info. 2.0 statuses without 1.6 counterpart are mapped to Invalid.
+// If no info was returned by the CSMS, the id tag is considered invalid, as it was never authorized.
+func idTagInfoFrom2(info *types.IdTokenInfo) *types16.IdTagInfo {
+	if info == nil {
+		return types16.NewIdTagInfo(types16.AuthorizationStatusInvalid)
+	}
+	idTagInfo := types16.NewIdTagInfo(types16.AuthorizationStatusInvalid)
+	switch info.Status {
+	case types.AuthorizationStatusAccepted, types.AuthorizationStatusBlocked, types.AuthorizationStatusExpired, types.AuthorizationStatusInvalid, types.AuthorizationStatusConcurrentTx:
+		idTagInfo.Status = types16.AuthorizationStatus(info.Status)
+	}
+	if info.CacheExpiryDateTime != nil {
+		idTagInfo.ExpiryDate = types16.NewDateTime(info.CacheExpiryDateTime.Time)
+	}
+	return idTagInfo
+}
+
+func connectorStatusFrom16(status core.ChargePointStatus) availability.ConnectorStatus {
+	switch status {
+	case core.ChargePointStatusPreparing, core.ChargePointStatusCharging, core.ChargePointStatusSuspendedEV, core.ChargePointStatusSuspendedEVSE, core.ChargePointStatusFinishing:
+		return availability.ConnectorStatusOccupied
+	default:
+		return availability.ConnectorStatus(status)
+	}
+}
+
+// Converts 1.6 meter values into 2.0 meter values.
+// Sampled values that cannot be represented in 2.0 (e.g. signed data or unsupported measurands) are dropped.
+func meterValuesFrom16(meterValues []types16.MeterValue) []types.MeterValue {
+	var result []types.MeterValue
+	for _, meterValue := range meterValues {
+		converted := types.MeterValue{Timestamp: dateTimeFrom16(meterValue.Timestamp)}
+		for _, sampledValue := range meterValue.SampledValue {
+			if sampledValue.Format == types16.ValueFormatSignedData {
+				continue
+			}
+			value, err := strconv.ParseFloat(sampledValue.Value, 64)
+			if err != nil {
+				continue
+			}
+			sample := types.SampledValue{
+				Value:     value,
+				Context:   types.ReadingContext(sampledValue.Context),
+				Measurand: types.Measurand(sampledValue.Measurand),
+				Phase:     types.Phase(sampledValue.Phase),
+				Location:  types.Location(sampledValue.Location),
+			}
+			if sampledValue.Unit != "" {
+				sample.UnitOfMeasure = types.NewUnitOfMeasure(string(sampledValue.Unit))
+			}
+			if types.Validate.Struct(sample) != nil {
+				continue
+			}
+			converted.SampledValue = append(converted.SampledValue, sample)
+		}
+		if len(converted.SampledValue) > 0 {
+			result = append(result, converted)
+		}
+	}
+	return result
+}
+
+// Returns the energy register reading reported at the start or end of a 1.6 transaction, as a 2.0 meter value.
+func registerMeterValue(value int, timestamp *types16.DateTime, context types.ReadingContext) types.MeterValue {
+	sample := types.SampledValue{Value: float64(value), Context: context, Measurand: types.MeasurandEnergyActiveImportRegister, UnitOfMeasure: types.NewUnitOfMeasure("Wh")}
+	return types.MeterValue{Timestamp: dateTimeFrom16(timestamp), SampledValue: []types.SampledValue{sample}}
+}
+
+// Maps a 1.6 stop reason to the 2.0 stopped reason and trigger reason.
+func stopReasonFrom16(reason core.Reason) (transactions.Reason, transactions.TriggerReason) {
+	switch reason {
+	case core.ReasonDeAuthorized:
+		return transactions.ReasonDeAuthorized, transactions.TriggerReasonDeauthorized
+	case core.ReasonEmergencyStop:
+		return transactions.ReasonEmergencyStop, transactions.TriggerReasonAbnormalCondition
+	case core.ReasonEVDisconnected:
+		return transactions.ReasonEVDisconnected, transactions.TriggerReasonEVCommunicationLost
+	case core.ReasonHardReset, core.ReasonSoftReset:
+		return transactions.ReasonImmediateReset, transactions.TriggerReasonResetCommand
+	case core.ReasonOther:
+		return transactions.ReasonOther, transactions.TriggerReasonAbnormalCondition
+	case core.ReasonPowerLoss:
+		return transactions.ReasonPowerLoss, transactions.TriggerReasonAbnormalCondition
+	case core.ReasonReboot:
+		return transactions.ReasonReboot, transactions.TriggerReasonAbnormalCondition
+	case core.ReasonRemote:
+		return transactions.ReasonRemote, transactions.TriggerReasonRemoteStop
+	case core.ReasonUnlockCommand:
+		return transactions.ReasonOther, transactions.TriggerReasonUnlockCommand
+	default:
+		return transactions.ReasonLocal, transactions.TriggerReasonStopAuthorized
+	}
+}
+
+func (h *upstreamHandler) OnAuthorize(chargePointId string, request *core.AuthorizeRequest) (confirmation *core.AuthorizeConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := session.station.Authorize(request.IdTag, types.IdTokenTypeISO14443)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewAuthorizationConfirmation(idTagInfoFrom2(&response.IdTokenInfo)), nil
+}
+
+func (h *upstreamHandler) OnBootNotification(chargePointId string, request *core.BootNotificationRequest) (confirmation *core.BootNotificationConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := session.station.BootNotification(session.bootReason(), request.ChargePointModel, request.ChargePointVendor, func(bootRequest *provisioning.BootNotificationRequest) {
+		bootRequest.ChargingStation.SerialNumber = request.ChargePointSerialNumber
+		bootRequest.ChargingStation.FirmwareVersion = request.FirmwareVersion
+		if request.Iccid != "" || request.Imsi != "" {
+			bootRequest.ChargingStation.Modem = &provisioning.ModemType{Iccid: request.Iccid, Imsi: request.Imsi}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return core.NewBootNotificationConfirmation(types16.NewDateTime(response.CurrentTime.Time), response.Interval, core.RegistrationStatus(response.Status)), nil
+}
+
+func (h *upstreamHandler) OnDataTransfer(chargePointId string, request *core.DataTransferRequest) (confirmation *core.DataTransferConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := session.station.DataTransfer(request.VendorId, func(dataRequest *data.DataTransferRequest) {
+		dataRequest.MessageId = request.MessageId
+		dataRequest.Data = request.Data
+	})
+	if err != nil {
+		return nil, err
+	}
+	confirmation = core.NewDataTransferConfirmation(core.DataTransferStatus(response.Status))
+	confirmation.Data = response.Data
+	return confirmation, nil
+}
+
+func (h *upstreamHandler) OnHeartbeat(chargePointId string, request *core.HeartbeatRequest) (confirmation *core.HeartbeatConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := session.station.Heartbeat()
+	if err != nil {
+		return nil, err
+	}
+	return core.NewHeartbeatConfirmation(types16.NewDateTime(response.CurrentTime.Time)), nil
+}
+
+func (h *upstreamHandler) OnMeterValues(chargePointId string, request *core.MeterValuesRequest) (confirmation *core.MeterValuesConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	meterValues := meterValuesFrom16(request.MeterValue)
+	if len(meterValues) == 0 {
+		// Nothing left to forward
+		return core.NewMeterValuesConfirmation(), nil
+	}
+	if request.TransactionId == nil {
+		_, err = session.station.MeterValues(request.ConnectorId, meterValues)
+		if err != nil {
+			return nil, err
+		}
+		return core.NewMeterValuesConfirmation(), nil
+	}
+	tx, seqNo := session.nextEvent(*request.TransactionId, request.ConnectorId)
+	info := transactions.Transaction{TransactionID: tx.id}
+	_, err = session.station.TransactionEvent(transactions.TransactionEventUpdated, meterValues[0].Timestamp, transactions.TriggerReasonMeterValuePeriodic, seqNo, info, func(eventRequest *transactions.TransactionEventRequest) {
+		eventRequest.MeterValue = meterValues
+	})
+	if err != nil {
+		return nil, err
+	}
+	return core.NewMeterValuesConfirmation(), nil
+}
+
+func (h *upstreamHandler) OnStatusNotification(chargePointId string, request *core.StatusNotificationRequest) (confirmation *core.StatusNotificationConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	connectorID := 0
+	if request.ConnectorId > 0 {
+		connectorID = 1
+	}
+	_, err = session.station.StatusNotification(dateTimeFrom16(request.Timestamp), connectorStatusFrom16(request.Status), request.ConnectorId, connectorID)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewStatusNotificationConfirmation(), nil
+}
+
+func (h *upstreamHandler) OnStartTransaction(chargePointId string, request *core.StartTransactionRequest) (confirmation *core.StartTransactionConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	transactionID := h.proxy.newTransactionID(chargePointId)
+	tx := session.startTransaction(transactionID, request.ConnectorId)
+	info := transactions.Transaction{TransactionID: tx.id, RemoteStartID: tx.remoteStartID}
+	triggerReason := transactions.TriggerReasonAuthorized
+	if tx.remoteStartID != nil {
+		triggerReason = transactions.TriggerReasonRemoteStart
+	}
+	response, err := session.station.TransactionEvent(transactions.TransactionEventStarted, dateTimeFrom16(request.Timestamp), triggerReason, 0, info, func(eventRequest *transactions.TransactionEventRequest) {
+		eventRequest.Evse = evseFromConnector(request.ConnectorId)
+		eventRequest.IDToken = idTokenFrom16(request.IdTag)
+		eventRequest.ReservationID = request.ReservationId
+		eventRequest.MeterValue = []types.MeterValue{registerMeterValue(request.MeterStart, request.Timestamp, types.ReadingContextTransactionBegin)}
+	})
+	if err != nil {
+		session.endTransaction(transactionID)
+		return nil, err
+	}
+	return core.NewStartTransactionConfirmation(idTagInfoFrom2(response.IDTokenInfo), transactionID), nil
+}
+
+func (h *upstreamHandler) OnStopTransaction(chargePointId string, request *core.StopTransactionRequest) (confirmation *core.StopTransactionConfirmation, err error) {
+	session, err := h.proxy.getSession(chargePointId)
+	if err != nil {
+		return nil, err
+	}
+	tx, seqNo := session.nextEvent(request.TransactionId, 0)
+	reason, triggerReason := stopReasonFrom16(request.Reason)
+	info := transactions.Transaction{TransactionID: tx.id, StoppedReason: reason, RemoteStartID: tx.remoteStartID}
+	meterValues := append(meterValuesFrom16(request.TransactionData), registerMeterValue(request.MeterStop, request.Timestamp, types.ReadingContextTransactionEnd))
+	response, err := session.station.TransactionEvent(transactions.TransactionEventEnded, dateTimeFrom16(request.Timestamp), triggerReason, seqNo, info, func(eventRequest *transactions.TransactionEventRequest) {
+		// StopTransaction carries no connector, hence the EVSE is only reported if known from earlier messages
+		if tx.evseID > 0 {
+			eventRequest.Evse = evseFromConnector(tx.evseID)
+		}
+		if request.IdTag != "" {
+			eventRequest.IDToken = idTokenFrom16(request.IdTag)
+		}
+		eventRequest.MeterValue = meterValues
+	})
+	if err != nil {
+		return nil, err
+	}
+	session.endTransaction(request.TransactionId)
+	confirmation = core.NewStopTransactionConfirmation()
+	if request.IdTag != "" && response.IDTokenInfo != nil {
+		confirmation.IdTagInfo = idTagInfoFrom2(response.IDTokenInfo)
+	}
+	return confirmation, nil
+}
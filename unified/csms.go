@@ -0,0 +1,291 @@
+package unified
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	remotecontrol201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+)
+
+const defaultHeartbeatInterval = 60 * time.Second
+
+var errNoHandler = errors.New("no unified handler set")
+
+type unifiedCSMS struct {
+	centralSystem     ocpp16.CentralSystem
+	csms              ocpp2.CSMS
+	csms201           ocpp201.CSMS
+	handler           Handler
+	heartbeatInterval time.Duration
+	stations          map[string]Station
+	sessions          map[string]map[string]*Session
+	generator         func(stationID string) int
+	lastRemoteStartID int
+	mutex             sync.Mutex
+}
+
+// Creates a new version-agnostic CSMS on top of an OCPP 1.6 central system, an OCPP 2.0 CSMS and an OCPP 2.0.1 CSMS.
+// Any of them may be nil, if the respective protocol version is not needed.
+//
+// The facade registers its own connection handlers, as well as the handlers for the 1.6 core profile and the
+// 2.0/2.0.1 provisioning, authorization, availability, transactions, meter and data profiles.
+// These must not be overwritten afterwards. Handlers for other profiles may still be set directly on the endpoints.
+//
+// The endpoints are not started by the facade. To serve several versions on the same port, refer to ws.ServerMux:
+//	mux := ws.NewServerMux(nil)
+//	centralSystem := ocpp16.NewCentralSystem(nil, mux.ForSubprotocol("ocpp1.6"))
+//	csms := ocpp201.NewCSMS(nil, mux.ForSubprotocol("ocpp2.0.1"))
+//	facade := unified.NewCSMS(centralSystem, nil, csms)
+//	facade.SetHandler(handler)
+//	go centralSystem.Start(8887, "/{ws}")
+//	csms.Start(8887, "/{ws}")
+func NewCSMS(centralSystem ocpp16.CentralSystem, csms ocpp2.CSMS, csms201 ocpp201.CSMS) CSMS {
+	cs := &unifiedCSMS{
+		centralSystem:     centralSystem,
+		csms:              csms,
+		csms201:           csms201,
+		heartbeatInterval: defaultHeartbeatInterval,
+		stations:          map[string]Station{},
+		sessions:          map[string]map[string]*Session{},
+		lastRemoteStartID: int(time.Now().Unix()),
+	}
+	cs.generator = cs.nextRemoteStartID
+	if centralSystem != nil {
+		h := &handler16{cs}
+		centralSystem.SetCoreHandler(h)
+		centralSystem.SetNewChargePointHandler(func(chargePoint ocpp16.ChargePointConnection) {
+			cs.onConnected(chargePoint.ID(), ProtocolVersion16)
+		})
+		centralSystem.SetChargePointDisconnectedHandler(func(chargePoint ocpp16.ChargePointConnection) {
+			cs.onDisconnected(chargePoint.ID(), ProtocolVersion16)
+		})
+	}
+	if csms != nil {
+		h := &handler2{handler2x{cs, ProtocolVersion20}}
+		csms.SetProvisioningHandler(h)
+		csms.SetAuthorizationHandler(h)
+		csms.SetAvailabilityHandler(h)
+		csms.SetTransactionsHandler(h)
+		csms.SetMeterHandler(h)
+		csms.SetDataHandler(h)
+		csms.SetNewChargingStationHandler(func(chargingStation ocpp2.ChargingStationConnection) {
+			cs.onConnected(chargingStation.ID(), ProtocolVersion20)
+		})
+		csms.SetChargingStationDisconnectedHandler(func(chargingStation ocpp2.ChargingStationConnection) {
+			cs.onDisconnected(chargingStation.ID(), ProtocolVersion20)
+		})
+	}
+	if csms201 != nil {
+		h := &handler201{handler2x{cs, ProtocolVersion201}}
+		csms201.SetProvisioningHandler(h)
+		csms201.SetAuthorizationHandler(h)
+		csms201.SetAvailabilityHandler(h)
+		csms201.SetTransactionsHandler(h)
+		csms201.SetMeterHandler(h)
+		csms201.SetDataHandler(h)
+		csms201.SetNewChargingStationHandler(func(chargingStation ocpp201.ChargingStationConnection) {
+			cs.onConnected(chargingStation.ID(), ProtocolVersion201)
+		})
+		csms201.SetChargingStationDisconnectedHandler(func(chargingStation ocpp201.ChargingStationConnection) {
+			cs.onDisconnected(chargingStation.ID(), ProtocolVersion201)
+		})
+	}
+	return cs
+}
+
+func (cs *unifiedCSMS) SetHandler(handler Handler) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.handler = handler
+}
+
+func (cs *unifiedCSMS) SetHeartbeatInterval(interval time.Duration) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.heartbeatInterval = interval
+}
+
+func (cs *unifiedCSMS) SetRemoteStartIDGenerator(generator func(stationID string) int) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.generator = generator
+}
+
+func (cs *unifiedCSMS) GetStation(stationID string) (Station, bool) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	station, ok := cs.stations[stationID]
+	return station, ok
+}
+
+func (cs *unifiedCSMS) GetSessions(stationID string) []Session {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	sessions := make([]Session, 0, len(cs.sessions[stationID]))
+	for _, session := range cs.sessions[stationID] {
+		sessions = append(sessions, *session)
+	}
+	return sessions
+}
+
+func (cs *unifiedCSMS) RemoteStart(stationID string, evseID int, idToken string, callback func(accepted bool, err error)) error {
+	station, ok := cs.GetStation(stationID)
+	if !ok {
+		return fmt.Errorf("station %v is not connected", stationID)
+	}
+	switch station.Version {
+	case ProtocolVersion16:
+		return cs.centralSystem.RemoteStartTransaction(stationID, func(confirmation *core.RemoteStartTransactionConfirmation, err error) {
+			callback(confirmation != nil && confirmation.Status == types16.RemoteStartStopStatusAccepted, err)
+		}, idToken, func(request *core.RemoteStartTransactionRequest) {
+			if evseID > 0 {
+				request.ConnectorId = &evseID
+			}
+		})
+	case ProtocolVersion20:
+		token := types.IdToken{IdToken: idToken, Type: types.IdTokenTypeCentral}
+		return cs.csms.RequestStartTransaction(stationID, func(response *remotecontrol.RequestStartTransactionResponse, err error) {
+			callback(response != nil && response.Status == remotecontrol.RequestStartStopStatusAccepted, err)
+		}, cs.newRemoteStartID(stationID), token, func(request *remotecontrol.RequestStartTransactionRequest) {
+			if evseID > 0 {
+				request.EvseID = &evseID
+			}
+		})
+	case ProtocolVersion201:
+		token := types201.IdToken{IdToken: idToken, Type: types201.IdTokenTypeCentral}
+		return cs.csms201.RequestStartTransaction(stationID, func(response *remotecontrol201.RequestStartTransactionResponse, err error) {
+			callback(response != nil && response.Status == remotecontrol201.RequestStartStopStatusAccepted, err)
+		}, cs.newRemoteStartID(stationID), token, func(request *remotecontrol201.RequestStartTransactionRequest) {
+			if evseID > 0 {
+				request.EvseID = &evseID
+			}
+		})
+	default:
+		return fmt.Errorf("unsupported protocol version %v for station %v", station.Version, stationID)
+	}
+}
+
+func (cs *unifiedCSMS) RemoteStop(stationID string, sessionID string, callback func(accepted bool, err error)) error {
+	station, ok := cs.GetStation(stationID)
+	if !ok {
+		return fmt.Errorf("station %v is not connected", stationID)
+	}
+	switch station.Version {
+	case ProtocolVersion16:
+		transactionID, err := strconv.Atoi(sessionID)
+		if err != nil {
+			return fmt.Errorf("invalid session ID %v for OCPP 1.6 station %v: %w", sessionID, stationID, err)
+		}
+		return cs.centralSystem.RemoteStopTransaction(stationID, func(confirmation *core.RemoteStopTransactionConfirmation, err error) {
+			callback(confirmation != nil && confirmation.Status == types16.RemoteStartStopStatusAccepted, err)
+		}, transactionID)
+	case ProtocolVersion20:
+		return cs.csms.RequestStopTransaction(stationID, func(response *remotecontrol.RequestStopTransactionResponse, err error) {
+			callback(response != nil && response.Status == remotecontrol.RequestStartStopStatusAccepted, err)
+		}, sessionID)
+	case ProtocolVersion201:
+		return cs.csms201.RequestStopTransaction(stationID, func(response *remotecontrol201.RequestStopTransactionResponse, err error) {
+			callback(response != nil && response.Status == remotecontrol201.RequestStartStopStatusAccepted, err)
+		}, sessionID)
+	default:
+		return fmt.Errorf("unsupported protocol version %v for station %v", station.Version, stationID)
+	}
+}
+
+func (cs *unifiedCSMS) onConnected(stationID string, version ProtocolVersion) {
+	station := cs.station(stationID, version)
+	if handler := cs.getHandler(); handler != nil {
+		handler.OnStationConnected(station)
+	}
+}
+
+func (cs *unifiedCSMS) onDisconnected(stationID string, version ProtocolVersion) {
+	cs.mutex.Lock()
+	delete(cs.stations, stationID)
+	handler := cs.handler
+	cs.mutex.Unlock()
+	if handler != nil {
+		handler.OnStationDisconnected(Station{ID: stationID, Version: version})
+	}
+}
+
+func (cs *unifiedCSMS) getHandler() Handler {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	return cs.handler
+}
+
+func (cs *unifiedCSMS) getHeartbeatInterval() int {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	return int(cs.heartbeatInterval.Seconds())
+}
+
+// Returns the station with the given ID. Stations sending messages without an active websocket connection
+// (e.g. via SOAP) are registered on their first message.
+func (cs *unifiedCSMS) station(stationID string, version ProtocolVersion) Station {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	station, ok := cs.stations[stationID]
+	if !ok || station.Version != version {
+		station = Station{ID: stationID, Version: version}
+		cs.stations[stationID] = station
+	}
+	return station
+}
+
+// Returns a copy of a tracked session, or nil if the session is unknown.
+func (cs *unifiedCSMS) getSession(stationID string, sessionID string) *Session {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	session, ok := cs.sessions[stationID][sessionID]
+	if !ok {
+		return nil
+	}
+	s := *session
+	return &s
+}
+
+func (cs *unifiedCSMS) storeSession(stationID string, session Session) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if cs.sessions[stationID] == nil {
+		cs.sessions[stationID] = map[string]*Session{}
+	}
+	cs.sessions[stationID][session.ID] = &session
+}
+
+func (cs *unifiedCSMS) deleteSession(stationID string, sessionID string) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	delete(cs.sessions[stationID], sessionID)
+	if len(cs.sessions[stationID]) == 0 {
+		delete(cs.sessions, stationID)
+	}
+}
+
+// The default remote start ID generator, shared by all stations.
+func (cs *unifiedCSMS) nextRemoteStartID(stationID string) int {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.lastRemoteStartID++
+	return cs.lastRemoteStartID
+}
+
+func (cs *unifiedCSMS) newRemoteStartID(stationID string) int {
+	cs.mutex.Lock()
+	generator := cs.generator
+	cs.mutex.Unlock()
+	return generator(stationID)
+}
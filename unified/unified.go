@@ -0,0 +1,191 @@
+// The unified package contains a version-agnostic CSMS facade, built on top of an OCPP 1.6 central system, an OCPP 2.0 CSMS and an OCPP 2.0.1 CSMS.
+//
+// Business logic is implemented once against the Handler interface, using common concepts
+// such as stations, EVSEs, charging sessions and meter samples. Incoming messages of either protocol version
+// are mapped onto these concepts, while commands sent via the CSMS interface are translated into the respective
+// protocol messages, depending on the version of the targeted station.
+package unified
+
+import (
+	"time"
+)
+
+// The OCPP version a station is connected with.
+type ProtocolVersion string
+
+const (
+	ProtocolVersion16  ProtocolVersion = "1.6"
+	ProtocolVersion20  ProtocolVersion = "2.0"
+	ProtocolVersion201 ProtocolVersion = "2.0.1"
+)
+
+// A charging station, connected to the CSMS.
+type Station struct {
+	ID      string
+	Version ProtocolVersion
+}
+
+// Identifies a connector of a station.
+//
+// OCPP 2.0 and 2.0.1 stations report an EVSE ID and a connector ID within that EVSE.
+// OCPP 1.6 stations only know connectors: connector c is mapped to EVSE c with connector ID 1.
+// The EVSE with ID 0 refers to the station as a whole, in which case the connector ID is 0 as well.
+// A connector ID of 0 on an EVSE other than 0 means that the connector is not known.
+type EVSE struct {
+	ID          int
+	ConnectorID int
+}
+
+// Information reported by a station when booting.
+type BootInfo struct {
+	Model           string
+	Vendor          string
+	SerialNumber    string
+	FirmwareVersion string
+}
+
+// The registration status returned to a booting station.
+type RegistrationStatus string
+
+const (
+	RegistrationStatusAccepted RegistrationStatus = "Accepted"
+	RegistrationStatusPending  RegistrationStatus = "Pending"
+	RegistrationStatusRejected RegistrationStatus = "Rejected"
+)
+
+// The status of a connector.
+//
+// OCPP 1.6 statuses Preparing, Charging, SuspendedEV, SuspendedEVSE and Finishing are all reported as ConnectorStatusOccupied.
+type ConnectorStatus string
+
+const (
+	ConnectorStatusAvailable   ConnectorStatus = "Available"
+	ConnectorStatusOccupied    ConnectorStatus = "Occupied"
+	ConnectorStatusReserved    ConnectorStatus = "Reserved"
+	ConnectorStatusUnavailable ConnectorStatus = "Unavailable"
+	ConnectorStatusFaulted     ConnectorStatus = "Faulted"
+)
+
+// The result of an authorization, containing the statuses supported by both protocol versions.
+// AuthorizationStatusUnknown is sent as Invalid to OCPP 1.6 stations.
+type AuthorizationStatus string
+
+const (
+	AuthorizationStatusAccepted     AuthorizationStatus = "Accepted"
+	AuthorizationStatusBlocked      AuthorizationStatus = "Blocked"
+	AuthorizationStatusExpired      AuthorizationStatus = "Expired"
+	AuthorizationStatusInvalid      AuthorizationStatus = "Invalid"
+	AuthorizationStatusConcurrentTx AuthorizationStatus = "ConcurrentTx"
+	AuthorizationStatusUnknown      AuthorizationStatus = "Unknown"
+)
+
+// The reason why a session ended, as reported by the station.
+// Both protocol versions share most values, hence reasons are forwarded as-is.
+type StopReason string
+
+const (
+	StopReasonDeAuthorized   StopReason = "DeAuthorized"
+	StopReasonEmergencyStop  StopReason = "EmergencyStop"
+	StopReasonEVDisconnected StopReason = "EVDisconnected"
+	StopReasonLocal          StopReason = "Local"
+	StopReasonOther          StopReason = "Other"
+	StopReasonPowerLoss      StopReason = "PowerLoss"
+	StopReasonReboot         StopReason = "Reboot"
+	StopReasonRemote         StopReason = "Remote"
+)
+
+// The status returned in response to a data transfer. All protocol versions share the same values.
+type DataTransferStatus string
+
+const (
+	DataTransferStatusAccepted         DataTransferStatus = "Accepted"
+	DataTransferStatusRejected         DataTransferStatus = "Rejected"
+	DataTransferStatusUnknownMessageId DataTransferStatus = "UnknownMessageId"
+	DataTransferStatusUnknownVendorId  DataTransferStatus = "UnknownVendorId"
+)
+
+// A single sampled meter value.
+//
+// Values are numeric, already scaled by the multiplier of the unit of measure, if any.
+// When not reported by the station, Measurand defaults to Energy.Active.Import.Register and Unit to Wh.
+type MeterSample struct {
+	Timestamp time.Time
+	Measurand string
+	Value     float64
+	Unit      string
+	Phase     string
+	Context   string
+	Location  string
+}
+
+// A charging session, corresponding to a transaction on the station.
+//
+// The session ID is the transaction ID assigned by the Handler (1.6) or by the station (2.0 and 2.0.1).
+type Session struct {
+	ID            string
+	EVSE          EVSE
+	IdToken       string
+	StartTime     time.Time
+	RemoteStartID *int
+}
+
+const (
+	defaultMeasurand = "Energy.Active.Import.Register"
+	defaultUnit      = "Wh"
+)
+
+// Handler contains the callbacks invoked by the CSMS facade, regardless of the protocol version of a station.
+// Messages that have no counterpart in the handler (e.g. heartbeats) are answered automatically.
+type Handler interface {
+	// OnStationConnected is called whenever a station connects to the CSMS.
+	OnStationConnected(station Station)
+	// OnStationDisconnected is called whenever a station disconnects from the CSMS.
+	OnStationDisconnected(station Station)
+	// OnBoot is called whenever a station boots. The returned status is forwarded to the station.
+	OnBoot(station Station, info BootInfo) RegistrationStatus
+	// OnAuthorize is called whenever a station requests authorization for an id token.
+	OnAuthorize(station Station, idToken string) AuthorizationStatus
+	// OnStatusChange is called whenever the status of a connector changes.
+	OnStatusChange(station Station, evse EVSE, status ConnectorStatus, timestamp time.Time)
+	// NewTransactionID is called whenever an OCPP 1.6 station starts a session, and returns the transaction ID assigned to it.
+	// Stations may still report transactions after the CSMS restarted, hence IDs must be unique across restarts,
+	// e.g. by using a persisted sequence.
+	NewTransactionID(station Station) int
+	// OnSessionStarted is called whenever a charging session starts.
+	// The returned status is forwarded to the station, as authorization status of the session's id token.
+	OnSessionStarted(station Station, session Session, samples []MeterSample) AuthorizationStatus
+	// OnSessionUpdated is called whenever new information about an ongoing session is received, such as meter values.
+	OnSessionUpdated(station Station, session Session, samples []MeterSample)
+	// OnSessionEnded is called whenever a charging session ends.
+	OnSessionEnded(station Station, session Session, reason StopReason, samples []MeterSample)
+	// OnMeterValues is called whenever meter values, which are not related to any session, are received.
+	OnMeterValues(station Station, evse EVSE, samples []MeterSample)
+	// OnDataTransfer is called whenever a station sends vendor-specific data.
+	// The returned status and optional data are forwarded to the station.
+	OnDataTransfer(station Station, vendorID string, messageID string, data interface{}) (DataTransferStatus, interface{})
+}
+
+// CSMS is a version-agnostic facade, that handles stations connected via OCPP 1.6, OCPP 2.0 and OCPP 2.0.1.
+//
+// Commands are translated into the protocol messages of the version the target station is connected with.
+// Command callbacks are invoked asynchronously, once the station responded.
+type CSMS interface {
+	// Registers the handler, which is invoked for messages received from stations of any version.
+	SetHandler(handler Handler)
+	// Sets the heartbeat interval, which is sent to stations on boot. Default is 60 seconds.
+	SetHeartbeatInterval(interval time.Duration)
+	// Sets the function generating the remote start ID of every RemoteStart sent to a 2.0 or 2.0.1 station.
+	// Stations report the ID in the session they started, hence IDs must be unique per station,
+	// also across restarts of the CSMS (e.g. a persisted sequence).
+	// By default, IDs are taken from a counter, which is initialized with the current Unix time when the CSMS is created.
+	SetRemoteStartIDGenerator(generator func(stationID string) int)
+	// Returns the station with the given ID, if currently connected.
+	GetStation(stationID string) (Station, bool)
+	// Returns the ongoing sessions of a station.
+	GetSessions(stationID string) []Session
+	// Instructs a station to start a session for the given id token.
+	// If evseID is 0, the station picks an EVSE on its own.
+	RemoteStart(stationID string, evseID int, idToken string, callback func(accepted bool, err error)) error
+	// Instructs a station to stop an ongoing session.
+	RemoteStop(stationID string, sessionID string, callback func(accepted bool, err error)) error
+}
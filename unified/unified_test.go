@@ -0,0 +1,446 @@
+package unified_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	data201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	remotecontrol201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	transactions201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+	"github.com/lorenzodonini/ocpp-go/unified"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+const (
+	testPort = 8895
+	testPath = "/ws/{id}"
+)
+
+// ---------------------- MOCK HANDLERS ----------------------
+
+type MockHandler struct {
+	mock.Mock
+	connected chan unified.Station
+}
+
+func (m *MockHandler) OnStationConnected(station unified.Station) {
+	m.connected <- station
+}
+
+func (m *MockHandler) OnStationDisconnected(station unified.Station) {
+}
+
+func (m *MockHandler) OnBoot(station unified.Station, info unified.BootInfo) unified.RegistrationStatus {
+	args := m.MethodCalled("OnBoot", station, info)
+	return args.Get(0).(unified.RegistrationStatus)
+}
+
+func (m *MockHandler) OnAuthorize(station unified.Station, idToken string) unified.AuthorizationStatus {
+	args := m.MethodCalled("OnAuthorize", station, idToken)
+	return args.Get(0).(unified.AuthorizationStatus)
+}
+
+func (m *MockHandler) OnStatusChange(station unified.Station, evse unified.EVSE, status unified.ConnectorStatus, timestamp time.Time) {
+	m.MethodCalled("OnStatusChange", station, evse, status, timestamp)
+}
+
+func (m *MockHandler) NewTransactionID(station unified.Station) int {
+	args := m.MethodCalled("NewTransactionID", station)
+	return args.Int(0)
+}
+
+func (m *MockHandler) OnSessionStarted(station unified.Station, session unified.Session, samples []unified.MeterSample) unified.AuthorizationStatus {
+	args := m.MethodCalled("OnSessionStarted", station, session, samples)
+	return args.Get(0).(unified.AuthorizationStatus)
+}
+
+func (m *MockHandler) OnSessionUpdated(station unified.Station, session unified.Session, samples []unified.MeterSample) {
+	m.MethodCalled("OnSessionUpdated", station, session, samples)
+}
+
+func (m *MockHandler) OnSessionEnded(station unified.Station, session unified.Session, reason unified.StopReason, samples []unified.MeterSample) {
+	m.MethodCalled("OnSessionEnded", station, session, reason, samples)
+}
+
+func (m *MockHandler) OnMeterValues(station unified.Station, evse unified.EVSE, samples []unified.MeterSample) {
+	m.MethodCalled("OnMeterValues", station, evse, samples)
+}
+
+func (m *MockHandler) OnDataTransfer(station unified.Station, vendorID string, messageID string, data interface{}) (unified.DataTransferStatus, interface{}) {
+	args := m.MethodCalled("OnDataTransfer", station, vendorID, messageID, data)
+	return args.Get(0).(unified.DataTransferStatus), args.Get(1)
+}
+
+// Matches a session, comparing timestamps by instant and remote start IDs by value.
+func sessionMatcher(expected unified.Session) interface{} {
+	return mock.MatchedBy(func(session unified.Session) bool {
+		if !session.StartTime.Equal(expected.StartTime) || (session.RemoteStartID == nil) != (expected.RemoteStartID == nil) {
+			return false
+		}
+		if session.RemoteStartID != nil && *session.RemoteStartID != *expected.RemoteStartID {
+			return false
+		}
+		session.StartTime = expected.StartTime
+		session.RemoteStartID = expected.RemoteStartID
+		return session == expected
+	})
+}
+
+// Only remote start/stop operations are implemented, other calls on the embedded interface will panic.
+type chargePointHandler16 struct {
+	core.ChargePointHandler
+	remoteStart chan *core.RemoteStartTransactionRequest
+	remoteStop  chan *core.RemoteStopTransactionRequest
+}
+
+func (h *chargePointHandler16) OnRemoteStartTransaction(request *core.RemoteStartTransactionRequest) (*core.RemoteStartTransactionConfirmation, error) {
+	h.remoteStart <- request
+	return core.NewRemoteStartTransactionConfirmation(types16.RemoteStartStopStatusAccepted), nil
+}
+
+func (h *chargePointHandler16) OnRemoteStopTransaction(request *core.RemoteStopTransactionRequest) (*core.RemoteStopTransactionConfirmation, error) {
+	h.remoteStop <- request
+	return core.NewRemoteStopTransactionConfirmation(types16.RemoteStartStopStatusAccepted), nil
+}
+
+type chargingStationHandler2 struct {
+	remotecontrol.ChargingStationHandler
+	remoteStart chan *remotecontrol.RequestStartTransactionRequest
+	remoteStop  chan *remotecontrol.RequestStopTransactionRequest
+}
+
+func (h *chargingStationHandler2) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (*remotecontrol.RequestStartTransactionResponse, error) {
+	h.remoteStart <- request
+	return remotecontrol.NewRequestStartTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (h *chargingStationHandler2) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (*remotecontrol.RequestStopTransactionResponse, error) {
+	h.remoteStop <- request
+	return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+type chargingStationHandler201 struct {
+	remotecontrol201.ChargingStationHandler
+	remoteStart chan *remotecontrol201.RequestStartTransactionRequest
+	remoteStop  chan *remotecontrol201.RequestStopTransactionRequest
+}
+
+func (h *chargingStationHandler201) OnRequestStartTransaction(request *remotecontrol201.RequestStartTransactionRequest) (*remotecontrol201.RequestStartTransactionResponse, error) {
+	h.remoteStart <- request
+	return remotecontrol201.NewRequestStartTransactionResponse(remotecontrol201.RequestStartStopStatusAccepted), nil
+}
+
+func (h *chargingStationHandler201) OnRequestStopTransaction(request *remotecontrol201.RequestStopTransactionRequest) (*remotecontrol201.RequestStopTransactionResponse, error) {
+	h.remoteStop <- request
+	return remotecontrol201.NewRequestStopTransactionResponse(remotecontrol201.RequestStartStopStatusAccepted), nil
+}
+
+// ---------------------- TEST SUITE ----------------------
+
+type UnifiedTestSuite struct {
+	suite.Suite
+	wsServer        *ws.Server
+	facade          unified.CSMS
+	handler         *MockHandler
+	chargePoint     ocpp16.ChargePoint
+	chargingStation ocpp2.ChargingStation
+	station201      ocpp201.ChargingStation
+	cpHandler       *chargePointHandler16
+	csHandler       *chargingStationHandler2
+	csHandler201    *chargingStationHandler201
+}
+
+func (suite *UnifiedTestSuite) SetupTest() {
+	t := suite.T()
+	suite.wsServer = ws.NewServer()
+	mux := ws.NewServerMux(suite.wsServer)
+	centralSystem := ocpp16.NewCentralSystem(nil, mux.ForSubprotocol(types16.V16Subprotocol))
+	csms := ocpp2.NewCSMS(nil, mux.ForSubprotocol(types.V2Subprotocol))
+	csms201 := ocpp201.NewCSMS(nil, mux.ForSubprotocol(types201.V2Subprotocol))
+	suite.handler = &MockHandler{connected: make(chan unified.Station, 3)}
+	suite.facade = unified.NewCSMS(centralSystem, csms, csms201)
+	suite.facade.SetHandler(suite.handler)
+	go centralSystem.Start(testPort, testPath)
+	go csms.Start(testPort, testPath)
+	go csms201.Start(testPort, testPath)
+	time.Sleep(100 * time.Millisecond)
+	url := fmt.Sprintf("ws://localhost:%v/ws", testPort)
+	suite.cpHandler = &chargePointHandler16{remoteStart: make(chan *core.RemoteStartTransactionRequest, 1), remoteStop: make(chan *core.RemoteStopTransactionRequest, 1)}
+	suite.chargePoint = ocpp16.NewChargePoint("cp16", nil, nil)
+	suite.chargePoint.SetCoreHandler(suite.cpHandler)
+	require.NoError(t, suite.chargePoint.Start(url))
+	assert.Equal(t, unified.Station{ID: "cp16", Version: unified.ProtocolVersion16}, <-suite.handler.connected)
+	suite.csHandler = &chargingStationHandler2{remoteStart: make(chan *remotecontrol.RequestStartTransactionRequest, 1), remoteStop: make(chan *remotecontrol.RequestStopTransactionRequest, 1)}
+	suite.chargingStation = ocpp2.NewChargingStation("cs20", nil, nil)
+	suite.chargingStation.SetRemoteControlHandler(suite.csHandler)
+	require.NoError(t, suite.chargingStation.Start(url))
+	assert.Equal(t, unified.Station{ID: "cs20", Version: unified.ProtocolVersion20}, <-suite.handler.connected)
+	suite.csHandler201 = &chargingStationHandler201{remoteStart: make(chan *remotecontrol201.RequestStartTransactionRequest, 1), remoteStop: make(chan *remotecontrol201.RequestStopTransactionRequest, 1)}
+	suite.station201 = ocpp201.NewChargingStation("cs201", nil, nil)
+	suite.station201.SetRemoteControlHandler(suite.csHandler201)
+	require.NoError(t, suite.station201.Start(url))
+	assert.Equal(t, unified.Station{ID: "cs201", Version: unified.ProtocolVersion201}, <-suite.handler.connected)
+}
+
+func (suite *UnifiedTestSuite) TearDownTest() {
+	suite.chargePoint.Stop()
+	suite.chargingStation.Stop()
+	suite.station201.Stop()
+	suite.wsServer.Stop()
+	time.Sleep(100 * time.Millisecond)
+}
+
+func (suite *UnifiedTestSuite) TestBoot() {
+	t := suite.T()
+	station16 := unified.Station{ID: "cp16", Version: unified.ProtocolVersion16}
+	station20 := unified.Station{ID: "cs20", Version: unified.ProtocolVersion20}
+	suite.handler.On("OnBoot", station16, unified.BootInfo{Model: "model1", Vendor: "vendor1"}).Return(unified.RegistrationStatusAccepted)
+	suite.handler.On("OnBoot", station20, unified.BootInfo{Model: "model2", Vendor: "vendor2"}).Return(unified.RegistrationStatusPending)
+	suite.facade.SetHeartbeatInterval(30 * time.Second)
+	confirmation, err := suite.chargePoint.BootNotification("model1", "vendor1")
+	require.NoError(t, err)
+	assert.Equal(t, core.RegistrationStatusAccepted, confirmation.Status)
+	assert.Equal(t, 30, confirmation.Interval)
+	response, err := suite.chargingStation.BootNotification(provisioning.BootReasonPowerUp, "model2", "vendor2")
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.RegistrationStatusPending, response.Status)
+	assert.Equal(t, 30, response.Interval)
+	station, ok := suite.facade.GetStation("cs20")
+	assert.True(t, ok)
+	assert.Equal(t, station20, station)
+}
+
+func (suite *UnifiedTestSuite) TestStatusChange() {
+	t := suite.T()
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	timestampMatcher := mock.MatchedBy(timestamp.Equal)
+	suite.handler.On("OnStatusChange", unified.Station{ID: "cp16", Version: unified.ProtocolVersion16}, unified.EVSE{ID: 2, ConnectorID: 1}, unified.ConnectorStatusOccupied, timestampMatcher).Return()
+	suite.handler.On("OnStatusChange", unified.Station{ID: "cs20", Version: unified.ProtocolVersion20}, unified.EVSE{ID: 2, ConnectorID: 3}, unified.ConnectorStatusFaulted, timestampMatcher).Return()
+	_, err := suite.chargePoint.StatusNotification(2, core.NoError, core.ChargePointStatusSuspendedEV, func(request *core.StatusNotificationRequest) {
+		request.Timestamp = types16.NewDateTime(timestamp)
+	})
+	require.NoError(t, err)
+	_, err = suite.chargingStation.StatusNotification(types.NewDateTime(timestamp), availability.ConnectorStatusFaulted, 2, 3)
+	require.NoError(t, err)
+	suite.handler.AssertNumberOfCalls(t, "OnStatusChange", 2)
+}
+
+func (suite *UnifiedTestSuite) TestSession16() {
+	t := suite.T()
+	station := unified.Station{ID: "cp16", Version: unified.ProtocolVersion16}
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+	var sessionID string
+	suite.handler.On("NewTransactionID", station).Return(42)
+	suite.handler.On("OnSessionStarted", station, mock.Anything, mock.Anything).Return(unified.AuthorizationStatusAccepted).Run(func(args mock.Arguments) {
+		session := args.Get(1).(unified.Session)
+		sessionID = session.ID
+		assert.Equal(t, unified.EVSE{ID: 1, ConnectorID: 1}, session.EVSE)
+		assert.Equal(t, "tag1", session.IdToken)
+		assert.True(t, start.Equal(session.StartTime))
+		samples := args.Get(2).([]unified.MeterSample)
+		require.Len(t, samples, 1)
+		assert.True(t, start.Equal(samples[0].Timestamp))
+		samples[0].Timestamp = start
+		assert.Equal(t, unified.MeterSample{Timestamp: start, Measurand: "Energy.Active.Import.Register", Value: 100, Unit: "Wh", Context: "Transaction.Begin"}, samples[0])
+	})
+	suite.handler.On("OnSessionEnded", station, mock.Anything, unified.StopReasonRemote, mock.Anything).Return().Run(func(args mock.Arguments) {
+		session := args.Get(1).(unified.Session)
+		assert.Equal(t, sessionID, session.ID)
+		assert.Equal(t, unified.EVSE{ID: 1, ConnectorID: 1}, session.EVSE)
+		samples := args.Get(3).([]unified.MeterSample)
+		require.Len(t, samples, 2)
+		assert.Equal(t, "Power.Active.Import", samples[0].Measurand)
+		assert.Equal(t, 11.5, samples[0].Value)
+		assert.Equal(t, 5100.0, samples[1].Value)
+	})
+	confirmation, err := suite.chargePoint.StartTransaction(1, "tag1", 100, types16.NewDateTime(start))
+	require.NoError(t, err)
+	assert.Equal(t, types16.AuthorizationStatusAccepted, confirmation.IdTagInfo.Status)
+	assert.Equal(t, 42, confirmation.TransactionId)
+	assert.Equal(t, sessionID, fmt.Sprintf("%v", confirmation.TransactionId))
+	require.Len(t, suite.facade.GetSessions("cp16"), 1)
+	// Remote stop
+	resultC := make(chan bool, 1)
+	err = suite.facade.RemoteStop("cp16", sessionID, func(accepted bool, err error) {
+		require.NoError(t, err)
+		resultC <- accepted
+	})
+	require.NoError(t, err)
+	request := <-suite.cpHandler.remoteStop
+	assert.Equal(t, confirmation.TransactionId, request.TransactionId)
+	assert.True(t, <-resultC)
+	_, err = suite.chargePoint.StopTransaction(5100, types16.NewDateTime(end), confirmation.TransactionId, func(request *core.StopTransactionRequest) {
+		request.Reason = core.ReasonRemote
+		request.TransactionData = []types16.MeterValue{{Timestamp: types16.NewDateTime(end), SampledValue: []types16.SampledValue{{Value: "11.5", Measurand: types16.MeasurandPowerActiveImport, Unit: types16.UnitOfMeasureKW}}}}
+	})
+	require.NoError(t, err)
+	suite.handler.AssertCalled(t, "OnSessionEnded", station, mock.Anything, unified.StopReasonRemote, mock.Anything)
+	assert.Empty(t, suite.facade.GetSessions("cp16"))
+}
+
+func (suite *UnifiedTestSuite) TestSession20() {
+	t := suite.T()
+	station := unified.Station{ID: "cs20", Version: unified.ProtocolVersion20}
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	connectorID := 2
+	multiplier := 3
+	// Remote start
+	resultC := make(chan bool, 1)
+	err := suite.facade.RemoteStart("cs20", 1, "token1", func(accepted bool, err error) {
+		require.NoError(t, err)
+		resultC <- accepted
+	})
+	require.NoError(t, err)
+	request := <-suite.csHandler.remoteStart
+	require.NotNil(t, request.EvseID)
+	assert.Equal(t, 1, *request.EvseID)
+	// Default IDs are seeded with the current time, so they don't collide with IDs of a previous run
+	assert.True(t, request.RemoteStartID > int(time.Now().Add(-time.Minute).Unix()))
+	assert.Equal(t, "token1", request.IDToken.IdToken)
+	assert.Equal(t, types.IdTokenTypeCentral, request.IDToken.Type)
+	assert.True(t, <-resultC)
+	// Session events
+	expectedSession := unified.Session{ID: "tx1", EVSE: unified.EVSE{ID: 1, ConnectorID: connectorID}, IdToken: "token1", StartTime: start, RemoteStartID: &request.RemoteStartID}
+	suite.handler.On("OnSessionStarted", station, sessionMatcher(expectedSession), []unified.MeterSample(nil)).Return(unified.AuthorizationStatusAccepted)
+	suite.handler.On("OnSessionUpdated", station, sessionMatcher(expectedSession), mock.Anything).Return().Run(func(args mock.Arguments) {
+		samples := args.Get(2).([]unified.MeterSample)
+		require.Len(t, samples, 1)
+		assert.True(t, start.Equal(samples[0].Timestamp))
+		assert.Equal(t, "Energy.Active.Import.Register", samples[0].Measurand)
+		assert.Equal(t, 1500.0, samples[0].Value)
+		assert.Equal(t, "Wh", samples[0].Unit)
+	})
+	suite.handler.On("OnSessionEnded", station, sessionMatcher(expectedSession), unified.StopReasonEVDisconnected, []unified.MeterSample(nil)).Return()
+	info := transactions.Transaction{TransactionID: "tx1", RemoteStartID: &request.RemoteStartID}
+	response, err := suite.chargingStation.TransactionEvent(transactions.TransactionEventStarted, types.NewDateTime(start), transactions.TriggerReasonRemoteStart, 0, info, func(request *transactions.TransactionEventRequest) {
+		request.Evse = &types.EVSE{ID: 1, ConnectorID: &connectorID}
+		request.IDToken = &types.IdToken{IdToken: "token1", Type: types.IdTokenTypeCentral}
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.IDTokenInfo)
+	assert.Equal(t, types.AuthorizationStatusAccepted, response.IDTokenInfo.Status)
+	_, err = suite.chargingStation.TransactionEvent(transactions.TransactionEventUpdated, types.NewDateTime(start), transactions.TriggerReasonMeterValuePeriodic, 1, transactions.Transaction{TransactionID: "tx1"}, func(request *transactions.TransactionEventRequest) {
+		request.MeterValue = []types.MeterValue{{Timestamp: types.NewDateTime(start), SampledValue: []types.SampledValue{{Value: 1.5, UnitOfMeasure: &types.UnitOfMeasure{Unit: "Wh", Multiplier: &multiplier}}}}}
+	})
+	require.NoError(t, err)
+	sessions := suite.facade.GetSessions("cs20")
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "tx1", sessions[0].ID)
+	_, err = suite.chargingStation.TransactionEvent(transactions.TransactionEventEnded, types.NewDateTime(start), transactions.TriggerReasonEVCommunicationLost, 2, transactions.Transaction{TransactionID: "tx1", StoppedReason: transactions.ReasonEVDisconnected})
+	require.NoError(t, err)
+	suite.handler.AssertExpectations(t)
+	assert.Empty(t, suite.facade.GetSessions("cs20"))
+}
+
+func (suite *UnifiedTestSuite) TestSession201() {
+	t := suite.T()
+	station := unified.Station{ID: "cs201", Version: unified.ProtocolVersion201}
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	// Remote start, using an ID from a custom generator
+	suite.facade.SetRemoteStartIDGenerator(func(stationID string) int {
+		assert.Equal(t, "cs201", stationID)
+		return 1234
+	})
+	resultC := make(chan bool, 1)
+	err := suite.facade.RemoteStart("cs201", 2, "token1", func(accepted bool, err error) {
+		require.NoError(t, err)
+		resultC <- accepted
+	})
+	require.NoError(t, err)
+	request := <-suite.csHandler201.remoteStart
+	require.NotNil(t, request.EvseID)
+	assert.Equal(t, 2, *request.EvseID)
+	assert.Equal(t, "token1", request.IDToken.IdToken)
+	assert.Equal(t, 1234, request.RemoteStartID)
+	assert.True(t, <-resultC)
+	// Session events
+	expectedSession := unified.Session{ID: "tx2", EVSE: unified.EVSE{ID: 2}, IdToken: "token1", StartTime: start, RemoteStartID: &request.RemoteStartID}
+	suite.handler.On("OnSessionStarted", station, sessionMatcher(expectedSession), []unified.MeterSample(nil)).Return(unified.AuthorizationStatusAccepted)
+	suite.handler.On("OnSessionEnded", station, sessionMatcher(expectedSession), unified.StopReasonRemote, []unified.MeterSample(nil)).Return()
+	info := transactions201.Transaction{TransactionID: "tx2", RemoteStartID: &request.RemoteStartID}
+	response, err := suite.station201.TransactionEvent(transactions201.TransactionEventStarted, types201.NewDateTime(start), transactions201.TriggerReasonRemoteStart, 0, info, func(request *transactions201.TransactionEventRequest) {
+		request.Evse = &types201.EVSE{ID: 2}
+		request.IDToken = &types201.IdToken{IdToken: "token1", Type: types201.IdTokenTypeCentral}
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.IDTokenInfo)
+	assert.Equal(t, types201.AuthorizationStatusAccepted, response.IDTokenInfo.Status)
+	require.Len(t, suite.facade.GetSessions("cs201"), 1)
+	// Remote stop
+	err = suite.facade.RemoteStop("cs201", "tx2", func(accepted bool, err error) {
+		require.NoError(t, err)
+		resultC <- accepted
+	})
+	require.NoError(t, err)
+	stopRequest := <-suite.csHandler201.remoteStop
+	assert.Equal(t, "tx2", stopRequest.TransactionID)
+	assert.True(t, <-resultC)
+	_, err = suite.station201.TransactionEvent(transactions201.TransactionEventEnded, types201.NewDateTime(start), transactions201.TriggerReasonRemoteStop, 1, transactions201.Transaction{TransactionID: "tx2", StoppedReason: transactions201.ReasonRemote})
+	require.NoError(t, err)
+	suite.handler.AssertExpectations(t)
+	assert.Empty(t, suite.facade.GetSessions("cs201"))
+}
+
+func (suite *UnifiedTestSuite) TestDataTransfer() {
+	t := suite.T()
+	suite.handler.On("OnDataTransfer", unified.Station{ID: "cp16", Version: unified.ProtocolVersion16}, "vendor1", "message1", "data1").Return(unified.DataTransferStatusAccepted, "reply1")
+	suite.handler.On("OnDataTransfer", unified.Station{ID: "cs20", Version: unified.ProtocolVersion20}, "vendor2", "", nil).Return(unified.DataTransferStatusUnknownVendorId, nil)
+	suite.handler.On("OnDataTransfer", unified.Station{ID: "cs201", Version: unified.ProtocolVersion201}, "vendor3", "message3", nil).Return(unified.DataTransferStatusRejected, nil)
+	confirmation, err := suite.chargePoint.DataTransfer("vendor1", func(request *core.DataTransferRequest) {
+		request.MessageId = "message1"
+		request.Data = "data1"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, core.DataTransferStatusAccepted, confirmation.Status)
+	assert.Equal(t, "reply1", confirmation.Data)
+	response, err := suite.chargingStation.DataTransfer("vendor2")
+	require.NoError(t, err)
+	assert.Equal(t, data.DataTransferStatusUnknownVendorId, response.Status)
+	response201, err := suite.station201.DataTransfer("vendor3", func(request *data201.DataTransferRequest) {
+		request.MessageId = "message3"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data201.DataTransferStatusRejected, response201.Status)
+}
+
+func (suite *UnifiedTestSuite) TestAuthorize() {
+	t := suite.T()
+	suite.handler.On("OnAuthorize", mock.Anything, "unknownTag").Return(unified.AuthorizationStatusUnknown)
+	confirmation, err := suite.chargePoint.Authorize("unknownTag")
+	require.NoError(t, err)
+	assert.Equal(t, types16.AuthorizationStatusInvalid, confirmation.IdTagInfo.Status)
+	response, err := suite.chargingStation.Authorize("unknownTag", types.IdTokenTypeISO14443)
+	require.NoError(t, err)
+	assert.Equal(t, types.AuthorizationStatusUnknown, response.IdTokenInfo.Status)
+}
+
+func (suite *UnifiedTestSuite) TestRemoteCommandErrors() {
+	t := suite.T()
+	err := suite.facade.RemoteStart("unknown", 0, "tag1", func(accepted bool, err error) {})
+	assert.Error(t, err)
+	err = suite.facade.RemoteStop("cp16", "notANumber", func(accepted bool, err error) {})
+	assert.Error(t, err)
+}
+
+func TestUnified(t *testing.T) {
+	suite.Run(t, new(UnifiedTestSuite))
+}
@@ -0,0 +1,193 @@
+package unified
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+)
+
+// Maps messages of the OCPP 1.6 core profile onto the unified handler.
+type handler16 struct {
+	cs *unifiedCSMS
+}
+
+func evseFromConnector(connectorID int) EVSE {
+	if connectorID == 0 {
+		return EVSE{}
+	}
+	return EVSE{ID: connectorID, ConnectorID: 1}
+}
+
+func connectorStatusFrom16(status core.ChargePointStatus) ConnectorStatus {
+	switch status {
+	case core.ChargePointStatusPreparing, core.ChargePointStatusCharging, core.ChargePointStatusSuspendedEV, core.ChargePointStatusSuspendedEVSE, core.ChargePointStatusFinishing:
+		return ConnectorStatusOccupied
+	default:
+		return ConnectorStatus(status)
+	}
+}
+
+func idTagInfoFrom(status AuthorizationStatus) *types.IdTagInfo {
+	if status == AuthorizationStatusUnknown {
+		return types.NewIdTagInfo(types.AuthorizationStatusInvalid)
+	}
+	return types.NewIdTagInfo(types.AuthorizationStatus(status))
+}
+
+func timeFrom16(dateTime *types.DateTime) time.Time {
+	if dateTime == nil {
+		return time.Now()
+	}
+	return dateTime.Time
+}
+
+// Converts 1.6 meter values into samples. Values which are not numeric (e.g. signed data) are skipped.
+func samplesFrom16(meterValues []types.MeterValue) []MeterSample {
+	var samples []MeterSample
+	for _, meterValue := range meterValues {
+		for _, sampledValue := range meterValue.SampledValue {
+			if sampledValue.Format == types.ValueFormatSignedData {
+				continue
+			}
+			value, err := strconv.ParseFloat(sampledValue.Value, 64)
+			if err != nil {
+				continue
+			}
+			sample := MeterSample{
+				Timestamp: timeFrom16(meterValue.Timestamp),
+				Measurand: string(sampledValue.Measurand),
+				Value:     value,
+				Unit:      string(sampledValue.Unit),
+				Phase:     string(sampledValue.Phase),
+				Context:   string(sampledValue.Context),
+				Location:  string(sampledValue.Location),
+			}
+			if sample.Measurand == "" {
+				sample.Measurand = defaultMeasurand
+			}
+			if sample.Unit == "" && sample.Measurand == defaultMeasurand {
+				sample.Unit = defaultUnit
+			}
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// Returns the energy register reading reported at the start or end of a 1.6 transaction, as a sample.
+func registerSample(value int, timestamp *types.DateTime, context types.ReadingContext) MeterSample {
+	return MeterSample{Timestamp: timeFrom16(timestamp), Measurand: defaultMeasurand, Value: float64(value), Unit: defaultUnit, Context: string(context)}
+}
+
+func (h *handler16) OnAuthorize(chargePointId string, request *core.AuthorizeRequest) (confirmation *core.AuthorizeConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	status := handler.OnAuthorize(station, request.IdTag)
+	return core.NewAuthorizationConfirmation(idTagInfoFrom(status)), nil
+}
+
+func (h *handler16) OnBootNotification(chargePointId string, request *core.BootNotificationRequest) (confirmation *core.BootNotificationConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	info := BootInfo{Model: request.ChargePointModel, Vendor: request.ChargePointVendor, SerialNumber: request.ChargePointSerialNumber, FirmwareVersion: request.FirmwareVersion}
+	status := handler.OnBoot(station, info)
+	return core.NewBootNotificationConfirmation(types.NewDateTime(time.Now()), h.cs.getHeartbeatInterval(), core.RegistrationStatus(status)), nil
+}
+
+func (h *handler16) OnDataTransfer(chargePointId string, request *core.DataTransferRequest) (confirmation *core.DataTransferConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	status, data := handler.OnDataTransfer(station, request.VendorId, request.MessageId, request.Data)
+	confirmation = core.NewDataTransferConfirmation(core.DataTransferStatus(status))
+	confirmation.Data = data
+	return confirmation, nil
+}
+
+func (h *handler16) OnHeartbeat(chargePointId string, request *core.HeartbeatRequest) (confirmation *core.HeartbeatConfirmation, err error) {
+	h.cs.station(chargePointId, ProtocolVersion16)
+	return core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil
+}
+
+func (h *handler16) OnMeterValues(chargePointId string, request *core.MeterValuesRequest) (confirmation *core.MeterValuesConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	samples := samplesFrom16(request.MeterValue)
+	if request.TransactionId == nil {
+		handler.OnMeterValues(station, evseFromConnector(request.ConnectorId), samples)
+		return core.NewMeterValuesConfirmation(), nil
+	}
+	sessionID := strconv.Itoa(*request.TransactionId)
+	session := h.cs.getSession(chargePointId, sessionID)
+	if session == nil {
+		// Session started before the CSMS was running
+		session = &Session{ID: sessionID, EVSE: evseFromConnector(request.ConnectorId)}
+		h.cs.storeSession(chargePointId, *session)
+	}
+	handler.OnSessionUpdated(station, *session, samples)
+	return core.NewMeterValuesConfirmation(), nil
+}
+
+func (h *handler16) OnStatusNotification(chargePointId string, request *core.StatusNotificationRequest) (confirmation *core.StatusNotificationConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	handler.OnStatusChange(station, evseFromConnector(request.ConnectorId), connectorStatusFrom16(request.Status), timeFrom16(request.Timestamp))
+	return core.NewStatusNotificationConfirmation(), nil
+}
+
+func (h *handler16) OnStartTransaction(chargePointId string, request *core.StartTransactionRequest) (confirmation *core.StartTransactionConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	transactionID := handler.NewTransactionID(station)
+	session := Session{
+		ID:        strconv.Itoa(transactionID),
+		EVSE:      evseFromConnector(request.ConnectorId),
+		IdToken:   request.IdTag,
+		StartTime: timeFrom16(request.Timestamp),
+	}
+	h.cs.storeSession(chargePointId, session)
+	samples := []MeterSample{registerSample(request.MeterStart, request.Timestamp, types.ReadingContextTransactionBegin)}
+	status := handler.OnSessionStarted(station, session, samples)
+	return core.NewStartTransactionConfirmation(idTagInfoFrom(status), transactionID), nil
+}
+
+func (h *handler16) OnStopTransaction(chargePointId string, request *core.StopTransactionRequest) (confirmation *core.StopTransactionConfirmation, err error) {
+	station := h.cs.station(chargePointId, ProtocolVersion16)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	sessionID := strconv.Itoa(request.TransactionId)
+	session := h.cs.getSession(chargePointId, sessionID)
+	if session == nil {
+		session = &Session{ID: sessionID, IdToken: request.IdTag}
+	}
+	h.cs.deleteSession(chargePointId, sessionID)
+	reason := StopReason(request.Reason)
+	if reason == "" {
+		reason = StopReasonLocal
+	}
+	samples := samplesFrom16(request.TransactionData)
+	samples = append(samples, registerSample(request.MeterStop, request.Timestamp, types.ReadingContextTransactionEnd))
+	handler.OnSessionEnded(station, *session, reason, samples)
+	return core.NewStopTransactionConfirmation(), nil
+}
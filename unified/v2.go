@@ -0,0 +1,143 @@
+package unified
+
+import (
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0/types"
+)
+
+// Maps messages of the OCPP 2.0 provisioning, authorization, availability, transactions, meter and data profiles onto the unified handler.
+// Version-independent mapping is performed by handler2x, this type only converts the OCPP 2.0 types.
+type handler2 struct {
+	handler2x
+}
+
+func evseFrom2(evse *types.EVSE) EVSE {
+	if evse == nil {
+		return EVSE{}
+	}
+	result := EVSE{ID: evse.ID}
+	if evse.ConnectorID != nil {
+		result.ConnectorID = *evse.ConnectorID
+	}
+	return result
+}
+
+func idTokenInfoFrom2(status AuthorizationStatus) *types.IdTokenInfo {
+	return types.NewIdTokenInfo(types.AuthorizationStatus(status))
+}
+
+func timeFrom2(dateTime *types.DateTime) time.Time {
+	if dateTime == nil {
+		return time.Now()
+	}
+	return dateTime.Time
+}
+
+func samplesFrom2(meterValues []types.MeterValue) []MeterSample {
+	var samples []MeterSample
+	for _, meterValue := range meterValues {
+		for _, sampledValue := range meterValue.SampledValue {
+			sample := MeterSample{
+				Timestamp: timeFrom2(meterValue.Timestamp),
+				Measurand: string(sampledValue.Measurand),
+				Value:     sampledValue.Value,
+				Phase:     string(sampledValue.Phase),
+				Context:   string(sampledValue.Context),
+				Location:  string(sampledValue.Location),
+			}
+			var multiplier *int
+			if unit := sampledValue.UnitOfMeasure; unit != nil {
+				sample.Unit = unit.Unit
+				multiplier = unit.Multiplier
+			}
+			samples = append(samples, meterSample2x(sample, multiplier))
+		}
+	}
+	return samples
+}
+
+func (h *handler2) OnBootNotification(chargingStationID string, request *provisioning.BootNotificationRequest) (response *provisioning.BootNotificationResponse, err error) {
+	info := BootInfo{Model: request.ChargingStation.Model, Vendor: request.ChargingStation.VendorName, SerialNumber: request.ChargingStation.SerialNumber, FirmwareVersion: request.ChargingStation.FirmwareVersion}
+	status, err := h.onBoot(chargingStationID, info)
+	if err != nil {
+		return nil, err
+	}
+	return provisioning.NewBootNotificationResponse(types.NewDateTime(time.Now()), h.cs.getHeartbeatInterval(), provisioning.RegistrationStatus(status)), nil
+}
+
+func (h *handler2) OnHeartbeat(chargingStationID string, request *provisioning.HeartbeatRequest) (response *provisioning.HeartbeatResponse, err error) {
+	h.onHeartbeat(chargingStationID)
+	return provisioning.NewHeartbeatResponse(types.NewDateTime(time.Now())), nil
+}
+
+func (h *handler2) OnNotifyReport(chargingStationID string, request *provisioning.NotifyReportRequest) (response *provisioning.NotifyReportResponse, err error) {
+	return provisioning.NewNotifyReportResponse(), nil
+}
+
+func (h *handler2) OnAuthorize(chargingStationID string, request *authorization.AuthorizeRequest) (response *authorization.AuthorizeResponse, err error) {
+	status, err := h.onAuthorize(chargingStationID, request.IdToken.IdToken)
+	if err != nil {
+		return nil, err
+	}
+	return authorization.NewAuthorizationResponse(*idTokenInfoFrom2(status)), nil
+}
+
+func (h *handler2) OnStatusNotification(chargingStationID string, request *availability.StatusNotificationRequest) (response *availability.StatusNotificationResponse, err error) {
+	evse := EVSE{ID: request.EvseID, ConnectorID: request.ConnectorID}
+	if err = h.onStatusNotification(chargingStationID, evse, ConnectorStatus(request.ConnectorStatus), timeFrom2(request.Timestamp)); err != nil {
+		return nil, err
+	}
+	return availability.NewStatusNotificationResponse(), nil
+}
+
+func (h *handler2) OnMeterValues(chargingStationID string, request *meter.MeterValuesRequest) (response *meter.MeterValuesResponse, err error) {
+	if err = h.onMeterValues(chargingStationID, EVSE{ID: request.EvseID}, samplesFrom2(request.MeterValue)); err != nil {
+		return nil, err
+	}
+	return meter.NewMeterValuesResponse(), nil
+}
+
+func (h *handler2) OnTransactionEvent(chargingStationID string, request *transactions.TransactionEventRequest) (response *transactions.TransactionEventResponse, err error) {
+	info := request.TransactionInfo
+	event := transactionEvent2x{
+		ended:         request.EventType == transactions.TransactionEventEnded,
+		transactionID: info.TransactionID,
+		remoteStartID: info.RemoteStartID,
+		stoppedReason: StopReason(info.StoppedReason),
+		timestamp:     timeFrom2(request.Timestamp),
+		samples:       samplesFrom2(request.MeterValue),
+	}
+	if request.Evse != nil {
+		evse := evseFrom2(request.Evse)
+		event.evse = &evse
+	}
+	if request.IDToken != nil {
+		event.idToken = &request.IDToken.IdToken
+	}
+	status, err := h.onTransactionEvent(chargingStationID, event)
+	if err != nil {
+		return nil, err
+	}
+	response = transactions.NewTransactionEventResponse()
+	if status != nil {
+		response.IDTokenInfo = idTokenInfoFrom2(*status)
+	}
+	return response, nil
+}
+
+func (h *handler2) OnDataTransfer(chargingStationID string, request *data.DataTransferRequest) (response *data.DataTransferResponse, err error) {
+	status, payload, err := h.onDataTransfer(chargingStationID, request.VendorId, request.MessageId, request.Data)
+	if err != nil {
+		return nil, err
+	}
+	response = data.NewDataTransferResponse(data.DataTransferStatus(status))
+	response.Data = payload
+	return response, nil
+}
@@ -0,0 +1,143 @@
+package unified
+
+import (
+	"math"
+	"time"
+)
+
+// Maps messages shared by the OCPP 2.0 and 2.0.1 profiles onto the unified handler.
+// The handlers of both versions convert their messages into plain values, and delegate to it.
+type handler2x struct {
+	cs      *unifiedCSMS
+	version ProtocolVersion
+}
+
+// Values of a TransactionEvent request, which are needed to keep track of sessions.
+// The EVSE and id token are nil, if they weren't contained in the request.
+type transactionEvent2x struct {
+	ended         bool
+	transactionID string
+	remoteStartID *int
+	stoppedReason StopReason
+	timestamp     time.Time
+	evse          *EVSE
+	idToken       *string
+	samples       []MeterSample
+}
+
+// Completes a sample converted from a sampled value, applying the multiplier of its unit of measure and the default measurand and unit.
+func meterSample2x(sample MeterSample, multiplier *int) MeterSample {
+	if multiplier != nil {
+		sample.Value *= math.Pow10(*multiplier)
+	}
+	if sample.Measurand == "" {
+		sample.Measurand = defaultMeasurand
+	}
+	if sample.Unit == "" && sample.Measurand == defaultMeasurand {
+		sample.Unit = defaultUnit
+	}
+	return sample
+}
+
+func (h *handler2x) onBoot(chargingStationID string, info BootInfo) (RegistrationStatus, error) {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return "", errNoHandler
+	}
+	return handler.OnBoot(station, info), nil
+}
+
+func (h *handler2x) onHeartbeat(chargingStationID string) {
+	h.cs.station(chargingStationID, h.version)
+}
+
+func (h *handler2x) onAuthorize(chargingStationID string, idToken string) (AuthorizationStatus, error) {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return "", errNoHandler
+	}
+	return handler.OnAuthorize(station, idToken), nil
+}
+
+func (h *handler2x) onStatusNotification(chargingStationID string, evse EVSE, status ConnectorStatus, timestamp time.Time) error {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return errNoHandler
+	}
+	handler.OnStatusChange(station, evse, status, timestamp)
+	return nil
+}
+
+func (h *handler2x) onMeterValues(chargingStationID string, evse EVSE, samples []MeterSample) error {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return errNoHandler
+	}
+	handler.OnMeterValues(station, evse, samples)
+	return nil
+}
+
+// Updates the session of a transaction event. Returns the authorization status of the id token to report in the response,
+// or nil if the response must not contain any id token info.
+func (h *handler2x) onTransactionEvent(chargingStationID string, event transactionEvent2x) (*AuthorizationStatus, error) {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return nil, errNoHandler
+	}
+	session := h.cs.getSession(chargingStationID, event.transactionID)
+	// Transactions that were started while the CSMS was not running are reported as started on their first event
+	started := session == nil && !event.ended
+	if session == nil {
+		session = &Session{ID: event.transactionID, StartTime: event.timestamp}
+	}
+	// EVSE, id token and remote start ID may only be reported in later events
+	if event.evse != nil {
+		session.EVSE = *event.evse
+	}
+	if event.remoteStartID != nil {
+		session.RemoteStartID = event.remoteStartID
+	}
+	if event.idToken != nil && session.IdToken == "" {
+		session.IdToken = *event.idToken
+	}
+	switch {
+	case started:
+		h.cs.storeSession(chargingStationID, *session)
+		status := handler.OnSessionStarted(station, *session, event.samples)
+		if event.idToken != nil {
+			return &status, nil
+		}
+		return nil, nil
+	case event.ended:
+		h.cs.deleteSession(chargingStationID, session.ID)
+		reason := event.stoppedReason
+		if reason == "" {
+			reason = StopReasonLocal
+		}
+		handler.OnSessionEnded(station, *session, reason, event.samples)
+	default:
+		h.cs.storeSession(chargingStationID, *session)
+		handler.OnSessionUpdated(station, *session, event.samples)
+	}
+	// The id token info is required whenever an id token was sent, e.g. when a different token stops the session
+	if event.idToken != nil {
+		status := handler.OnAuthorize(station, *event.idToken)
+		return &status, nil
+	}
+	return nil, nil
+}
+
+func (h *handler2x) onDataTransfer(chargingStationID string, vendorID string, messageID string, data interface{}) (DataTransferStatus, interface{}, error) {
+	station := h.cs.station(chargingStationID, h.version)
+	handler := h.cs.getHandler()
+	if handler == nil {
+		return "", nil, errNoHandler
+	}
+	status, payload := handler.OnDataTransfer(station, vendorID, messageID, data)
+	return status, payload, nil
+}
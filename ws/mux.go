@@ -0,0 +1,238 @@
+package ws
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// ServerMux allows multiple endpoints to share a single websocket server, hence a single port and path.
+//
+// Every endpoint is bound to a subprotocol via the ForSubprotocol function, which returns a WsServer.
+// Incoming connections are routed to the endpoint matching the subprotocol negotiated during the handshake.
+// If a client offers multiple supported subprotocols, the one registered first is picked.
+//
+// To serve OCPP 1.6 and OCPP 2.0.1 clients on the same port and path, importing the ocpp2.0.1 package as ocpp201, use:
+//	mux := ws.NewServerMux(ws.NewServer())
+//	centralSystem := ocpp16.NewCentralSystem(nil, mux.ForSubprotocol("ocpp1.6"))
+//	csms := ocpp201.NewCSMS(nil, mux.ForSubprotocol("ocpp2.0.1"))
+//	go centralSystem.Start(8887, "/{ws}")
+//	csms.Start(8887, "/{ws}")
+//
+// The underlying server is started together with the first endpoint, using that endpoint's port and path,
+// and is stopped once all started endpoints were stopped.
+// Handlers must not be set directly on the underlying server, as they are managed by the mux.
+type ServerMux struct {
+	server  *Server
+	routes  map[string]*subprotocolServer
+	running map[*subprotocolServer]bool
+	started bool
+	done    chan struct{}
+	mutex   sync.Mutex
+}
+
+// Creates a new ServerMux on top of a websocket server. If no server is passed, a new default server is created.
+func NewServerMux(server *Server) *ServerMux {
+	if server == nil {
+		server = NewServer()
+	}
+	mux := &ServerMux{
+		server:  server,
+		routes:  map[string]*subprotocolServer{},
+		running: map[*subprotocolServer]bool{},
+	}
+	server.SetNewClientHandler(mux.onNewClient)
+	server.SetMessageHandler(mux.onMessage)
+	server.SetDisconnectedClientHandler(mux.onDisconnectedClient)
+	return mux
+}
+
+// Returns a WsServer, which receives all connections that negotiated the given subprotocol.
+// The subprotocol is automatically added to the supported subprotocols of the underlying server.
+//
+// Calling the function multiple times with the same subprotocol returns the same WsServer.
+func (mux *ServerMux) ForSubprotocol(subprotocol string) WsServer {
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+	if route, ok := mux.routes[subprotocol]; ok {
+		return route
+	}
+	route := &subprotocolServer{mux: mux, subprotocol: subprotocol, clients: map[string]bool{}}
+	mux.routes[subprotocol] = route
+	mux.server.AddSupportedSubprotocol(subprotocol)
+	return route
+}
+
+func (mux *ServerMux) route(ws Channel) *subprotocolServer {
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+	return mux.routes[ws.Subprotocol()]
+}
+
+func (mux *ServerMux) onNewClient(ws Channel) {
+	route := mux.route(ws)
+	if route == nil {
+		mux.server.error(fmt.Errorf("no endpoint for subprotocol '%v' of %s", ws.Subprotocol(), ws.ID()))
+		_ = mux.server.StopConnection(ws.ID(), websocket.CloseError{Code: websocket.CloseProtocolError, Text: "unsupported subprotocol"})
+		return
+	}
+	route.addClient(ws.ID())
+	if route.newClientHandler != nil {
+		route.newClientHandler(ws)
+	}
+}
+
+func (mux *ServerMux) onMessage(ws Channel, data []byte) error {
+	route := mux.route(ws)
+	if route == nil {
+		return fmt.Errorf("no endpoint for subprotocol '%v'", ws.Subprotocol())
+	}
+	// Messages may be received before the new client handler was invoked
+	route.addClient(ws.ID())
+	if route.messageHandler != nil {
+		return route.messageHandler(ws, data)
+	}
+	return nil
+}
+
+func (mux *ServerMux) onDisconnectedClient(ws Channel) {
+	route := mux.route(ws)
+	if route == nil {
+		return
+	}
+	route.clientMutex.Lock()
+	delete(route.clients, ws.ID())
+	route.clientMutex.Unlock()
+	if route.disconnectedHandler != nil {
+		route.disconnectedHandler(ws)
+	}
+}
+
+// Starts the underlying server, unless already running, and blocks until either the route is stopped or the server terminates.
+func (mux *ServerMux) start(route *subprotocolServer, port int, listenPath string) {
+	mux.mutex.Lock()
+	if !mux.started {
+		mux.started = true
+		mux.done = make(chan struct{})
+		go mux.run(port, listenPath, mux.done)
+	}
+	route.stopped = make(chan struct{})
+	mux.running[route] = true
+	stopped := route.stopped
+	done := mux.done
+	mux.mutex.Unlock()
+	select {
+	case <-stopped:
+	case <-done:
+	}
+}
+
+func (mux *ServerMux) run(port int, listenPath string, done chan struct{}) {
+	mux.server.Start(port, listenPath)
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+	mux.started = false
+	mux.running = map[*subprotocolServer]bool{}
+	close(done)
+}
+
+// Stops a route. The underlying server is stopped, once no more routes are running.
+func (mux *ServerMux) stop(route *subprotocolServer) {
+	mux.mutex.Lock()
+	if !mux.running[route] {
+		mux.mutex.Unlock()
+		return
+	}
+	delete(mux.running, route)
+	close(route.stopped)
+	last := len(mux.running) == 0
+	mux.mutex.Unlock()
+	if last {
+		mux.server.Stop()
+	}
+}
+
+// A virtual websocket server, bound to a single subprotocol of a ServerMux.
+type subprotocolServer struct {
+	mux                 *ServerMux
+	subprotocol         string
+	clients             map[string]bool
+	clientMutex         sync.RWMutex
+	messageHandler      func(ws Channel, data []byte) error
+	newClientHandler    func(ws Channel)
+	disconnectedHandler func(ws Channel)
+	stopped             chan struct{}
+}
+
+func (s *subprotocolServer) Start(port int, listenPath string) {
+	s.mux.start(s, port, listenPath)
+}
+
+func (s *subprotocolServer) Stop() {
+	s.mux.stop(s)
+}
+
+func (s *subprotocolServer) addClient(id string) {
+	s.clientMutex.Lock()
+	defer s.clientMutex.Unlock()
+	s.clients[id] = true
+}
+
+func (s *subprotocolServer) hasClient(id string) bool {
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+	return s.clients[id]
+}
+
+func (s *subprotocolServer) StopConnection(id string, closeError websocket.CloseError) error {
+	if !s.hasClient(id) {
+		return fmt.Errorf("couldn't stop websocket connection. No connection with id %s is open for subprotocol %v", id, s.subprotocol)
+	}
+	return s.mux.server.StopConnection(id, closeError)
+}
+
+func (s *subprotocolServer) Errors() <-chan error {
+	return s.mux.server.Errors()
+}
+
+func (s *subprotocolServer) SetMessageHandler(handler func(ws Channel, data []byte) error) {
+	s.messageHandler = handler
+}
+
+func (s *subprotocolServer) SetNewClientHandler(handler func(ws Channel)) {
+	s.newClientHandler = handler
+}
+
+func (s *subprotocolServer) SetDisconnectedClientHandler(handler func(ws Channel)) {
+	s.disconnectedHandler = handler
+}
+
+func (s *subprotocolServer) SetTimeoutConfig(config ServerTimeoutConfig) {
+	s.mux.server.SetTimeoutConfig(config)
+}
+
+func (s *subprotocolServer) Write(webSocketId string, data []byte) error {
+	if !s.hasClient(webSocketId) {
+		return fmt.Errorf("couldn't write to websocket. No socket with id %v is open for subprotocol %v", webSocketId, s.subprotocol)
+	}
+	return s.mux.server.Write(webSocketId, data)
+}
+
+// The server is bound to a single subprotocol, which is already supported, hence the function has no effect.
+func (s *subprotocolServer) AddSupportedSubprotocol(subProto string) {
+}
+
+func (s *subprotocolServer) SetBasicAuthHandler(handler func(username string, password string) bool) {
+	s.mux.server.SetBasicAuthHandler(handler)
+}
+
+func (s *subprotocolServer) SetCheckOriginHandler(handler func(r *http.Request) bool) {
+	s.mux.server.SetCheckOriginHandler(handler)
+}
+
+func (s *subprotocolServer) Addr() *net.TCPAddr {
+	return s.mux.server.Addr()
+}
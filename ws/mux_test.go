@@ -0,0 +1,152 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubprotocolClient(t *testing.T, subprotocols ...string) *Client {
+	wsClient := newWebsocketClient(t, nil)
+	wsClient.AddOption(func(dialer *websocket.Dialer) {
+		dialer.Subprotocols = subprotocols
+	})
+	return wsClient
+}
+
+func TestServerMuxRouting(t *testing.T) {
+	messages16 := make(chan string, 1)
+	messages20 := make(chan string, 1)
+	mux := NewServerMux(NewServer())
+	server16 := mux.ForSubprotocol("ocpp1.6")
+	server20 := mux.ForSubprotocol("ocpp2.0.1")
+	assert.Equal(t, server16, mux.ForSubprotocol("ocpp1.6"))
+	server16.SetNewClientHandler(func(ws Channel) {
+		assert.Equal(t, "ocpp1.6", ws.Subprotocol())
+	})
+	server16.SetMessageHandler(func(ws Channel, data []byte) error {
+		assert.Equal(t, "ocpp1.6", ws.Subprotocol())
+		messages16 <- ws.ID()
+		return nil
+	})
+	server20.SetNewClientHandler(func(ws Channel) {
+		assert.Equal(t, "ocpp2.0.1", ws.Subprotocol())
+	})
+	server20.SetMessageHandler(func(ws Channel, data []byte) error {
+		assert.Equal(t, "ocpp2.0.1", ws.Subprotocol())
+		messages20 <- ws.ID()
+		return nil
+	})
+	go server16.Start(serverPort, serverPath)
+	go server20.Start(serverPort, serverPath)
+	time.Sleep(200 * time.Millisecond)
+
+	host := fmt.Sprintf("localhost:%v", serverPort)
+	client16 := newSubprotocolClient(t, "ocpp1.6")
+	err := client16.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp16"}).String())
+	require.NoError(t, err)
+	assert.Equal(t, "ocpp1.6", client16.webSocket.Subprotocol())
+	// Client offering multiple subprotocols is routed to the preferred one
+	client20 := newSubprotocolClient(t, "ocpp2.0.1", "ocpp1.6")
+	err = client20.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp20"}).String())
+	require.NoError(t, err)
+	assert.Equal(t, "ocpp1.6", client20.webSocket.Subprotocol())
+	client20.Stop()
+	client20 = newSubprotocolClient(t, "ocpp2.0.1")
+	err = client20.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp20"}).String())
+	require.NoError(t, err)
+	assert.Equal(t, "ocpp2.0.1", client20.webSocket.Subprotocol())
+
+	require.NoError(t, client16.Write([]byte("hello")))
+	assert.Equal(t, "cp16", <-messages16)
+	require.NoError(t, client20.Write([]byte("hello")))
+	assert.Equal(t, "cp20", <-messages20)
+	// Writes are only possible towards clients of the same subprotocol
+	assert.NoError(t, server16.Write("cp16", []byte("hello")))
+	assert.Error(t, server16.Write("cp20", []byte("hello")))
+	assert.NoError(t, server20.Write("cp20", []byte("hello")))
+	assert.Error(t, server20.Write("cp16", []byte("hello")))
+	// Unsupported subprotocol is rejected
+	client := newSubprotocolClient(t, "ocpp2.0")
+	err = client.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp"}).String())
+	assert.Error(t, err)
+	// Cleanup
+	client16.Stop()
+	client20.Stop()
+	server16.Stop()
+	server20.Stop()
+}
+
+func TestServerMuxStop(t *testing.T) {
+	mux := NewServerMux(nil)
+	server16 := mux.ForSubprotocol("ocpp1.6")
+	server20 := mux.ForSubprotocol("ocpp2.0.1")
+	stopped16 := make(chan bool, 1)
+	stopped20 := make(chan bool, 1)
+	go func() {
+		server16.Start(serverPort, serverPath)
+		stopped16 <- true
+	}()
+	go func() {
+		server20.Start(serverPort, serverPath)
+		stopped20 <- true
+	}()
+	time.Sleep(200 * time.Millisecond)
+	host := fmt.Sprintf("localhost:%v", serverPort)
+	// Stopping one endpoint keeps the server running
+	server16.Stop()
+	assert.True(t, <-stopped16)
+	client := newSubprotocolClient(t, "ocpp2.0.1")
+	err := client.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp20"}).String())
+	require.NoError(t, err)
+	client.Stop()
+	// Stopping the last endpoint stops the server
+	server20.Stop()
+	assert.True(t, <-stopped20)
+	client = newSubprotocolClient(t, "ocpp2.0.1")
+	err = client.Start((&url.URL{Scheme: "ws", Host: host, Path: "/ws/cp20"}).String())
+	assert.Error(t, err)
+}
+
+func TestClientNoSubprotocolNegotiated(t *testing.T) {
+	// Server upgrading the connection without picking any subprotocol
+	upgrader := websocket.Upgrader{Subprotocols: []string{}}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer httpServer.Close()
+	wsClient := newSubprotocolClient(t, defaultSubProtocol)
+	err := wsClient.Start(strings.Replace(httpServer.URL, "http", "ws", 1) + testPath)
+	require.Error(t, err)
+	assert.False(t, wsClient.IsConnected())
+}
+
+func TestServerAcceptsAnySubprotocol(t *testing.T) {
+	subprotocolC := make(chan string, 1)
+	wsServer := newWebsocketServer(t, nil)
+	wsServer.SetNewClientHandler(func(ws Channel) {
+		subprotocolC <- ws.Subprotocol()
+	})
+	go wsServer.Start(serverPort, serverPath)
+	time.Sleep(200 * time.Millisecond)
+	wsClient := newSubprotocolClient(t, "custom1", "custom2")
+	host := fmt.Sprintf("localhost:%v", serverPort)
+	err := wsClient.Start((&url.URL{Scheme: "ws", Host: host, Path: testPath}).String())
+	require.NoError(t, err)
+	assert.Equal(t, "custom1", <-subprotocolC)
+	assert.Equal(t, "custom1", wsClient.webSocket.Subprotocol())
+	// Cleanup
+	wsClient.Stop()
+	wsServer.Stop()
+}
@@ -116,6 +116,8 @@ func NewClientTimeoutConfig() ClientTimeoutConfig {
 type Channel interface {
 	ID() string
 	TLSConnectionState() *tls.ConnectionState
+	// Returns the subprotocol negotiated during the websocket handshake, or an empty string if none was negotiated.
+	Subprotocol() string
 }
 
 // WebSocket is a wrapper for a single websocket channel.
@@ -129,6 +131,7 @@ type WebSocket struct {
 	closeSignal        chan error // used by the readPump to notify the closed connection to the writePump
 	pingMessage        chan []byte
 	tlsConnectionState *tls.ConnectionState
+	subprotocol        string
 	closed             chan struct{}
 }
 
@@ -142,6 +145,11 @@ func (websocket *WebSocket) TLSConnectionState() *tls.ConnectionState {
 	return websocket.tlsConnectionState
 }
 
+// Returns the subprotocol negotiated during the handshake, if any.
+func (websocket *WebSocket) Subprotocol() string {
+	return websocket.subprotocol
+}
+
 // ConnectionError is a websocket
 type HttpConnectionError struct {
 	Message    string
@@ -396,7 +404,9 @@ func (server *Server) stopConnections() {
 			if err := conn.connection.Close(); err != nil {
 				server.error(fmt.Errorf("failed to close connection %s", conn.id))
 			}
-			server.disconnectedHandler(conn)
+			if server.disconnectedHandler != nil {
+				server.disconnectedHandler(conn)
+			}
 		}()
 	}
 }
@@ -480,7 +490,12 @@ func (server *Server) wsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	// Upgrade websocket
-	conn, err := server.upgrader.Upgrade(w, r, nil)
+	upgrader := server.upgrader
+	if len(upgrader.Subprotocols) == 0 {
+		// All subprotocols are accepted, so the first one requested by the client is picked
+		upgrader.Subprotocols = clientSubprotocols
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		server.error(fmt.Errorf("upgrade failed: %w", err))
 		return
@@ -495,6 +510,7 @@ func (server *Server) wsHandler(w http.ResponseWriter, r *http.Request) {
 		closeSignal:        make(chan error, 1),
 		pingMessage:        make(chan []byte, 1),
 		tlsConnectionState: r.TLS,
+		subprotocol:        conn.Subprotocol(),
 		closed:             make(chan struct{}),
 	}
 	server.connMutex.Lock()
@@ -949,6 +965,16 @@ func (client *Client) Start(urlStr string) error {
 		}
 		return err
 	}
+	// The server must pick one of the offered subprotocols, if any were offered
+	if len(dialer.Subprotocols) > 0 && !containsSubprotocol(dialer.Subprotocols, ws.Subprotocol()) {
+		_ = ws.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseProtocolError, "unsupported subprotocol"),
+			time.Now().Add(client.timeoutConfig.WriteWait),
+		)
+		_ = ws.Close()
+		return fmt.Errorf("server negotiated subprotocol '%v', expected one of %v", ws.Subprotocol(), dialer.Subprotocols)
+	}
 
 	// The id of the charge point is the final path element
 	id := path.Base(url.Path)
@@ -959,6 +985,7 @@ func (client *Client) Start(urlStr string) error {
 		outQueue:           make(chan []byte),
 		closeSignal:        make(chan error, 1),
 		tlsConnectionState: resp.TLS,
+		subprotocol:        ws.Subprotocol(),
 	}
 	client.stopped = make(chan struct{})
 	client.setConnected(true)
@@ -991,3 +1018,12 @@ func (client *Client) Errors() <-chan error {
 	}
 	return client.errC
 }
+
+func containsSubprotocol(subprotocols []string, subprotocol string) bool {
+	for _, s := range subprotocols {
+		if s == subprotocol {
+			return true
+		}
+	}
+	return false
+}